@@ -1,8 +1,15 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
 )
 
 type DeploymentController struct {
@@ -17,3 +24,120 @@ func (c *DeploymentController) ListDeployments(r *http.Request, vars map[string]
 
 	return Ok(deployments)
 }
+
+// GetDeployment returns a deployment of the version endpoint, including the configuration it deployed
+func (c *DeploymentController) GetDeployment(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	deployment, resp := c.findDeployment(vars["deployment_id"], vars["endpoint_id"])
+	if resp != nil {
+		return resp
+	}
+
+	return Ok(deployment)
+}
+
+// DiffDeployments returns the configuration changes from one deployment of the version endpoint to another
+func (c *DeploymentController) DiffDeployments(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	if vars["from"] == "" || vars["to"] == "" {
+		return BadRequest("Both from and to deployment ids are required")
+	}
+
+	from, resp := c.findDeployment(vars["from"], vars["endpoint_id"])
+	if resp != nil {
+		return resp
+	}
+	to, resp := c.findDeployment(vars["to"], vars["endpoint_id"])
+	if resp != nil {
+		return resp
+	}
+
+	for _, deployment := range []*models.Deployment{from, to} {
+		if deployment.Spec == nil {
+			return BadRequest(fmt.Sprintf("Deployment %d has no recorded configuration", deployment.ID))
+		}
+	}
+
+	changes, err := models.DiffDeploymentSpecs(from.Spec, to.Spec)
+	if err != nil {
+		return InternalServerError(fmt.Sprintf("Error comparing deployments: %v", err))
+	}
+
+	return Ok(&models.DeploymentDiff{
+		FromDeploymentID: from.ID,
+		ToDeploymentID:   to.ID,
+		Changes:          changes,
+	})
+}
+
+// RollbackDeployment redeploys the configuration of a previous deployment of the version endpoint
+func (c *DeploymentController) RollbackDeployment(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	ctx := r.Context()
+
+	modelID, _ := models.ParseID(vars["model_id"])
+	versionID, _ := models.ParseID(vars["version_id"])
+	endpointID, err := uuid.Parse(vars["endpoint_id"])
+	if err != nil {
+		return BadRequest(fmt.Sprintf("Unable to parse endpoint_id %s: %v", vars["endpoint_id"], err))
+	}
+
+	model, version, err := c.getModelAndVersion(ctx, modelID, versionID)
+	if err != nil {
+		return NotFound(fmt.Sprintf("Error getting model / version: %v", err))
+	}
+
+	endpoint, err := c.EndpointsService.FindByID(ctx, endpointID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NotFound(fmt.Sprintf("Endpoint not found: %v", err))
+		}
+		return InternalServerError(fmt.Sprintf("Error getting endpoint: %v", err))
+	}
+
+	deployment, resp := c.findDeployment(vars["deployment_id"], vars["endpoint_id"])
+	if resp != nil {
+		return resp
+	}
+
+	env, err := c.EnvironmentService.GetEnvironment(endpoint.EnvironmentName)
+	if err != nil {
+		return InternalServerError(fmt.Sprintf("Unable to find environment %s: %v", endpoint.EnvironmentName, err))
+	}
+
+	// the rolled back configuration is validated as an update of the endpoint, e.g. against the current logger allowlist
+	if deployment.Spec != nil {
+		newEndpoint := deployment.Spec.RollbackVersionEndpoint(endpoint)
+		if err := validateRequest(c.updateEndpointValidators(ctx, model, version, endpoint, newEndpoint)...); err != nil {
+			return BadRequest(fmt.Sprintf("Request validation failed: %v", err))
+		}
+	}
+
+	endpoint, err = c.EndpointsService.RollbackEndpoint(ctx, env, model, version, endpoint, deployment)
+	if err != nil {
+		if errors.Is(err, merror.ErrInvalidInput) {
+			return BadRequest(fmt.Sprintf("Unable to roll back version endpoint: %v", err))
+		}
+		return InternalServerError(fmt.Sprintf("Unable to roll back version endpoint: %v", err))
+	}
+
+	return Ok(endpoint)
+}
+
+// findDeployment returns the deployment with the given id, or the response to return if it doesn't belong to the version endpoint
+func (c *DeploymentController) findDeployment(rawDeploymentID string, endpointID string) (*models.Deployment, *Response) {
+	deploymentID, err := models.ParseID(rawDeploymentID)
+	if err != nil {
+		return nil, BadRequest(fmt.Sprintf("Unable to parse deployment id %s: %v", rawDeploymentID, err))
+	}
+
+	deployment, err := c.DeploymentService.GetDeployment(deploymentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NotFound(fmt.Sprintf("Deployment not found: %v", err))
+		}
+		return nil, InternalServerError(fmt.Sprintf("Error getting deployment: %v", err))
+	}
+
+	if deployment.VersionEndpointID.String() != endpointID {
+		return nil, NotFound(fmt.Sprintf("Deployment %d not found in endpoint %s", deploymentID, endpointID))
+	}
+	return deployment, nil
+}
@@ -6,9 +6,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/caraml-dev/merlin/config"
 	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/pkg/deployment"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"github.com/caraml-dev/merlin/service/mocks"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestDeploymentController_ListDeployments(t *testing.T) {
@@ -98,3 +104,269 @@ func TestDeploymentController_ListDeployments(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentController_DiffDeployments(t *testing.T) {
+	endpointUUID := uuid.New()
+	endpointUUIDString := fmt.Sprint(endpointUUID)
+
+	previous := &models.Deployment{
+		ID:                models.ID(1),
+		VersionEndpointID: endpointUUID,
+		Status:            models.EndpointTerminated,
+		Spec: &models.DeploymentSpec{
+			DeploymentMode: deployment.ServerlessDeploymentMode,
+			EnvVars:        models.EnvVars{{Name: "WORKERS", Value: "2"}},
+		},
+	}
+	current := &models.Deployment{
+		ID:                models.ID(2),
+		VersionEndpointID: endpointUUID,
+		Status:            models.EndpointRunning,
+		Spec: &models.DeploymentSpec{
+			DeploymentMode: deployment.ServerlessDeploymentMode,
+			EnvVars:        models.EnvVars{{Name: "WORKERS", Value: "4"}},
+		},
+	}
+
+	testCases := []struct {
+		desc              string
+		vars              map[string]string
+		deploymentService func() *mocks.DeploymentService
+		expected          *Response
+	}{
+		{
+			desc: "Should return the changes between deployments",
+			vars: map[string]string{"endpoint_id": endpointUUIDString, "from": "1", "to": "2"},
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(1)).Return(previous, nil)
+				mockSvc.On("GetDeployment", models.ID(2)).Return(current, nil)
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: &models.DeploymentDiff{
+					FromDeploymentID: models.ID(1),
+					ToDeploymentID:   models.ID(2),
+					Changes:          []*models.DeploymentSpecChange{{Field: "env_vars[WORKERS].value", From: "2", To: "4"}},
+				},
+			},
+		},
+		{
+			desc: "Should return 400 when deployment has no recorded configuration",
+			vars: map[string]string{"endpoint_id": endpointUUIDString, "from": "3", "to": "2"},
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(3)).Return(&models.Deployment{ID: models.ID(3), VersionEndpointID: endpointUUID}, nil)
+				mockSvc.On("GetDeployment", models.ID(2)).Return(current, nil)
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Deployment 3 has no recorded configuration"},
+			},
+		},
+		{
+			desc: "Should return 404 when deployment belongs to another endpoint",
+			vars: map[string]string{"endpoint_id": endpointUUIDString, "from": "4", "to": "2"},
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(4)).Return(&models.Deployment{ID: models.ID(4), VersionEndpointID: uuid.New()}, nil)
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusNotFound,
+				data: Error{Message: fmt.Sprintf("Deployment 4 not found in endpoint %s", endpointUUIDString)},
+			},
+		},
+		{
+			desc: "Should return 400 when to is missing",
+			vars: map[string]string{"endpoint_id": endpointUUIDString, "from": "1"},
+			deploymentService: func() *mocks.DeploymentService {
+				return &mocks.DeploymentService{}
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Both from and to deployment ids are required"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctl := &DeploymentController{
+				AppContext: &AppContext{
+					DeploymentService: tC.deploymentService(),
+				},
+			}
+			resp := ctl.DiffDeployments(&http.Request{}, tC.vars, nil)
+			assertEqualResponses(t, tC.expected, resp)
+		})
+	}
+}
+
+func TestDeploymentController_RollbackDeployment(t *testing.T) {
+	endpointUUID := uuid.New()
+	endpointUUIDString := fmt.Sprint(endpointUUID)
+
+	model := &models.Model{ID: models.ID(1), Name: "model"}
+	version := &models.Version{ID: models.ID(1), ModelID: models.ID(1)}
+	env := &models.Environment{Name: "env1"}
+	endpoint := &models.VersionEndpoint{ID: endpointUUID, EnvironmentName: env.Name, Status: models.EndpointRunning, DeploymentMode: deployment.ServerlessDeploymentMode}
+	previous := &models.Deployment{
+		ID:                models.ID(1),
+		VersionEndpointID: endpointUUID,
+		Status:            models.EndpointTerminated,
+		Spec:              &models.DeploymentSpec{DeploymentMode: deployment.ServerlessDeploymentMode},
+	}
+	previousWithSink := &models.Deployment{
+		ID:                models.ID(2),
+		VersionEndpointID: endpointUUID,
+		Status:            models.EndpointTerminated,
+		Spec: &models.DeploymentSpec{
+			DeploymentMode: deployment.ServerlessDeploymentMode,
+			Logger: &models.Logger{
+				Model: &models.LoggerConfig{
+					Enabled: true,
+					Mode:    models.LogAll,
+					Sinks:   []rules.Sink{{Name: "audit", Url: "kafka:broker:9092"}},
+				},
+			},
+		},
+	}
+	previousRawDeployment := &models.Deployment{
+		ID:                models.ID(3),
+		VersionEndpointID: endpointUUID,
+		Status:            models.EndpointTerminated,
+		Spec:              &models.DeploymentSpec{DeploymentMode: deployment.RawDeploymentMode},
+	}
+	vars := map[string]string{"model_id": "1", "version_id": "1", "endpoint_id": endpointUUIDString, "deployment_id": "1"}
+
+	testCases := []struct {
+		desc              string
+		vars              map[string]string
+		deploymentService func() *mocks.DeploymentService
+		endpointsService  func() *mocks.EndpointsService
+		expected          *Response
+	}{
+		{
+			desc: "Should redeploy the previous deployment",
+			vars: vars,
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(1)).Return(previous, nil)
+				return mockSvc
+			},
+			endpointsService: func() *mocks.EndpointsService {
+				mockSvc := &mocks.EndpointsService{}
+				mockSvc.On("FindByID", mock.Anything, endpointUUID).Return(endpoint, nil)
+				mockSvc.On("RollbackEndpoint", mock.Anything, env, model, version, endpoint, previous).
+					Return(&models.VersionEndpoint{ID: endpointUUID, Status: models.EndpointPending}, nil)
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: &models.VersionEndpoint{ID: endpointUUID, Status: models.EndpointPending},
+			},
+		},
+		{
+			desc: "Should return 400 when the rollback is invalid",
+			vars: vars,
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(1)).Return(previous, nil)
+				return mockSvc
+			},
+			endpointsService: func() *mocks.EndpointsService {
+				mockSvc := &mocks.EndpointsService{}
+				mockSvc.On("FindByID", mock.Anything, endpointUUID).Return(endpoint, nil)
+				mockSvc.On("RollbackEndpoint", mock.Anything, env, model, version, endpoint, previous).
+					Return(nil, merror.NewInvalidInputError("deployment 1 has no recorded configuration to roll back to"))
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Unable to roll back version endpoint: invalid input: deployment 1 has no recorded configuration to roll back to"},
+			},
+		},
+		{
+			desc: "Should return 400 when the logger sink of the previous deployment is no longer allowed",
+			vars: map[string]string{"model_id": "1", "version_id": "1", "endpoint_id": endpointUUIDString, "deployment_id": "2"},
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(2)).Return(previousWithSink, nil)
+				return mockSvc
+			},
+			endpointsService: func() *mocks.EndpointsService {
+				mockSvc := &mocks.EndpointsService{}
+				mockSvc.On("FindByID", mock.Anything, endpointUUID).Return(endpoint, nil)
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Request validation failed: invalid model logger config: sink audit has kind kafka which is not allowed, allowed kinds: [webhook]"},
+			},
+		},
+		{
+			desc: "Should return 400 when the previous deployment mode differs from the running endpoint",
+			vars: map[string]string{"model_id": "1", "version_id": "1", "endpoint_id": endpointUUIDString, "deployment_id": "3"},
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(3)).Return(previousRawDeployment, nil)
+				return mockSvc
+			},
+			endpointsService: func() *mocks.EndpointsService {
+				mockSvc := &mocks.EndpointsService{}
+				mockSvc.On("FindByID", mock.Anything, endpointUUID).Return(endpoint, nil)
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Request validation failed: changing deployment type of a running model is not allowed, please terminate it first"},
+			},
+		},
+		{
+			desc: "Should return 404 when deployment is not found",
+			vars: vars,
+			deploymentService: func() *mocks.DeploymentService {
+				mockSvc := &mocks.DeploymentService{}
+				mockSvc.On("GetDeployment", models.ID(1)).Return(nil, gorm.ErrRecordNotFound)
+				return mockSvc
+			},
+			endpointsService: func() *mocks.EndpointsService {
+				mockSvc := &mocks.EndpointsService{}
+				mockSvc.On("FindByID", mock.Anything, endpointUUID).Return(endpoint, nil)
+				return mockSvc
+			},
+			expected: &Response{
+				code: http.StatusNotFound,
+				data: Error{Message: "Deployment not found: record not found"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			modelsService := &mocks.ModelsService{}
+			modelsService.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+			versionsService := &mocks.VersionsService{}
+			versionsService.On("FindByID", mock.Anything, models.ID(1), models.ID(1), mock.Anything).Return(version, nil)
+			envService := &mocks.EnvironmentService{}
+			envService.On("GetEnvironment", env.Name).Return(env, nil)
+
+			endpointsService := tC.endpointsService()
+
+			ctl := &DeploymentController{
+				AppContext: &AppContext{
+					ModelsService:         modelsService,
+					VersionsService:       versionsService,
+					EnvironmentService:    envService,
+					EndpointsService:      endpointsService,
+					DeploymentService:     tC.deploymentService(),
+					InferenceLoggerConfig: config.InferenceLoggerConfig{AllowedSinkKinds: []string{"webhook"}},
+				},
+			}
+			resp := ctl.RollbackDeployment(&http.Request{}, tC.vars, nil)
+			assertEqualResponses(t, tC.expected, resp)
+			endpointsService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/service"
+)
+
+// ModelEndpointExperimentController controls the model endpoint experiment API
+type ModelEndpointExperimentController struct {
+	*AppContext
+}
+
+// AddExperimentRewards adds the successes and failures reported for the variants of the model endpoint's experiment
+func (c *ModelEndpointExperimentController) AddExperimentRewards(r *http.Request, vars map[string]string, body interface{}) *Response {
+	ctx := r.Context()
+
+	if !c.FeatureToggleConfig.ExperimentConfig.Enabled {
+		return BadRequest("Experiment is not enabled")
+	}
+
+	_, endpoint, response := c.findModelEndpoint(r, vars)
+	if response != nil {
+		return response
+	}
+
+	request, ok := body.(*models.ExperimentRewards)
+	if !ok {
+		return BadRequest("Invalid request body")
+	}
+
+	if err := c.ModelEndpointExperimentService.AddRewards(ctx, endpoint, request.Rewards); err != nil {
+		if errors.Is(err, service.ErrInvalidExperiment) {
+			return BadRequest(fmt.Sprintf("Error adding experiment rewards: %v", err))
+		}
+		return InternalServerError(fmt.Sprintf("Error adding experiment rewards: %v", err))
+	}
+	return NoContent()
+}
+
+// ListExperimentRewards lists the total rewards of the variants of the model endpoint's experiment
+func (c *ModelEndpointExperimentController) ListExperimentRewards(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	ctx := r.Context()
+
+	if !c.FeatureToggleConfig.ExperimentConfig.Enabled {
+		return BadRequest("Experiment is not enabled")
+	}
+
+	_, endpoint, response := c.findModelEndpoint(r, vars)
+	if response != nil {
+		return response
+	}
+
+	rewards, err := c.ModelEndpointExperimentService.ListRewards(ctx, endpoint)
+	if err != nil {
+		return InternalServerError(fmt.Sprintf("Error listing experiment rewards: %v", err))
+	}
+	return Ok(&models.ExperimentRewards{Rewards: rewards})
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/service"
+	"github.com/caraml-dev/merlin/service/mocks"
+)
+
+func TestAddExperimentRewards(t *testing.T) {
+	model := &models.Model{ID: 1, Name: "model-1"}
+	endpoint := &models.ModelEndpoint{ID: 2, ModelID: 1}
+	rewards := []*models.ExperimentReward{{Variant: "control", Successes: 10, Failures: 5}}
+
+	testCases := []struct {
+		desc              string
+		enabled           bool
+		body              interface{}
+		experimentService func() *mocks.ModelEndpointExperimentService
+		expected          *Response
+	}{
+		{
+			desc:    "Should add rewards",
+			enabled: true,
+			body:    &models.ExperimentRewards{Rewards: rewards},
+			experimentService: func() *mocks.ModelEndpointExperimentService {
+				svc := &mocks.ModelEndpointExperimentService{}
+				svc.On("AddRewards", mock.Anything, endpoint, rewards).Return(nil)
+				return svc
+			},
+			expected: &Response{code: http.StatusNoContent},
+		},
+		{
+			desc: "Should return 400 if experiment is disabled",
+			body: &models.ExperimentRewards{Rewards: rewards},
+			experimentService: func() *mocks.ModelEndpointExperimentService {
+				return &mocks.ModelEndpointExperimentService{}
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Experiment is not enabled"},
+			},
+		},
+		{
+			desc:    "Should return 400 if rewards are invalid",
+			enabled: true,
+			body:    &models.ExperimentRewards{Rewards: rewards},
+			experimentService: func() *mocks.ModelEndpointExperimentService {
+				svc := &mocks.ModelEndpointExperimentService{}
+				svc.On("AddRewards", mock.Anything, endpoint, rewards).Return(fmt.Errorf("%w: unknown variant", service.ErrInvalidExperiment))
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Error adding experiment rewards: invalid experiment: unknown variant"},
+			},
+		},
+		{
+			desc:    "Should return 500 if rewards can't be added",
+			enabled: true,
+			body:    &models.ExperimentRewards{Rewards: rewards},
+			experimentService: func() *mocks.ModelEndpointExperimentService {
+				svc := &mocks.ModelEndpointExperimentService{}
+				svc.On("AddRewards", mock.Anything, endpoint, rewards).Return(fmt.Errorf("db is down"))
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusInternalServerError,
+				data: Error{Message: "Error adding experiment rewards: db is down"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			modelsService := &mocks.ModelsService{}
+			modelsService.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+			modelEndpointsService := &mocks.ModelEndpointsService{}
+			modelEndpointsService.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+
+			experimentService := tC.experimentService()
+			ctl := &ModelEndpointExperimentController{
+				AppContext: &AppContext{
+					ModelsService:                  modelsService,
+					ModelEndpointsService:          modelEndpointsService,
+					ModelEndpointExperimentService: experimentService,
+					FeatureToggleConfig: config.FeatureToggleConfig{
+						ExperimentConfig: config.ExperimentConfig{Enabled: tC.enabled},
+					},
+				},
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/models/1/endpoints/2/experiment/rewards", strings.NewReader(""))
+			resp := ctl.AddExperimentRewards(r, map[string]string{"model_id": "1", "model_endpoint_id": "2"}, tC.body)
+			assertEqualResponses(t, tC.expected, resp)
+			experimentService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListExperimentRewards(t *testing.T) {
+	model := &models.Model{ID: 1, Name: "model-1"}
+	endpoint := &models.ModelEndpoint{ID: 2, ModelID: 1}
+	rewards := []*models.ExperimentReward{{ModelEndpointID: 2, ExperimentName: "exp", Variant: "control", Successes: 10, Failures: 5}}
+
+	testCases := []struct {
+		desc              string
+		experimentService func() *mocks.ModelEndpointExperimentService
+		expected          *Response
+	}{
+		{
+			desc: "Should list rewards",
+			experimentService: func() *mocks.ModelEndpointExperimentService {
+				svc := &mocks.ModelEndpointExperimentService{}
+				svc.On("ListRewards", mock.Anything, endpoint).Return(rewards, nil)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: &models.ExperimentRewards{Rewards: rewards},
+			},
+		},
+		{
+			desc: "Should return 500 if rewards can't be listed",
+			experimentService: func() *mocks.ModelEndpointExperimentService {
+				svc := &mocks.ModelEndpointExperimentService{}
+				svc.On("ListRewards", mock.Anything, endpoint).Return(nil, fmt.Errorf("db is down"))
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusInternalServerError,
+				data: Error{Message: "Error listing experiment rewards: db is down"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			modelsService := &mocks.ModelsService{}
+			modelsService.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+			modelEndpointsService := &mocks.ModelEndpointsService{}
+			modelEndpointsService.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+
+			experimentService := tC.experimentService()
+			ctl := &ModelEndpointExperimentController{
+				AppContext: &AppContext{
+					ModelsService:                  modelsService,
+					ModelEndpointsService:          modelEndpointsService,
+					ModelEndpointExperimentService: experimentService,
+					FeatureToggleConfig: config.FeatureToggleConfig{
+						ExperimentConfig: config.ExperimentConfig{Enabled: true},
+					},
+				},
+			}
+			r := httptest.NewRequest(http.MethodGet, "/v1/models/1/endpoints/2/experiment/rewards", strings.NewReader(""))
+			resp := ctl.ListExperimentRewards(r, map[string]string{"model_id": "1", "model_endpoint_id": "2"}, nil)
+			assertEqualResponses(t, tC.expected, resp)
+			experimentService.AssertExpectations(t)
+		})
+	}
+}
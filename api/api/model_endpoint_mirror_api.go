@@ -0,0 +1,131 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/merlin/models"
+	mlogs "github.com/caraml-dev/merlin/pkg/log"
+)
+
+// maxMirrorReportBodyBytes is the maximum size of the decompressed inference logs compared by a mirror report
+const maxMirrorReportBodyBytes = 64 << 20
+
+// ModelEndpointMirrorController controls the model endpoint mirror API
+type ModelEndpointMirrorController struct {
+	*AppContext
+}
+
+// CreateMirrorReport compares the responses of the model endpoint's mirrors with the primary responses.
+//
+// The request body is a JSON array of InferenceLogMessage, e.g. the logs exported from the inference logger's sink.
+// The logs aren't stored by Merlin.
+func (c *ModelEndpointMirrorController) CreateMirrorReport(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	ctx := r.Context()
+
+	model, endpoint, response := c.findModelEndpoint(r, vars)
+	if response != nil {
+		return response
+	}
+
+	tolerance := models.DefaultMirrorAgreementTolerance
+	if vars["tolerance"] != "" {
+		parsed, err := strconv.ParseFloat(vars["tolerance"], 64)
+		if err != nil || parsed < 0 {
+			return BadRequest(fmt.Sprintf("Invalid tolerance: %s", vars["tolerance"]))
+		}
+		tolerance = parsed
+	}
+
+	logs, err := decodeInferenceLogs(r)
+	if err != nil {
+		return BadRequest(fmt.Sprintf("Failed to deserialize inference logs: %v", err))
+	}
+
+	report, err := c.ModelEndpointMirrorService.Report(ctx, model, endpoint, logs, tolerance)
+	if err != nil {
+		return InternalServerError(fmt.Sprintf("Error computing mirror report: %v", err))
+	}
+	return Ok(report)
+}
+
+// findModelEndpoint returns the model and its model endpoint of the request path, or the error response
+func (c *AppContext) findModelEndpoint(r *http.Request, vars map[string]string) (*models.Model, *models.ModelEndpoint, *Response) {
+	ctx := r.Context()
+
+	modelID, _ := models.ParseID(vars["model_id"])
+	model, err := c.ModelsService.FindByID(ctx, modelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, NotFound(fmt.Sprintf("Model not found: %v", err))
+		}
+		return nil, nil, InternalServerError(fmt.Sprintf("Error getting model: %v", err))
+	}
+
+	modelEndpointID, _ := models.ParseID(vars["model_endpoint_id"])
+	endpoint, err := c.ModelEndpointsService.FindByID(ctx, modelEndpointID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, NotFound(fmt.Sprintf("Model endpoint not found: %v", err))
+		}
+		return nil, nil, InternalServerError(fmt.Sprintf("Error getting model endpoint: %v", err))
+	}
+	if endpoint.ModelID != model.ID {
+		return nil, nil, NotFound(fmt.Sprintf("Model endpoint %d not found in model %d", endpoint.ID, model.ID))
+	}
+
+	return model, endpoint, nil
+}
+
+// decodeInferenceLogs decodes the JSON array of InferenceLogMessage, which may be gzip compressed, up to
+// maxMirrorReportBodyBytes once decompressed
+func decodeInferenceLogs(r *http.Request) ([]*mlogs.InferenceLogMessage, error) {
+	var reader io.ReadCloser = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close() //nolint:errcheck
+		reader = gzipReader
+	}
+	reader = http.MaxBytesReader(nil, reader, maxMirrorReportBodyBytes)
+
+	var messages []json.RawMessage
+	if err := json.NewDecoder(reader).Decode(&messages); err != nil {
+		return nil, err
+	}
+
+	unmarshalOptions := protojson.UnmarshalOptions{DiscardUnknown: true}
+	logs := make([]*mlogs.InferenceLogMessage, 0, len(messages))
+	for _, message := range messages {
+		inferenceLog := &mlogs.InferenceLogMessage{}
+		if err := unmarshalOptions.Unmarshal(message, inferenceLog); err != nil {
+			return nil, err
+		}
+		logs = append(logs, inferenceLog)
+	}
+	return logs, nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/merlin/models"
+	mlogs "github.com/caraml-dev/merlin/pkg/log"
+	"github.com/caraml-dev/merlin/service/mocks"
+)
+
+const testInferenceLogs = `[{"requestId":"1","modelName":"model-1","modelVersion":"2","request":{"header":{"X-Merlin-Mirrored":"true"}},"response":{"statusCode":200,"body":"{\"predictions\":[1]}"},"unknownField":true}]`
+
+func TestCreateMirrorReport(t *testing.T) {
+	model := &models.Model{ID: 1, Name: "model-1"}
+	endpoint := &models.ModelEndpoint{ID: 2, ModelID: 1}
+	agreementRate := 0.9
+	report := &models.MirrorReport{
+		ModelEndpointID: 2,
+		StartTime:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Tolerance:       0.01,
+		Mirrors:         []*models.MirrorComparison{{ModelVersion: "2", MirroredRequests: 10, MatchedRequests: 10, AgreementRate: &agreementRate}},
+	}
+
+	testCases := []struct {
+		desc                 string
+		vars                 map[string]string
+		body                 string
+		gzip                 bool
+		contentEncoding      string
+		modelEndpointService func() *mocks.ModelEndpointsService
+		mirrorService        func() *mocks.ModelEndpointMirrorService
+		expected             *Response
+	}{
+		{
+			desc: "Should return mirror report of gzip compressed logs",
+			vars: map[string]string{"model_id": "1", "model_endpoint_id": "2", "tolerance": "0.01"},
+			body: testInferenceLogs,
+			gzip: true,
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				svc := &mocks.ModelEndpointMirrorService{}
+				svc.On("Report", mock.Anything, model, endpoint, mock.MatchedBy(func(logs []*mlogs.InferenceLogMessage) bool {
+					return len(logs) == 1 && logs[0].RequestId == "1" && logs[0].Request.Header["X-Merlin-Mirrored"] == "true"
+				}), 0.01).Return(report, nil)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: report,
+			},
+		},
+		{
+			desc: "Should return mirror report with default tolerance",
+			vars: map[string]string{"model_id": "1", "model_endpoint_id": "2"},
+			body: testInferenceLogs,
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				svc := &mocks.ModelEndpointMirrorService{}
+				svc.On("Report", mock.Anything, model, endpoint, mock.Anything, models.DefaultMirrorAgreementTolerance).Return(report, nil)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: report,
+			},
+		},
+		{
+			desc:            "Should return 400 if body is invalid",
+			vars:            map[string]string{"model_id": "1", "model_endpoint_id": "2"},
+			body:            testInferenceLogs,
+			contentEncoding: "gzip",
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				return &mocks.ModelEndpointMirrorService{}
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Failed to deserialize inference logs: gzip: invalid header"},
+			},
+		},
+		{
+			desc: "Should return 400 if decompressed body is too large",
+			vars: map[string]string{"model_id": "1", "model_endpoint_id": "2"},
+			body: "[" + strings.Repeat(" ", maxMirrorReportBodyBytes) + "]",
+			gzip: true,
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				return &mocks.ModelEndpointMirrorService{}
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Failed to deserialize inference logs: http: request body too large"},
+			},
+		},
+		{
+			desc: "Should return 400 if tolerance is negative",
+			vars: map[string]string{"model_id": "1", "model_endpoint_id": "2", "tolerance": "-1"},
+			body: testInferenceLogs,
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				return &mocks.ModelEndpointMirrorService{}
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Invalid tolerance: -1"},
+			},
+		},
+		{
+			desc: "Should return 404 if model endpoint belongs to other model",
+			vars: map[string]string{"model_id": "1", "model_endpoint_id": "3"},
+			body: testInferenceLogs,
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(3)).Return(&models.ModelEndpoint{ID: 3, ModelID: 5}, nil)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				return &mocks.ModelEndpointMirrorService{}
+			},
+			expected: &Response{
+				code: http.StatusNotFound,
+				data: Error{Message: "Model endpoint 3 not found in model 1"},
+			},
+		},
+		{
+			desc: "Should return 404 if model endpoint is not found",
+			vars: map[string]string{"model_id": "1", "model_endpoint_id": "2"},
+			body: testInferenceLogs,
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(2)).Return(nil, gorm.ErrRecordNotFound)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				return &mocks.ModelEndpointMirrorService{}
+			},
+			expected: &Response{
+				code: http.StatusNotFound,
+				data: Error{Message: "Model endpoint not found: record not found"},
+			},
+		},
+		{
+			desc: "Should return 500 if report fails",
+			vars: map[string]string{"model_id": "1", "model_endpoint_id": "2"},
+			body: testInferenceLogs,
+			modelEndpointService: func() *mocks.ModelEndpointsService {
+				svc := &mocks.ModelEndpointsService{}
+				svc.On("FindByID", mock.Anything, models.ID(2)).Return(endpoint, nil)
+				return svc
+			},
+			mirrorService: func() *mocks.ModelEndpointMirrorService {
+				svc := &mocks.ModelEndpointMirrorService{}
+				svc.On("Report", mock.Anything, model, endpoint, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("unexpected error"))
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusInternalServerError,
+				data: Error{Message: "Error computing mirror report: unexpected error"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			body := []byte(tC.body)
+			contentEncoding := tC.contentEncoding
+			if tC.gzip {
+				var buf bytes.Buffer
+				gzipWriter := gzip.NewWriter(&buf)
+				_, err := gzipWriter.Write(body)
+				require.NoError(t, err)
+				require.NoError(t, gzipWriter.Close())
+				body = buf.Bytes()
+				contentEncoding = "gzip"
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/models/1/endpoints/2/mirror/report", bytes.NewReader(body))
+			r.Header.Set("Content-Encoding", contentEncoding)
+
+			modelsService := &mocks.ModelsService{}
+			modelsService.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+
+			mirrorService := tC.mirrorService()
+			ctl := &ModelEndpointMirrorController{
+				AppContext: &AppContext{
+					ModelsService:              modelsService,
+					ModelEndpointsService:      tC.modelEndpointService(),
+					ModelEndpointMirrorService: mirrorService,
+				},
+			}
+			resp := ctl.CreateMirrorReport(r, tC.vars, nil)
+			assertEqualResponses(t, tC.expected, resp)
+			mirrorService.AssertExpectations(t)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/caraml-dev/merlin/log"
 	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/service"
 	"github.com/caraml-dev/merlin/webhook"
 	"gorm.io/gorm"
 )
@@ -120,9 +121,16 @@ func (c *ModelEndpointsController) CreateModelEndpoint(r *http.Request, vars map
 	}
 	endpoint.Environment = env
 
+	if endpoint.Experiment != nil && !c.FeatureToggleConfig.ExperimentConfig.Enabled {
+		return BadRequest("Experiment is not enabled")
+	}
+
 	// Deploy model endpoint as Istio's VirtualService
 	endpoint, err = c.ModelEndpointsService.DeployEndpoint(ctx, model, endpoint)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidRollout) || errors.Is(err, service.ErrInvalidRoute) || errors.Is(err, service.ErrInvalidMirror) || errors.Is(err, service.ErrInvalidExperiment) {
+			return BadRequest(fmt.Sprintf("Error creating model endpoint: %v", err))
+		}
 		return InternalServerError(fmt.Sprintf("Error creating model endpoint: %v", err))
 	}
 
@@ -188,6 +196,13 @@ func (c *ModelEndpointsController) UpdateModelEndpoint(r *http.Request, vars map
 		return BadRequest("Invalid request model endpoint id")
 	}
 
+	if newEndpoint.Rollout != nil && newEndpoint.Rollout.Status == nil && !c.FeatureToggleConfig.CanaryRolloutConfig.Enabled {
+		return BadRequest("Canary rollout is not enabled")
+	}
+	if newEndpoint.Experiment != nil && !c.FeatureToggleConfig.ExperimentConfig.Enabled {
+		return BadRequest("Experiment is not enabled")
+	}
+
 	if currentEndpoint.Status == models.EndpointTerminated {
 		newEndpoint, err = c.ModelEndpointsService.DeployEndpoint(ctx, model, newEndpoint)
 	} else {
@@ -196,6 +211,9 @@ func (c *ModelEndpointsController) UpdateModelEndpoint(r *http.Request, vars map
 	}
 
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidRollout) || errors.Is(err, service.ErrInvalidRoute) || errors.Is(err, service.ErrInvalidMirror) || errors.Is(err, service.ErrInvalidExperiment) {
+			return BadRequest(fmt.Sprintf("Error updating model endpoint: %v", err))
+		}
 		return InternalServerError(fmt.Sprintf("Error updating model endpoint: %v", err))
 	}
 
@@ -0,0 +1,98 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/service"
+)
+
+// ModelManifestController controls the model manifest API.
+type ModelManifestController struct {
+	*AppContext
+}
+
+// ApplyModelManifest converges the schemas, version endpoints, model endpoints and alerts of a model to the manifest
+// in the request body, in YAML or JSON. The request body is read by the handler since it isn't necessarily JSON.
+func (c *ModelManifestController) ApplyModelManifest(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	ctx := r.Context()
+
+	modelID, _ := models.ParseID(vars["model_id"])
+
+	options := service.ApplyOptions{User: vars["user"], ValidateVersionEndpoint: c.validateVersionEndpoint}
+	var err error
+	if options.DryRun, err = parseDryRun(vars); err != nil {
+		return BadRequest(fmt.Sprintf("Invalid dry_run: %v", err))
+	}
+	if options.Prune, err = parseBoolVar(vars, "prune"); err != nil {
+		return BadRequest(fmt.Sprintf("Invalid prune: %v", err))
+	}
+	if options.Commit, err = parseBoolVar(vars, "commit"); err != nil {
+		return BadRequest(fmt.Sprintf("Invalid commit: %v", err))
+	}
+
+	model, err := c.ModelsService.FindByID(ctx, modelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NotFound(fmt.Sprintf("Model not found: %v", err))
+		}
+		return InternalServerError(fmt.Sprintf("Error getting model: %v", err))
+	}
+
+	options.RawManifest, err = io.ReadAll(r.Body)
+	if err != nil {
+		return BadRequest(fmt.Sprintf("Unable to read request body: %v", err))
+	}
+	manifest, err := models.ParseModelManifest(options.RawManifest)
+	if err != nil {
+		return BadRequest(fmt.Sprintf("Invalid model manifest: %v", err))
+	}
+
+	plan, err := c.ModelManifestService.Apply(ctx, model, manifest, options)
+	if err != nil {
+		// the plan of a partially applied manifest is returned with its error
+		if plan != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, merror.ErrInvalidInput) {
+				code = http.StatusBadRequest
+			}
+			return &Response{code: code, data: plan}
+		}
+		if errors.Is(err, merror.ErrInvalidInput) {
+			return BadRequest(fmt.Sprintf("Unable to apply model manifest: %v", err))
+		}
+		return InternalServerError(fmt.Sprintf("Error applying model manifest: %v", err))
+	}
+
+	return Ok(plan)
+}
+
+// validateVersionEndpoint validates the deployments and undeployments of version endpoints by a manifest with the
+// validators of the version endpoint API
+func (c *ModelManifestController) validateVersionEndpoint(ctx context.Context, model *models.Model, version *models.Version, env *models.Environment, prev *models.VersionEndpoint, new *models.VersionEndpoint) error {
+	if prev == nil {
+		return validateRequest(c.createEndpointValidators(ctx, model, version, env, new)...)
+	}
+	return validateRequest(c.updateEndpointValidators(ctx, model, version, prev, new)...)
+}
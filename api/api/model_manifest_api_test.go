@@ -0,0 +1,265 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+	"github.com/caraml-dev/merlin/service"
+	"github.com/caraml-dev/merlin/service/mocks"
+)
+
+func TestModelManifestController_ApplyModelManifest(t *testing.T) {
+	model := &models.Model{ID: models.ID(1), Name: "my-model"}
+	body := `
+model: my-model
+version_endpoints:
+  - version_id: 1
+    environment_name: production
+`
+	manifest := &models.ModelManifest{
+		Model:            "my-model",
+		VersionEndpoints: []*models.VersionEndpointManifest{{VersionID: 1, EnvironmentName: "production"}},
+	}
+	plan := &models.ApplyPlan{
+		DryRun: true,
+		Changes: []*models.ManifestChange{
+			{Kind: models.ManifestVersionEndpoint, Action: models.ManifestActionCreate, VersionID: 1, EnvironmentName: "production"},
+		},
+		Warnings: []string{},
+	}
+	partialPlan := &models.ApplyPlan{
+		Changes: []*models.ManifestChange{
+			{Kind: models.ManifestVersionEndpoint, Action: models.ManifestActionCreate, VersionID: 1, EnvironmentName: "production"},
+		},
+		Warnings: []string{},
+		Error:    "failed to create version endpoint of version 1 in production: db is down",
+	}
+
+	tests := []struct {
+		desc                 string
+		vars                 map[string]string
+		body                 string
+		modelsService        func() *mocks.ModelsService
+		modelManifestService func() *mocks.ModelManifestService
+		expected             *Response
+	}{
+		{
+			desc: "Should return the plan of a dry-run",
+			vars: map[string]string{"model_id": "1", "dry_run": "true", "prune": "true", "user": "user@example.com"},
+			body: body,
+			modelsService: func() *mocks.ModelsService {
+				svc := &mocks.ModelsService{}
+				svc.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+				return svc
+			},
+			modelManifestService: func() *mocks.ModelManifestService {
+				svc := &mocks.ModelManifestService{}
+				svc.On("Apply", mock.Anything, model, manifest, mock.MatchedBy(func(options service.ApplyOptions) bool {
+					return options.User == "user@example.com" && options.DryRun && options.Prune && !options.Commit &&
+						string(options.RawManifest) == body && options.ValidateVersionEndpoint != nil
+				})).Return(plan, nil)
+				return svc
+			},
+			expected: Ok(plan),
+		},
+		{
+			desc: "Should return bad request if the prune option is invalid",
+			vars: map[string]string{"model_id": "1", "prune": "maybe"},
+			body: body,
+			modelsService: func() *mocks.ModelsService {
+				return &mocks.ModelsService{}
+			},
+			modelManifestService: func() *mocks.ModelManifestService {
+				return &mocks.ModelManifestService{}
+			},
+			expected: BadRequest(`Invalid prune: strconv.ParseBool: parsing "maybe": invalid syntax`),
+		},
+		{
+			desc: "Should return not found if the model doesn't exist",
+			vars: map[string]string{"model_id": "1"},
+			body: body,
+			modelsService: func() *mocks.ModelsService {
+				svc := &mocks.ModelsService{}
+				svc.On("FindByID", mock.Anything, models.ID(1)).Return(nil, gorm.ErrRecordNotFound)
+				return svc
+			},
+			modelManifestService: func() *mocks.ModelManifestService {
+				return &mocks.ModelManifestService{}
+			},
+			expected: NotFound("Model not found: record not found"),
+		},
+		{
+			desc: "Should return bad request if the manifest has an unknown field",
+			vars: map[string]string{"model_id": "1"},
+			body: "model: my-model\nendpoints: []\n",
+			modelsService: func() *mocks.ModelsService {
+				svc := &mocks.ModelsService{}
+				svc.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+				return svc
+			},
+			modelManifestService: func() *mocks.ModelManifestService {
+				return &mocks.ModelManifestService{}
+			},
+			expected: BadRequest(`Invalid model manifest: error unmarshaling JSON: while decoding JSON: json: unknown field "endpoints"`),
+		},
+		{
+			desc: "Should return bad request if the manifest is invalid",
+			vars: map[string]string{"model_id": "1"},
+			body: body,
+			modelsService: func() *mocks.ModelsService {
+				svc := &mocks.ModelsService{}
+				svc.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+				return svc
+			},
+			modelManifestService: func() *mocks.ModelManifestService {
+				svc := &mocks.ModelManifestService{}
+				svc.On("Apply", mock.Anything, model, manifest, mock.Anything).
+					Return(nil, merror.NewInvalidInputError("environment production not found"))
+				return svc
+			},
+			expected: BadRequest("Unable to apply model manifest: invalid input: environment production not found"),
+		},
+		{
+			desc: "Should return internal server error if the apply fails",
+			vars: map[string]string{"model_id": "1"},
+			body: body,
+			modelsService: func() *mocks.ModelsService {
+				svc := &mocks.ModelsService{}
+				svc.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+				return svc
+			},
+			modelManifestService: func() *mocks.ModelManifestService {
+				svc := &mocks.ModelManifestService{}
+				svc.On("Apply", mock.Anything, model, manifest, mock.Anything).Return(nil, errors.New("db is down"))
+				return svc
+			},
+			expected: InternalServerError("Error applying model manifest: db is down"),
+		},
+		{
+			desc: "Should return the partially applied plan if a change fails",
+			vars: map[string]string{"model_id": "1"},
+			body: body,
+			modelsService: func() *mocks.ModelsService {
+				svc := &mocks.ModelsService{}
+				svc.On("FindByID", mock.Anything, models.ID(1)).Return(model, nil)
+				return svc
+			},
+			modelManifestService: func() *mocks.ModelManifestService {
+				svc := &mocks.ModelManifestService{}
+				svc.On("Apply", mock.Anything, model, manifest, mock.Anything).Return(partialPlan, errors.New(partialPlan.Error))
+				return svc
+			},
+			expected: &Response{code: http.StatusInternalServerError, data: partialPlan},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			modelManifestService := tt.modelManifestService()
+			ctl := &ModelManifestController{
+				AppContext: &AppContext{
+					ModelsService:        tt.modelsService(),
+					ModelManifestService: modelManifestService,
+				},
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/models/1/apply", strings.NewReader(tt.body))
+			resp := ctl.ApplyModelManifest(r, tt.vars, nil)
+			assertEqualResponses(t, tt.expected, resp)
+			modelManifestService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModelManifestController_validateVersionEndpoint(t *testing.T) {
+	model := &models.Model{ID: models.ID(1), Name: "my-model", Type: models.ModelTypePyFunc}
+	version := &models.Version{ID: models.ID(1), ModelID: model.ID}
+	env := &models.Environment{Name: "production"}
+	sinkLogger := &models.Logger{
+		Model: &models.LoggerConfig{
+			Enabled: true,
+			Mode:    models.LogAll,
+			Sinks:   []rules.Sink{{Name: "audit", Url: "kafka:broker:9092"}},
+		},
+	}
+
+	tests := []struct {
+		desc    string
+		prev    *models.VersionEndpoint
+		new     *models.VersionEndpoint
+		wantErr string
+	}{
+		{
+			desc: "new version endpoint",
+			new:  &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointRunning},
+		},
+		{
+			desc:    "new version endpoint with a sink which is not allowed",
+			new:     &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointRunning, Logger: sinkLogger},
+			wantErr: "invalid model logger config",
+		},
+		{
+			desc: "redeployment",
+			prev: &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointRunning},
+			new:  &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointRunning},
+		},
+		{
+			desc:    "redeployment with a sink which is not allowed",
+			prev:    &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointRunning},
+			new:     &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointRunning, Logger: sinkLogger},
+			wantErr: "invalid model logger config",
+		},
+		{
+			desc:    "undeployment of a serving version endpoint",
+			prev:    &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointServing},
+			new:     &models.VersionEndpoint{EnvironmentName: env.Name, Status: models.EndpointTerminated},
+			wantErr: "not allowed when the endpoint is currently in the serving state",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			endpointsService := &mocks.EndpointsService{}
+			endpointsService.On("CountEndpoints", mock.Anything, env, model).Return(0, nil)
+			ctl := &ModelManifestController{
+				AppContext: &AppContext{
+					EndpointsService:      endpointsService,
+					InferenceLoggerConfig: config.InferenceLoggerConfig{AllowedSinkKinds: []string{"webhook"}},
+				},
+			}
+
+			err := ctl.validateVersionEndpoint(context.Background(), model, version, env, tt.prev, tt.new)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -53,25 +53,30 @@ type AppContext struct {
 	DB       *gorm.DB
 	Enforcer enforcer.Enforcer
 
-	DeploymentService         service.DeploymentService
-	EnvironmentService        service.EnvironmentService
-	ProjectsService           service.ProjectsService
-	ModelsService             service.ModelsService
-	ModelEndpointsService     service.ModelEndpointsService
-	VersionsService           service.VersionsService
-	VersionImageService       service.VersionImageService
-	EndpointsService          service.EndpointsService
-	LogService                service.LogService
-	PredictionJobService      service.PredictionJobService
-	SecretService             service.SecretService
-	ModelEndpointAlertService service.ModelEndpointAlertService
-	TransformerService        service.TransformerService
-	MlflowDeleteService       mlflowDelete.Service
-	ModelSchemaService        service.ModelSchemaService
+	DeploymentService          service.DeploymentService
+	EnvironmentService         service.EnvironmentService
+	ProjectsService            service.ProjectsService
+	ModelsService              service.ModelsService
+	ModelEndpointsService      service.ModelEndpointsService
+	ModelEndpointMirrorService service.ModelEndpointMirrorService
+	// ModelEndpointExperimentService is nil if experiments are disabled
+	ModelEndpointExperimentService service.ModelEndpointExperimentService
+	VersionsService                service.VersionsService
+	VersionImageService            service.VersionImageService
+	EndpointsService               service.EndpointsService
+	LogService                     service.LogService
+	PredictionJobService           service.PredictionJobService
+	SecretService                  service.SecretService
+	ModelEndpointAlertService      service.ModelEndpointAlertService
+	TransformerService             service.TransformerService
+	MlflowDeleteService            mlflowDelete.Service
+	ModelSchemaService             service.ModelSchemaService
+	ModelManifestService           service.ModelManifestService
 
 	AuthorizationEnabled      bool
 	FeatureToggleConfig       config.FeatureToggleConfig
 	StandardTransformerConfig config.StandardTransformerConfig
+	InferenceLoggerConfig     config.InferenceLoggerConfig
 
 	FeastCoreClient core.CoreServiceClient
 	MlflowClient    mlflow.Client
@@ -162,6 +167,8 @@ func NewRouter(appCtx AppContext) (*mux.Router, error) {
 	environmentController := EnvironmentController{&appCtx}
 	projectsController := ProjectsController{&appCtx}
 	modelEndpointsController := ModelEndpointsController{&appCtx}
+	modelEndpointMirrorController := ModelEndpointMirrorController{&appCtx}
+	modelEndpointExperimentController := ModelEndpointExperimentController{&appCtx}
 	versionsController := VersionsController{&appCtx}
 	versionImageController := VersionImageController{&appCtx}
 	modelsController := ModelsController{&appCtx, &versionsController}
@@ -172,6 +179,7 @@ func NewRouter(appCtx AppContext) (*mux.Router, error) {
 	alertsController := AlertsController{&appCtx}
 	transformerController := TransformerController{&appCtx}
 	modelSchemaController := ModelSchemaController{&appCtx}
+	modelManifestController := ModelManifestController{&appCtx}
 
 	routes := []Route{
 		// Environment API
@@ -199,6 +207,9 @@ func NewRouter(appCtx AppContext) (*mux.Router, error) {
 		{http.MethodGet, "/models/{model_id:[0-9]+}/endpoints/{model_endpoint_id}", nil, modelEndpointsController.GetModelEndpoint, "GetModelEndpoint"},
 		{http.MethodPut, "/models/{model_id:[0-9]+}/endpoints/{model_endpoint_id}", models.ModelEndpoint{}, modelEndpointsController.UpdateModelEndpoint, "UpdateModelEndpoint"},
 		{http.MethodDelete, "/models/{model_id:[0-9]+}/endpoints/{model_endpoint_id}", nil, modelEndpointsController.DeleteModelEndpoint, "DeleteModelEndpoint"},
+		{http.MethodPost, "/models/{model_id:[0-9]+}/endpoints/{model_endpoint_id}/mirror/report", nil, modelEndpointMirrorController.CreateMirrorReport, "CreateModelEndpointMirrorReport"},
+		{http.MethodPost, "/models/{model_id:[0-9]+}/endpoints/{model_endpoint_id}/experiment/rewards", models.ExperimentRewards{}, modelEndpointExperimentController.AddExperimentRewards, "AddModelEndpointExperimentRewards"},
+		{http.MethodGet, "/models/{model_id:[0-9]+}/endpoints/{model_endpoint_id}/experiment/rewards", nil, modelEndpointExperimentController.ListExperimentRewards, "ListModelEndpointExperimentRewards"},
 
 		// Version API
 		{http.MethodGet, "/models/{model_id:[0-9]+}/versions", nil, versionsController.ListVersions, "ListVersions"},
@@ -219,11 +230,15 @@ func NewRouter(appCtx AppContext) (*mux.Router, error) {
 
 		// Deployments API
 		{http.MethodGet, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoints/{endpoint_id}/deployments", nil, deploymentController.ListDeployments, "ListDeployments"},
+		{http.MethodGet, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoints/{endpoint_id}/deployments/diff", nil, deploymentController.DiffDeployments, "DiffDeployments"},
+		{http.MethodGet, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoints/{endpoint_id}/deployments/{deployment_id:[0-9]+}", nil, deploymentController.GetDeployment, "GetDeployment"},
+		{http.MethodPost, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoints/{endpoint_id}/deployments/{deployment_id:[0-9]+}/rollback", nil, deploymentController.RollbackDeployment, "RollbackDeployment"},
 
 		{http.MethodGet, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoint/{endpoint_id}", nil, endpointsController.GetEndpoint, "GetEndpoint"},
 		{http.MethodPut, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoint/{endpoint_id}", models.VersionEndpoint{}, endpointsController.UpdateEndpoint, "UpdateEndpoint"},
 		{http.MethodDelete, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoint/{endpoint_id}", nil, endpointsController.DeleteEndpoint, "DeleteEndpoint"},
 		{http.MethodGet, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoint/{endpoint_id}/containers", nil, endpointsController.ListContainers, "ListContainers"},
+		{http.MethodGet, "/models/{model_id:[0-9]+}/versions/{version_id:[0-9]+}/endpoint/{endpoint_id}/scaling", nil, endpointsController.GetEffectiveScaling, "GetEffectiveScaling"},
 
 		// Prediction Job API
 		{http.MethodGet, "/projects/{project_id:[0-9]+}/jobs", nil, predictionJobController.ListAllInProject, "ListAllPredictionJobInProject"},
@@ -243,6 +258,9 @@ func NewRouter(appCtx AppContext) (*mux.Router, error) {
 		{http.MethodGet, "/models/{model_id:[0-9]+}/schemas/{schema_id:[0-9]+}", models.ModelSchema{}, modelSchemaController.GetSchema, "GetSchemaDetail"},
 		{http.MethodPut, "/models/{model_id:[0-9]+}/schemas", models.ModelSchema{}, modelSchemaController.CreateOrUpdateSchema, "CreateOrUpdateSchema"},
 		{http.MethodDelete, "/models/{model_id:[0-9]+}/schemas/{schema_id:[0-9]+}", models.ModelSchema{}, modelSchemaController.DeleteSchema, "DeleteSchema"},
+
+		// Model Manifest API
+		{http.MethodPost, "/models/{model_id:[0-9]+}/apply", nil, modelManifestController.ApplyModelManifest, "ApplyModelManifest"},
 	}
 
 	if appCtx.FeatureToggleConfig.ModelDeletionConfig.Enabled {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"gorm.io/gorm"
 
@@ -43,3 +44,11 @@ func (c *AppContext) getModelAndVersion(ctx context.Context, modelID models.ID,
 
 	return model, version, nil
 }
+
+// parseBoolVar parses a boolean query parameter, it is false if not set
+func parseBoolVar(vars map[string]string, key string) (bool, error) {
+	if vars[key] == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(vars[key])
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/caraml-dev/merlin/config"
 	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	"github.com/caraml-dev/merlin/service"
 	"github.com/feast-dev/feast/sdk/go/protos/feast/core"
@@ -174,7 +175,7 @@ func deploymentModeValidation(prev *models.VersionEndpoint, new *models.VersionE
 	})
 }
 
-func modelObservabilityValidation(endpoint *models.VersionEndpoint, model *models.Model) requestValidator {
+func modelObservabilityValidation(endpoint *models.VersionEndpoint, model *models.Model, version *models.Version) requestValidator {
 	return newFuncValidate(func() error {
 		if !endpoint.IsModelMonitoringEnabled() {
 			return nil
@@ -184,6 +185,46 @@ func modelObservabilityValidation(endpoint *models.VersionEndpoint, model *model
 			return fmt.Errorf("%s: %w", model.Type, ErrUnsupportedObservabilityModelType)
 		}
 
+		if mapping := endpoint.ModelObservability.PredictionLogMapping; mapping != nil {
+			if version.ModelSchema == nil || version.ModelSchema.Spec == nil {
+				return fmt.Errorf("prediction log mapping requires the model version to have model schema")
+			}
+			if err := mapping.Validate(); err != nil {
+				return fmt.Errorf("invalid prediction log mapping: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func loggerValidation(endpoint *models.VersionEndpoint, loggerConfig config.InferenceLoggerConfig) requestValidator {
+	return newFuncValidate(func() error {
+		if endpoint.Logger == nil {
+			return nil
+		}
+
+		sinkAllowlist := &rules.SinkAllowlist{
+			Kinds: loggerConfig.AllowedSinkKinds,
+			Hosts: loggerConfig.AllowedSinkHosts,
+		}
+		if endpoint.Logger.Model != nil {
+			if err := validateLoggerConfig(endpoint.Logger.Model, sinkAllowlist); err != nil {
+				return fmt.Errorf("invalid model logger config: %w", err)
+			}
+		}
+		if endpoint.Logger.Transformer != nil {
+			if err := validateLoggerConfig(endpoint.Logger.Transformer, sinkAllowlist); err != nil {
+				return fmt.Errorf("invalid transformer logger config: %w", err)
+			}
+		}
 		return nil
 	})
 }
+
+func validateLoggerConfig(loggerConfig *models.LoggerConfig, sinkAllowlist *rules.SinkAllowlist) error {
+	if err := loggerConfig.PayloadRules().Validate(); err != nil {
+		return err
+	}
+	return sinkAllowlist.Validate(loggerConfig.Sinks)
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	merror "github.com/caraml-dev/merlin/pkg/errors"
 	"github.com/caraml-dev/merlin/pkg/protocol"
@@ -112,14 +113,44 @@ func (c *EndpointsController) GetEndpoint(r *http.Request, vars map[string]strin
 	return Ok(endpoint)
 }
 
+// GetEffectiveScaling get the current scaling of a version endpoint, taking its scaling schedules into account
+func (c *EndpointsController) GetEffectiveScaling(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	ctx := r.Context()
+
+	modelID, _ := models.ParseID(vars["model_id"])
+	versionID, _ := models.ParseID(vars["version_id"])
+	endpointID, _ := uuid.Parse(vars["endpoint_id"])
+
+	_, err := c.VersionsService.FindByID(ctx, modelID, versionID, c.FeatureToggleConfig.MonitoringConfig)
+	if err != nil {
+		return NotFound(fmt.Sprintf("Version not found: %v", err))
+	}
+
+	endpoint, err := c.EndpointsService.FindByID(ctx, endpointID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NotFound(fmt.Sprintf("Version endpoint not found: %v", err))
+		}
+		return InternalServerError(fmt.Sprintf("Error getting version endpoint: %v", err))
+	}
+
+	return Ok(models.NewEffectiveScaling(endpoint, time.Now()))
+}
+
 // CreateEndpoint create new endpoint from a model version and deploy to certain environment as specified by request
 // If target environment is not set then fallback to default environment
+// If dry_run is true, the deployment is validated and its Kubernetes resources are returned without deploying anything
 func (c *EndpointsController) CreateEndpoint(r *http.Request, vars map[string]string, body interface{}) *Response {
 	ctx := r.Context()
 
 	modelID, _ := models.ParseID(vars["model_id"])
 	versionID, _ := models.ParseID(vars["version_id"])
 
+	dryRun, err := parseDryRun(vars)
+	if err != nil {
+		return BadRequest(fmt.Sprintf("Invalid dry_run: %v", err))
+	}
+
 	model, version, err := c.getModelAndVersion(ctx, modelID, versionID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -157,18 +188,12 @@ func (c *EndpointsController) CreateEndpoint(r *http.Request, vars map[string]st
 		newEndpoint.SetModeObservabilityIfNil()
 	}
 
-	validationRules := []requestValidator{
-		resourceRequestValidation(newEndpoint),
-		customModelValidation(model, version),
-		upiModelValidation(model, newEndpoint.Protocol),
-		newVersionEndpointValidation(version, env.Name),
-		deploymentQuotaValidation(ctx, model, env, c.EndpointsService),
-		transformerValidation(ctx, newEndpoint, c.StandardTransformerConfig, c.FeastCoreClient),
-		modelObservabilityValidation(newEndpoint, model),
+	if err := validateRequest(c.createEndpointValidators(ctx, model, version, env, newEndpoint)...); err != nil {
+		return BadRequest(fmt.Sprintf("Request validation failed: %v", err))
 	}
 
-	if err := validateRequest(validationRules...); err != nil {
-		return BadRequest(fmt.Sprintf("Request validation failed: %v", err))
+	if dryRun {
+		return c.dryRunEndpoint(ctx, env, model, version, newEndpoint)
 	}
 
 	endpoint, err := c.EndpointsService.DeployEndpoint(ctx, env, model, version, newEndpoint)
@@ -183,6 +208,7 @@ func (c *EndpointsController) CreateEndpoint(r *http.Request, vars map[string]st
 }
 
 // UpdateEndpoint update a an existing endpoint i.e. trigger redeployment
+// If dry_run is true, the redeployment is validated and its Kubernetes resources are returned without deploying anything
 func (c *EndpointsController) UpdateEndpoint(r *http.Request, vars map[string]string, body interface{}) *Response {
 	ctx := r.Context()
 
@@ -190,6 +216,11 @@ func (c *EndpointsController) UpdateEndpoint(r *http.Request, vars map[string]st
 	versionID, _ := models.ParseID(vars["version_id"])
 	endpointID, _ := uuid.Parse(vars["endpoint_id"])
 
+	dryRun, err := parseDryRun(vars)
+	if err != nil {
+		return BadRequest(fmt.Sprintf("Invalid dry_run: %v", err))
+	}
+
 	model, version, err := c.getModelAndVersion(ctx, modelID, versionID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -221,24 +252,17 @@ func (c *EndpointsController) UpdateEndpoint(r *http.Request, vars map[string]st
 		return InternalServerError(fmt.Sprintf("Error getting the specified environment: %v", err))
 	}
 
-	validationRules := []requestValidator{
-		resourceRequestValidation(newEndpoint),
-		customModelValidation(model, version),
-		updateRequestValidation(endpoint, newEndpoint),
-		modelObservabilityValidation(newEndpoint, model),
-	}
+	validationRules := c.updateEndpointValidators(ctx, model, version, endpoint, newEndpoint)
 
 	if newEndpoint.Status == models.EndpointRunning || newEndpoint.Status == models.EndpointServing {
-		validationRules = append(
-			validationRules,
-			transformerValidation(ctx, newEndpoint, c.StandardTransformerConfig, c.FeastCoreClient),
-			deploymentModeValidation(endpoint, newEndpoint),
-		)
-
 		if err := validateRequest(validationRules...); err != nil {
 			return BadRequest(fmt.Sprintf("Request validation failed: %v", err))
 		}
 
+		if dryRun {
+			return c.dryRunEndpoint(ctx, env, model, version, newEndpoint)
+		}
+
 		endpoint, err = c.EndpointsService.DeployEndpoint(ctx, env, model, version, newEndpoint)
 		if err != nil {
 			if errors.Is(err, merror.ErrInvalidInput) {
@@ -248,6 +272,10 @@ func (c *EndpointsController) UpdateEndpoint(r *http.Request, vars map[string]st
 			return InternalServerError(fmt.Sprintf("Unable to deploy model version: %v", err))
 		}
 	} else if newEndpoint.Status == models.EndpointTerminated {
+		if dryRun {
+			return BadRequest("Dry-run is only supported for deployments")
+		}
+
 		if err := validateRequest(validationRules...); err != nil {
 			return BadRequest(fmt.Sprintf("Request validation failed: %v", err))
 		}
@@ -263,6 +291,58 @@ func (c *EndpointsController) UpdateEndpoint(r *http.Request, vars map[string]st
 	return Ok(endpoint)
 }
 
+// createEndpointValidators returns the validators of the deployment of a new version endpoint in env
+func (c *AppContext) createEndpointValidators(ctx context.Context, model *models.Model, version *models.Version, env *models.Environment, new *models.VersionEndpoint) []requestValidator {
+	return []requestValidator{
+		resourceRequestValidation(new),
+		customModelValidation(model, version),
+		upiModelValidation(model, new.Protocol),
+		newVersionEndpointValidation(version, env.Name),
+		deploymentQuotaValidation(ctx, model, env, c.EndpointsService),
+		transformerValidation(ctx, new, c.StandardTransformerConfig, c.FeastCoreClient),
+		modelObservabilityValidation(new, model, version),
+		loggerValidation(new, c.InferenceLoggerConfig),
+	}
+}
+
+// updateEndpointValidators returns the validators of the update of a version endpoint from prev to new, the transformer
+// and the deployment mode are only validated when new is deployed
+func (c *AppContext) updateEndpointValidators(ctx context.Context, model *models.Model, version *models.Version, prev *models.VersionEndpoint, new *models.VersionEndpoint) []requestValidator {
+	validationRules := []requestValidator{
+		resourceRequestValidation(new),
+		customModelValidation(model, version),
+		updateRequestValidation(prev, new),
+		modelObservabilityValidation(new, model, version),
+		loggerValidation(new, c.InferenceLoggerConfig),
+	}
+
+	if new.Status == models.EndpointRunning || new.Status == models.EndpointServing {
+		validationRules = append(
+			validationRules,
+			transformerValidation(ctx, new, c.StandardTransformerConfig, c.FeastCoreClient),
+			deploymentModeValidation(prev, new),
+		)
+	}
+	return validationRules
+}
+
+func (c *EndpointsController) dryRunEndpoint(ctx context.Context, env *models.Environment, model *models.Model, version *models.Version, newEndpoint *models.VersionEndpoint) *Response {
+	plan, err := c.EndpointsService.DryRunEndpoint(ctx, env, model, version, newEndpoint)
+	if err != nil {
+		if errors.Is(err, merror.ErrInvalidInput) {
+			return BadRequest(fmt.Sprintf("Dry-run of model version deployment failed: %v", err))
+		}
+		return InternalServerError(fmt.Sprintf("Unable to dry-run model version deployment: %v", err))
+	}
+
+	return Ok(plan)
+}
+
+// parseDryRun returns whether the request only asks for a dry-run of the deployment
+func parseDryRun(vars map[string]string) (bool, error) {
+	return parseBoolVar(vars, "dry_run")
+}
+
 // DeleteEndpoint undeploys running model version endpoint.
 func (c *EndpointsController) DeleteEndpoint(r *http.Request, vars map[string]string, _ interface{}) *Response {
 	ctx := r.Context()
@@ -24,6 +24,7 @@ import (
 	"github.com/caraml-dev/merlin/mlp"
 	"github.com/caraml-dev/merlin/models"
 	"github.com/caraml-dev/merlin/pkg/deployment"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	"github.com/caraml-dev/merlin/pkg/transformer"
 	feastmocks "github.com/caraml-dev/merlin/pkg/transformer/feast/mocks"
@@ -492,6 +493,112 @@ func TestGetEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetEffectiveScaling(t *testing.T) {
+	uuid := uuid.New()
+	testCases := []struct {
+		desc            string
+		vars            map[string]string
+		versionService  func() *mocks.VersionsService
+		endpointService func() *mocks.EndpointsService
+		expected        *Response
+	}{
+		{
+			desc: "Should success get effective scaling",
+			vars: map[string]string{
+				"model_id":    "1",
+				"version_id":  "1",
+				"endpoint_id": uuid.String(),
+			},
+			versionService: func() *mocks.VersionsService {
+				svc := &mocks.VersionsService{}
+				svc.On("FindByID", context.Background(), models.ID(1), models.ID(1), mock.Anything).Return(&models.Version{
+					ID:      models.ID(1),
+					ModelID: models.ID(1),
+				}, nil)
+				return svc
+			},
+			endpointService: func() *mocks.EndpointsService {
+				svc := &mocks.EndpointsService{}
+				svc.On("FindByID", context.Background(), uuid).Return(&models.VersionEndpoint{
+					ID:             uuid,
+					VersionID:      models.ID(1),
+					VersionModelID: models.ID(1),
+					Status:         models.EndpointServing,
+					ResourceRequest: &models.ResourceRequest{
+						MinReplica: 1,
+						MaxReplica: 2,
+					},
+				}, nil)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: &models.EffectiveScaling{
+					MinReplica: 1,
+					MaxReplica: 2,
+				},
+			},
+		},
+		{
+			desc: "Should return 404 if model version is not found",
+			vars: map[string]string{
+				"model_id":    "1",
+				"version_id":  "1",
+				"endpoint_id": uuid.String(),
+			},
+			versionService: func() *mocks.VersionsService {
+				svc := &mocks.VersionsService{}
+				svc.On("FindByID", context.Background(), models.ID(1), models.ID(1), mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+				return svc
+			},
+			endpointService: func() *mocks.EndpointsService {
+				return &mocks.EndpointsService{}
+			},
+			expected: &Response{
+				code: http.StatusNotFound,
+				data: Error{Message: "Version not found: record not found"},
+			},
+		},
+		{
+			desc: "Should return 404 if there is no version endpoint in the db",
+			vars: map[string]string{
+				"model_id":    "1",
+				"version_id":  "1",
+				"endpoint_id": uuid.String(),
+			},
+			versionService: func() *mocks.VersionsService {
+				svc := &mocks.VersionsService{}
+				svc.On("FindByID", context.Background(), models.ID(1), models.ID(1), mock.Anything).Return(&models.Version{
+					ID:      models.ID(1),
+					ModelID: models.ID(1),
+				}, nil)
+				return svc
+			},
+			endpointService: func() *mocks.EndpointsService {
+				svc := &mocks.EndpointsService{}
+				svc.On("FindByID", context.Background(), uuid).Return(nil, gorm.ErrRecordNotFound)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusNotFound,
+				data: Error{Message: "Version endpoint not found: record not found"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctl := &EndpointsController{
+				AppContext: &AppContext{
+					VersionsService:  tC.versionService(),
+					EndpointsService: tC.endpointService(),
+				},
+			}
+			resp := ctl.GetEffectiveScaling(&http.Request{}, tC.vars, nil)
+			assertEqualResponses(t, tC.expected, resp)
+		})
+	}
+}
+
 func TestListContainers(t *testing.T) {
 	uuid := uuid.New()
 	testCases := []struct {
@@ -6030,6 +6137,184 @@ func TestUpdateEndpoint(t *testing.T) {
 	}
 }
 
+func TestDryRunEndpoint(t *testing.T) {
+	endpointID := uuid.New()
+	model := &models.Model{ID: models.ID(1), Name: "model-1", ProjectID: models.ID(1), Type: "pyfunc"}
+	version := &models.Version{ID: models.ID(1), ModelID: models.ID(1), Model: model}
+	env := &models.Environment{ID: models.ID(1), Name: "dev", Cluster: "dev"}
+	resourceRequest := &models.ResourceRequest{
+		MinReplica:    1,
+		MaxReplica:    4,
+		CPURequest:    resource.MustParse("1"),
+		MemoryRequest: resource.MustParse("1Gi"),
+	}
+	plan := &models.DeploymentPlan{
+		Endpoint: &models.VersionEndpoint{ID: endpointID, ResourceRequest: resourceRequest},
+		Manifests: []*models.DeploymentManifest{
+			{Kind: "InferenceService", Name: "model-1-1-r2", Namespace: "project", Verified: true},
+		},
+		Warnings: []string{},
+	}
+
+	testCases := []struct {
+		desc            string
+		update          bool
+		vars            map[string]string
+		requestBody     *models.VersionEndpoint
+		endpointService func() *mocks.EndpointsService
+		expected        *Response
+	}{
+		{
+			desc: "Should return the deployment plan of a new endpoint",
+			vars: map[string]string{"model_id": "1", "version_id": "1", "dry_run": "true"},
+			requestBody: &models.VersionEndpoint{
+				EnvironmentName: "dev",
+				ResourceRequest: resourceRequest,
+			},
+			endpointService: func() *mocks.EndpointsService {
+				svc := &mocks.EndpointsService{}
+				svc.On("CountEndpoints", context.Background(), env, model).Return(0, nil)
+				svc.On("DryRunEndpoint", context.Background(), env, model, version, mock.Anything).Return(plan, nil)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: plan,
+			},
+		},
+		{
+			desc: "Should return bad request if the cluster rejects the deployment",
+			vars: map[string]string{"model_id": "1", "version_id": "1", "dry_run": "true"},
+			requestBody: &models.VersionEndpoint{
+				EnvironmentName: "dev",
+				ResourceRequest: resourceRequest,
+			},
+			endpointService: func() *mocks.EndpointsService {
+				svc := &mocks.EndpointsService{}
+				svc.On("CountEndpoints", context.Background(), env, model).Return(0, nil)
+				svc.On("DryRunEndpoint", context.Background(), env, model, version, mock.Anything).
+					Return(nil, merror.NewInvalidInputError("inference service model-1-1-r2 is rejected by the cluster: exceeded quota"))
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Dry-run of model version deployment failed: invalid input: inference service model-1-1-r2 is rejected by the cluster: exceeded quota"},
+			},
+		},
+		{
+			desc: "Should return internal server error if the dry-run fails",
+			vars: map[string]string{"model_id": "1", "version_id": "1", "dry_run": "true"},
+			requestBody: &models.VersionEndpoint{
+				EnvironmentName: "dev",
+				ResourceRequest: resourceRequest,
+			},
+			endpointService: func() *mocks.EndpointsService {
+				svc := &mocks.EndpointsService{}
+				svc.On("CountEndpoints", context.Background(), env, model).Return(0, nil)
+				svc.On("DryRunEndpoint", context.Background(), env, model, version, mock.Anything).Return(nil, fmt.Errorf("connection refused"))
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusInternalServerError,
+				data: Error{Message: "Unable to dry-run model version deployment: connection refused"},
+			},
+		},
+		{
+			desc: "Should return bad request if dry_run is invalid",
+			vars: map[string]string{"model_id": "1", "version_id": "1", "dry_run": "maybe"},
+			requestBody: &models.VersionEndpoint{
+				EnvironmentName: "dev",
+				ResourceRequest: resourceRequest,
+			},
+			endpointService: func() *mocks.EndpointsService {
+				return &mocks.EndpointsService{}
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Invalid dry_run: strconv.ParseBool: parsing \"maybe\": invalid syntax"},
+			},
+		},
+		{
+			desc:   "Should return the deployment plan of an updated endpoint",
+			update: true,
+			vars:   map[string]string{"model_id": "1", "version_id": "1", "endpoint_id": endpointID.String(), "dry_run": "true"},
+			requestBody: &models.VersionEndpoint{
+				EnvironmentName: "dev",
+				Status:          models.EndpointRunning,
+				ResourceRequest: resourceRequest,
+			},
+			endpointService: func() *mocks.EndpointsService {
+				svc := &mocks.EndpointsService{}
+				svc.On("FindByID", context.Background(), endpointID).Return(&models.VersionEndpoint{
+					ID:              endpointID,
+					EnvironmentName: "dev",
+					Status:          models.EndpointRunning,
+				}, nil)
+				svc.On("DryRunEndpoint", context.Background(), env, model, version, mock.Anything).Return(plan, nil)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusOK,
+				data: plan,
+			},
+		},
+		{
+			desc:   "Should return bad request when dry-running an undeployment",
+			update: true,
+			vars:   map[string]string{"model_id": "1", "version_id": "1", "endpoint_id": endpointID.String(), "dry_run": "true"},
+			requestBody: &models.VersionEndpoint{
+				EnvironmentName: "dev",
+				Status:          models.EndpointTerminated,
+			},
+			endpointService: func() *mocks.EndpointsService {
+				svc := &mocks.EndpointsService{}
+				svc.On("FindByID", context.Background(), endpointID).Return(&models.VersionEndpoint{
+					ID:              endpointID,
+					EnvironmentName: "dev",
+					Status:          models.EndpointRunning,
+				}, nil)
+				return svc
+			},
+			expected: &Response{
+				code: http.StatusBadRequest,
+				data: Error{Message: "Dry-run is only supported for deployments"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			modelSvc := &mocks.ModelsService{}
+			modelSvc.On("FindByID", context.Background(), models.ID(1)).Return(model, nil)
+			versionSvc := &mocks.VersionsService{}
+			versionSvc.On("FindByID", context.Background(), models.ID(1), models.ID(1), mock.Anything).Return(version, nil)
+			envSvc := &mocks.EnvironmentService{}
+			envSvc.On("GetDefaultEnvironment").Return(env, nil)
+			envSvc.On("GetEnvironment", "dev").Return(env, nil)
+			endpointSvc := tC.endpointService()
+
+			ctl := &EndpointsController{
+				AppContext: &AppContext{
+					ModelsService:      modelSvc,
+					VersionsService:    versionSvc,
+					EnvironmentService: envSvc,
+					EndpointsService:   endpointSvc,
+					FeastCoreClient:    &feastmocks.CoreServiceClient{},
+				},
+			}
+
+			var resp *Response
+			if tC.update {
+				resp = ctl.UpdateEndpoint(&http.Request{}, tC.vars, tC.requestBody)
+			} else {
+				resp = ctl.CreateEndpoint(&http.Request{}, tC.vars, tC.requestBody)
+			}
+			assertEqualResponses(t, tC.expected, resp)
+			endpointSvc.AssertNotCalled(t, "DeployEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			endpointSvc.AssertNotCalled(t, "UndeployEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestDeleteEndpoint(t *testing.T) {
 	uuid := uuid.New()
 	trueBoolean := true
@@ -514,6 +514,130 @@ func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointEndpointIdPut
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetRequest struct {
+	ctx        context.Context
+	ApiService *EndpointAPIService
+	modelId    int32
+	versionId  int32
+	endpointId string
+}
+
+func (r ApiModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetRequest) Execute() (*EffectiveScaling, *http.Response, error) {
+	return r.ApiService.ModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetExecute(r)
+}
+
+/*
+ModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGet Get the current scaling of a version endpoint, taking its scaling schedules into account
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param versionId
+	@param endpointId
+	@return ApiModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetRequest
+*/
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGet(ctx context.Context, modelId int32, versionId int32, endpointId string) ApiModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetRequest {
+	return ApiModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetRequest{
+		ApiService: a,
+		ctx:        ctx,
+		modelId:    modelId,
+		versionId:  versionId,
+		endpointId: endpointId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return EffectiveScaling
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetExecute(r ApiModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGetRequest) (*EffectiveScaling, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *EffectiveScaling
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "EndpointAPIService.ModelsModelIdVersionsVersionIdEndpointEndpointIdScalingGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/versions/{version_id}/endpoint/{endpoint_id}/scaling"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"version_id"+"}", url.PathEscape(parameterValueToString(r.versionId, "versionId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"endpoint_id"+"}", url.PathEscape(parameterValueToString(r.endpointId, "endpointId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"*/*"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiModelsModelIdVersionsVersionIdEndpointGetRequest struct {
 	ctx        context.Context
 	ApiService *EndpointAPIService
@@ -761,3 +885,527 @@ func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointPostExecute(r
 
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
+
+type ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetRequest struct {
+	ctx          context.Context
+	ApiService   *EndpointAPIService
+	modelId      int32
+	versionId    int32
+	endpointId   string
+	deploymentId int32
+}
+
+func (r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetRequest) Execute() (*Deployment, *http.Response, error) {
+	return r.ApiService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetExecute(r)
+}
+
+/*
+ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGet Get a deployment of a version endpoint, including the configuration it deployed
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param versionId
+	@param endpointId
+	@param deploymentId
+	@return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetRequest
+*/
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGet(ctx context.Context, modelId int32, versionId int32, endpointId string, deploymentId int32) ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetRequest {
+	return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetRequest{
+		ApiService:   a,
+		ctx:          ctx,
+		modelId:      modelId,
+		versionId:    versionId,
+		endpointId:   endpointId,
+		deploymentId: deploymentId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return Deployment
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetExecute(r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGetRequest) (*Deployment, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *Deployment
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "EndpointAPIService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/versions/{version_id}/endpoints/{endpoint_id}/deployments/{deployment_id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"version_id"+"}", url.PathEscape(parameterValueToString(r.versionId, "versionId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"endpoint_id"+"}", url.PathEscape(parameterValueToString(r.endpointId, "endpointId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"deployment_id"+"}", url.PathEscape(parameterValueToString(r.deploymentId, "deploymentId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"*/*"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostRequest struct {
+	ctx          context.Context
+	ApiService   *EndpointAPIService
+	modelId      int32
+	versionId    int32
+	endpointId   string
+	deploymentId int32
+}
+
+func (r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostRequest) Execute() (*VersionEndpoint, *http.Response, error) {
+	return r.ApiService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostExecute(r)
+}
+
+/*
+ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPost Redeploy the configuration of a previous deployment of the version endpoint
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param versionId
+	@param endpointId
+	@param deploymentId
+	@return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostRequest
+*/
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPost(ctx context.Context, modelId int32, versionId int32, endpointId string, deploymentId int32) ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostRequest {
+	return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostRequest{
+		ApiService:   a,
+		ctx:          ctx,
+		modelId:      modelId,
+		versionId:    versionId,
+		endpointId:   endpointId,
+		deploymentId: deploymentId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return VersionEndpoint
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostExecute(r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPostRequest) (*VersionEndpoint, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *VersionEndpoint
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "EndpointAPIService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDeploymentIdRollbackPost")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/versions/{version_id}/endpoints/{endpoint_id}/deployments/{deployment_id}/rollback"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"version_id"+"}", url.PathEscape(parameterValueToString(r.versionId, "versionId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"endpoint_id"+"}", url.PathEscape(parameterValueToString(r.endpointId, "endpointId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"deployment_id"+"}", url.PathEscape(parameterValueToString(r.deploymentId, "deploymentId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"*/*"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest struct {
+	ctx        context.Context
+	ApiService *EndpointAPIService
+	modelId    int32
+	versionId  int32
+	endpointId string
+	from       *int32
+	to         *int32
+}
+
+func (r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest) From(from int32) ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest {
+	r.from = &from
+	return r
+}
+
+func (r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest) To(to int32) ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest {
+	r.to = &to
+	return r
+}
+
+func (r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest) Execute() (*DeploymentDiff, *http.Response, error) {
+	return r.ApiService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetExecute(r)
+}
+
+/*
+ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGet Compare the configurations deployed by two deployments of a version endpoint
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param versionId
+	@param endpointId
+	@return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest
+*/
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGet(ctx context.Context, modelId int32, versionId int32, endpointId string) ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest {
+	return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest{
+		ApiService: a,
+		ctx:        ctx,
+		modelId:    modelId,
+		versionId:  versionId,
+		endpointId: endpointId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return DeploymentDiff
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetExecute(r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGetRequest) (*DeploymentDiff, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *DeploymentDiff
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "EndpointAPIService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsDiffGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/versions/{version_id}/endpoints/{endpoint_id}/deployments/diff"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"version_id"+"}", url.PathEscape(parameterValueToString(r.versionId, "versionId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"endpoint_id"+"}", url.PathEscape(parameterValueToString(r.endpointId, "endpointId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.from == nil {
+		return localVarReturnValue, nil, reportError("from is required and must be specified")
+	}
+	if r.to == nil {
+		return localVarReturnValue, nil, reportError("to is required and must be specified")
+	}
+
+	parameterAddToHeaderOrQuery(localVarQueryParams, "from", r.from, "")
+	parameterAddToHeaderOrQuery(localVarQueryParams, "to", r.to, "")
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"*/*"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetRequest struct {
+	ctx        context.Context
+	ApiService *EndpointAPIService
+	modelId    int32
+	versionId  int32
+	endpointId string
+}
+
+func (r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetRequest) Execute() ([]Deployment, *http.Response, error) {
+	return r.ApiService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetExecute(r)
+}
+
+/*
+ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGet List the deployments of a version endpoint
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param versionId
+	@param endpointId
+	@return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetRequest
+*/
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGet(ctx context.Context, modelId int32, versionId int32, endpointId string) ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetRequest {
+	return ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetRequest{
+		ApiService: a,
+		ctx:        ctx,
+		modelId:    modelId,
+		versionId:  versionId,
+		endpointId: endpointId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []Deployment
+func (a *EndpointAPIService) ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetExecute(r ApiModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGetRequest) ([]Deployment, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []Deployment
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "EndpointAPIService.ModelsModelIdVersionsVersionIdEndpointsEndpointIdDeploymentsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/versions/{version_id}/endpoints/{endpoint_id}/deployments"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"version_id"+"}", url.PathEscape(parameterValueToString(r.versionId, "versionId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"endpoint_id"+"}", url.PathEscape(parameterValueToString(r.endpointId, "endpointId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"*/*"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
@@ -246,6 +246,242 @@ func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdDeleteEx
 	return localVarHTTPResponse, nil
 }
 
+type ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetRequest struct {
+	ctx             context.Context
+	ApiService      *ModelEndpointsAPIService
+	modelId         int32
+	modelEndpointId int32
+}
+
+func (r ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetRequest) Execute() (*ExperimentRewards, *http.Response, error) {
+	return r.ApiService.ModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetExecute(r)
+}
+
+/*
+ModelsModelIdEndpointsModelEndpointIdExperimentRewardsGet List the total rewards of the variants of the model endpoint's experiment
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param modelEndpointId
+	@return ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetRequest
+*/
+func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdExperimentRewardsGet(ctx context.Context, modelId int32, modelEndpointId int32) ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetRequest {
+	return ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetRequest{
+		ApiService:      a,
+		ctx:             ctx,
+		modelId:         modelId,
+		modelEndpointId: modelEndpointId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return ExperimentRewards
+func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetExecute(r ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsGetRequest) (*ExperimentRewards, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *ExperimentRewards
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ModelEndpointsAPIService.ModelsModelIdEndpointsModelEndpointIdExperimentRewardsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/endpoints/{model_endpoint_id}/experiment/rewards"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"model_endpoint_id"+"}", url.PathEscape(parameterValueToString(r.modelEndpointId, "modelEndpointId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"*/*"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest struct {
+	ctx             context.Context
+	ApiService      *ModelEndpointsAPIService
+	modelId         int32
+	modelEndpointId int32
+	body            *ExperimentRewards
+}
+
+func (r ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest) Body(body ExperimentRewards) ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest {
+	r.body = &body
+	return r
+}
+
+func (r ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest) Execute() (*http.Response, error) {
+	return r.ApiService.ModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostExecute(r)
+}
+
+/*
+ModelsModelIdEndpointsModelEndpointIdExperimentRewardsPost Add the successes and failures observed for the variants of the model endpoint's experiment
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param modelEndpointId
+	@return ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest
+*/
+func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdExperimentRewardsPost(ctx context.Context, modelId int32, modelEndpointId int32) ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest {
+	return ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest{
+		ApiService:      a,
+		ctx:             ctx,
+		modelId:         modelId,
+		modelEndpointId: modelEndpointId,
+	}
+}
+
+// Execute executes the request
+func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostExecute(r ApiModelsModelIdEndpointsModelEndpointIdExperimentRewardsPostRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod = http.MethodPost
+		localVarPostBody   interface{}
+		formFiles          []formFile
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ModelEndpointsAPIService.ModelsModelIdEndpointsModelEndpointIdExperimentRewardsPost")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/endpoints/{model_endpoint_id}/experiment/rewards"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"model_endpoint_id"+"}", url.PathEscape(parameterValueToString(r.modelEndpointId, "modelEndpointId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.body
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
 type ApiModelsModelIdEndpointsModelEndpointIdGetRequest struct {
 	ctx             context.Context
 	ApiService      *ModelEndpointsAPIService
@@ -366,6 +602,148 @@ func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdGetExecu
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest struct {
+	ctx             context.Context
+	ApiService      *ModelEndpointsAPIService
+	modelId         int32
+	modelEndpointId int32
+	body            *[]map[string]interface{}
+	tolerance       *float32
+}
+
+func (r ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest) Body(body []map[string]interface{}) ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest {
+	r.body = &body
+	return r
+}
+
+// Maximum absolute difference of two scores that agree
+func (r ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest) Tolerance(tolerance float32) ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest {
+	r.tolerance = &tolerance
+	return r
+}
+
+func (r ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest) Execute() (*MirrorReport, *http.Response, error) {
+	return r.ApiService.ModelsModelIdEndpointsModelEndpointIdMirrorReportPostExecute(r)
+}
+
+/*
+ModelsModelIdEndpointsModelEndpointIdMirrorReportPost Compare the responses of the model endpoint's mirrors with the primary responses
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param modelId
+	@param modelEndpointId
+	@return ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest
+*/
+func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdMirrorReportPost(ctx context.Context, modelId int32, modelEndpointId int32) ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest {
+	return ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest{
+		ApiService:      a,
+		ctx:             ctx,
+		modelId:         modelId,
+		modelEndpointId: modelEndpointId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return MirrorReport
+func (a *ModelEndpointsAPIService) ModelsModelIdEndpointsModelEndpointIdMirrorReportPostExecute(r ApiModelsModelIdEndpointsModelEndpointIdMirrorReportPostRequest) (*MirrorReport, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *MirrorReport
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ModelEndpointsAPIService.ModelsModelIdEndpointsModelEndpointIdMirrorReportPost")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/models/{model_id}/endpoints/{model_endpoint_id}/mirror/report"
+	localVarPath = strings.Replace(localVarPath, "{"+"model_id"+"}", url.PathEscape(parameterValueToString(r.modelId, "modelId")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"model_endpoint_id"+"}", url.PathEscape(parameterValueToString(r.modelEndpointId, "modelEndpointId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.body == nil {
+		return localVarReturnValue, nil, reportError("body is required and must be specified")
+	}
+
+	if r.tolerance != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tolerance", r.tolerance, "")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"*/*"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.body
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Bearer"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiModelsModelIdEndpointsModelEndpointIdPutRequest struct {
 	ctx             context.Context
 	ApiService      *ModelEndpointsAPIService
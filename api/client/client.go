@@ -56,6 +56,8 @@ type APIClient struct {
 
 	ModelEndpointsAPI *ModelEndpointsAPIService
 
+	ModelManifestAPI *ModelManifestAPIService
+
 	ModelSchemaAPI *ModelSchemaAPIService
 
 	ModelsAPI *ModelsAPIService
@@ -93,6 +95,7 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.EnvironmentAPI = (*EnvironmentAPIService)(&c.common)
 	c.LogAPI = (*LogAPIService)(&c.common)
 	c.ModelEndpointsAPI = (*ModelEndpointsAPIService)(&c.common)
+	c.ModelManifestAPI = (*ModelManifestAPIService)(&c.common)
 	c.ModelSchemaAPI = (*ModelSchemaAPIService)(&c.common)
 	c.ModelsAPI = (*ModelsAPIService)(&c.common)
 	c.PredictionJobsAPI = (*PredictionJobsAPIService)(&c.common)
@@ -20,8 +20,9 @@ var _ MappedNullable = &AutoscalingPolicy{}
 
 // AutoscalingPolicy struct for AutoscalingPolicy
 type AutoscalingPolicy struct {
-	MetricsType MetricsType `json:"metrics_type"`
-	TargetValue float32     `json:"target_value"`
+	MetricsType       MetricsType         `json:"metrics_type"`
+	TargetValue       float32             `json:"target_value"`
+	AdditionalMetrics []AutoscalingMetric `json:"additional_metrics,omitempty"`
 }
 
 type _AutoscalingPolicy AutoscalingPolicy
@@ -93,6 +94,38 @@ func (o *AutoscalingPolicy) SetTargetValue(v float32) {
 	o.TargetValue = v
 }
 
+// GetAdditionalMetrics returns the AdditionalMetrics field value if set, zero value otherwise.
+func (o *AutoscalingPolicy) GetAdditionalMetrics() []AutoscalingMetric {
+	if o == nil || IsNil(o.AdditionalMetrics) {
+		var ret []AutoscalingMetric
+		return ret
+	}
+	return o.AdditionalMetrics
+}
+
+// GetAdditionalMetricsOk returns a tuple with the AdditionalMetrics field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AutoscalingPolicy) GetAdditionalMetricsOk() ([]AutoscalingMetric, bool) {
+	if o == nil || IsNil(o.AdditionalMetrics) {
+		return nil, false
+	}
+	return o.AdditionalMetrics, true
+}
+
+// HasAdditionalMetrics returns a boolean if a field has been set.
+func (o *AutoscalingPolicy) HasAdditionalMetrics() bool {
+	if o != nil && !IsNil(o.AdditionalMetrics) {
+		return true
+	}
+
+	return false
+}
+
+// SetAdditionalMetrics gets a reference to the given []AutoscalingMetric and assigns it to the AdditionalMetrics field.
+func (o *AutoscalingPolicy) SetAdditionalMetrics(v []AutoscalingMetric) {
+	o.AdditionalMetrics = v
+}
+
 func (o AutoscalingPolicy) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -105,6 +138,9 @@ func (o AutoscalingPolicy) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
 	toSerialize["metrics_type"] = o.MetricsType
 	toSerialize["target_value"] = o.TargetValue
+	if !IsNil(o.AdditionalMetrics) {
+		toSerialize["additional_metrics"] = o.AdditionalMetrics
+	}
 	return toSerialize, nil
 }
 
@@ -20,8 +20,15 @@ var _ MappedNullable = &LoggerConfig{}
 
 // LoggerConfig struct for LoggerConfig
 type LoggerConfig struct {
-	Enabled bool       `json:"enabled"`
-	Mode    LoggerMode `json:"mode"`
+	Enabled                bool                  `json:"enabled"`
+	Mode                   LoggerMode            `json:"mode"`
+	SamplingRate           *float32              `json:"sampling_rate,omitempty"`
+	AlwaysLogErrors        *bool                 `json:"always_log_errors,omitempty"`
+	HeaderAllowlist        []string              `json:"header_allowlist,omitempty"`
+	HeaderDenylist         []string              `json:"header_denylist,omitempty"`
+	RedactionRules         []LoggerRedactionRule `json:"redaction_rules,omitempty"`
+	Sinks                  []LoggerSink          `json:"sinks,omitempty"`
+	HttpSinkAuthSecretName *string               `json:"http_sink_auth_secret_name,omitempty"`
 }
 
 type _LoggerConfig LoggerConfig
@@ -93,6 +100,230 @@ func (o *LoggerConfig) SetMode(v LoggerMode) {
 	o.Mode = v
 }
 
+// GetSamplingRate returns the SamplingRate field value if set, zero value otherwise.
+func (o *LoggerConfig) GetSamplingRate() float32 {
+	if o == nil || IsNil(o.SamplingRate) {
+		var ret float32
+		return ret
+	}
+	return *o.SamplingRate
+}
+
+// GetSamplingRateOk returns a tuple with the SamplingRate field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoggerConfig) GetSamplingRateOk() (*float32, bool) {
+	if o == nil || IsNil(o.SamplingRate) {
+		return nil, false
+	}
+	return o.SamplingRate, true
+}
+
+// HasSamplingRate returns a boolean if a field has been set.
+func (o *LoggerConfig) HasSamplingRate() bool {
+	if o != nil && !IsNil(o.SamplingRate) {
+		return true
+	}
+
+	return false
+}
+
+// SetSamplingRate gets a reference to the given float32 and assigns it to the SamplingRate field.
+func (o *LoggerConfig) SetSamplingRate(v float32) {
+	o.SamplingRate = &v
+}
+
+// GetAlwaysLogErrors returns the AlwaysLogErrors field value if set, zero value otherwise.
+func (o *LoggerConfig) GetAlwaysLogErrors() bool {
+	if o == nil || IsNil(o.AlwaysLogErrors) {
+		var ret bool
+		return ret
+	}
+	return *o.AlwaysLogErrors
+}
+
+// GetAlwaysLogErrorsOk returns a tuple with the AlwaysLogErrors field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoggerConfig) GetAlwaysLogErrorsOk() (*bool, bool) {
+	if o == nil || IsNil(o.AlwaysLogErrors) {
+		return nil, false
+	}
+	return o.AlwaysLogErrors, true
+}
+
+// HasAlwaysLogErrors returns a boolean if a field has been set.
+func (o *LoggerConfig) HasAlwaysLogErrors() bool {
+	if o != nil && !IsNil(o.AlwaysLogErrors) {
+		return true
+	}
+
+	return false
+}
+
+// SetAlwaysLogErrors gets a reference to the given bool and assigns it to the AlwaysLogErrors field.
+func (o *LoggerConfig) SetAlwaysLogErrors(v bool) {
+	o.AlwaysLogErrors = &v
+}
+
+// GetHeaderAllowlist returns the HeaderAllowlist field value if set, zero value otherwise.
+func (o *LoggerConfig) GetHeaderAllowlist() []string {
+	if o == nil || IsNil(o.HeaderAllowlist) {
+		var ret []string
+		return ret
+	}
+	return o.HeaderAllowlist
+}
+
+// GetHeaderAllowlistOk returns a tuple with the HeaderAllowlist field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoggerConfig) GetHeaderAllowlistOk() ([]string, bool) {
+	if o == nil || IsNil(o.HeaderAllowlist) {
+		return nil, false
+	}
+	return o.HeaderAllowlist, true
+}
+
+// HasHeaderAllowlist returns a boolean if a field has been set.
+func (o *LoggerConfig) HasHeaderAllowlist() bool {
+	if o != nil && !IsNil(o.HeaderAllowlist) {
+		return true
+	}
+
+	return false
+}
+
+// SetHeaderAllowlist gets a reference to the given []string and assigns it to the HeaderAllowlist field.
+func (o *LoggerConfig) SetHeaderAllowlist(v []string) {
+	o.HeaderAllowlist = v
+}
+
+// GetHeaderDenylist returns the HeaderDenylist field value if set, zero value otherwise.
+func (o *LoggerConfig) GetHeaderDenylist() []string {
+	if o == nil || IsNil(o.HeaderDenylist) {
+		var ret []string
+		return ret
+	}
+	return o.HeaderDenylist
+}
+
+// GetHeaderDenylistOk returns a tuple with the HeaderDenylist field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoggerConfig) GetHeaderDenylistOk() ([]string, bool) {
+	if o == nil || IsNil(o.HeaderDenylist) {
+		return nil, false
+	}
+	return o.HeaderDenylist, true
+}
+
+// HasHeaderDenylist returns a boolean if a field has been set.
+func (o *LoggerConfig) HasHeaderDenylist() bool {
+	if o != nil && !IsNil(o.HeaderDenylist) {
+		return true
+	}
+
+	return false
+}
+
+// SetHeaderDenylist gets a reference to the given []string and assigns it to the HeaderDenylist field.
+func (o *LoggerConfig) SetHeaderDenylist(v []string) {
+	o.HeaderDenylist = v
+}
+
+// GetRedactionRules returns the RedactionRules field value if set, zero value otherwise.
+func (o *LoggerConfig) GetRedactionRules() []LoggerRedactionRule {
+	if o == nil || IsNil(o.RedactionRules) {
+		var ret []LoggerRedactionRule
+		return ret
+	}
+	return o.RedactionRules
+}
+
+// GetRedactionRulesOk returns a tuple with the RedactionRules field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoggerConfig) GetRedactionRulesOk() ([]LoggerRedactionRule, bool) {
+	if o == nil || IsNil(o.RedactionRules) {
+		return nil, false
+	}
+	return o.RedactionRules, true
+}
+
+// HasRedactionRules returns a boolean if a field has been set.
+func (o *LoggerConfig) HasRedactionRules() bool {
+	if o != nil && !IsNil(o.RedactionRules) {
+		return true
+	}
+
+	return false
+}
+
+// SetRedactionRules gets a reference to the given []LoggerRedactionRule and assigns it to the RedactionRules field.
+func (o *LoggerConfig) SetRedactionRules(v []LoggerRedactionRule) {
+	o.RedactionRules = v
+}
+
+// GetSinks returns the Sinks field value if set, zero value otherwise.
+func (o *LoggerConfig) GetSinks() []LoggerSink {
+	if o == nil || IsNil(o.Sinks) {
+		var ret []LoggerSink
+		return ret
+	}
+	return o.Sinks
+}
+
+// GetSinksOk returns a tuple with the Sinks field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoggerConfig) GetSinksOk() ([]LoggerSink, bool) {
+	if o == nil || IsNil(o.Sinks) {
+		return nil, false
+	}
+	return o.Sinks, true
+}
+
+// HasSinks returns a boolean if a field has been set.
+func (o *LoggerConfig) HasSinks() bool {
+	if o != nil && !IsNil(o.Sinks) {
+		return true
+	}
+
+	return false
+}
+
+// SetSinks gets a reference to the given []LoggerSink and assigns it to the Sinks field.
+func (o *LoggerConfig) SetSinks(v []LoggerSink) {
+	o.Sinks = v
+}
+
+// GetHttpSinkAuthSecretName returns the HttpSinkAuthSecretName field value if set, zero value otherwise.
+func (o *LoggerConfig) GetHttpSinkAuthSecretName() string {
+	if o == nil || IsNil(o.HttpSinkAuthSecretName) {
+		var ret string
+		return ret
+	}
+	return *o.HttpSinkAuthSecretName
+}
+
+// GetHttpSinkAuthSecretNameOk returns a tuple with the HttpSinkAuthSecretName field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoggerConfig) GetHttpSinkAuthSecretNameOk() (*string, bool) {
+	if o == nil || IsNil(o.HttpSinkAuthSecretName) {
+		return nil, false
+	}
+	return o.HttpSinkAuthSecretName, true
+}
+
+// HasHttpSinkAuthSecretName returns a boolean if a field has been set.
+func (o *LoggerConfig) HasHttpSinkAuthSecretName() bool {
+	if o != nil && !IsNil(o.HttpSinkAuthSecretName) {
+		return true
+	}
+
+	return false
+}
+
+// SetHttpSinkAuthSecretName gets a reference to the given string and assigns it to the HttpSinkAuthSecretName field.
+func (o *LoggerConfig) SetHttpSinkAuthSecretName(v string) {
+	o.HttpSinkAuthSecretName = &v
+}
+
 func (o LoggerConfig) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -105,6 +336,27 @@ func (o LoggerConfig) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
 	toSerialize["enabled"] = o.Enabled
 	toSerialize["mode"] = o.Mode
+	if !IsNil(o.SamplingRate) {
+		toSerialize["sampling_rate"] = o.SamplingRate
+	}
+	if !IsNil(o.AlwaysLogErrors) {
+		toSerialize["always_log_errors"] = o.AlwaysLogErrors
+	}
+	if !IsNil(o.HeaderAllowlist) {
+		toSerialize["header_allowlist"] = o.HeaderAllowlist
+	}
+	if !IsNil(o.HeaderDenylist) {
+		toSerialize["header_denylist"] = o.HeaderDenylist
+	}
+	if !IsNil(o.RedactionRules) {
+		toSerialize["redaction_rules"] = o.RedactionRules
+	}
+	if !IsNil(o.Sinks) {
+		toSerialize["sinks"] = o.Sinks
+	}
+	if !IsNil(o.HttpSinkAuthSecretName) {
+		toSerialize["http_sink_auth_secret_name"] = o.HttpSinkAuthSecretName
+	}
 	return toSerialize, nil
 }
 
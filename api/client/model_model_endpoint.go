@@ -20,17 +20,19 @@ var _ MappedNullable = &ModelEndpoint{}
 
 // ModelEndpoint struct for ModelEndpoint
 type ModelEndpoint struct {
-	Id              *int32             `json:"id,omitempty"`
-	ModelId         *int32             `json:"model_id,omitempty"`
-	Model           *Model             `json:"model,omitempty"`
-	Status          *EndpointStatus    `json:"status,omitempty"`
-	Url             *string            `json:"url,omitempty"`
-	Rule            *ModelEndpointRule `json:"rule,omitempty"`
-	EnvironmentName *string            `json:"environment_name,omitempty"`
-	Environment     *Environment       `json:"environment,omitempty"`
-	Protocol        *Protocol          `json:"protocol,omitempty"`
-	CreatedAt       *time.Time         `json:"created_at,omitempty"`
-	UpdatedAt       *time.Time         `json:"updated_at,omitempty"`
+	Id              *int32                   `json:"id,omitempty"`
+	ModelId         *int32                   `json:"model_id,omitempty"`
+	Model           *Model                   `json:"model,omitempty"`
+	Status          *EndpointStatus          `json:"status,omitempty"`
+	Url             *string                  `json:"url,omitempty"`
+	Rule            *ModelEndpointRule       `json:"rule,omitempty"`
+	EnvironmentName *string                  `json:"environment_name,omitempty"`
+	Environment     *Environment             `json:"environment,omitempty"`
+	Protocol        *Protocol                `json:"protocol,omitempty"`
+	Rollout         *ModelEndpointRollout    `json:"rollout,omitempty"`
+	Experiment      *ModelEndpointExperiment `json:"experiment,omitempty"`
+	CreatedAt       *time.Time               `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time               `json:"updated_at,omitempty"`
 }
 
 // NewModelEndpoint instantiates a new ModelEndpoint object
@@ -338,6 +340,70 @@ func (o *ModelEndpoint) SetProtocol(v Protocol) {
 	o.Protocol = &v
 }
 
+// GetRollout returns the Rollout field value if set, zero value otherwise.
+func (o *ModelEndpoint) GetRollout() ModelEndpointRollout {
+	if o == nil || IsNil(o.Rollout) {
+		var ret ModelEndpointRollout
+		return ret
+	}
+	return *o.Rollout
+}
+
+// GetRolloutOk returns a tuple with the Rollout field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelEndpoint) GetRolloutOk() (*ModelEndpointRollout, bool) {
+	if o == nil || IsNil(o.Rollout) {
+		return nil, false
+	}
+	return o.Rollout, true
+}
+
+// HasRollout returns a boolean if a field has been set.
+func (o *ModelEndpoint) HasRollout() bool {
+	if o != nil && !IsNil(o.Rollout) {
+		return true
+	}
+
+	return false
+}
+
+// SetRollout gets a reference to the given ModelEndpointRollout and assigns it to the Rollout field.
+func (o *ModelEndpoint) SetRollout(v ModelEndpointRollout) {
+	o.Rollout = &v
+}
+
+// GetExperiment returns the Experiment field value if set, zero value otherwise.
+func (o *ModelEndpoint) GetExperiment() ModelEndpointExperiment {
+	if o == nil || IsNil(o.Experiment) {
+		var ret ModelEndpointExperiment
+		return ret
+	}
+	return *o.Experiment
+}
+
+// GetExperimentOk returns a tuple with the Experiment field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelEndpoint) GetExperimentOk() (*ModelEndpointExperiment, bool) {
+	if o == nil || IsNil(o.Experiment) {
+		return nil, false
+	}
+	return o.Experiment, true
+}
+
+// HasExperiment returns a boolean if a field has been set.
+func (o *ModelEndpoint) HasExperiment() bool {
+	if o != nil && !IsNil(o.Experiment) {
+		return true
+	}
+
+	return false
+}
+
+// SetExperiment gets a reference to the given ModelEndpointExperiment and assigns it to the Experiment field.
+func (o *ModelEndpoint) SetExperiment(v ModelEndpointExperiment) {
+	o.Experiment = &v
+}
+
 // GetCreatedAt returns the CreatedAt field value if set, zero value otherwise.
 func (o *ModelEndpoint) GetCreatedAt() time.Time {
 	if o == nil || IsNil(o.CreatedAt) {
@@ -439,6 +505,12 @@ func (o ModelEndpoint) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Protocol) {
 		toSerialize["protocol"] = o.Protocol
 	}
+	if !IsNil(o.Rollout) {
+		toSerialize["rollout"] = o.Rollout
+	}
+	if !IsNil(o.Experiment) {
+		toSerialize["experiment"] = o.Experiment
+	}
 	if !IsNil(o.CreatedAt) {
 		toSerialize["created_at"] = o.CreatedAt
 	}
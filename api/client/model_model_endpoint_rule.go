@@ -20,7 +20,10 @@ var _ MappedNullable = &ModelEndpointRule{}
 // ModelEndpointRule struct for ModelEndpointRule
 type ModelEndpointRule struct {
 	Destinations []ModelEndpointRuleDestination `json:"destinations,omitempty"`
+	Routes       []ModelEndpointRuleRoute       `json:"routes,omitempty"`
 	Mirror       *VersionEndpoint               `json:"mirror,omitempty"`
+	// Percentage of the requests mirrored, defaults to 100
+	MirrorPercentage *float32 `json:"mirror_percentage,omitempty"`
 }
 
 // NewModelEndpointRule instantiates a new ModelEndpointRule object
@@ -72,6 +75,38 @@ func (o *ModelEndpointRule) SetDestinations(v []ModelEndpointRuleDestination) {
 	o.Destinations = v
 }
 
+// GetRoutes returns the Routes field value if set, zero value otherwise.
+func (o *ModelEndpointRule) GetRoutes() []ModelEndpointRuleRoute {
+	if o == nil || IsNil(o.Routes) {
+		var ret []ModelEndpointRuleRoute
+		return ret
+	}
+	return o.Routes
+}
+
+// GetRoutesOk returns a tuple with the Routes field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelEndpointRule) GetRoutesOk() ([]ModelEndpointRuleRoute, bool) {
+	if o == nil || IsNil(o.Routes) {
+		return nil, false
+	}
+	return o.Routes, true
+}
+
+// HasRoutes returns a boolean if a field has been set.
+func (o *ModelEndpointRule) HasRoutes() bool {
+	if o != nil && !IsNil(o.Routes) {
+		return true
+	}
+
+	return false
+}
+
+// SetRoutes gets a reference to the given []ModelEndpointRuleRoute and assigns it to the Routes field.
+func (o *ModelEndpointRule) SetRoutes(v []ModelEndpointRuleRoute) {
+	o.Routes = v
+}
+
 // GetMirror returns the Mirror field value if set, zero value otherwise.
 func (o *ModelEndpointRule) GetMirror() VersionEndpoint {
 	if o == nil || IsNil(o.Mirror) {
@@ -104,6 +139,38 @@ func (o *ModelEndpointRule) SetMirror(v VersionEndpoint) {
 	o.Mirror = &v
 }
 
+// GetMirrorPercentage returns the MirrorPercentage field value if set, zero value otherwise.
+func (o *ModelEndpointRule) GetMirrorPercentage() float32 {
+	if o == nil || IsNil(o.MirrorPercentage) {
+		var ret float32
+		return ret
+	}
+	return *o.MirrorPercentage
+}
+
+// GetMirrorPercentageOk returns a tuple with the MirrorPercentage field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelEndpointRule) GetMirrorPercentageOk() (*float32, bool) {
+	if o == nil || IsNil(o.MirrorPercentage) {
+		return nil, false
+	}
+	return o.MirrorPercentage, true
+}
+
+// HasMirrorPercentage returns a boolean if a field has been set.
+func (o *ModelEndpointRule) HasMirrorPercentage() bool {
+	if o != nil && !IsNil(o.MirrorPercentage) {
+		return true
+	}
+
+	return false
+}
+
+// SetMirrorPercentage gets a reference to the given float32 and assigns it to the MirrorPercentage field.
+func (o *ModelEndpointRule) SetMirrorPercentage(v float32) {
+	o.MirrorPercentage = &v
+}
+
 func (o ModelEndpointRule) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -117,9 +184,15 @@ func (o ModelEndpointRule) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Destinations) {
 		toSerialize["destinations"] = o.Destinations
 	}
+	if !IsNil(o.Routes) {
+		toSerialize["routes"] = o.Routes
+	}
 	if !IsNil(o.Mirror) {
 		toSerialize["mirror"] = o.Mirror
 	}
+	if !IsNil(o.MirrorPercentage) {
+		toSerialize["mirror_percentage"] = o.MirrorPercentage
+	}
 	return toSerialize, nil
 }
 
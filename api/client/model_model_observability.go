@@ -24,6 +24,7 @@ type ModelObservability struct {
 	GroundTruthSource                     *GroundTruthSource                     `json:"ground_truth_source,omitempty"`
 	GroundTruthJob                        *GroundTruthJob                        `json:"ground_truth_job,omitempty"`
 	PredictionLogIngestionResourceRequest *PredictionLogIngestionResourceRequest `json:"prediction_log_ingestion_resource_request,omitempty"`
+	PredictionLogMapping                  *PredictionLogMapping                  `json:"prediction_log_mapping,omitempty"`
 }
 
 type _ModelObservability ModelObservability
@@ -166,6 +167,38 @@ func (o *ModelObservability) SetPredictionLogIngestionResourceRequest(v Predicti
 	o.PredictionLogIngestionResourceRequest = &v
 }
 
+// GetPredictionLogMapping returns the PredictionLogMapping field value if set, zero value otherwise.
+func (o *ModelObservability) GetPredictionLogMapping() PredictionLogMapping {
+	if o == nil || IsNil(o.PredictionLogMapping) {
+		var ret PredictionLogMapping
+		return ret
+	}
+	return *o.PredictionLogMapping
+}
+
+// GetPredictionLogMappingOk returns a tuple with the PredictionLogMapping field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelObservability) GetPredictionLogMappingOk() (*PredictionLogMapping, bool) {
+	if o == nil || IsNil(o.PredictionLogMapping) {
+		return nil, false
+	}
+	return o.PredictionLogMapping, true
+}
+
+// HasPredictionLogMapping returns a boolean if a field has been set.
+func (o *ModelObservability) HasPredictionLogMapping() bool {
+	if o != nil && !IsNil(o.PredictionLogMapping) {
+		return true
+	}
+
+	return false
+}
+
+// SetPredictionLogMapping gets a reference to the given PredictionLogMapping and assigns it to the PredictionLogMapping field.
+func (o *ModelObservability) SetPredictionLogMapping(v PredictionLogMapping) {
+	o.PredictionLogMapping = &v
+}
+
 func (o ModelObservability) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -186,6 +219,9 @@ func (o ModelObservability) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.PredictionLogIngestionResourceRequest) {
 		toSerialize["prediction_log_ingestion_resource_request"] = o.PredictionLogIngestionResourceRequest
 	}
+	if !IsNil(o.PredictionLogMapping) {
+		toSerialize["prediction_log_mapping"] = o.PredictionLogMapping
+	}
 	return toSerialize, nil
 }
 
@@ -36,7 +36,9 @@ type VersionEndpoint struct {
 	Transformer                 *Transformer        `json:"transformer,omitempty"`
 	Logger                      *Logger             `json:"logger,omitempty"`
 	DeploymentMode              *DeploymentMode     `json:"deployment_mode,omitempty"`
+	DeploymentStrategy          *DeploymentStrategy `json:"deployment_strategy,omitempty"`
 	AutoscalingPolicy           *AutoscalingPolicy  `json:"autoscaling_policy,omitempty"`
+	ScalingSchedules            []ScalingSchedule   `json:"scaling_schedules,omitempty"`
 	Protocol                    *Protocol           `json:"protocol,omitempty"`
 	EnableModelObservability    *bool               `json:"enable_model_observability,omitempty"`
 	ModelObservability          *ModelObservability `json:"model_observability,omitempty"`
@@ -577,6 +579,38 @@ func (o *VersionEndpoint) SetDeploymentMode(v DeploymentMode) {
 	o.DeploymentMode = &v
 }
 
+// GetDeploymentStrategy returns the DeploymentStrategy field value if set, zero value otherwise.
+func (o *VersionEndpoint) GetDeploymentStrategy() DeploymentStrategy {
+	if o == nil || IsNil(o.DeploymentStrategy) {
+		var ret DeploymentStrategy
+		return ret
+	}
+	return *o.DeploymentStrategy
+}
+
+// GetDeploymentStrategyOk returns a tuple with the DeploymentStrategy field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *VersionEndpoint) GetDeploymentStrategyOk() (*DeploymentStrategy, bool) {
+	if o == nil || IsNil(o.DeploymentStrategy) {
+		return nil, false
+	}
+	return o.DeploymentStrategy, true
+}
+
+// HasDeploymentStrategy returns a boolean if a field has been set.
+func (o *VersionEndpoint) HasDeploymentStrategy() bool {
+	if o != nil && !IsNil(o.DeploymentStrategy) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeploymentStrategy gets a reference to the given DeploymentStrategy and assigns it to the DeploymentStrategy field.
+func (o *VersionEndpoint) SetDeploymentStrategy(v DeploymentStrategy) {
+	o.DeploymentStrategy = &v
+}
+
 // GetAutoscalingPolicy returns the AutoscalingPolicy field value if set, zero value otherwise.
 func (o *VersionEndpoint) GetAutoscalingPolicy() AutoscalingPolicy {
 	if o == nil || IsNil(o.AutoscalingPolicy) {
@@ -609,6 +643,38 @@ func (o *VersionEndpoint) SetAutoscalingPolicy(v AutoscalingPolicy) {
 	o.AutoscalingPolicy = &v
 }
 
+// GetScalingSchedules returns the ScalingSchedules field value if set, zero value otherwise.
+func (o *VersionEndpoint) GetScalingSchedules() []ScalingSchedule {
+	if o == nil || IsNil(o.ScalingSchedules) {
+		var ret []ScalingSchedule
+		return ret
+	}
+	return o.ScalingSchedules
+}
+
+// GetScalingSchedulesOk returns a tuple with the ScalingSchedules field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *VersionEndpoint) GetScalingSchedulesOk() ([]ScalingSchedule, bool) {
+	if o == nil || IsNil(o.ScalingSchedules) {
+		return nil, false
+	}
+	return o.ScalingSchedules, true
+}
+
+// HasScalingSchedules returns a boolean if a field has been set.
+func (o *VersionEndpoint) HasScalingSchedules() bool {
+	if o != nil && !IsNil(o.ScalingSchedules) {
+		return true
+	}
+
+	return false
+}
+
+// SetScalingSchedules gets a reference to the given []ScalingSchedule and assigns it to the ScalingSchedules field.
+func (o *VersionEndpoint) SetScalingSchedules(v []ScalingSchedule) {
+	o.ScalingSchedules = v
+}
+
 // GetProtocol returns the Protocol field value if set, zero value otherwise.
 func (o *VersionEndpoint) GetProtocol() Protocol {
 	if o == nil || IsNil(o.Protocol) {
@@ -827,9 +893,15 @@ func (o VersionEndpoint) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.DeploymentMode) {
 		toSerialize["deployment_mode"] = o.DeploymentMode
 	}
+	if !IsNil(o.DeploymentStrategy) {
+		toSerialize["deployment_strategy"] = o.DeploymentStrategy
+	}
 	if !IsNil(o.AutoscalingPolicy) {
 		toSerialize["autoscaling_policy"] = o.AutoscalingPolicy
 	}
+	if !IsNil(o.ScalingSchedules) {
+		toSerialize["scaling_schedules"] = o.ScalingSchedules
+	}
 	if !IsNil(o.Protocol) {
 		toSerialize["protocol"] = o.Protocol
 	}
@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/caraml-dev/merlin/log"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/pkg/deployment"
+)
+
+// blueGreenReadinessPollInterval is how often the readiness of the new revision is checked once it receives the traffic
+const blueGreenReadinessPollInterval = time.Second
+
+// isBlueGreenDeployment returns true if the model service replaces a running revision using the blue/green strategy
+func isBlueGreenDeployment(modelService *models.Service) bool {
+	return modelService.DeploymentMode == deployment.RawDeploymentMode &&
+		modelService.DeploymentStrategy == deployment.BlueGreenDeploymentStrategy &&
+		modelService.CurrentIsvcName != "" &&
+		modelService.CurrentIsvcName != modelService.Name
+}
+
+func (c *controller) blueGreenReadinessTimeout() time.Duration {
+	if c.deploymentConfig.BlueGreenReadinessTimeout > 0 {
+		return c.deploymentConfig.BlueGreenReadinessTimeout
+	}
+	return c.deploymentConfig.DeploymentTimeout
+}
+
+// getCurrentRevision returns the revision serving the model version, it returns nil if the revision doesn't exist anymore
+func (c *controller) getCurrentRevision(ctx context.Context, modelService *models.Service) (*kservev1beta1.InferenceService, error) {
+	isvc, err := c.kserveClient.InferenceServices(modelService.Namespace).Get(ctx, modelService.CurrentIsvcName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Warnf("current revision %s is not found, deploying %s without blue/green switch", modelService.CurrentIsvcName, modelService.Name)
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToGetInferenceServiceStatus, modelService.CurrentIsvcName))
+	}
+	return isvc, nil
+}
+
+// switchToNewRevision routes the traffic of the model version from the current revision to the ready new revision,
+// and deletes the current revision afterwards. The traffic is routed back to the current revision and the new revision
+// is deleted if the virtual service can't be updated or the new revision isn't ready again within the blue/green
+// readiness timeout once it receives the traffic.
+func (c *controller) switchToNewRevision(
+	ctx context.Context,
+	modelService *models.Service,
+	current *kservev1beta1.InferenceService,
+	next *kservev1beta1.InferenceService,
+) (*models.Service, error) {
+	currentVsCfg, err := NewVirtualService(modelService, models.GetInferenceURL(current.Status.URL, current.Name, modelService.Protocol))
+	if err != nil {
+		log.Errorf("unable to initialize virtual service builder: %v", err)
+		c.deleteNewRevision(ctx, modelService)
+		return nil, errors.Wrapf(err, fmt.Sprintf("%v", ErrUnableToCreateVirtualService))
+	}
+
+	inferenceURL := models.GetInferenceURL(next.Status.URL, next.Name, modelService.Protocol)
+	nextVsCfg, err := NewVirtualService(modelService, inferenceURL)
+	if err != nil {
+		log.Errorf("unable to initialize virtual service builder: %v", err)
+		c.deleteNewRevision(ctx, modelService)
+		return nil, errors.Wrapf(err, fmt.Sprintf("%v", ErrUnableToCreateVirtualService))
+	}
+
+	vs, err := c.deployVirtualService(ctx, nextVsCfg)
+	if err != nil {
+		log.Errorf("unable to switch virtual service to revision %s: %v", next.Name, err)
+		c.revertToCurrentRevision(ctx, modelService, currentVsCfg)
+		return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToCreateVirtualService, nextVsCfg.Name))
+	}
+
+	next, err = c.waitNewRevisionReady(ctx, modelService)
+	if err != nil {
+		log.Errorf("revision %s is not ready after switching the traffic: %v", modelService.Name, err)
+		c.revertToCurrentRevision(ctx, modelService, currentVsCfg)
+		return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToCreateInferenceService, modelService.Name))
+	}
+
+	if vs != nil && len(vs.Spec.Hosts) > 0 {
+		inferenceURL = nextVsCfg.getInferenceURL(vs)
+	}
+
+	// the new revision is already serving the traffic, failing to delete the current revision doesn't fail the deployment
+	if err := c.deletePreviousRevision(ctx, modelService); err != nil {
+		log.Warnf("unable to delete revision %s replaced by revision %s: %v", modelService.CurrentIsvcName, modelService.Name, err)
+	}
+
+	return newDeployedService(modelService, next, inferenceURL), nil
+}
+
+// waitNewRevisionReady polls the new revision until it is ready once it receives the traffic, it may be briefly unready
+// while scaling to the traffic. It returns the last readiness error if the revision isn't ready within the timeout.
+func (c *controller) waitNewRevisionReady(ctx context.Context, modelService *models.Service) (*kservev1beta1.InferenceService, error) {
+	var isvc *kservev1beta1.InferenceService
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, blueGreenReadinessPollInterval, c.blueGreenReadinessTimeout(), true, func(ctx context.Context) (bool, error) {
+		next, err := c.kserveClient.InferenceServices(modelService.Namespace).Get(ctx, modelService.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, err
+			}
+			lastErr = err
+			return false, nil
+		}
+		if !next.Status.IsReady() {
+			lastErr = ErrNewRevisionNotReady
+			return false, nil
+		}
+		isvc = next
+		return true, nil
+	})
+	if err != nil {
+		if lastErr != nil && wait.Interrupted(err) {
+			return nil, lastErr
+		}
+		return nil, err
+	}
+	return isvc, nil
+}
+
+// revertToCurrentRevision routes the traffic of the model version back to the current revision and deletes the new revision
+func (c *controller) revertToCurrentRevision(ctx context.Context, modelService *models.Service, currentVsCfg *VirtualService) {
+	if _, err := c.deployVirtualService(ctx, currentVsCfg); err != nil {
+		log.Errorf("unable to route the traffic back to revision %s: %v", modelService.CurrentIsvcName, err)
+	}
+	c.deleteNewRevision(ctx, modelService)
+}
+
+// deleteNewRevision deletes the inference service, the pdbs and the secrets created for the model service
+func (c *controller) deleteNewRevision(ctx context.Context, modelService *models.Service) {
+	if err := c.deleteInferenceService(ctx, modelService.Name, modelService.Namespace); err != nil {
+		log.Errorf("unable to delete inference service %s with error %v", modelService.Name, err)
+	}
+
+	if c.deploymentConfig.PodDisruptionBudget.Enabled {
+		pdbs := generatePDBSpecs(modelService, c.deploymentConfig.PodDisruptionBudget)
+		if err := c.deletePodDisruptionBudgets(ctx, pdbs); err != nil {
+			log.Warnf("unable to delete pdb of inference service %s: %v", modelService.Name, err)
+		}
+	}
+
+	if err := c.deleteSecrets(ctx, modelService.Name, modelService.Namespace); err != nil {
+		log.Warnf("failed deleting secret for deployment %s: %v", modelService.Name, err)
+	}
+}
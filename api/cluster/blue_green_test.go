@@ -0,0 +1,245 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	fakekserve "github.com/kserve/kserve/pkg/client/clientset/versioned/fake"
+	fakekservev1beta1 "github.com/kserve/kserve/pkg/client/clientset/versioned/typed/serving/v1beta1/fake"
+	"github.com/stretchr/testify/assert"
+	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
+	fakeistionetworking "istio.io/client-go/pkg/clientset/versioned/typed/networking/v1beta1/fake"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	fakecorev1 "k8s.io/client-go/kubernetes/typed/core/v1/fake"
+	ktesting "k8s.io/client-go/testing"
+	knservingfake "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	clusterresource "github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/config"
+	mlpMock "github.com/caraml-dev/merlin/mlp/mocks"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/pkg/deployment"
+)
+
+func TestController_DeployInferenceService_BlueGreen(t *testing.T) {
+	namespace := "my-project"
+	currentName := models.CreateInferenceServiceName("my-model", "1", "1")
+	newName := models.CreateInferenceServiceName("my-model", "1", "2")
+
+	current := &kservev1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: currentName, Namespace: namespace},
+		Status:     createServiceReadyStatus(currentName, namespace, baseUrl),
+	}
+	newReady := &kservev1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: newName, Namespace: namespace},
+		Status:     createServiceReadyStatus(newName, namespace, baseUrl),
+	}
+	newNotReady := &kservev1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: newName, Namespace: namespace},
+		Status:     createPredErrorCond(),
+	}
+	newNotReady.Status.URL = newReady.Status.URL
+
+	currentHost := fmt.Sprintf("%s.%s.%s", currentName, namespace, baseUrl)
+	newHost := fmt.Sprintf("%s.%s.%s", newName, namespace, baseUrl)
+
+	modelSvc := &models.Service{
+		Name:               newName,
+		ModelName:          "my-model",
+		ModelVersion:       "1",
+		RevisionID:         models.ID(2),
+		Namespace:          namespace,
+		Options:            &models.ModelOption{},
+		DeploymentMode:     deployment.RawDeploymentMode,
+		DeploymentStrategy: deployment.BlueGreenDeploymentStrategy,
+		CurrentIsvcName:    currentName,
+	}
+
+	tests := []struct {
+		name          string
+		getCurrentErr error
+		watchedIsvc   *kservev1beta1.InferenceService
+		switchedIsvc  *kservev1beta1.InferenceService
+		// unreadyPolls is the number of times the new revision is unready after the switch before being switchedIsvc
+		unreadyPolls     int
+		failedVsHost     string
+		deleteCurrentErr error
+		wantVsHosts      []string
+		wantDeletedIsvcs []string
+		wantError        bool
+		wantIsvcName     string
+	}{
+		{
+			name:             "success: traffic is switched to the new revision",
+			watchedIsvc:      newReady,
+			switchedIsvc:     newReady,
+			wantVsHosts:      []string{newHost},
+			wantDeletedIsvcs: []string{currentName},
+			wantIsvcName:     newName,
+		},
+		{
+			name:             "success: new revision is ready again after the switch",
+			watchedIsvc:      newReady,
+			switchedIsvc:     newReady,
+			unreadyPolls:     1,
+			wantVsHosts:      []string{newHost},
+			wantDeletedIsvcs: []string{currentName},
+			wantIsvcName:     newName,
+		},
+		{
+			name:             "success: failing to delete the current revision",
+			watchedIsvc:      newReady,
+			switchedIsvc:     newReady,
+			deleteCurrentErr: errors.New("delete error"),
+			wantVsHosts:      []string{newHost},
+			wantDeletedIsvcs: []string{currentName},
+			wantIsvcName:     newName,
+		},
+		{
+			name:             "success: current revision not found",
+			getCurrentErr:    kerrors.NewNotFound(schema.GroupResource{Resource: inferenceServiceResource}, currentName),
+			watchedIsvc:      newReady,
+			wantVsHosts:      []string{newHost},
+			wantDeletedIsvcs: []string{currentName},
+			wantIsvcName:     newName,
+		},
+		{
+			name:             "error: new revision is not ready in time",
+			watchedIsvc:      newNotReady,
+			wantVsHosts:      []string{},
+			wantDeletedIsvcs: []string{newName},
+			wantError:        true,
+		},
+		{
+			name:             "error: traffic can't be switched",
+			watchedIsvc:      newReady,
+			switchedIsvc:     newReady,
+			failedVsHost:     newHost,
+			wantVsHosts:      []string{newHost, currentHost},
+			wantDeletedIsvcs: []string{newName},
+			wantError:        true,
+		},
+		{
+			name:             "error: new revision is not ready after the switch",
+			watchedIsvc:      newReady,
+			switchedIsvc:     newNotReady,
+			wantVsHosts:      []string{newHost, currentHost},
+			wantDeletedIsvcs: []string{newName},
+			wantError:        true,
+		},
+		{
+			name:             "error: unable to get current revision",
+			getCurrentErr:    errors.New("get error"),
+			watchedIsvc:      newReady,
+			wantVsHosts:      []string{},
+			wantDeletedIsvcs: []string{},
+			wantError:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			knClient := knservingfake.NewSimpleClientset()
+
+			created := false
+			unreadyPolls := 0
+			kfClient := fakekserve.NewSimpleClientset().ServingV1beta1().(*fakekservev1beta1.FakeServingV1beta1)
+			kfClient.WatchReactionChain = []ktesting.WatchReactor{newIsvcWatchReactor(tt.watchedIsvc)}
+			kfClient.PrependReactor(getMethod, inferenceServiceResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				switch action.(ktesting.GetAction).GetName() {
+				case currentName:
+					if tt.getCurrentErr != nil {
+						return true, nil, tt.getCurrentErr
+					}
+					return true, current, nil
+				default:
+					if !created {
+						return true, nil, kerrors.NewNotFound(schema.GroupResource{Resource: inferenceServiceResource}, newName)
+					}
+					if unreadyPolls < tt.unreadyPolls {
+						unreadyPolls++
+						return true, newNotReady, nil
+					}
+					return true, tt.switchedIsvc, nil
+				}
+			})
+			kfClient.PrependReactor(createMethod, inferenceServiceResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				created = true
+				return true, &kservev1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: newName, Namespace: namespace}}, nil
+			})
+			deletedIsvcs := []string{}
+			kfClient.PrependReactor(deleteMethod, inferenceServiceResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				name := action.(ktesting.DeleteAction).GetName()
+				deletedIsvcs = append(deletedIsvcs, name)
+				if name == currentName {
+					return true, nil, tt.deleteCurrentErr
+				}
+				return true, nil, nil
+			})
+
+			v1Client := fake.NewSimpleClientset().CoreV1()
+			nsClient := v1Client.Namespaces().(*fakecorev1.FakeNamespaces)
+			nsClient.Fake.PrependReactor(getMethod, namespaceResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				return true, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: namespace},
+					Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+				}, nil
+			})
+
+			policyV1Client := fake.NewSimpleClientset().PolicyV1()
+
+			vsHosts := []string{}
+			istioClient := fakeistio.NewSimpleClientset().NetworkingV1beta1().(*fakeistionetworking.FakeNetworkingV1beta1)
+			istioClient.PrependReactor(patchMethod, virtualServiceResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				vs := &istiov1beta1.VirtualService{}
+				if err := json.Unmarshal(action.(ktesting.PatchAction).GetPatch(), vs); err != nil {
+					return true, nil, err
+				}
+				host := vs.Spec.Http[0].Route[0].Headers.Request.Set["Host"]
+				vsHosts = append(vsHosts, host)
+				if host == tt.failedVsHost {
+					return true, nil, errors.New("patch error")
+				}
+				return true, vs, nil
+			})
+
+			deployConfig := config.DeploymentConfig{
+				DeploymentTimeout:                     time.Minute,
+				BlueGreenReadinessTimeout:             2 * tickDurationSecond * time.Second,
+				NamespaceTimeout:                      2 * tickDurationSecond * time.Second,
+				DefaultModelResourceRequests:          &config.ResourceRequests{},
+				DefaultTransformerResourceRequests:    &config.ResourceRequests{},
+				UserContainerCPUDefaultLimit:          userContainerCPUDefaultLimit,
+				UserContainerCPULimitRequestFactor:    userContainerCPULimitRequestFactor,
+				UserContainerMemoryLimitRequestFactor: userContainerMemoryLimitRequestFactor,
+			}
+
+			containerFetcher := NewContainerFetcher(v1Client, clusterMetadata)
+			templater := clusterresource.NewInferenceServiceTemplater(deployConfig)
+
+			ctl, _ := newController(knClient.ServingV1(), kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), istioClient, deployConfig, containerFetcher, templater, &mlpMock.APIClient{})
+			iSvc, err := ctl.Deploy(context.Background(), modelSvc, 1)
+
+			assert.Equal(t, tt.wantVsHosts, vsHosts)
+			assert.Equal(t, tt.wantDeletedIsvcs, deletedIsvcs)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Nil(t, iSvc)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIsvcName, iSvc.CurrentIsvcName)
+		})
+	}
+}
@@ -92,7 +92,7 @@ func TestContainer_GetContainers(t *testing.T) {
 
 		mockMlpAPIClient := &mlpMock.APIClient{}
 
-		ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, nil, config.DeploymentConfig{}, containerFetcher, nil, mockMlpAPIClient)
+		ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), nil, config.DeploymentConfig{}, containerFetcher, nil, mockMlpAPIClient)
 		containers, err := ctl.GetContainers(context.Background(), tt.args.namespace, tt.args.labelSelector)
 		if !tt.wantError {
 			assert.NoErrorf(t, err, "expected no error got %v", err)
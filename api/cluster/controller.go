@@ -30,9 +30,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingv2client "k8s.io/client-go/kubernetes/typed/autoscaling/v2"
 	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	policyv1client "k8s.io/client-go/kubernetes/typed/policy/v1"
+	rbacv1client "k8s.io/client-go/kubernetes/typed/rbac/v1"
 	"k8s.io/client-go/rest"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	knservingclientset "knative.dev/serving/pkg/client/clientset/versioned"
@@ -51,6 +53,7 @@ import (
 type Controller interface {
 	Deploy(ctx context.Context, modelService *models.Service, projectID int) (*models.Service, error)
 	Delete(ctx context.Context, modelService *models.Service) (*models.Service, error)
+	DryRun(ctx context.Context, modelService *models.Service, projectID int) (*models.DeploymentPlan, error)
 
 	ListPods(ctx context.Context, namespace, labelSelector string) (*corev1.PodList, error)
 	StreamPodLogs(ctx context.Context, namespace, podName string, opts *corev1.PodLogOptions) (io.ReadCloser, error)
@@ -61,6 +64,8 @@ type Controller interface {
 
 	GetCurrentDeploymentScale(ctx context.Context, namespace string,
 		components map[kservev1beta1.ComponentType]kservev1beta1.ComponentStatusSpec) resource.DeploymentScale
+	UpdateInferenceServiceReplicas(ctx context.Context, namespace string, name string,
+		replicas map[kservev1beta1.ComponentType]resource.ComponentReplicas) error
 
 	ContainerFetcher
 }
@@ -89,6 +94,8 @@ type controller struct {
 	clusterClient              corev1client.CoreV1Interface
 	batchClient                batchv1client.BatchV1Interface
 	policyClient               policyv1client.PolicyV1Interface
+	autoscalingClient          autoscalingv2client.AutoscalingV2Interface
+	rbacClient                 rbacv1client.RbacV1Interface
 	istioClient                networkingv1beta1.NetworkingV1beta1Interface
 	namespaceCreator           NamespaceCreator
 	deploymentConfig           *config.DeploymentConfig
@@ -134,6 +141,16 @@ func NewController(clusterConfig Config, deployConfig config.DeploymentConfig, m
 		return nil, err
 	}
 
+	autoscalingV2Client, err := autoscalingv2client.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rbacV1Client, err := rbacv1client.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	istioClient, err := networkingv1beta1.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -151,6 +168,8 @@ func NewController(clusterConfig Config, deployConfig config.DeploymentConfig, m
 		coreV1Client,
 		batchV1Client,
 		policyV1Client,
+		autoscalingV2Client,
+		rbacV1Client,
 		istioClient,
 		deployConfig,
 		containerFetcher,
@@ -165,6 +184,8 @@ func newController(
 	coreV1Client corev1client.CoreV1Interface,
 	batchV1Client batchv1client.BatchV1Interface,
 	policyV1Client policyv1client.PolicyV1Interface,
+	autoscalingV2Client autoscalingv2client.AutoscalingV2Interface,
+	rbacV1Client rbacv1client.RbacV1Interface,
 	istioClient networkingv1beta1.NetworkingV1beta1Interface,
 	deploymentConfig config.DeploymentConfig,
 	containerFetcher ContainerFetcher,
@@ -177,6 +198,8 @@ func newController(
 		clusterClient:              coreV1Client,
 		batchClient:                batchV1Client,
 		policyClient:               policyV1Client,
+		autoscalingClient:          autoscalingV2Client,
+		rbacClient:                 rbacV1Client,
 		istioClient:                istioClient,
 		namespaceCreator:           NewNamespaceCreator(coreV1Client, deploymentConfig.NamespaceTimeout),
 		deploymentConfig:           &deploymentConfig,
@@ -187,26 +210,8 @@ func newController(
 }
 
 func (c *controller) Deploy(ctx context.Context, modelService *models.Service, projectID int) (*models.Service, error) {
-	if modelService.ResourceRequest != nil {
-		cpuRequest, _ := modelService.ResourceRequest.CPURequest.AsInt64()
-		maxCPU, _ := c.deploymentConfig.MaxCPU.AsInt64()
-		if cpuRequest > maxCPU {
-			log.Errorf("insufficient available cpu resource to fulfil user request of %d", cpuRequest)
-			return nil, ErrInsufficientCPU
-		}
-		memRequest, _ := modelService.ResourceRequest.MemoryRequest.AsInt64()
-		maxMem, _ := c.deploymentConfig.MaxMemory.AsInt64()
-		if memRequest > maxMem {
-			log.Errorf("insufficient available memory resource to fulfil user request of %d", memRequest)
-			return nil, ErrInsufficientMem
-		}
-		if modelService.ResourceRequest.MaxReplica > c.deploymentConfig.MaxAllowedReplica {
-			log.Errorf("Requested Max Replica (%d) is more than max permissible (%d)",
-				modelService.ResourceRequest.MaxReplica,
-				c.deploymentConfig.MaxAllowedReplica,
-			)
-			return nil, ErrRequestedMaxReplicasNotAllowed
-		}
+	if err := c.validateResourceRequest(modelService); err != nil {
+		return nil, err
 	}
 
 	_, err := c.namespaceCreator.CreateNamespace(ctx, modelService.Namespace)
@@ -220,21 +225,17 @@ func (c *controller) Deploy(ctx context.Context, modelService *models.Service, p
 		return nil, fmt.Errorf("failed creating secret for deployment %s: %w", modelService.Name, err)
 	}
 
+	err = c.deployLoggerConfig(ctx, modelService, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating logger config for deployment %s: %w", modelService.Name, err)
+	}
+
 	isvcName := modelService.Name
 
 	// Get current scale of the existing deployment
-	deploymentScale := resource.DeploymentScale{}
-	if modelService.CurrentIsvcName != "" {
-		if modelService.DeploymentMode == deployment.ServerlessDeploymentMode ||
-			modelService.DeploymentMode == deployment.EmptyDeploymentMode {
-			currentIsvc, err := c.kserveClient.InferenceServices(modelService.Namespace).Get(ctx,
-				modelService.CurrentIsvcName, metav1.GetOptions{})
-			if err != nil && !kerrors.IsNotFound(err) {
-				return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToGetInferenceServiceStatus, isvcName))
-			}
-
-			deploymentScale = c.GetCurrentDeploymentScale(ctx, modelService.Namespace, currentIsvc.Status.Components)
-		}
+	deploymentScale, err := c.currentDeploymentScale(ctx, modelService)
+	if err != nil {
+		return nil, err
 	}
 
 	// create new resource
@@ -244,6 +245,15 @@ func (c *controller) Deploy(ctx context.Context, modelService *models.Service, p
 		return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToCreateInferenceService, isvcName))
 	}
 
+	// blue/green deployments keep the current revision serving until the traffic is switched to the new revision
+	var currentIsvc *kservev1beta1.InferenceService
+	if isBlueGreenDeployment(modelService) {
+		currentIsvc, err = c.getCurrentRevision(ctx, modelService)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// check the cluster to see if the inference service has already been deployed
 	s, err := c.kserveClient.InferenceServices(modelService.Namespace).Get(ctx, modelService.Name, metav1.GetOptions{})
 	if err != nil {
@@ -269,7 +279,18 @@ func (c *controller) Deploy(ctx context.Context, modelService *models.Service, p
 		}
 	}
 
-	s, err = c.waitInferenceServiceReady(s)
+	// Create / update the horizontal pod autoscalers of raw deployments not autoscaled by KServe
+	if err := c.deployHorizontalPodAutoscalers(ctx, modelService, s); err != nil {
+		log.Errorf("unable to create horizontal pod autoscaler: %v", err)
+		return nil, errors.Wrapf(err, fmt.Sprintf("%v", ErrUnableToCreateHPA))
+	}
+
+	readinessTimeout := c.deploymentConfig.DeploymentTimeout
+	if currentIsvc != nil {
+		readinessTimeout = c.blueGreenReadinessTimeout()
+	}
+
+	s, err = c.waitInferenceServiceReady(s, readinessTimeout)
 	if err != nil {
 		// remove created inferenceservice when got error
 		if err := c.deleteInferenceService(ctx, isvcName, modelService.Namespace); err != nil {
@@ -283,6 +304,10 @@ func (c *controller) Deploy(ctx context.Context, modelService *models.Service, p
 		return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToCreateInferenceService, isvcName))
 	}
 
+	if currentIsvc != nil {
+		return c.switchToNewRevision(ctx, modelService, currentIsvc, s)
+	}
+
 	inferenceURL := models.GetInferenceURL(s.Status.URL, isvcName, modelService.Protocol)
 
 	// Create / update virtual service
@@ -304,25 +329,84 @@ func (c *controller) Deploy(ctx context.Context, modelService *models.Service, p
 
 	// Delete previous inference service and pdb
 	if modelService.CurrentIsvcName != "" {
-		if err := c.deleteInferenceService(ctx, modelService.CurrentIsvcName, modelService.Namespace); err != nil {
-			log.Errorf("unable to delete prevision revision %s with error %v", modelService.CurrentIsvcName, err)
-			return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToDeletePreviousInferenceService, modelService.CurrentIsvcName))
+		if err := c.deletePreviousRevision(ctx, modelService); err != nil {
+			return nil, err
 		}
+	}
 
-		unusedPdbs, err := c.getUnusedPodDisruptionBudgets(ctx, modelService)
-		if err != nil {
-			log.Warnf("unable to get model name %s, version %s unused pdb: %v", modelService.ModelName, modelService.ModelVersion, err)
-		} else {
-			if err := c.deletePodDisruptionBudgets(ctx, unusedPdbs); err != nil {
-				log.Warnf("unable to delete model name %s, version %s unused pdb: %v", modelService.ModelName, modelService.ModelVersion, err)
+	return newDeployedService(modelService, s, inferenceURL), nil
+}
+
+// validateResourceRequest checks that the resource request of the model service fits in the deployment limits
+func (c *controller) validateResourceRequest(modelService *models.Service) error {
+	if modelService.ResourceRequest != nil {
+		cpuRequest, _ := modelService.ResourceRequest.CPURequest.AsInt64()
+		maxCPU, _ := c.deploymentConfig.MaxCPU.AsInt64()
+		if cpuRequest > maxCPU {
+			log.Errorf("insufficient available cpu resource to fulfil user request of %d", cpuRequest)
+			return ErrInsufficientCPU
+		}
+		memRequest, _ := modelService.ResourceRequest.MemoryRequest.AsInt64()
+		maxMem, _ := c.deploymentConfig.MaxMemory.AsInt64()
+		if memRequest > maxMem {
+			log.Errorf("insufficient available memory resource to fulfil user request of %d", memRequest)
+			return ErrInsufficientMem
+		}
+		if modelService.ResourceRequest.MaxReplica > c.deploymentConfig.MaxAllowedReplica {
+			log.Errorf("Requested Max Replica (%d) is more than max permissible (%d)",
+				modelService.ResourceRequest.MaxReplica,
+				c.deploymentConfig.MaxAllowedReplica,
+			)
+			return ErrRequestedMaxReplicasNotAllowed
+		}
+	}
+	return nil
+}
+
+// currentDeploymentScale returns the scale of the serverless revision replaced by the model service
+func (c *controller) currentDeploymentScale(ctx context.Context, modelService *models.Service) (resource.DeploymentScale, error) {
+	deploymentScale := resource.DeploymentScale{}
+	if modelService.CurrentIsvcName != "" {
+		if modelService.DeploymentMode == deployment.ServerlessDeploymentMode ||
+			modelService.DeploymentMode == deployment.EmptyDeploymentMode {
+			currentIsvc, err := c.kserveClient.InferenceServices(modelService.Namespace).Get(ctx,
+				modelService.CurrentIsvcName, metav1.GetOptions{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					return deploymentScale, nil
+				}
+				return deploymentScale, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToGetInferenceServiceStatus, modelService.Name))
 			}
+
+			deploymentScale = c.GetCurrentDeploymentScale(ctx, modelService.Namespace, currentIsvc.Status.Components)
 		}
+	}
+	return deploymentScale, nil
+}
 
-		if err := c.deleteSecrets(ctx, modelService.CurrentIsvcName, modelService.Namespace); err != nil {
-			log.Warnf("failed deleting secret for deployment %s: %w", modelService.Name, err)
+// deletePreviousRevision deletes the inference service, the unused pdbs and the secrets of the revision replaced by the model service
+func (c *controller) deletePreviousRevision(ctx context.Context, modelService *models.Service) error {
+	if err := c.deleteInferenceService(ctx, modelService.CurrentIsvcName, modelService.Namespace); err != nil {
+		log.Errorf("unable to delete prevision revision %s with error %v", modelService.CurrentIsvcName, err)
+		return errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToDeletePreviousInferenceService, modelService.CurrentIsvcName))
+	}
+
+	unusedPdbs, err := c.getUnusedPodDisruptionBudgets(ctx, modelService)
+	if err != nil {
+		log.Warnf("unable to get model name %s, version %s unused pdb: %v", modelService.ModelName, modelService.ModelVersion, err)
+	} else {
+		if err := c.deletePodDisruptionBudgets(ctx, unusedPdbs); err != nil {
+			log.Warnf("unable to delete model name %s, version %s unused pdb: %v", modelService.ModelName, modelService.ModelVersion, err)
 		}
 	}
 
+	if err := c.deleteSecrets(ctx, modelService.CurrentIsvcName, modelService.Namespace); err != nil {
+		log.Warnf("failed deleting secret for deployment %s: %w", modelService.Name, err)
+	}
+	return nil
+}
+
+func newDeployedService(modelService *models.Service, s *kservev1beta1.InferenceService, inferenceURL string) *models.Service {
 	return &models.Service{
 		Name:            s.Name,
 		Namespace:       s.Namespace,
@@ -330,7 +414,7 @@ func (c *controller) Deploy(ctx context.Context, modelService *models.Service, p
 		URL:             inferenceURL,
 		Metadata:        modelService.Metadata,
 		CurrentIsvcName: s.Name,
-	}, nil
+	}
 }
 
 func (c *controller) Delete(ctx context.Context, modelService *models.Service) (*models.Service, error) {
@@ -384,10 +468,10 @@ func (c *controller) deleteInferenceService(ctx context.Context, serviceName str
 	return nil
 }
 
-func (c *controller) waitInferenceServiceReady(service *kservev1beta1.InferenceService) (isvc *kservev1beta1.InferenceService, err error) {
+func (c *controller) waitInferenceServiceReady(service *kservev1beta1.InferenceService, readinessTimeout time.Duration) (isvc *kservev1beta1.InferenceService, err error) {
 	ctx := context.Background()
 
-	timeout := time.After(c.deploymentConfig.DeploymentTimeout)
+	timeout := time.After(readinessTimeout)
 
 	isvcConditionTable := ""
 	podContainerTable := ""
@@ -530,6 +614,54 @@ func (c *controller) GetCurrentDeploymentScale(
 	return deploymentScale
 }
 
+// UpdateInferenceServiceReplicas updates the replicas range of the components of a running inference service,
+// the components not deployed by the inference service are ignored
+func (c *controller) UpdateInferenceServiceReplicas(
+	ctx context.Context,
+	namespace string,
+	name string,
+	replicas map[kservev1beta1.ComponentType]resource.ComponentReplicas,
+) error {
+	isvc, err := c.kserveClient.InferenceServices(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToGetInferenceServiceStatus, name))
+	}
+
+	updated := false
+	for componentType, componentReplicas := range replicas {
+		var component *kservev1beta1.ComponentExtensionSpec
+		switch componentType {
+		case kservev1beta1.PredictorComponent:
+			component = &isvc.Spec.Predictor.ComponentExtensionSpec
+		case kservev1beta1.TransformerComponent:
+			if isvc.Spec.Transformer != nil {
+				component = &isvc.Spec.Transformer.ComponentExtensionSpec
+			}
+		}
+		if component == nil {
+			continue
+		}
+
+		if component.MinReplicas == nil || *component.MinReplicas != componentReplicas.MinReplica ||
+			component.MaxReplicas != componentReplicas.MaxReplica {
+			minReplica := componentReplicas.MinReplica
+			component.MinReplicas = &minReplica
+			component.MaxReplicas = componentReplicas.MaxReplica
+			updated = true
+		}
+	}
+	if updated {
+		if _, err := c.kserveClient.InferenceServices(namespace).Update(ctx, isvc, metav1.UpdateOptions{}); err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToUpdateInferenceService, name))
+		}
+	}
+
+	if err := c.updateHorizontalPodAutoscalerReplicas(ctx, isvc, replicas); err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToUpdateHPA, name))
+	}
+	return nil
+}
+
 func (c *controller) createSecrets(ctx context.Context, modelService *models.Service, projectID int) error {
 	err := c.createSecretForComponent(ctx, modelService.Name, modelService.Secrets, modelService.Namespace, projectID)
 	if err != nil {
@@ -571,6 +703,9 @@ func (c *controller) deleteSecrets(ctx context.Context, modelServiceName string,
 	if err != nil && !kerrors.IsNotFound(err) {
 		return fmt.Errorf("failed deleting secret for transformer %s in namespace %s: %w", transformerSecretName, namespace, err)
 	}
+
+	c.deleteLoggerConfig(ctx, modelServiceName, namespace)
+	c.deleteLoggerServiceAccount(ctx, modelServiceName, namespace)
 	return nil
 }
 
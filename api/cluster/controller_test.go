@@ -341,7 +341,7 @@ func TestController_DeployInferenceService_NamespaceCreation(t *testing.T) {
 
 			mockMlpAPIClient := &mlpMock.APIClient{}
 
-			ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, istioClient, deployConfig, containerFetcher, clusterresource.NewInferenceServiceTemplater(deployConfig), mockMlpAPIClient)
+			ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), istioClient, deployConfig, containerFetcher, clusterresource.NewInferenceServiceTemplater(deployConfig), mockMlpAPIClient)
 			iSvc, err := ctl.Deploy(context.Background(), modelSvc, 1)
 
 			if tt.wantError {
@@ -522,7 +522,7 @@ func TestController_DeployInferenceService_SecretCreation(t *testing.T) {
 			tt.mockMLPClient(mockMlpAPIClient)
 
 			modelSvc.Secrets = tt.secrets
-			ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, istioClient, deployConfig, containerFetcher, clusterresource.NewInferenceServiceTemplater(deployConfig), mockMlpAPIClient)
+			ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), istioClient, deployConfig, containerFetcher, clusterresource.NewInferenceServiceTemplater(deployConfig), mockMlpAPIClient)
 			iSvc, err := ctl.Deploy(context.Background(), modelSvc, 1)
 
 			assert.Equal(t, tt.reactorsCalled, called)
@@ -932,7 +932,7 @@ func TestController_DeployInferenceService(t *testing.T) {
 
 			mockMlpAPIClient := &mlpMock.APIClient{}
 
-			ctl, _ := newController(knClient.ServingV1(), kfClient, v1Client, nil, policyV1Client, istioClient, deployConfig, containerFetcher, templater, mockMlpAPIClient)
+			ctl, _ := newController(knClient.ServingV1(), kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), istioClient, deployConfig, containerFetcher, templater, mockMlpAPIClient)
 			iSvc, err := ctl.Deploy(context.Background(), tt.modelService, 1)
 
 			if tt.wantError {
@@ -1153,7 +1153,7 @@ func TestController_DeployInferenceService_PodDisruptionBudgetsRemoval(t *testin
 
 			mockMlpAPIClient := &mlpMock.APIClient{}
 
-			ctl, _ := newController(knClient.ServingV1(), kfClient, v1Client, nil, policyV1Client, istioClient, deployConfig, containerFetcher, templater, mockMlpAPIClient)
+			ctl, _ := newController(knClient.ServingV1(), kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), istioClient, deployConfig, containerFetcher, templater, mockMlpAPIClient)
 			iSvc, err := ctl.Deploy(context.Background(), tt.modelService, 1)
 
 			if tt.deletedPdbResult.err == nil {
@@ -1291,7 +1291,7 @@ func TestGetCurrentDeploymentScale(t *testing.T) {
 			mockMlpAPIClient := &mlpMock.APIClient{}
 
 			// Create test controller
-			ctl, _ := newController(knClient.ServingV1(), kfClient, v1Client, nil, policyV1Client, nil, deployConfig, containerFetcher, templater, mockMlpAPIClient)
+			ctl, _ := newController(knClient.ServingV1(), kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), nil, deployConfig, containerFetcher, templater, mockMlpAPIClient)
 
 			desiredReplicas := ctl.GetCurrentDeploymentScale(context.TODO(), testNamespace, tt.components)
 			assert.Equal(t, tt.expectedScale, desiredReplicas)
@@ -1627,7 +1627,7 @@ func TestController_Delete(t *testing.T) {
 
 			mockMlpAPIClient := &mlpMock.APIClient{}
 
-			ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, istioClient, tt.deployConfig, containerFetcher, templater, mockMlpAPIClient)
+			ctl, _ := newController(knClient, kfClient, v1Client, nil, policyV1Client, nil, fake.NewSimpleClientset().RbacV1(), istioClient, tt.deployConfig, containerFetcher, templater, mockMlpAPIClient)
 			mSvc, err := ctl.Delete(context.Background(), tt.modelService)
 
 			if tt.wantError {
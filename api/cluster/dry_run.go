@@ -0,0 +1,397 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+)
+
+// redactedSecretValue replaces the values of the secrets rendered by a dry-run
+const redactedSecretValue = "<redacted>"
+
+// DryRun validates the deployment of the model service and renders the Kubernetes resources created by Deploy.
+// The resources are submitted to the cluster with a server-side dry-run, hence nothing is persisted. Resource requests
+// exceeding the deployment limits, invalid configurations and resources rejected by the cluster are returned as
+// invalid input errors.
+func (c *controller) DryRun(ctx context.Context, modelService *models.Service, projectID int) (*models.DeploymentPlan, error) {
+	if err := c.validateResourceRequest(modelService); err != nil {
+		return nil, merror.NewInvalidInputError(err.Error())
+	}
+
+	plan := models.NewDeploymentPlan()
+
+	// the resources of a namespace that doesn't exist yet can't be submitted to the cluster
+	verify := true
+	if _, err := c.clusterClient.Namespaces().Get(ctx, modelService.Namespace, metav1.GetOptions{}); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToGetNamespaceStatus, modelService.Namespace))
+		}
+		verify = false
+		plan.AddWarning("namespace %s doesn't exist and will be created on deployment, the resources are not validated by the cluster", modelService.Namespace)
+	}
+
+	if err := c.dryRunSecrets(ctx, plan, modelService, projectID, verify); err != nil {
+		return nil, err
+	}
+
+	if err := c.dryRunLoggerConfig(ctx, plan, modelService, projectID, verify); err != nil {
+		return nil, err
+	}
+
+	isvc, err := c.dryRunInferenceService(ctx, plan, modelService, verify)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.deploymentConfig.PodDisruptionBudget.Enabled {
+		if err := c.dryRunPodDisruptionBudgets(ctx, plan, modelService, verify); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.dryRunHorizontalPodAutoscalers(ctx, plan, modelService, isvc, verify); err != nil {
+		return nil, err
+	}
+
+	if err := c.dryRunVirtualService(ctx, plan, modelService, verify); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (c *controller) dryRunSecrets(ctx context.Context, plan *models.DeploymentPlan, modelService *models.Service, projectID int, verify bool) error {
+	componentSecrets := map[string]models.Secrets{modelService.Name: modelService.Secrets}
+	secretNames := []string{modelService.Name}
+	if modelService.Transformer != nil && modelService.Transformer.Enabled {
+		transformerSecretName := fmt.Sprintf("%s-transformer", modelService.Name)
+		componentSecrets[transformerSecretName] = modelService.Transformer.Secrets
+		secretNames = append(secretNames, transformerSecretName)
+	}
+
+	for _, secretName := range secretNames {
+		secretMap, err := c.getMLPSecrets(ctx, componentSecrets[secretName], modelService.Namespace, projectID)
+		if err != nil {
+			return merror.NewInvalidInputErrorf("unable to render secret %s: %v", secretName, err)
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: modelService.Namespace,
+			},
+			StringData: secretMap,
+			Type:       corev1.SecretTypeOpaque,
+		}
+
+		if verify {
+			secret, err = c.dryRunSecret(ctx, secret)
+			if err != nil {
+				return dryRunError(err, "secret", secretName)
+			}
+		}
+
+		secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+		plan.AddManifest(secret.Kind, secret.Name, secret.Namespace, verify, redactSecret(secret))
+	}
+	return nil
+}
+
+func (c *controller) dryRunSecret(ctx context.Context, secret *corev1.Secret) (*corev1.Secret, error) {
+	_, err := c.clusterClient.Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err == nil {
+		return c.clusterClient.Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	return c.clusterClient.Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+}
+
+// dryRunLoggerConfig renders the logger config secret of the model service with the resources allowing the inference
+// loggers to read it, the values of the secret are redacted
+func (c *controller) dryRunLoggerConfig(ctx context.Context, plan *models.DeploymentPlan, modelService *models.Service, projectID int, verify bool) error {
+	resources, err := c.renderLoggerResources(ctx, modelService, projectID)
+	if err != nil {
+		return err
+	}
+
+	if serviceAccount := resources.serviceAccount; serviceAccount != nil {
+		if verify {
+			serviceAccount, err = c.dryRunServiceAccount(ctx, serviceAccount)
+			if err != nil {
+				return dryRunError(err, "service account", resources.serviceAccount.Name)
+			}
+		}
+		serviceAccount.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"}
+		plan.AddManifest(serviceAccount.Kind, serviceAccount.Name, serviceAccount.Namespace, verify, serviceAccount)
+	}
+	if resources.secret == nil {
+		return nil
+	}
+
+	secret := resources.secret
+	if verify {
+		secret, err = c.dryRunSecret(ctx, secret)
+		if err != nil {
+			return dryRunError(err, "secret", resources.secret.Name)
+		}
+	}
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	plan.AddManifest(secret.Kind, secret.Name, secret.Namespace, verify, redactSecret(secret))
+
+	role := resources.role
+	if verify {
+		role, err = c.dryRunRole(ctx, role)
+		if err != nil {
+			return dryRunError(err, "role", resources.role.Name)
+		}
+	}
+	role.TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"}
+	plan.AddManifest(role.Kind, role.Name, role.Namespace, verify, role)
+
+	roleBinding := resources.roleBinding
+	if verify {
+		roleBinding, err = c.dryRunRoleBinding(ctx, roleBinding)
+		if err != nil {
+			return dryRunError(err, "role binding", resources.roleBinding.Name)
+		}
+	}
+	roleBinding.TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"}
+	plan.AddManifest(roleBinding.Kind, roleBinding.Name, roleBinding.Namespace, verify, roleBinding)
+	return nil
+}
+
+// dryRunServiceAccount submits the service account to the cluster, an existing service account is kept by Deploy
+func (c *controller) dryRunServiceAccount(ctx context.Context, serviceAccount *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+	existing, err := c.clusterClient.ServiceAccounts(serviceAccount.Namespace).Get(ctx, serviceAccount.Name, metav1.GetOptions{})
+	if err == nil {
+		return existing, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	return c.clusterClient.ServiceAccounts(serviceAccount.Namespace).Create(ctx, serviceAccount, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+}
+
+func (c *controller) dryRunRole(ctx context.Context, role *rbacv1.Role) (*rbacv1.Role, error) {
+	_, err := c.rbacClient.Roles(role.Namespace).Get(ctx, role.Name, metav1.GetOptions{})
+	if err == nil {
+		return c.rbacClient.Roles(role.Namespace).Update(ctx, role, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	return c.rbacClient.Roles(role.Namespace).Create(ctx, role, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+}
+
+// dryRunRoleBinding submits the role binding to the cluster, an existing role binding is kept by Deploy
+func (c *controller) dryRunRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	existing, err := c.rbacClient.RoleBindings(roleBinding.Namespace).Get(ctx, roleBinding.Name, metav1.GetOptions{})
+	if err == nil {
+		return existing, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	return c.rbacClient.RoleBindings(roleBinding.Namespace).Create(ctx, roleBinding, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+}
+
+func (c *controller) dryRunInferenceService(ctx context.Context, plan *models.DeploymentPlan, modelService *models.Service, verify bool) (*kservev1beta1.InferenceService, error) {
+	deploymentScale, err := c.currentDeploymentScale(ctx, modelService)
+	if err != nil {
+		return nil, err
+	}
+
+	isvc, err := c.kfServingResourceTemplater.CreateInferenceServiceSpec(modelService, deploymentScale)
+	if err != nil {
+		return nil, merror.NewInvalidInputErrorf("%v (%s): %v", ErrUnableToCreateInferenceService, modelService.Name, err)
+	}
+
+	verified := false
+	if verify {
+		_, err := c.kserveClient.InferenceServices(modelService.Namespace).Get(ctx, modelService.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			plan.AddWarning("inference service %s already exists, its creation will be skipped on deployment", modelService.Name)
+		case kerrors.IsNotFound(err):
+			isvc, err = c.kserveClient.InferenceServices(modelService.Namespace).Create(ctx, isvc, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+			if err != nil {
+				return nil, dryRunError(err, "inference service", modelService.Name)
+			}
+			verified = true
+		default:
+			return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToGetInferenceServiceStatus, modelService.Name))
+		}
+	}
+
+	isvc.TypeMeta = metav1.TypeMeta{APIVersion: kservev1beta1.SchemeGroupVersion.String(), Kind: "InferenceService"}
+	plan.AddManifest(isvc.Kind, isvc.Name, isvc.Namespace, verified, isvc)
+	return isvc, nil
+}
+
+func (c *controller) dryRunPodDisruptionBudgets(ctx context.Context, plan *models.DeploymentPlan, modelService *models.Service, verify bool) error {
+	for _, pdb := range generatePDBSpecs(modelService, c.deploymentConfig.PodDisruptionBudget) {
+		pdbSpec, err := pdb.BuildPDBSpec()
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToCreatePDB, pdb.Name))
+		}
+
+		if verify {
+			pdbJSON, err := json.Marshal(pdbSpec)
+			if err != nil {
+				return err
+			}
+
+			pdbSpec, err = c.policyClient.PodDisruptionBudgets(pdb.Namespace).
+				Patch(ctx, pdb.Name, types.ApplyPatchType, pdbJSON, dryRunApplyOptions())
+			if err != nil {
+				return dryRunError(err, "pod disruption budget", pdb.Name)
+			}
+			pdbSpec.TypeMeta = metav1.TypeMeta{APIVersion: "policy/v1", Kind: "PodDisruptionBudget"}
+		}
+
+		plan.AddManifest(pdbSpec.Kind, pdbSpec.Name, pdbSpec.Namespace, verify, pdbSpec)
+	}
+	return nil
+}
+
+func (c *controller) dryRunHorizontalPodAutoscalers(
+	ctx context.Context,
+	plan *models.DeploymentPlan,
+	modelService *models.Service,
+	isvc *kservev1beta1.InferenceService,
+	verify bool,
+) error {
+	hpas, err := c.kfServingResourceTemplater.CreateHorizontalPodAutoscalerSpecs(modelService, isvc)
+	if err != nil {
+		return merror.NewInvalidInputErrorf("%v: %v", ErrUnableToCreateHPA, err)
+	}
+
+	for _, hpa := range hpas {
+		hpa.OwnerReferences = inferenceServiceOwnerReferences(isvc)
+
+		if verify {
+			hpaJSON, err := json.Marshal(hpa)
+			if err != nil {
+				return err
+			}
+
+			name := hpa.Name
+			hpa, err = c.autoscalingClient.HorizontalPodAutoscalers(hpa.Namespace).
+				Patch(ctx, name, types.ApplyPatchType, hpaJSON, dryRunApplyOptions())
+			if err != nil {
+				return dryRunError(err, "horizontal pod autoscaler", name)
+			}
+		}
+
+		hpa.TypeMeta = metav1.TypeMeta{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler"}
+		plan.AddManifest(hpa.Kind, hpa.Name, hpa.Namespace, verify, hpa)
+	}
+	return nil
+}
+
+func (c *controller) dryRunVirtualService(ctx context.Context, plan *models.DeploymentPlan, modelService *models.Service, verify bool) error {
+	isvcURL, err := c.predictInferenceServiceURL(ctx, modelService)
+	if err != nil {
+		return err
+	}
+	if isvcURL == nil {
+		plan.AddWarning("virtual service is rendered on deployment, once the cluster assigns the URL of inference service %s", modelService.Name)
+		return nil
+	}
+
+	vsCfg, err := NewVirtualService(modelService, models.GetInferenceURL(isvcURL, modelService.Name, modelService.Protocol))
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("%v", ErrUnableToCreateVirtualService))
+	}
+
+	vs, err := vsCfg.BuildVirtualServiceSpec()
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToCreateVirtualService, vsCfg.Name))
+	}
+
+	if verify {
+		vsJSON, err := json.Marshal(vs)
+		if err != nil {
+			return err
+		}
+
+		vs, err = c.istioClient.VirtualServices(vsCfg.Namespace).
+			Patch(ctx, vsCfg.Name, types.ApplyPatchType, vsJSON, dryRunApplyOptions())
+		if err != nil {
+			return dryRunError(err, "virtual service", vsCfg.Name)
+		}
+		vs.TypeMeta = metav1.TypeMeta{APIVersion: "networking.istio.io/v1beta1", Kind: "VirtualService"}
+	}
+
+	plan.AddManifest(vs.Kind, vs.Name, vs.Namespace, verify, vs)
+	return nil
+}
+
+// predictInferenceServiceURL predicts the URL of the inference service of the model service from the URL of the
+// revision it replaces, as the URL is assigned by the cluster once the inference service is created. It returns nil if
+// there is no such revision.
+func (c *controller) predictInferenceServiceURL(ctx context.Context, modelService *models.Service) (*apis.URL, error) {
+	if modelService.CurrentIsvcName == "" {
+		return nil, nil
+	}
+
+	current, err := c.kserveClient.InferenceServices(modelService.Namespace).Get(ctx, modelService.CurrentIsvcName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, fmt.Sprintf("%v (%s)", ErrUnableToGetInferenceServiceStatus, modelService.CurrentIsvcName))
+	}
+
+	if current.Status.URL == nil || !strings.HasPrefix(current.Status.URL.Host, modelService.CurrentIsvcName) {
+		return nil, nil
+	}
+
+	isvcURL := *current.Status.URL
+	isvcURL.Host = modelService.Name + strings.TrimPrefix(isvcURL.Host, modelService.CurrentIsvcName)
+	return &isvcURL, nil
+}
+
+func dryRunApplyOptions() metav1.PatchOptions {
+	forceEnabled := true
+	return metav1.PatchOptions{FieldManager: "application/apply-patch", Force: &forceEnabled, DryRun: []string{metav1.DryRunAll}}
+}
+
+// dryRunError returns an invalid input error if the resource is rejected by the server-side dry-run of the cluster,
+// e.g. because of an invalid spec, an exceeded resource quota or an admission webhook
+func dryRunError(err error, kind string, name string) error {
+	if kerrors.IsInvalid(err) || kerrors.IsForbidden(err) || kerrors.IsBadRequest(err) {
+		return merror.NewInvalidInputErrorf("%s %s is rejected by the cluster: %v", kind, name, err)
+	}
+	return errors.Wrapf(err, "unable to dry-run %s %s", kind, name)
+}
+
+// redactSecret hides the values of a secret rendered by a dry-run
+func redactSecret(secret *corev1.Secret) *corev1.Secret {
+	redacted := secret.DeepCopy()
+	stringData := map[string]string{}
+	for key := range redacted.StringData {
+		stringData[key] = redactedSecretValue
+	}
+	for key := range redacted.Data {
+		stringData[key] = redactedSecretValue
+	}
+	redacted.StringData = stringData
+	redacted.Data = nil
+	return redacted
+}
@@ -0,0 +1,229 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	fakekserve "github.com/kserve/kserve/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
+	fakeistionetworking "istio.io/client-go/pkg/clientset/versioned/typed/networking/v1beta1/fake"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	knservingfake "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	clusterresource "github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/mlp"
+	mlpMock "github.com/caraml-dev/merlin/mlp/mocks"
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+)
+
+func TestController_DryRun(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-project"}}
+	currentIsvc := &kservev1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model-1-r1", Namespace: "my-project"},
+		Status: kservev1beta1.InferenceServiceStatus{
+			URL: apis.HTTP("my-model-1-r1.my-project.example.com"),
+		},
+	}
+	newIsvc := &kservev1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model-1-r2", Namespace: "my-project"},
+	}
+
+	newModelService := func(cpuRequest string) *models.Service {
+		return &models.Service{
+			Name:            "my-model-1-r2",
+			ModelName:       "my-model",
+			ModelVersion:    "1",
+			RevisionID:      models.ID(2),
+			Namespace:       "my-project",
+			CurrentIsvcName: "my-model-1-r1",
+			Options:         &models.ModelOption{},
+			ResourceRequest: &models.ResourceRequest{
+				MinReplica:    1,
+				MaxReplica:    2,
+				CPURequest:    resource.MustParse(cpuRequest),
+				MemoryRequest: resource.MustParse("1Gi"),
+			},
+			Secrets: models.Secrets{{MLPSecretName: "my-secret", EnvVarName: "MY_SECRET"}},
+		}
+	}
+
+	newModelServiceWithLogger := func(httpSinkAuthSecretName string) *models.Service {
+		modelService := newModelService("1")
+		modelService.Logger = &models.Logger{
+			Model: &models.LoggerConfig{Enabled: true, Mode: models.LogAll, HTTPSinkAuthSecretName: httpSinkAuthSecretName},
+		}
+		return modelService
+	}
+
+	tests := []struct {
+		name          string
+		modelService  *models.Service
+		objects       []runtime.Object
+		isvcObjects   []runtime.Object
+		secretErr     error
+		createIsvcErr error
+		wantKinds     []string
+		wantVerified  []bool
+		wantWarnings  int
+		wantErr       error
+	}{
+		{
+			name:         "resources are validated by the cluster",
+			modelService: newModelService("1"),
+			objects:      []runtime.Object{namespace},
+			isvcObjects:  []runtime.Object{currentIsvc},
+			wantKinds:    []string{"Secret", "InferenceService", "VirtualService"},
+			wantVerified: []bool{true, true, true},
+		},
+		{
+			name:         "namespace doesn't exist",
+			modelService: newModelService("1"),
+			wantKinds:    []string{"Secret", "InferenceService"},
+			wantVerified: []bool{false, false},
+			wantWarnings: 2,
+		},
+		{
+			name:         "inference service already exists",
+			modelService: newModelService("1"),
+			objects:      []runtime.Object{namespace},
+			isvcObjects:  []runtime.Object{currentIsvc, newIsvc},
+			wantKinds:    []string{"Secret", "InferenceService", "VirtualService"},
+			wantVerified: []bool{true, false, true},
+			wantWarnings: 1,
+		},
+		{
+			name:         "logger config is validated by the cluster",
+			modelService: newModelServiceWithLogger("my-secret"),
+			objects:      []runtime.Object{namespace},
+			isvcObjects:  []runtime.Object{currentIsvc},
+			wantKinds:    []string{"Secret", "ServiceAccount", "Secret", "Role", "RoleBinding", "InferenceService", "VirtualService"},
+			wantVerified: []bool{true, true, true, true, true, true, true},
+		},
+		{
+			name:         "logger without any config",
+			modelService: newModelServiceWithLogger(""),
+			objects:      []runtime.Object{namespace},
+			isvcObjects:  []runtime.Object{currentIsvc},
+			wantKinds:    []string{"Secret", "ServiceAccount", "InferenceService", "VirtualService"},
+			wantVerified: []bool{true, true, true, true},
+		},
+		{
+			name:         "http sink auth secret of the logger is not found",
+			modelService: newModelServiceWithLogger("unknown-secret"),
+			objects:      []runtime.Object{namespace},
+			wantErr:      merror.ErrInvalidInput,
+		},
+		{
+			name:         "cpu request exceeds the limit",
+			modelService: newModelService("10"),
+			wantErr:      merror.ErrInvalidInput,
+		},
+		{
+			name:         "secret is not found",
+			modelService: newModelService("1"),
+			objects:      []runtime.Object{namespace},
+			secretErr:    errors.New("secret not found"),
+			wantErr:      merror.ErrInvalidInput,
+		},
+		{
+			name:          "inference service is rejected by the cluster",
+			modelService:  newModelService("1"),
+			objects:       []runtime.Object{namespace},
+			isvcObjects:   []runtime.Object{currentIsvc},
+			createIsvcErr: kerrors.NewForbidden(schema.GroupResource{Resource: inferenceServiceResource}, "my-model-1-r2", errors.New("exceeded quota")),
+			wantErr:       merror.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kserveClient := fakekserve.NewSimpleClientset(tt.isvcObjects...)
+			kserveClient.PrependReactor(createMethod, inferenceServiceResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				return true, action.(ktesting.CreateAction).GetObject(), tt.createIsvcErr
+			})
+
+			istioClient := fakeistio.NewSimpleClientset().NetworkingV1beta1().(*fakeistionetworking.FakeNetworkingV1beta1)
+			istioClient.Fake.PrependReactor(patchMethod, virtualServiceResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				vs := &istiov1beta1.VirtualService{}
+				err = json.Unmarshal(action.(ktesting.PatchAction).GetPatch(), vs)
+				return true, vs, err
+			})
+
+			mlpAPIClient := &mlpMock.APIClient{}
+			mlpAPIClient.On("GetSecretByName", mock.Anything, "my-secret", int32(1)).
+				Return(mlp.Secret{Name: "my-secret", Data: "secret-data"}, tt.secretErr)
+			mlpAPIClient.On("GetSecretByName", mock.Anything, "unknown-secret", int32(1)).
+				Return(mlp.Secret{}, errors.New("secret not found"))
+
+			deployConfig := config.DeploymentConfig{
+				MaxCPU:                                resource.MustParse("8"),
+				MaxMemory:                             resource.MustParse("8Gi"),
+				MaxAllowedReplica:                     10,
+				UserContainerCPUDefaultLimit:          userContainerCPUDefaultLimit,
+				UserContainerCPULimitRequestFactor:    userContainerCPULimitRequestFactor,
+				UserContainerMemoryLimitRequestFactor: userContainerMemoryLimitRequestFactor,
+			}
+
+			clientset := fake.NewSimpleClientset(tt.objects...)
+			ctl := &controller{
+				knServingClient:            knservingfake.NewSimpleClientset().ServingV1(),
+				clusterClient:              clientset.CoreV1(),
+				rbacClient:                 clientset.RbacV1(),
+				kserveClient:               kserveClient.ServingV1beta1(),
+				istioClient:                istioClient,
+				deploymentConfig:           &deployConfig,
+				kfServingResourceTemplater: clusterresource.NewInferenceServiceTemplater(deployConfig),
+				mlpAPIClient:               mlpAPIClient,
+			}
+
+			plan, err := ctl.DryRun(context.Background(), tt.modelService, 1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			kinds := []string{}
+			verified := []bool{}
+			for _, manifest := range plan.Manifests {
+				kinds = append(kinds, manifest.Kind)
+				verified = append(verified, manifest.Verified)
+			}
+			assert.Equal(t, tt.wantKinds, kinds)
+			assert.Equal(t, tt.wantVerified, verified)
+			assert.Len(t, plan.Warnings, tt.wantWarnings)
+
+			secret := plan.Manifests[0].Manifest.(*corev1.Secret)
+			assert.Equal(t, map[string]string{"my-secret": redactedSecretValue}, secret.StringData)
+
+			for _, manifest := range plan.Manifests[1:] {
+				if loggerSecret, ok := manifest.Manifest.(*corev1.Secret); ok {
+					assert.Equal(t, "my-model-1-r2-logger", loggerSecret.Name)
+					assert.Equal(t, map[string]string{"predictor": redactedSecretValue}, loggerSecret.StringData)
+				}
+			}
+
+			if kinds[len(kinds)-1] == "VirtualService" {
+				vs := plan.Manifests[len(plan.Manifests)-1].Manifest.(*istiov1beta1.VirtualService)
+				assert.Equal(t, []string{"my-model-1.my-project.example.com"}, vs.Spec.Hosts)
+				assert.Equal(t, "my-model-1-r2.my-project.example.com", vs.Spec.Http[0].Route[0].Headers.Request.Set["Host"])
+			}
+		})
+	}
+}
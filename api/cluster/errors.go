@@ -29,8 +29,11 @@ var (
 	ErrUnableToDeleteInferenceService         = errors.New("error deleting inference service")
 	ErrUnableToDeletePreviousInferenceService = errors.New("error deleting previous inference service")
 	ErrTimeoutCreateInferenceService          = errors.New("timeout waiting inference service to be ready")
+	ErrNewRevisionNotReady                    = errors.New("new revision is not ready after switching the traffic")
 	ErrUnableToCreatePDB                      = errors.New("error deploying pod disruption budget")
 	ErrUnableToDeletePDB                      = errors.New("error deleting pod disruption budget")
+	ErrUnableToCreateHPA                      = errors.New("error deploying horizontal pod autoscaler")
+	ErrUnableToUpdateHPA                      = errors.New("error updating horizontal pod autoscaler")
 	ErrUnableToCreateVirtualService           = errors.New("error deploying virtual service")
 	ErrUnableToDeleteVirtualService           = errors.New("error deleting virtual service")
 )
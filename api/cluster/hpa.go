@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	kserveconstant "github.com/kserve/kserve/pkg/constants"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/models"
+)
+
+// deployHorizontalPodAutoscalers creates or updates the horizontal pod autoscalers of a raw deployment autoscaled by
+// merlin instead of KServe. The autoscalers are owned by the inference service so that they are deleted together.
+func (c *controller) deployHorizontalPodAutoscalers(ctx context.Context, modelService *models.Service, isvc *kservev1beta1.InferenceService) error {
+	hpas, err := c.kfServingResourceTemplater.CreateHorizontalPodAutoscalerSpecs(modelService, isvc)
+	if err != nil {
+		return err
+	}
+
+	for _, hpa := range hpas {
+		hpa.OwnerReferences = inferenceServiceOwnerReferences(isvc)
+		if err := c.deployHorizontalPodAutoscaler(ctx, hpa); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func inferenceServiceOwnerReferences(isvc *kservev1beta1.InferenceService) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: kservev1beta1.SchemeGroupVersion.String(),
+			Kind:       "InferenceService",
+			Name:       isvc.Name,
+			UID:        isvc.UID,
+		},
+	}
+}
+
+func (c *controller) deployHorizontalPodAutoscaler(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	hpaJSON, err := json.Marshal(hpa)
+	if err != nil {
+		return err
+	}
+
+	forceEnabled := true
+
+	_, err = c.autoscalingClient.HorizontalPodAutoscalers(hpa.Namespace).
+		Patch(ctx, hpa.Name, types.ApplyPatchType, hpaJSON, metav1.PatchOptions{FieldManager: "application/apply-patch", Force: &forceEnabled})
+	return err
+}
+
+// updateHorizontalPodAutoscalerReplicas updates the replicas range of the horizontal pod autoscalers of an inference
+// service autoscaled by merlin, it does nothing if the inference service is autoscaled by KServe
+func (c *controller) updateHorizontalPodAutoscalerReplicas(
+	ctx context.Context,
+	isvc *kservev1beta1.InferenceService,
+	replicas map[kservev1beta1.ComponentType]resource.ComponentReplicas,
+) error {
+	if isvc.Annotations[kserveconstant.AutoscalerClass] != string(kserveconstant.AutoscalerClassExternal) {
+		return nil
+	}
+
+	for componentType, componentReplicas := range replicas {
+		var name string
+		switch componentType {
+		case kservev1beta1.PredictorComponent:
+			name = kserveconstant.PredictorServiceName(isvc.Name)
+		case kservev1beta1.TransformerComponent:
+			name = kserveconstant.TransformerServiceName(isvc.Name)
+		default:
+			continue
+		}
+
+		hpa, err := c.autoscalingClient.HorizontalPodAutoscalers(isvc.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		// the horizontal pod autoscaler requires at least 1 replica
+		minReplicas := int32(1)
+		if componentReplicas.MinReplica > 1 {
+			minReplicas = int32(componentReplicas.MinReplica)
+		}
+		maxReplicas := int32(componentReplicas.MaxReplica)
+		if maxReplicas < minReplicas {
+			maxReplicas = minReplicas
+		}
+		if hpa.Spec.MinReplicas != nil && *hpa.Spec.MinReplicas == minReplicas && hpa.Spec.MaxReplicas == maxReplicas {
+			continue
+		}
+
+		hpa.Spec.MinReplicas = &minReplicas
+		hpa.Spec.MaxReplicas = maxReplicas
+		if _, err := c.autoscalingClient.HorizontalPodAutoscalers(isvc.Namespace).Update(ctx, hpa, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	kserveconstant "github.com/kserve/kserve/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	fakeautoscalingv2 "k8s.io/client-go/kubernetes/typed/autoscaling/v2/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/pkg/autoscaling"
+	"github.com/caraml-dev/merlin/pkg/deployment"
+)
+
+const hpaResource = "horizontalpodautoscalers"
+
+func TestController_deployHorizontalPodAutoscalers(t *testing.T) {
+	isvc := &kservev1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model-1-r1", Namespace: "my-project", UID: "isvc-uid"},
+		Spec: kservev1beta1.InferenceServiceSpec{
+			Predictor:   kservev1beta1.PredictorSpec{ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{MaxReplicas: 2}},
+			Transformer: &kservev1beta1.TransformerSpec{ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{MaxReplicas: 2}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		modelSvc    *models.Service
+		patchErr    error
+		wantPatched []string
+		wantErr     bool
+	}{
+		{
+			name: "autoscaled by kserve",
+			modelSvc: &models.Service{
+				DeploymentMode:    deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{MetricsType: autoscaling.CPUUtilization, TargetValue: 50},
+			},
+			wantPatched: []string{},
+		},
+		{
+			name: "autoscaled by merlin",
+			modelSvc: &models.Service{
+				DeploymentMode:    deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{MetricsType: autoscaling.RPS, TargetValue: 50},
+			},
+			wantPatched: []string{"my-model-1-r1-predictor", "my-model-1-r1-transformer"},
+		},
+		{
+			name: "failed to patch",
+			modelSvc: &models.Service{
+				DeploymentMode:    deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{MetricsType: autoscaling.RPS, TargetValue: 50},
+			},
+			patchErr:    errors.New("patch error"),
+			wantPatched: []string{"my-model-1-r1-predictor"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched := []string{}
+			autoscalingClient := fake.NewSimpleClientset().AutoscalingV2().(*fakeautoscalingv2.FakeAutoscalingV2)
+			autoscalingClient.Fake.PrependReactor(patchMethod, hpaResource, func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				if err := json.Unmarshal(action.(ktesting.PatchAction).GetPatch(), hpa); err != nil {
+					return true, nil, err
+				}
+				patched = append(patched, hpa.Name)
+				assert.Equal(t, "isvc-uid", string(hpa.OwnerReferences[0].UID))
+				return true, hpa, tt.patchErr
+			})
+
+			ctl := &controller{
+				autoscalingClient:          autoscalingClient,
+				kfServingResourceTemplater: resource.NewInferenceServiceTemplater(config.DeploymentConfig{}),
+			}
+
+			err := ctl.deployHorizontalPodAutoscalers(context.Background(), tt.modelSvc, isvc)
+			assert.Equal(t, tt.wantPatched, patched)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestController_updateHorizontalPodAutoscalerReplicas(t *testing.T) {
+	one := int32(1)
+	existing := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model-1-r1-predictor", Namespace: "my-project"},
+		Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: &one, MaxReplicas: 2},
+	}
+
+	newIsvc := func(autoscalerClass kserveconstant.AutoscalerClassType) *kservev1beta1.InferenceService {
+		return &kservev1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-model-1-r1",
+				Namespace:   "my-project",
+				Annotations: map[string]string{kserveconstant.AutoscalerClass: string(autoscalerClass)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		isvc     *kservev1beta1.InferenceService
+		replicas resource.ComponentReplicas
+		wantMin  int32
+		wantMax  int32
+	}{
+		{
+			name:     "autoscaled by kserve",
+			isvc:     newIsvc(kserveconstant.AutoscalerClassHPA),
+			replicas: resource.ComponentReplicas{MinReplica: 3, MaxReplica: 5},
+			wantMin:  1,
+			wantMax:  2,
+		},
+		{
+			name:     "autoscaled by merlin",
+			isvc:     newIsvc(kserveconstant.AutoscalerClassExternal),
+			replicas: resource.ComponentReplicas{MinReplica: 3, MaxReplica: 5},
+			wantMin:  3,
+			wantMax:  5,
+		},
+		{
+			name:     "autoscaler keeps at least 1 replica",
+			isvc:     newIsvc(kserveconstant.AutoscalerClassExternal),
+			replicas: resource.ComponentReplicas{MinReplica: 0, MaxReplica: 0},
+			wantMin:  1,
+			wantMax:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			autoscalingClient := fake.NewSimpleClientset(existing.DeepCopy()).AutoscalingV2()
+			ctl := &controller{autoscalingClient: autoscalingClient}
+
+			err := ctl.updateHorizontalPodAutoscalerReplicas(context.Background(), tt.isvc, map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent:   tt.replicas,
+				kservev1beta1.TransformerComponent: tt.replicas,
+			})
+			assert.NoError(t, err)
+
+			hpa, err := autoscalingClient.HorizontalPodAutoscalers("my-project").Get(context.Background(), existing.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMin, *hpa.Spec.MinReplicas)
+			assert.Equal(t, tt.wantMax, hpa.Spec.MaxReplicas)
+		})
+	}
+}
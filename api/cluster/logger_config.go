@@ -0,0 +1,292 @@
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/log"
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+)
+
+// loggerHashKeyBytes is the size of the generated hash keys of the inference loggers
+const loggerHashKeyBytes = 32
+
+// defaultServiceAccountName is the service account of the pods not running any inference logger
+const defaultServiceAccountName = "default"
+
+// loggerResources are the resources allowing the inference loggers of a model service to read their config
+type loggerResources struct {
+	// serviceAccount of the pods running an inference logger, nil if none of the loggers is enabled
+	serviceAccount *corev1.ServiceAccount
+	// secret, role and roleBinding are nil if none of the loggers needs any configuration
+	secret      *corev1.Secret
+	role        *rbacv1.Role
+	roleBinding *rbacv1.RoleBinding
+}
+
+// deployLoggerConfig creates the service account of the pods running an inference logger, and creates or updates the
+// logger config secret of the model service together with the role allowing that service account to read it. The
+// secret is deleted if none of the loggers needs any configuration.
+func (c *controller) deployLoggerConfig(ctx context.Context, modelService *models.Service, projectID int) error {
+	resources, err := c.renderLoggerResources(ctx, modelService, projectID)
+	if err != nil {
+		return err
+	}
+	if resources.secret == nil {
+		c.deleteLoggerConfig(ctx, modelService.Name, modelService.Namespace)
+	}
+
+	if resources.serviceAccount != nil {
+		if _, err := c.clusterClient.ServiceAccounts(modelService.Namespace).Create(ctx, resources.serviceAccount, metav1.CreateOptions{}); err != nil {
+			if !kerrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed creating service account %s in namespace %s: %w", resources.serviceAccount.Name, modelService.Namespace, err)
+			}
+		}
+	}
+	if resources.secret == nil {
+		return nil
+	}
+
+	if _, err := c.createK8sSecret(ctx, resources.secret.Name, modelService.Namespace, resources.secret.StringData); err != nil {
+		return err
+	}
+
+	role := resources.role
+	if _, err := c.rbacClient.Roles(modelService.Namespace).Create(ctx, role, metav1.CreateOptions{}); err != nil {
+		if !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed creating role %s in namespace %s: %w", role.Name, modelService.Namespace, err)
+		}
+		if _, err := c.rbacClient.Roles(modelService.Namespace).Update(ctx, role, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed updating role %s in namespace %s: %w", role.Name, modelService.Namespace, err)
+		}
+	}
+
+	roleBinding := resources.roleBinding
+	if _, err := c.rbacClient.RoleBindings(modelService.Namespace).Create(ctx, roleBinding, metav1.CreateOptions{}); err != nil {
+		if !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed creating role binding %s in namespace %s: %w", roleBinding.Name, modelService.Namespace, err)
+		}
+	}
+	return nil
+}
+
+// renderLoggerResources renders the resources of the inference loggers of the model service. Invalid logger configs,
+// including http sink auth secrets that don't exist, are returned as invalid input errors.
+func (c *controller) renderLoggerResources(ctx context.Context, modelService *models.Service, projectID int) (*loggerResources, error) {
+	resources := &loggerResources{}
+	if !loggerEnabled(modelService) {
+		return resources, nil
+	}
+
+	serviceAccount, err := c.newLoggerServiceAccount(ctx, modelService)
+	if err != nil {
+		return nil, err
+	}
+	resources.serviceAccount = serviceAccount
+
+	hashKey, err := c.loggerHashKey(ctx, modelService)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := c.getMLPSecrets(ctx, loggerSecrets(modelService), modelService.Namespace, projectID)
+	if err != nil {
+		return nil, merror.NewInvalidInputErrorf("error retrieving logger secrets: %v", err)
+	}
+
+	data, err := c.kfServingResourceTemplater.CreateLoggerConfig(modelService, hashKey, secrets)
+	if err != nil {
+		return nil, merror.NewInvalidInputErrorf("invalid logger config: %v", err)
+	}
+	if data == nil {
+		return resources, nil
+	}
+
+	secretName := rules.ConfigSecretName(modelService.Name)
+	resources.secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: modelService.Namespace,
+		},
+		StringData: data,
+		Type:       corev1.SecretTypeOpaque,
+	}
+
+	resources.role = &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: modelService.Namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{secretName},
+				Verbs:         []string{"get"},
+			},
+		},
+	}
+
+	resources.roleBinding = &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: modelService.Namespace,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccount.Name,
+				Namespace: modelService.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     secretName,
+		},
+	}
+	return resources, nil
+}
+
+// newLoggerServiceAccount renders the service account of the model service pods running an inference logger. The
+// image pull secrets of the default service account of the namespace are kept, so that the pods can still pull the
+// images of the model and transformer.
+func (c *controller) newLoggerServiceAccount(ctx context.Context, modelService *models.Service) (*corev1.ServiceAccount, error) {
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resource.LoggerServiceAccountName(modelService.Name),
+			Namespace: modelService.Namespace,
+		},
+	}
+
+	defaultServiceAccount, err := c.clusterClient.ServiceAccounts(modelService.Namespace).Get(ctx, defaultServiceAccountName, metav1.GetOptions{})
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed getting service account %s in namespace %s: %w", defaultServiceAccountName, modelService.Namespace, err)
+	}
+	if err == nil {
+		serviceAccount.ImagePullSecrets = defaultServiceAccount.ImagePullSecrets
+	}
+	return serviceAccount, nil
+}
+
+// loggerEnabled returns true if the model or the transformer of the model service runs an inference logger
+func loggerEnabled(modelService *models.Service) bool {
+	if modelService.Logger == nil {
+		return false
+	}
+	for _, loggerConfig := range []*models.LoggerConfig{modelService.Logger.Model, modelService.Logger.Transformer} {
+		if loggerConfig != nil && loggerConfig.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// loggerSecrets returns the MLP secrets used by the inference loggers of the model service
+func loggerSecrets(modelService *models.Service) models.Secrets {
+	secrets := models.Secrets{}
+	if modelService.Logger == nil {
+		return secrets
+	}
+	for _, loggerConfig := range []*models.LoggerConfig{modelService.Logger.Model, modelService.Logger.Transformer} {
+		if loggerConfig != nil && loggerConfig.Enabled && loggerConfig.HTTPSinkAuthSecretName != "" {
+			secrets = append(secrets, models.Secret{MLPSecretName: loggerConfig.HTTPSinkAuthSecretName})
+		}
+	}
+	return secrets
+}
+
+// loggerHashKey returns the hash key of the inference loggers of the model service. The key of the revision being
+// replaced is reused, so that the hashes of the redacted values don't change across the revisions of the deployment.
+func (c *controller) loggerHashKey(ctx context.Context, modelService *models.Service) (string, error) {
+	for _, isvcName := range []string{modelService.CurrentIsvcName, modelService.Name} {
+		if isvcName == "" {
+			continue
+		}
+
+		secretName := rules.ConfigSecretName(isvcName)
+		secret, err := c.clusterClient.Secrets(modelService.Namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed getting secret %s in namespace %s: %w", secretName, modelService.Namespace, err)
+		}
+		if hashKey := loggerConfigHashKey(secret); hashKey != "" {
+			return hashKey, nil
+		}
+	}
+
+	key := make([]byte, loggerHashKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed generating logger hash key: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// loggerConfigHashKey returns the hash key of any of the logger configs stored in the secret
+func loggerConfigHashKey(secret *corev1.Secret) string {
+	configs := make([][]byte, 0, len(secret.Data)+len(secret.StringData))
+	for _, data := range secret.Data {
+		configs = append(configs, data)
+	}
+	for _, data := range secret.StringData {
+		configs = append(configs, []byte(data))
+	}
+
+	for _, data := range configs {
+		cfg := &rules.Config{}
+		if err := json.Unmarshal(data, cfg); err == nil && cfg.HashKey != "" {
+			return cfg.HashKey
+		}
+	}
+	return ""
+}
+
+// deleteLoggerConfig deletes the logger config secret of the inference service and its role, if the secret exists.
+// Failures are only logged, since the leftovers don't affect the deployments, which may not use any logger at all.
+func (c *controller) deleteLoggerConfig(ctx context.Context, inferenceServiceName string, namespace string) {
+	secretName := rules.ConfigSecretName(inferenceServiceName)
+	if _, err := c.clusterClient.Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{}); err != nil {
+		if !kerrors.IsNotFound(err) {
+			log.Warnf("failed getting logger config secret %s in namespace %s: %v", secretName, namespace, err)
+		}
+		return
+	}
+
+	if err := c.deleteK8sSecret(ctx, secretName, namespace); err != nil {
+		log.Warnf("failed deleting logger config secret %s in namespace %s: %v", secretName, namespace, err)
+	}
+
+	err := c.rbacClient.RoleBindings(namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
+	if client.IgnoreNotFound(err) != nil {
+		log.Warnf("failed deleting role binding %s in namespace %s: %v", secretName, namespace, err)
+	}
+
+	err = c.rbacClient.Roles(namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
+	if client.IgnoreNotFound(err) != nil {
+		log.Warnf("failed deleting role %s in namespace %s: %v", secretName, namespace, err)
+	}
+}
+
+// deleteLoggerServiceAccount deletes the service account of the inference service pods running an inference logger.
+// Failures are only logged, like the ones of the logger config.
+func (c *controller) deleteLoggerServiceAccount(ctx context.Context, inferenceServiceName string, namespace string) {
+	serviceAccountName := resource.LoggerServiceAccountName(inferenceServiceName)
+	err := c.clusterClient.ServiceAccounts(namespace).Delete(ctx, serviceAccountName, metav1.DeleteOptions{})
+	if client.IgnoreNotFound(err) != nil {
+		log.Warnf("failed deleting service account %s in namespace %s: %v", serviceAccountName, namespace, err)
+	}
+}
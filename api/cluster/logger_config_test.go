@@ -0,0 +1,184 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/mlp"
+	mlpMock "github.com/caraml-dev/merlin/mlp/mocks"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+)
+
+func TestController_deployLoggerConfig(t *testing.T) {
+	modelSvc := &models.Service{
+		Name:      "my-model-1-r1",
+		Namespace: "my-project",
+		Logger: &models.Logger{
+			Model: &models.LoggerConfig{Enabled: true, Mode: models.LogAll},
+		},
+	}
+
+	clientset := fake.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "my-project"},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+	})
+	ctl := &controller{
+		clusterClient: clientset.CoreV1(),
+		rbacClient:    clientset.RbacV1(),
+		kfServingResourceTemplater: resource.NewInferenceServiceTemplater(config.DeploymentConfig{
+			InferenceLogger: config.InferenceLoggerConfig{SpoolEnabled: true, SpoolDir: "/tmp/spool", SpoolMaxBytes: 1024},
+		}),
+	}
+
+	ctx := context.Background()
+	// deploying twice updates the existing resources
+	for i := 0; i < 2; i++ {
+		require.NoError(t, ctl.deployLoggerConfig(ctx, modelSvc, 1))
+	}
+
+	secret, err := clientset.CoreV1().Secrets("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"spool":{"dir":"/tmp/spool","max_bytes":1024}}`, secret.StringData["predictor"])
+
+	role, err := clientset.RbacV1().Roles("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"my-model-1-r1-logger"}, role.Rules[0].ResourceNames)
+	assert.Equal(t, []string{"get"}, role.Rules[0].Verbs)
+
+	roleBinding, err := clientset.RbacV1().RoleBindings("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "my-model-1-r1-logger", roleBinding.Subjects[0].Name)
+	assert.Equal(t, "my-model-1-r1-logger", roleBinding.RoleRef.Name)
+
+	serviceAccount, err := clientset.CoreV1().ServiceAccounts("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}}, serviceAccount.ImagePullSecrets)
+
+	// the config is deleted once the logger is disabled
+	modelSvc.Logger.Model.Enabled = false
+	require.NoError(t, ctl.deployLoggerConfig(ctx, modelSvc, 1))
+
+	_, err = clientset.CoreV1().Secrets("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+	_, err = clientset.RbacV1().Roles("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+	_, err = clientset.RbacV1().RoleBindings("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+}
+
+func TestController_deployLoggerConfig_LoggerDisabled(t *testing.T) {
+	modelSvc := &models.Service{
+		Name:      "my-model-1-r1",
+		Namespace: "my-project",
+	}
+
+	clientset := fake.NewSimpleClientset()
+	// the api may not be allowed to manage the resources of the logger config
+	clientset.PrependReactor("*", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if action.GetVerb() == "get" {
+			return false, nil, nil
+		}
+		return true, nil, kerrors.NewForbidden(action.GetResource().GroupResource(), "", nil)
+	})
+	ctl := &controller{
+		clusterClient:              clientset.CoreV1(),
+		rbacClient:                 clientset.RbacV1(),
+		kfServingResourceTemplater: resource.NewInferenceServiceTemplater(config.DeploymentConfig{}),
+	}
+
+	ctx := context.Background()
+	require.NoError(t, ctl.deployLoggerConfig(ctx, modelSvc, 1))
+	// nothing is deleted if the logger config secret doesn't exist
+	for _, action := range clientset.Actions() {
+		assert.Equal(t, "get", action.GetVerb())
+	}
+}
+
+func TestController_loggerHashKey(t *testing.T) {
+	modelSvc := &models.Service{
+		Name:      "my-model-1-r2",
+		Namespace: "my-project",
+		Logger: &models.Logger{
+			Model: &models.LoggerConfig{
+				Enabled:        true,
+				Mode:           models.LogAll,
+				RedactionRules: []rules.RedactionRule{{JsonPath: "$.phone", Action: rules.ActionHash}},
+			},
+		},
+	}
+
+	clientset := fake.NewSimpleClientset()
+	ctl := &controller{
+		clusterClient:              clientset.CoreV1(),
+		rbacClient:                 clientset.RbacV1(),
+		kfServingResourceTemplater: resource.NewInferenceServiceTemplater(config.DeploymentConfig{}),
+	}
+
+	ctx := context.Background()
+	hashKey, err := ctl.loggerHashKey(ctx, modelSvc)
+	require.NoError(t, err)
+	assert.Len(t, hashKey, 2*loggerHashKeyBytes)
+
+	// a new hash key is generated for another deployment
+	otherHashKey, err := ctl.loggerHashKey(ctx, modelSvc)
+	require.NoError(t, err)
+	assert.NotEqual(t, hashKey, otherHashKey)
+
+	require.NoError(t, ctl.deployLoggerConfig(ctx, modelSvc, 1))
+	secret, err := clientset.CoreV1().Secrets("my-project").Get(ctx, "my-model-1-r2-logger", metav1.GetOptions{})
+	require.NoError(t, err)
+	deployedHashKey := loggerConfigHashKey(secret)
+	assert.NotEmpty(t, deployedHashKey)
+
+	// the hash key of the revision being replaced is reused by the new revision
+	newModelSvc := *modelSvc
+	newModelSvc.Name = "my-model-1-r3"
+	newModelSvc.CurrentIsvcName = "my-model-1-r2"
+	hashKey, err = ctl.loggerHashKey(ctx, &newModelSvc)
+	require.NoError(t, err)
+	assert.Equal(t, deployedHashKey, hashKey)
+}
+
+func TestController_deployLoggerConfig_HTTPSinkAuthToken(t *testing.T) {
+	modelSvc := &models.Service{
+		Name:      "my-model-1-r1",
+		Namespace: "my-project",
+		Logger: &models.Logger{
+			Model: &models.LoggerConfig{Enabled: true, Mode: models.LogAll, HTTPSinkAuthSecretName: "webhook-token"},
+		},
+	}
+
+	ctx := context.Background()
+	mlpAPIClient := &mlpMock.APIClient{}
+	mlpAPIClient.On("GetSecretByName", ctx, "webhook-token", int32(1)).Return(mlp.Secret{
+		ID:   1,
+		Name: "webhook-token",
+		Data: "my-token",
+	}, nil)
+
+	clientset := fake.NewSimpleClientset()
+	ctl := &controller{
+		clusterClient:              clientset.CoreV1(),
+		rbacClient:                 clientset.RbacV1(),
+		mlpAPIClient:               mlpAPIClient,
+		kfServingResourceTemplater: resource.NewInferenceServiceTemplater(config.DeploymentConfig{}),
+	}
+	require.NoError(t, ctl.deployLoggerConfig(ctx, modelSvc, 1))
+
+	secret, err := clientset.CoreV1().Secrets("my-project").Get(ctx, "my-model-1-r1-logger", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"http_sink_auth_token":"my-token"}`, secret.StringData["predictor"])
+	mlpAPIClient.AssertExpectations(t)
+}
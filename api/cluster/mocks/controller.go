@@ -123,6 +123,36 @@ func (_m *Controller) Deploy(ctx context.Context, modelService *models.Service,
 	return r0, r1
 }
 
+// DryRun provides a mock function with given fields: ctx, modelService, projectID
+func (_m *Controller) DryRun(ctx context.Context, modelService *models.Service, projectID int) (*models.DeploymentPlan, error) {
+	ret := _m.Called(ctx, modelService, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DryRun")
+	}
+
+	var r0 *models.DeploymentPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Service, int) (*models.DeploymentPlan, error)); ok {
+		return rf(ctx, modelService, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Service, int) *models.DeploymentPlan); ok {
+		r0 = rf(ctx, modelService, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeploymentPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Service, int) error); ok {
+		r1 = rf(ctx, modelService, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetContainers provides a mock function with given fields: ctx, namespace, labelSelector
 func (_m *Controller) GetContainers(ctx context.Context, namespace string, labelSelector string) ([]*models.Container, error) {
 	ret := _m.Called(ctx, namespace, labelSelector)
@@ -261,6 +291,24 @@ func (_m *Controller) StreamPodLogs(ctx context.Context, namespace string, podNa
 	return r0, r1
 }
 
+// UpdateInferenceServiceReplicas provides a mock function with given fields: ctx, namespace, name, replicas
+func (_m *Controller) UpdateInferenceServiceReplicas(ctx context.Context, namespace string, name string, replicas map[v1beta1.ComponentType]resource.ComponentReplicas) error {
+	ret := _m.Called(ctx, namespace, name, replicas)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateInferenceServiceReplicas")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[v1beta1.ComponentType]resource.ComponentReplicas) error); ok {
+		r0 = rf(ctx, namespace, name, replicas)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewController creates a new instance of Controller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewController(t interface {
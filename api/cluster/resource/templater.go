@@ -24,6 +24,7 @@ import (
 	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	kserveconstant "github.com/kserve/kserve/pkg/constants"
 	"github.com/mitchellh/copystructure"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +36,7 @@ import (
 	"github.com/caraml-dev/merlin/models"
 	"github.com/caraml-dev/merlin/pkg/autoscaling"
 	"github.com/caraml-dev/merlin/pkg/deployment"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	prt "github.com/caraml-dev/merlin/pkg/protocol"
 	transformerpkg "github.com/caraml-dev/merlin/pkg/transformer"
@@ -92,6 +94,9 @@ const (
 	envPublisherKafkaConfig       = "PUBLISHER_KAFKA_CONFIG"
 
 	grpcHealthProbeCommand = "grpc_health_probe"
+
+	defaultRPSMetricName         = "requests_per_second"
+	defaultConcurrencyMetricName = "requests_in_flight"
 )
 
 var grpcServerlessContainerPorts = []corev1.ContainerPort{
@@ -116,6 +121,12 @@ type DeploymentScale struct {
 	Transformer *int
 }
 
+// ComponentReplicas is the replicas range of an inference service component
+type ComponentReplicas struct {
+	MinReplica int
+	MaxReplica int
+}
+
 type InferenceServiceTemplater struct {
 	deploymentConfig config.DeploymentConfig
 }
@@ -191,6 +202,212 @@ func (t *InferenceServiceTemplater) CreateInferenceServiceSpec(modelService *mod
 	return inferenceService, nil
 }
 
+// CreateHorizontalPodAutoscalerSpecs creates the horizontal pod autoscalers of the components of a raw deployment
+// autoscaling on metrics not supported by KServe, the replicas range of each autoscaler is the one of its component in
+// the given inference service. It returns no autoscaler if the deployment is autoscaled by KServe.
+func (t *InferenceServiceTemplater) CreateHorizontalPodAutoscalerSpecs(modelService *models.Service, isvc *kservev1beta1.InferenceService) ([]*autoscalingv2.HorizontalPodAutoscaler, error) {
+	if !usesExternalAutoscaler(modelService) {
+		return nil, nil
+	}
+
+	metrics, err := t.toHPAMetrics(modelService.AutoscalingPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	hpas := []*autoscalingv2.HorizontalPodAutoscaler{
+		newHorizontalPodAutoscaler(isvc, kserveconstant.PredictorServiceName(isvc.Name), isvc.Spec.Predictor.ComponentExtensionSpec, metrics),
+	}
+	if isvc.Spec.Transformer != nil {
+		hpas = append(hpas, newHorizontalPodAutoscaler(isvc, kserveconstant.TransformerServiceName(isvc.Name), isvc.Spec.Transformer.ComponentExtensionSpec, metrics))
+	}
+	return hpas, nil
+}
+
+// CreateLoggerConfig creates the data of the logger config secret of the model service, keyed by the name of the
+// component whose inference logger is enabled. The hash key is only added to the config of the loggers redacting
+// values with the hash action. The auth token of the http sinks is read from the MLP secrets, keyed by secret name.
+// It returns no data if none of the loggers needs any configuration.
+func (t *InferenceServiceTemplater) CreateLoggerConfig(modelService *models.Service, hashKey string, secrets map[string]string) (map[string]string, error) {
+	if modelService.Logger == nil {
+		return nil, nil
+	}
+
+	components := map[string]*models.LoggerConfig{
+		string(kserveconstant.Predictor): modelService.Logger.Model,
+	}
+	if modelService.Transformer != nil && modelService.Transformer.Enabled {
+		components[string(kserveconstant.Transformer)] = modelService.Logger.Transformer
+	}
+
+	data := map[string]string{}
+	for component, loggerConfig := range components {
+		if loggerConfig == nil || !loggerConfig.Enabled {
+			continue
+		}
+
+		cfg := t.createLoggerConfig(loggerConfig)
+		// prediction log schema is used by the model observability sink of the model logger
+		if component == string(kserveconstant.Predictor) {
+			if schema := modelService.PredictionLogSchema(); schema != nil {
+				if cfg.Rules == nil {
+					cfg.Rules = &rules.Rules{}
+				}
+				cfg.Rules.PredictionLogSchema = schema
+			}
+		}
+		if secretName := loggerConfig.HTTPSinkAuthSecretName; secretName != "" {
+			token, ok := secrets[secretName]
+			if !ok {
+				return nil, fmt.Errorf("http sink auth secret %s of %s logger is not found", secretName, component)
+			}
+			cfg.HTTPSinkAuthToken = token
+		}
+		if cfg.IsEmpty() {
+			continue
+		}
+		if cfg.Rules.UsesHashAction() {
+			cfg.HashKey = hashKey
+		}
+
+		encoded, err := json.Marshal(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s logger config: %w", component, err)
+		}
+		data[component] = string(encoded)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return data, nil
+}
+
+func (t *InferenceServiceTemplater) createLoggerConfig(loggerConfig *models.LoggerConfig) *rules.Config {
+	cfg := &rules.Config{}
+	if payloadRules := loggerConfig.PayloadRules(); !payloadRules.IsEmpty() {
+		cfg.Rules = payloadRules
+	}
+	cfg.MetricsPort = t.deploymentConfig.InferenceLogger.MetricsPort
+	if t.deploymentConfig.InferenceLogger.SpoolEnabled {
+		cfg.Spool = &rules.SpoolConfig{
+			Dir:      t.deploymentConfig.InferenceLogger.SpoolDir,
+			MaxBytes: t.deploymentConfig.InferenceLogger.SpoolMaxBytes,
+		}
+	}
+	return cfg
+}
+
+// usesExternalAutoscaler returns true if the model service is a raw deployment whose autoscaling policy can't be
+// rendered as the horizontal pod autoscaler managed by KServe, which only scales on a single cpu or memory metrics
+func usesExternalAutoscaler(modelService *models.Service) bool {
+	policy := modelService.AutoscalingPolicy
+	if modelService.DeploymentMode != deployment.RawDeploymentMode || policy == nil {
+		return false
+	}
+
+	return len(policy.AdditionalMetrics) > 0 ||
+		(policy.MetricsType != autoscaling.CPUUtilization && policy.MetricsType != autoscaling.MemoryUtilization)
+}
+
+func newHorizontalPodAutoscaler(
+	isvc *kservev1beta1.InferenceService,
+	name string,
+	component kservev1beta1.ComponentExtensionSpec,
+	metrics []autoscalingv2.MetricSpec,
+) *autoscalingv2.HorizontalPodAutoscaler {
+	// the horizontal pod autoscaler requires at least 1 replica
+	minReplicas := int32(1)
+	if component.MinReplicas != nil && *component.MinReplicas > 1 {
+		minReplicas = int32(*component.MinReplicas)
+	}
+	maxReplicas := int32(component.MaxReplicas)
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2",
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: isvc.Namespace,
+			Labels:    isvc.Labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: maxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+// toHPAMetrics converts the metrics of the autoscaling policy to horizontal pod autoscaler metrics, the autoscaler
+// scales on the highest number of replicas required by any of them
+func (t *InferenceServiceTemplater) toHPAMetrics(policy *autoscaling.AutoscalingPolicy) ([]autoscalingv2.MetricSpec, error) {
+	metrics := []autoscalingv2.MetricSpec{}
+	for _, metric := range policy.Metrics() {
+		switch metric.MetricsType {
+		case autoscaling.CPUUtilization:
+			metrics = append(metrics, resourceMetricSpec(corev1.ResourceCPU, metric.TargetValue))
+		case autoscaling.MemoryUtilization:
+			metrics = append(metrics, resourceMetricSpec(corev1.ResourceMemory, metric.TargetValue))
+		case autoscaling.RPS:
+			metricName := t.deploymentConfig.AutoscalingCustomMetrics.RPSMetricName
+			if metricName == "" {
+				metricName = defaultRPSMetricName
+			}
+			metrics = append(metrics, podsMetricSpec(metricName, metric.TargetValue))
+		case autoscaling.Concurrency:
+			metricName := t.deploymentConfig.AutoscalingCustomMetrics.ConcurrencyMetricName
+			if metricName == "" {
+				metricName = defaultConcurrencyMetricName
+			}
+			metrics = append(metrics, podsMetricSpec(metricName, metric.TargetValue))
+		default:
+			return nil, fmt.Errorf("unsupported autoscaler metrics on raw deployment: %s", metric.MetricsType)
+		}
+	}
+	return metrics, nil
+}
+
+func resourceMetricSpec(name corev1.ResourceName, targetUtilization float64) autoscalingv2.MetricSpec {
+	utilization := int32(targetUtilization)
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+func podsMetricSpec(name string, targetValue float64) autoscalingv2.MetricSpec {
+	averageValue := resource.NewMilliQuantity(int64(targetValue*1000), resource.DecimalSI)
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: name,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: averageValue,
+			},
+		},
+	}
+}
+
 func (t *InferenceServiceTemplater) createPredictorSpec(modelService *models.Service) (kservev1beta1.PredictorSpec, error) {
 	limits, envVars, err := t.getResourceLimitsAndEnvVars(modelService.ResourceRequest, modelService.EnvVars, modelService.Secrets, modelService.Name)
 	if err != nil {
@@ -362,6 +579,8 @@ func (t *InferenceServiceTemplater) createPredictorSpec(modelService *models.Ser
 	if modelService.Logger != nil && modelService.Logger.Model != nil && modelService.Logger.Model.Enabled {
 		logger := modelService.Logger
 		loggerSpec = createLoggerSpec(logger.DestinationURL, *logger.Model)
+		predictorSpec.Annotations = t.createLoggerAnnotations(modelService)
+		predictorSpec.ServiceAccountName = LoggerServiceAccountName(modelService.Name)
 	}
 
 	predictorSpec.MinReplicas = &(modelService.ResourceRequest.MinReplica)
@@ -391,9 +610,13 @@ func (t *InferenceServiceTemplater) createTransformerSpec(
 	envVars = MergeEnvVars(envVars, defaultEnvVars)
 
 	var loggerSpec *kservev1beta1.LoggerSpec
+	var annotations map[string]string
+	var serviceAccountName string
 	if modelService.Logger != nil && modelService.Logger.Transformer != nil && modelService.Logger.Transformer.Enabled {
 		logger := modelService.Logger
 		loggerSpec = createLoggerSpec(logger.DestinationURL, *logger.Transformer)
+		annotations = t.createLoggerAnnotations(modelService)
+		serviceAccountName = LoggerServiceAccountName(modelService.Name)
 	}
 
 	var transformerCommand []string
@@ -434,11 +657,13 @@ func (t *InferenceServiceTemplater) createTransformerSpec(
 					Ports:         containerPorts,
 				},
 			},
+			ServiceAccountName: serviceAccountName,
 		},
 		ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
 			MinReplicas: &(transformer.ResourceRequest.MinReplica),
 			MaxReplicas: transformer.ResourceRequest.MaxReplica,
 			Logger:      loggerSpec,
+			Annotations: annotations,
 		},
 	}
 
@@ -591,7 +816,10 @@ func (t *InferenceServiceTemplater) createAnnotations(modelService *models.Servi
 	annotations[kserveconstant.DeploymentMode] = deployMode
 
 	if modelService.AutoscalingPolicy != nil {
-		if modelService.DeploymentMode == deployment.RawDeploymentMode {
+		if usesExternalAutoscaler(modelService) {
+			// the horizontal pod autoscalers are created by merlin, see CreateHorizontalPodAutoscalerSpecs
+			annotations[kserveconstant.AutoscalerClass] = string(kserveconstant.AutoscalerClassExternal)
+		} else if modelService.DeploymentMode == deployment.RawDeploymentMode {
 			annotations[kserveconstant.AutoscalerClass] = string(kserveconstant.AutoscalerClassHPA)
 			autoscalingMetrics, err := toKServeAutoscalerMetrics(modelService.AutoscalingPolicy.MetricsType)
 			if err != nil {
@@ -640,6 +868,27 @@ func createContainerPorts(protocolValue protocol.Protocol, deploymentMode deploy
 	return containerPorts
 }
 
+// createLoggerAnnotations creates the annotations of the component pods for scraping the metrics of the inference
+// logger. Prometheus scrapes a single port of the pod, hence the metrics aren't scraped if the port is already taken by
+// the pyfunc server.
+func (t *InferenceServiceTemplater) createLoggerAnnotations(modelService *models.Service) map[string]string {
+	metricsPort := t.deploymentConfig.InferenceLogger.MetricsPort
+	if metricsPort == 0 || modelService.Type == models.ModelTypePyFunc {
+		return nil
+	}
+	return map[string]string{
+		annotationPrometheusScrapeFlag: "true",
+		annotationPrometheusScrapePort: fmt.Sprint(metricsPort),
+	}
+}
+
+// LoggerServiceAccountName returns the name of the service account of the inference service pods running an inference
+// logger. It's the only service account allowed to read the logger config secret, which holds the hash key and the
+// http sink auth token of the loggers.
+func LoggerServiceAccountName(inferenceServiceName string) string {
+	return rules.ConfigSecretName(inferenceServiceName)
+}
+
 func createLoggerSpec(loggerURL string, loggerConfig models.LoggerConfig) *kservev1beta1.LoggerSpec {
 	loggerMode := models.ToKFServingLoggerMode(loggerConfig.Mode)
 	return &kservev1beta1.LoggerSpec{
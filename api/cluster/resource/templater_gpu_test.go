@@ -947,7 +947,7 @@ func TestCreateInferenceServiceSpecWithGPU(t *testing.T) {
 			},
 		},
 		{
-			name: "raw deployment using not supported autoscaling metrics",
+			name: "raw deployment using memory autoscaling",
 			modelSvc: &models.Service{
 				Name:           modelSvc.Name,
 				ModelName:      modelSvc.ModelName,
@@ -967,7 +967,117 @@ func TestCreateInferenceServiceSpecWithGPU(t *testing.T) {
 			},
 			resourcePercentage: queueResourcePercentage,
 			deploymentScale:    defaultDeploymentScale,
-			wantErr:            true,
+			exp: &kservev1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      modelSvc.Name,
+					Namespace: project.Name,
+					Annotations: map[string]string{
+						knserving.QueueSidecarResourcePercentageAnnotationKey: queueResourcePercentage,
+						kserveconstant.DeploymentMode:                         string(kserveconstant.RawDeployment),
+						kserveconstant.AutoscalerClass:                        string(kserveconstant.AutoscalerClassHPA),
+						kserveconstant.AutoscalerMetrics:                      string(kserveconstant.AutoScalerMetricsMemory),
+						kserveconstant.TargetUtilizationPercentage:            "30",
+					},
+					Labels: map[string]string{
+						"gojek.com/app":          modelSvc.Metadata.App,
+						"gojek.com/component":    models.ComponentModelVersion,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       modelSvc.Metadata.Stream,
+						"gojek.com/team":         modelSvc.Metadata.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: kservev1beta1.InferenceServiceSpec{
+					Predictor: kservev1beta1.PredictorSpec{
+						Tensorflow: &kservev1beta1.TFServingSpec{
+							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
+								StorageURI: &storageUri,
+								Container: corev1.Container{
+									Name:          kserveconstant.InferenceServiceContainerName,
+									Resources:     expDefaultModelResourceRequestsWithGPU,
+									LivenessProbe: probeConfig,
+								},
+							},
+						},
+						ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
+							MinReplicas: &defaultModelResourceRequests.MinReplica,
+							MaxReplicas: defaultModelResourceRequests.MaxReplica,
+						},
+						PodSpec: kservev1beta1.PodSpec{
+							NodeSelector: defaultGPUNodeSelector,
+							Tolerations:  defaultGPUTolerations,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "raw deployment using multiple metrics autoscaling",
+			modelSvc: &models.Service{
+				Name:           modelSvc.Name,
+				ModelName:      modelSvc.ModelName,
+				ModelVersion:   modelSvc.ModelVersion,
+				Namespace:      project.Name,
+				ArtifactURI:    modelSvc.ArtifactURI,
+				Type:           models.ModelTypeTensorflow,
+				Options:        &models.ModelOption{},
+				Metadata:       modelSvc.Metadata,
+				DeploymentMode: deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{
+					MetricsType: autoscaling.CPUUtilization,
+					TargetValue: 30,
+					AdditionalMetrics: []autoscaling.AutoscalingMetric{
+						{MetricsType: autoscaling.RPS, TargetValue: 100},
+					},
+				},
+				Protocol:        protocol.HttpJson,
+				ResourceRequest: modelSvc.ResourceRequest,
+			},
+			resourcePercentage: queueResourcePercentage,
+			deploymentScale:    defaultDeploymentScale,
+			exp: &kservev1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      modelSvc.Name,
+					Namespace: project.Name,
+					Annotations: map[string]string{
+						knserving.QueueSidecarResourcePercentageAnnotationKey: queueResourcePercentage,
+						kserveconstant.DeploymentMode:                         string(kserveconstant.RawDeployment),
+						kserveconstant.AutoscalerClass:                        string(kserveconstant.AutoscalerClassExternal),
+					},
+					Labels: map[string]string{
+						"gojek.com/app":          modelSvc.Metadata.App,
+						"gojek.com/component":    models.ComponentModelVersion,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       modelSvc.Metadata.Stream,
+						"gojek.com/team":         modelSvc.Metadata.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: kservev1beta1.InferenceServiceSpec{
+					Predictor: kservev1beta1.PredictorSpec{
+						Tensorflow: &kservev1beta1.TFServingSpec{
+							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
+								StorageURI: &storageUri,
+								Container: corev1.Container{
+									Name:          kserveconstant.InferenceServiceContainerName,
+									Resources:     expDefaultModelResourceRequestsWithGPU,
+									LivenessProbe: probeConfig,
+								},
+							},
+						},
+						ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
+							MinReplicas: &defaultModelResourceRequests.MinReplica,
+							MaxReplicas: defaultModelResourceRequests.MaxReplica,
+						},
+						PodSpec: kservev1beta1.PodSpec{
+							NodeSelector: defaultGPUNodeSelector,
+							Tolerations:  defaultGPUTolerations,
+						},
+					},
+				},
+			},
 		},
 		{
 			name: "serverless deployment using CPU autoscaling",
@@ -27,6 +27,7 @@ import (
 	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	kserveconstant "github.com/kserve/kserve/pkg/constants"
 	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,7 @@ import (
 	"github.com/caraml-dev/merlin/models"
 	"github.com/caraml-dev/merlin/pkg/autoscaling"
 	"github.com/caraml-dev/merlin/pkg/deployment"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	transformerpkg "github.com/caraml-dev/merlin/pkg/transformer"
 )
@@ -1376,7 +1378,7 @@ func TestCreateInferenceServiceSpec(t *testing.T) {
 			},
 		},
 		{
-			name: "raw deployment using not supported autoscaling metrics",
+			name: "raw deployment using memory autoscaling",
 			modelSvc: &models.Service{
 				Name:           modelSvc.Name,
 				ModelName:      modelSvc.ModelName,
@@ -1394,7 +1396,111 @@ func TestCreateInferenceServiceSpec(t *testing.T) {
 				Protocol: protocol.HttpJson,
 			},
 			resourcePercentage: queueResourcePercentage,
-			wantErr:            true,
+			deploymentScale:    defaultDeploymentScale,
+			exp: &kservev1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      modelSvc.Name,
+					Namespace: project.Name,
+					Annotations: map[string]string{
+						knserving.QueueSidecarResourcePercentageAnnotationKey: queueResourcePercentage,
+						kserveconstant.DeploymentMode:                         string(kserveconstant.RawDeployment),
+						kserveconstant.AutoscalerClass:                        string(kserveconstant.AutoscalerClassHPA),
+						kserveconstant.AutoscalerMetrics:                      string(kserveconstant.AutoScalerMetricsMemory),
+						kserveconstant.TargetUtilizationPercentage:            "30",
+					},
+					Labels: map[string]string{
+						"gojek.com/app":          modelSvc.Metadata.App,
+						"gojek.com/component":    models.ComponentModelVersion,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       modelSvc.Metadata.Stream,
+						"gojek.com/team":         modelSvc.Metadata.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: kservev1beta1.InferenceServiceSpec{
+					Predictor: kservev1beta1.PredictorSpec{
+						Tensorflow: &kservev1beta1.TFServingSpec{
+							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
+								StorageURI: &storageUri,
+								Container: corev1.Container{
+									Name:          kserveconstant.InferenceServiceContainerName,
+									Resources:     expDefaultModelResourceRequests,
+									LivenessProbe: probeConfig,
+									Env:           []corev1.EnvVar{defaultEnvVarWithoutCPULimits},
+								},
+							},
+						},
+						ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
+							MinReplicas: &defaultModelResourceRequests.MinReplica,
+							MaxReplicas: defaultModelResourceRequests.MaxReplica,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "raw deployment using multiple metrics autoscaling",
+			modelSvc: &models.Service{
+				Name:           modelSvc.Name,
+				ModelName:      modelSvc.ModelName,
+				ModelVersion:   modelSvc.ModelVersion,
+				Namespace:      project.Name,
+				ArtifactURI:    modelSvc.ArtifactURI,
+				Type:           models.ModelTypeTensorflow,
+				Options:        &models.ModelOption{},
+				Metadata:       modelSvc.Metadata,
+				DeploymentMode: deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{
+					MetricsType: autoscaling.CPUUtilization,
+					TargetValue: 30,
+					AdditionalMetrics: []autoscaling.AutoscalingMetric{
+						{MetricsType: autoscaling.RPS, TargetValue: 100},
+					},
+				},
+				Protocol: protocol.HttpJson,
+			},
+			resourcePercentage: queueResourcePercentage,
+			deploymentScale:    defaultDeploymentScale,
+			exp: &kservev1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      modelSvc.Name,
+					Namespace: project.Name,
+					Annotations: map[string]string{
+						knserving.QueueSidecarResourcePercentageAnnotationKey: queueResourcePercentage,
+						kserveconstant.DeploymentMode:                         string(kserveconstant.RawDeployment),
+						kserveconstant.AutoscalerClass:                        string(kserveconstant.AutoscalerClassExternal),
+					},
+					Labels: map[string]string{
+						"gojek.com/app":          modelSvc.Metadata.App,
+						"gojek.com/component":    models.ComponentModelVersion,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       modelSvc.Metadata.Stream,
+						"gojek.com/team":         modelSvc.Metadata.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: kservev1beta1.InferenceServiceSpec{
+					Predictor: kservev1beta1.PredictorSpec{
+						Tensorflow: &kservev1beta1.TFServingSpec{
+							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
+								StorageURI: &storageUri,
+								Container: corev1.Container{
+									Name:          kserveconstant.InferenceServiceContainerName,
+									Resources:     expDefaultModelResourceRequests,
+									LivenessProbe: probeConfig,
+									Env:           []corev1.EnvVar{defaultEnvVarWithoutCPULimits},
+								},
+							},
+						},
+						ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
+							MinReplicas: &defaultModelResourceRequests.MinReplica,
+							MaxReplicas: defaultModelResourceRequests.MaxReplica,
+						},
+					},
+				},
+			},
 		},
 		{
 			name: "serverless deployment using CPU autoscaling",
@@ -2991,6 +3097,7 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 	}
 
 	loggerDestinationURL := "http://destination.default"
+	samplingRate := 0.1
 	modelSvc := &models.Service{
 		Name:         "model-1",
 		ModelName:    "model",
@@ -3028,6 +3135,7 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 		exp             *kservev1beta1.InferenceService
 		wantErr         bool
 	}{
+
 		{
 			name: "model logger enabled",
 			modelSvc: &models.Service{
@@ -3070,6 +3178,84 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 				},
 				Spec: kservev1beta1.InferenceServiceSpec{
 					Predictor: kservev1beta1.PredictorSpec{
+						PodSpec: kservev1beta1.PodSpec{
+							ServiceAccountName: LoggerServiceAccountName(modelSvc.Name),
+						},
+						Tensorflow: &kservev1beta1.TFServingSpec{
+							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
+								StorageURI: &storageUri,
+								Container: corev1.Container{
+									Name:          kserveconstant.InferenceServiceContainerName,
+									Resources:     expDefaultModelResourceRequests,
+									LivenessProbe: probeConfig,
+								},
+							},
+						},
+						ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
+							MinReplicas: &defaultModelResourceRequests.MinReplica,
+							MaxReplicas: defaultModelResourceRequests.MaxReplica,
+							Logger: &kservev1beta1.LoggerSpec{
+								URL:  &loggerDestinationURL,
+								Mode: kservev1beta1.LogAll,
+							},
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name: "model logger enabled with payload rules stored in the logger config",
+			modelSvc: &models.Service{
+				Name:         modelSvc.Name,
+				ModelName:    modelSvc.ModelName,
+				ModelVersion: modelSvc.ModelVersion,
+				Namespace:    project.Name,
+				ArtifactURI:  modelSvc.ArtifactURI,
+				Type:         models.ModelTypeTensorflow,
+				Options:      &models.ModelOption{},
+				Metadata:     modelSvc.Metadata,
+				Logger: &models.Logger{
+					DestinationURL: loggerDestinationURL,
+					Model: &models.LoggerConfig{
+						Enabled:      true,
+						Mode:         models.LogAll,
+						SamplingRate: &samplingRate,
+						RedactionRules: []rules.RedactionRule{
+							{JsonPath: "$.customer.email", Action: rules.ActionRedact},
+						},
+						Sinks: []rules.Sink{
+							{Name: "audit", Url: "file:/var/log/audit", Mode: rules.SinkModeRequest},
+						},
+					},
+				},
+				Protocol: protocol.HttpJson,
+			},
+			deploymentScale: defaultDeploymentScale,
+			exp: &kservev1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      modelSvc.Name,
+					Namespace: project.Name,
+					Annotations: map[string]string{
+						knserving.QueueSidecarResourcePercentageAnnotationKey: queueResourcePercentage,
+						kserveconstant.DeploymentMode:                         string(kserveconstant.Serverless),
+						knautoscaling.InitialScaleAnnotationKey:               fmt.Sprint(testPredictorScale),
+					},
+					Labels: map[string]string{
+						"gojek.com/app":          modelSvc.Metadata.App,
+						"gojek.com/component":    models.ComponentModelVersion,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       modelSvc.Metadata.Stream,
+						"gojek.com/team":         modelSvc.Metadata.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: kservev1beta1.InferenceServiceSpec{
+					Predictor: kservev1beta1.PredictorSpec{
+						PodSpec: kservev1beta1.PodSpec{
+							ServiceAccountName: LoggerServiceAccountName(modelSvc.Name),
+						},
 						Tensorflow: &kservev1beta1.TFServingSpec{
 							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
 								StorageURI: &storageUri,
@@ -3092,6 +3278,92 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 				},
 			},
 		},
+
+		{
+			name: "model logger enabled with model observability prediction log mapping stored in the logger config",
+			modelSvc: &models.Service{
+				Name:         modelSvc.Name,
+				ModelName:    modelSvc.ModelName,
+				ModelVersion: modelSvc.ModelVersion,
+				Namespace:    project.Name,
+				ArtifactURI:  modelSvc.ArtifactURI,
+				Type:         models.ModelTypeTensorflow,
+				Options:      &models.ModelOption{},
+				Metadata:     modelSvc.Metadata,
+				Logger: &models.Logger{
+					DestinationURL: loggerDestinationURL,
+					Model: &models.LoggerConfig{
+						Enabled: true,
+						Mode:    models.LogAll,
+					},
+				},
+				Protocol:                  protocol.HttpJson,
+				EnabledModelObservability: true,
+				ModelSchema: &models.ModelSchema{
+					Spec: &models.SchemaSpec{
+						FeatureOrders: []string{"age", "income"},
+						ModelPredictionOutput: &models.ModelPredictionOutput{
+							RegressionOutput: &models.RegressionOutput{
+								PredictionScoreColumn: "score",
+								OutputClass:           models.Regression,
+							},
+						},
+					},
+				},
+				PredictionLogMapping: &rules.PredictionLogMapping{
+					InstancesPath:        "$.instances",
+					SessionIdPath:        "$.session_id",
+					PredictionScoresPath: "$.predictions",
+				},
+			},
+			deploymentScale: defaultDeploymentScale,
+			exp: &kservev1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      modelSvc.Name,
+					Namespace: project.Name,
+					Annotations: map[string]string{
+						knserving.QueueSidecarResourcePercentageAnnotationKey: queueResourcePercentage,
+						kserveconstant.DeploymentMode:                         string(kserveconstant.Serverless),
+						knautoscaling.InitialScaleAnnotationKey:               fmt.Sprint(testPredictorScale),
+					},
+					Labels: map[string]string{
+						"gojek.com/app":          modelSvc.Metadata.App,
+						"gojek.com/component":    models.ComponentModelVersion,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       modelSvc.Metadata.Stream,
+						"gojek.com/team":         modelSvc.Metadata.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: kservev1beta1.InferenceServiceSpec{
+					Predictor: kservev1beta1.PredictorSpec{
+						PodSpec: kservev1beta1.PodSpec{
+							ServiceAccountName: LoggerServiceAccountName(modelSvc.Name),
+						},
+						Tensorflow: &kservev1beta1.TFServingSpec{
+							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
+								StorageURI: &storageUri,
+								Container: corev1.Container{
+									Name:          kserveconstant.InferenceServiceContainerName,
+									Resources:     expDefaultModelResourceRequests,
+									LivenessProbe: probeConfig,
+								},
+							},
+						},
+						ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
+							MinReplicas: &defaultModelResourceRequests.MinReplica,
+							MaxReplicas: defaultModelResourceRequests.MaxReplica,
+							Logger: &kservev1beta1.LoggerSpec{
+								URL:  &loggerDestinationURL,
+								Mode: kservev1beta1.LogAll,
+							},
+						},
+					},
+				},
+			},
+		},
+
 		{
 			name: "model logger enabled with transformer",
 			modelSvc: &models.Service{
@@ -3140,6 +3412,9 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 				},
 				Spec: kservev1beta1.InferenceServiceSpec{
 					Predictor: kservev1beta1.PredictorSpec{
+						PodSpec: kservev1beta1.PodSpec{
+							ServiceAccountName: LoggerServiceAccountName(modelSvc.Name),
+						},
 						Tensorflow: &kservev1beta1.TFServingSpec{
 							PredictorExtensionSpec: kservev1beta1.PredictorExtensionSpec{
 								StorageURI: &storageUri,
@@ -3181,6 +3456,7 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 				},
 			},
 		},
+
 		{
 			name: "model logger disabled with transformer",
 			modelSvc: &models.Service{
@@ -3266,6 +3542,7 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 				},
 			},
 		},
+
 		{
 			name: "transformer logger enabled",
 			modelSvc: &models.Service{
@@ -3342,6 +3619,7 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 									LivenessProbe: transformerProbeConfig,
 								},
 							},
+							ServiceAccountName: LoggerServiceAccountName(modelSvc.Name),
 						},
 						ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{
 							MinReplicas: &defaultTransformerResourceRequests.MinReplica,
@@ -3355,6 +3633,7 @@ func TestCreateInferenceServiceSpecWithLogger(t *testing.T) {
 				},
 			},
 		},
+
 		{
 			name: "transformer logger disabled",
 			modelSvc: &models.Service{
@@ -4409,6 +4688,310 @@ func TestCreateInferenceServiceSpecWithTopologySpreadConstraints(t *testing.T) {
 	}
 }
 
+func TestCreateHorizontalPodAutoscalerSpecs(t *testing.T) {
+	minReplicas := 2
+	isvc := &kservev1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model-1-r1",
+			Namespace: "my-project",
+			Labels:    map[string]string{"gojek.com/app": "my-model"},
+		},
+		Spec: kservev1beta1.InferenceServiceSpec{
+			Predictor: kservev1beta1.PredictorSpec{
+				ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{MinReplicas: &minReplicas, MaxReplicas: 4},
+			},
+		},
+	}
+	isvcWithTransformer := isvc.DeepCopy()
+	isvcWithTransformer.Spec.Transformer = &kservev1beta1.TransformerSpec{
+		ComponentExtensionSpec: kservev1beta1.ComponentExtensionSpec{MaxReplicas: 3},
+	}
+
+	cpuTarget := int32(50)
+	hpa := func(name string, minReplicas, maxReplicas int32, metrics []autoscalingv2.MetricSpec) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			TypeMeta: metav1.TypeMeta{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "my-project",
+				Labels:    map[string]string{"gojek.com/app": "my-model"},
+			},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: name},
+				MinReplicas:    &minReplicas,
+				MaxReplicas:    maxReplicas,
+				Metrics:        metrics,
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		modelSvc      *models.Service
+		isvc          *kservev1beta1.InferenceService
+		customMetrics config.AutoscalingCustomMetricsConfig
+		exp           []*autoscalingv2.HorizontalPodAutoscaler
+		wantErr       bool
+	}{
+		{
+			name: "raw deployment autoscaled by kserve",
+			modelSvc: &models.Service{
+				DeploymentMode:    deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{MetricsType: autoscaling.CPUUtilization, TargetValue: 50},
+			},
+			isvc: isvc,
+		},
+		{
+			name: "serverless deployment",
+			modelSvc: &models.Service{
+				DeploymentMode:    deployment.ServerlessDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{MetricsType: autoscaling.RPS, TargetValue: 50},
+			},
+			isvc: isvc,
+		},
+		{
+			name: "raw deployment using rps autoscaling",
+			modelSvc: &models.Service{
+				DeploymentMode:    deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{MetricsType: autoscaling.RPS, TargetValue: 20.5},
+			},
+			isvc: isvc,
+			exp: []*autoscalingv2.HorizontalPodAutoscaler{
+				hpa("my-model-1-r1-predictor", 2, 4, []autoscalingv2.MetricSpec{
+					{
+						Type: autoscalingv2.PodsMetricSourceType,
+						Pods: &autoscalingv2.PodsMetricSource{
+							Metric: autoscalingv2.MetricIdentifier{Name: defaultRPSMetricName},
+							Target: autoscalingv2.MetricTarget{
+								Type:         autoscalingv2.AverageValueMetricType,
+								AverageValue: resource.NewMilliQuantity(20500, resource.DecimalSI),
+							},
+						},
+					},
+				}),
+			},
+		},
+		{
+			name: "raw deployment with transformer using multiple metrics autoscaling",
+			modelSvc: &models.Service{
+				DeploymentMode: deployment.RawDeploymentMode,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{
+					MetricsType: autoscaling.CPUUtilization,
+					TargetValue: 50,
+					AdditionalMetrics: []autoscaling.AutoscalingMetric{
+						{MetricsType: autoscaling.Concurrency, TargetValue: 2},
+					},
+				},
+			},
+			isvc:          isvcWithTransformer,
+			customMetrics: config.AutoscalingCustomMetricsConfig{ConcurrencyMetricName: "inflight_requests"},
+			exp: func() []*autoscalingv2.HorizontalPodAutoscaler {
+				metrics := []autoscalingv2.MetricSpec{
+					{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricSource{
+							Name:   corev1.ResourceCPU,
+							Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &cpuTarget},
+						},
+					},
+					{
+						Type: autoscalingv2.PodsMetricSourceType,
+						Pods: &autoscalingv2.PodsMetricSource{
+							Metric: autoscalingv2.MetricIdentifier{Name: "inflight_requests"},
+							Target: autoscalingv2.MetricTarget{
+								Type:         autoscalingv2.AverageValueMetricType,
+								AverageValue: resource.NewMilliQuantity(2000, resource.DecimalSI),
+							},
+						},
+					},
+				}
+				return []*autoscalingv2.HorizontalPodAutoscaler{
+					hpa("my-model-1-r1-predictor", 2, 4, metrics),
+					hpa("my-model-1-r1-transformer", 1, 3, metrics),
+				}
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := NewInferenceServiceTemplater(config.DeploymentConfig{AutoscalingCustomMetrics: tt.customMetrics})
+			hpas, err := tpl.CreateHorizontalPodAutoscalerSpecs(tt.modelSvc, tt.isvc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.exp, hpas)
+		})
+	}
+}
+
+func TestCreateLoggerConfig(t *testing.T) {
+	spoolConfig := config.InferenceLoggerConfig{
+		SpoolEnabled:  true,
+		SpoolDir:      "/tmp/spool",
+		SpoolMaxBytes: 1024,
+	}
+	enabledLogger := &models.LoggerConfig{Enabled: true, Mode: models.LogAll}
+	transformer := &models.Transformer{Enabled: true}
+
+	tests := []struct {
+		name         string
+		modelSvc     *models.Service
+		loggerConfig config.InferenceLoggerConfig
+		exp          map[string]string
+		expErr       string
+	}{
+		{
+			name:         "logger not configured",
+			modelSvc:     &models.Service{},
+			loggerConfig: spoolConfig,
+		},
+		{
+			name: "spool disabled",
+			modelSvc: &models.Service{
+				Logger: &models.Logger{Model: enabledLogger},
+			},
+		},
+		{
+			name: "spool enabled for model logger",
+			modelSvc: &models.Service{
+				Logger: &models.Logger{Model: enabledLogger, Transformer: enabledLogger},
+			},
+			loggerConfig: spoolConfig,
+			exp: map[string]string{
+				"predictor": `{"spool":{"dir":"/tmp/spool","max_bytes":1024}}`,
+			},
+		},
+		{
+			name: "spool enabled for model and transformer loggers",
+			modelSvc: &models.Service{
+				Transformer: transformer,
+				Logger:      &models.Logger{Model: enabledLogger, Transformer: enabledLogger},
+			},
+			loggerConfig: spoolConfig,
+			exp: map[string]string{
+				"predictor":   `{"spool":{"dir":"/tmp/spool","max_bytes":1024}}`,
+				"transformer": `{"spool":{"dir":"/tmp/spool","max_bytes":1024}}`,
+			},
+		},
+		{
+			name: "rules with hash redaction",
+			modelSvc: &models.Service{
+				Transformer: transformer,
+				Logger: &models.Logger{
+					Model: &models.LoggerConfig{
+						Enabled:        true,
+						Mode:           models.LogAll,
+						RedactionRules: []rules.RedactionRule{{JsonPath: "$.phone", Action: rules.ActionHash}},
+					},
+					Transformer: &models.LoggerConfig{
+						Enabled:        true,
+						Mode:           models.LogAll,
+						HeaderDenylist: []string{"Authorization"},
+					},
+				},
+			},
+			exp: map[string]string{
+				"predictor":   `{"rules":{"redaction_rules":[{"json_path":"$.phone","action":"hash"}]},"hash_key":"hash-key"}`,
+				"transformer": `{"rules":{"header_denylist":["Authorization"]}}`,
+			},
+		},
+		{
+			name: "metrics port",
+			modelSvc: &models.Service{
+				Logger: &models.Logger{Model: enabledLogger},
+			},
+			loggerConfig: config.InferenceLoggerConfig{MetricsPort: 9095},
+			exp: map[string]string{
+				"predictor": `{"metrics_port":9095}`,
+			},
+		},
+		{
+			name: "http sink auth token",
+			modelSvc: &models.Service{
+				Logger: &models.Logger{
+					Model: &models.LoggerConfig{Enabled: true, Mode: models.LogAll, HTTPSinkAuthSecretName: "webhook-token"},
+				},
+			},
+			exp: map[string]string{
+				"predictor": `{"http_sink_auth_token":"my-token"}`,
+			},
+		},
+		{
+			name: "http sink auth secret not found",
+			modelSvc: &models.Service{
+				Logger: &models.Logger{
+					Model: &models.LoggerConfig{Enabled: true, Mode: models.LogAll, HTTPSinkAuthSecretName: "other-token"},
+				},
+			},
+			expErr: "http sink auth secret other-token of predictor logger is not found",
+		},
+		{
+			name: "spool enabled for transformer logger",
+			modelSvc: &models.Service{
+				Transformer: transformer,
+				Logger:      &models.Logger{Model: &models.LoggerConfig{Enabled: false}, Transformer: enabledLogger},
+			},
+			loggerConfig: spoolConfig,
+			exp: map[string]string{
+				"transformer": `{"spool":{"dir":"/tmp/spool","max_bytes":1024}}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := NewInferenceServiceTemplater(config.DeploymentConfig{InferenceLogger: tt.loggerConfig})
+			data, err := tpl.CreateLoggerConfig(tt.modelSvc, "hash-key", map[string]string{"webhook-token": "my-token"})
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.exp, data)
+		})
+	}
+}
+
+func TestCreateLoggerAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		modelType   string
+		metricsPort int
+		exp         map[string]string
+	}{
+		{
+			name:        "metrics port set",
+			modelType:   models.ModelTypeTensorflow,
+			metricsPort: 9095,
+			exp: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "9095",
+			},
+		},
+		{
+			name:      "metrics disabled",
+			modelType: models.ModelTypeTensorflow,
+		},
+		{
+			name:        "pyfunc server metrics are scraped instead",
+			modelType:   models.ModelTypePyFunc,
+			metricsPort: 9095,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := NewInferenceServiceTemplater(config.DeploymentConfig{
+				InferenceLogger: config.InferenceLoggerConfig{MetricsPort: tt.metricsPort},
+			})
+			assert.Equal(t, tt.exp, tpl.createLoggerAnnotations(&models.Service{Type: tt.modelType}))
+		})
+	}
+}
+
 func TestCreateTransformerSpec(t *testing.T) {
 	one := 1
 	cpuRequest := resource.MustParse("1")
@@ -41,6 +41,8 @@ func toKServeAutoscalerMetrics(metricsType autoscaling.MetricsType) (string, err
 	switch metricsType {
 	case autoscaling.CPUUtilization:
 		return string(kserveconstant.AutoScalerMetricsCPU), nil
+	case autoscaling.MemoryUtilization:
+		return string(kserveconstant.AutoScalerMetricsMemory), nil
 	default:
 		return "", fmt.Errorf("unsupported autoscaler metrics on raw deployment: %s", metricsType)
 	}
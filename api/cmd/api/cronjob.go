@@ -34,6 +34,29 @@ func initCronJob(dependencies deps, db *gorm.DB) error {
 	if err != nil {
 		return err
 	}
+	if dependencies.rolloutController != nil {
+		syncInterval := dependencies.apiContext.FeatureToggleConfig.CanaryRolloutConfig.SyncInterval
+		err = c.AddFunc(fmt.Sprintf("@every %s", syncInterval), dependencies.rolloutController.Sync)
+		if err != nil {
+			return err
+		}
+	}
+
+	if dependencies.experimentController != nil {
+		syncInterval := dependencies.apiContext.FeatureToggleConfig.ExperimentConfig.BanditSyncInterval
+		err = c.AddFunc(fmt.Sprintf("@every %s", syncInterval), dependencies.experimentController.Sync)
+		if err != nil {
+			return err
+		}
+	}
+
+	if dependencies.scalingController != nil {
+		syncInterval := dependencies.apiContext.FeatureToggleConfig.ScalingScheduleConfig.SyncInterval
+		err = c.AddFunc(fmt.Sprintf("@every %s", syncInterval), dependencies.scalingController.Sync)
+		if err != nil {
+			return err
+		}
+	}
 
 	c.Start()
 
@@ -334,31 +334,77 @@ func buildDependencies(ctx context.Context, cfg *config.Config, db *gorm.DB, dis
 		log.Panicf("failed initializing mlflow delete package: %v", err)
 	}
 
+	var rolloutController *service.ModelEndpointRolloutController
+	if cfg.FeatureToggleConfig.CanaryRolloutConfig.Enabled {
+		rolloutAnalyzer, err := service.NewPrometheusRolloutAnalyzer(cfg.FeatureToggleConfig.CanaryRolloutConfig)
+		if err != nil {
+			log.Panicf("failed initializing rollout analyzer: %v", err)
+		}
+		rolloutController = service.NewModelEndpointRolloutController(modelEndpointService, modelsService,
+			storage.NewModelEndpointStorage(db), storage.NewVersionEndpointStorage(db), storage.NewDeploymentStorage(db), rolloutAnalyzer)
+	}
+
+	var experimentController *service.ModelEndpointExperimentController
+	var modelEndpointExperimentService service.ModelEndpointExperimentService
+	if cfg.FeatureToggleConfig.ExperimentConfig.Enabled {
+		experimentRewardStorage := storage.NewExperimentRewardStorage(db)
+		modelEndpointExperimentService = service.NewModelEndpointExperimentService(experimentRewardStorage)
+		experimentController = service.NewModelEndpointExperimentController(modelEndpointService, modelsService,
+			storage.NewModelEndpointStorage(db), experimentRewardStorage, cfg.FeatureToggleConfig.ExperimentConfig.BanditMinWeightChange)
+	}
+
+	var scalingController *service.VersionEndpointScalingController
+	if cfg.FeatureToggleConfig.ScalingScheduleConfig.Enabled {
+		scalingController = service.NewVersionEndpointScalingController(clusterControllers, storage.NewVersionEndpointStorage(db))
+	}
+
 	transformerService := service.NewTransformerService(cfg.StandardTransformerConfig)
 	modelSchemaService := service.NewModelSchemaService(storage.NewModelSchemaStorage(db))
-	apiContext := api.AppContext{
-		DB:       db,
-		Enforcer: authEnforcer,
+	modelEndpointMirrorService := service.NewModelEndpointMirrorService()
 
-		DeploymentService:         deploymentService,
+	var manifestAlertService service.ModelEndpointAlertService
+	if cfg.FeatureToggleConfig.AlertConfig.AlertEnabled {
+		manifestAlertService = modelEndpointAlertService
+	}
+	modelManifestService := service.NewModelManifestService(service.ModelManifestServiceParams{
 		EnvironmentService:        environmentService,
-		ProjectsService:           projectsService,
-		ModelsService:             modelsService,
-		ModelEndpointsService:     modelEndpointService,
 		VersionsService:           versionsService,
-		VersionImageService:       versionImageService,
 		EndpointsService:          versionEndpointService,
-		LogService:                logService,
-		PredictionJobService:      predictionJobService,
-		SecretService:             secretService,
-		ModelEndpointAlertService: modelEndpointAlertService,
-		TransformerService:        transformerService,
-		MlflowDeleteService:       mlflowDeleteService,
+		ModelEndpointsService:     modelEndpointService,
+		ModelEndpointAlertService: manifestAlertService,
 		ModelSchemaService:        modelSchemaService,
+		MonitoringConfig:          cfg.FeatureToggleConfig.MonitoringConfig,
+		GitlabClient:              gitlabClient,
+		ManifestRepository:        cfg.FeatureToggleConfig.ModelManifestConfig.GitlabRepository,
+		ManifestBranch:            cfg.FeatureToggleConfig.ModelManifestConfig.GitlabBranch,
+	})
+	apiContext := api.AppContext{
+		DB:       db,
+		Enforcer: authEnforcer,
+
+		DeploymentService:              deploymentService,
+		EnvironmentService:             environmentService,
+		ProjectsService:                projectsService,
+		ModelsService:                  modelsService,
+		ModelEndpointsService:          modelEndpointService,
+		ModelEndpointMirrorService:     modelEndpointMirrorService,
+		ModelEndpointExperimentService: modelEndpointExperimentService,
+		VersionsService:                versionsService,
+		VersionImageService:            versionImageService,
+		EndpointsService:               versionEndpointService,
+		LogService:                     logService,
+		PredictionJobService:           predictionJobService,
+		SecretService:                  secretService,
+		ModelEndpointAlertService:      modelEndpointAlertService,
+		TransformerService:             transformerService,
+		MlflowDeleteService:            mlflowDeleteService,
+		ModelSchemaService:             modelSchemaService,
+		ModelManifestService:           modelManifestService,
 
 		AuthorizationEnabled:      cfg.AuthorizationConfig.AuthorizationEnabled,
 		FeatureToggleConfig:       cfg.FeatureToggleConfig,
 		StandardTransformerConfig: cfg.StandardTransformerConfig,
+		InferenceLoggerConfig:     cfg.InferenceLoggerConfig,
 
 		FeastCoreClient: coreClient,
 		MlflowClient:    mlflowClient,
@@ -370,5 +416,8 @@ func buildDependencies(ctx context.Context, cfg *config.Config, db *gorm.DB, dis
 		batchDeployment:         batchDeployment,
 		observabilityDeployment: observabilityPublisherDeployment,
 		imageBuilderJanitor:     imageBuilderJanitor,
+		rolloutController:       rolloutController,
+		experimentController:    experimentController,
+		scalingController:       scalingController,
 	}
 }
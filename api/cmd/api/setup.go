@@ -44,6 +44,12 @@ type deps struct {
 	batchDeployment         *work.BatchDeployment
 	observabilityDeployment *work.ObservabilityPublisherDeployment
 	imageBuilderJanitor     *imagebuilder.Janitor
+	// rolloutController is nil if canary rollout is disabled
+	rolloutController *service.ModelEndpointRolloutController
+	// experimentController is nil if experiments are disabled
+	experimentController *service.ModelEndpointExperimentController
+	// scalingController is nil if scaling schedules are disabled
+	scalingController *service.VersionEndpointScalingController
 }
 
 func initMLPAPIClient(cfg config.MlpAPIConfig) mlp.APIClient {
@@ -370,7 +376,7 @@ func initModelEndpointService(cfg *config.Config, db *gorm.DB, observabilityEven
 		istioClients[env.Name] = istioClient
 	}
 
-	return service.NewModelEndpointsService(istioClients, storage.NewModelEndpointStorage(db), storage.NewVersionEndpointStorage(db), cfg.Environment, observabilityEvent)
+	return service.NewModelEndpointsService(istioClients, storage.NewModelEndpointStorage(db), storage.NewVersionEndpointStorage(db), cfg.Environment, observabilityEvent, cfg.FeatureToggleConfig.ExperimentConfig)
 }
 
 func initBatchDeployment(cfg *config.Config, db *gorm.DB, controllers map[string]batch.Controller, builder imagebuilder.ImageBuilder) *work.BatchDeployment {
@@ -5,23 +5,37 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/caraml-dev/merlin/pkg/inference-logger/liveness"
 	merlinlogger "github.com/caraml-dev/merlin/pkg/inference-logger/logger"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/kelseyhightower/envconfig"
 	nrconfig "github.com/newrelic/newrelic-client-go/v2/pkg/config"
 	nrlog "github.com/newrelic/newrelic-client-go/v2/pkg/logs"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 	"knative.dev/networking/pkg/http/header"
 	"knative.dev/networking/pkg/http/proxy"
 	pkgnet "knative.dev/pkg/network"
@@ -30,17 +44,40 @@ import (
 	"knative.dev/serving/pkg/queue"
 	"knative.dev/serving/pkg/queue/health"
 	"knative.dev/serving/pkg/queue/readiness"
+	"sigs.k8s.io/yaml"
 )
 
 var (
 	logUrl           = flag.String("log-url", "localhost:8002", "The URL to send request/response logs to")
 	kafkaConfigPath  = flag.String("kafka-config-path", "", "Additional Kafka configuration. This should be path to a file containing the configuration")
+	kafkaMessageKey  = flag.String("kafka-message-key", string(merlinlogger.KafkaMessageKeyLogKey), "Key of the kafka inference log messages, either 'log_key' or 'request_id'")
+	kafkaRetryBudget = flag.Duration("kafka-delivery-retry-budget", merlinlogger.DefaultDeliveryRetryBudget, "Maximum duration to deliver a log entry to kafka over all delivery attempts, which bounds the message.timeout.ms of the producer")
+	kafkaRegistryUrl = flag.String("kafka-schema-registry-url", "", "URL of Confluent compatible schema registry. If set, kafka message values are framed with the id of the latest schema of <topic>-value subject")
+	sinksConfigPath  = flag.String("sinks-config-path", "", "Path to YAML file listing additional sinks the logs are fanned out to, each with its own mode, sampling and filters")
 	port             = flag.String("port", "9081", "Logger port")
 	componentPort    = flag.String("component-port", "8080", "Component port")
 	workers          = flag.Int("workers", 5, "Number of workers")
 	logMode          = flag.String("log-mode", string(merlinlogger.LogModeAll), "Whether to log 'request', 'response' or 'all'")
 	inferenceService = flag.String("inference-service", "my-model-1", "The InferenceService name to add as header to log events")
 	namespace        = flag.String("namespace", "my-project", "The namespace to add as header to log events")
+	metricsPort      = flag.String("metrics-port", "", "Port to expose prometheus metrics on, defaults to the metrics port of the logger config secret. Metrics are not exposed if empty")
+	maxBodyBytes     = flag.Int("max-captured-body-bytes", merlinlogger.DefaultMaxBodyBytes, "Maximum size in bytes of request and response body captured in a log entry, the rest is truncated and the log entry is marked as truncated. Negative means unlimited")
+
+	failReadinessOnSaturation = flag.Bool("fail-readiness-on-saturation", false, "Report not ready while the queue or spool of any log sink is saturated, so that log entries are not dropped")
+
+	spoolDir                 = flag.String("spool-dir", "", "Directory of the on-disk spool, defaults to the spool dir of the logger config secret. Log entries are buffered in memory if empty")
+	spoolMaxSegmentBytes     = flag.Int64("spool-max-segment-bytes", 16*1024*1024, "Size in bytes after which a spool segment is sealed and sent to the log sink")
+	spoolMaxSegmentAge       = flag.Duration("spool-max-segment-age", 5*time.Second, "Age after which a spool segment is sealed and sent to the log sink")
+	spoolMaxBytes            = flag.Int64("spool-max-bytes", 1024*1024*1024, "Maximum total size in bytes of the spool, log entries are dropped when it is reached. 0 means unlimited. Overridden by the logger config secret if the spool dir is not set")
+	spoolRetryInitialBackoff = flag.Duration("spool-retry-initial-backoff", 100*time.Millisecond, "Initial backoff before retrying to send spooled log entries to the log sink")
+	spoolRetryMaxBackoff     = flag.Duration("spool-retry-max-backoff", 30*time.Second, "Maximum backoff between retries to send spooled log entries to the log sink")
+
+	httpSinkAuthHeader     = flag.String("http-sink-auth-header", merlinlogger.DefaultHTTPSinkAuthHeader, "Header carrying the auth token of webhook and otlp sinks, the token is read from the logger config secret or "+httpSinkAuthTokenEnv+" environment variable")
+	httpSinkGzip           = flag.Bool("http-sink-gzip", true, "Whether to gzip the requests of webhook and otlp sinks")
+	httpSinkTimeout        = flag.Duration("http-sink-timeout", 10*time.Second, "Timeout of each request of webhook and otlp sinks")
+	httpSinkMaxRetries     = flag.Int("http-sink-max-retries", merlinlogger.DefaultHTTPSinkMaxRetries, "Number of retries of a failed request of webhook and otlp sinks")
+	httpSinkInitialBackoff = flag.Duration("http-sink-initial-backoff", merlinlogger.DefaultHTTPSinkInitialBackoff, "Maximum jittered backoff before the first retry of webhook and otlp sinks")
+	httpSinkMaxBackoff     = flag.Duration("http-sink-max-backoff", merlinlogger.DefaultHTTPSinkMaxBackoff, "Maximum jittered backoff between retries of webhook and otlp sinks")
 
 	// These flags are not needed by our logger but provided by Kserve, hence we need to parse it to avoid error.
 	sourceUri     = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
@@ -69,6 +106,16 @@ const (
 
 	// NewRelic client configuration
 	NewRelicLogLevel = "info"
+
+	// Name of the route of the sink configured by log-url
+	defaultRouteName = "default"
+
+	// Timeout of reading the logger config secret
+	loggerConfigTimeout = 10 * time.Second
+
+	// Environment variable of the auth token of webhook and otlp sinks, so that the token is not exposed in the container
+	// args. The token of the logger config secret takes precedence.
+	httpSinkAuthTokenEnv = "HTTP_SINK_AUTH_TOKEN"
 )
 
 type config struct {
@@ -84,7 +131,6 @@ func main() {
 	// Avoid unused variable linting error
 	_ = *sourceUri
 	_ = *endpoint
-	_ = *component
 
 	l, _ := zap.NewProduction()
 	log := l.Sugar()
@@ -106,9 +152,49 @@ func main() {
 		os.Exit(-1)
 	}
 
-	_, err := url.Parse(*logUrl)
+	// KServe doesn't pass any other configuration to the injected logger, hence the rest of it, including the sampling,
+	// filtering and redaction rules, is read from the logger config secret created by Merlin
+	loggerConfig, err := loadLoggerConfig(*namespace, *inferenceService, *component)
+	if err != nil {
+		log.Infof("Failed reading logger config: %v", err)
+		os.Exit(1)
+	}
+
+	// Rules of deployments created before the logger config secret are encoded in the log-url
+	sinkUrl, logRules, err := rules.ParseLogUrl(*logUrl)
 	if err != nil {
-		log.Info("Malformed log-url", "URL", *logUrl)
+		log.Info("Malformed logger rules in log-url", "error", err)
+		os.Exit(-1)
+	}
+
+	hashKey := ""
+	httpSinkAuthToken := os.Getenv(httpSinkAuthTokenEnv)
+	if loggerConfig != nil {
+		if loggerConfig.Rules != nil {
+			logRules = loggerConfig.Rules
+		}
+		hashKey = loggerConfig.HashKey
+		if loggerConfig.HTTPSinkAuthToken != "" {
+			httpSinkAuthToken = loggerConfig.HTTPSinkAuthToken
+		}
+		if loggerConfig.MetricsPort != 0 && *metricsPort == "" {
+			*metricsPort = strconv.Itoa(loggerConfig.MetricsPort)
+		}
+		if loggerConfig.Spool != nil && *spoolDir == "" {
+			*spoolDir = loggerConfig.Spool.Dir
+			*spoolMaxBytes = loggerConfig.Spool.MaxBytes
+		}
+	}
+
+	logFilter, err := rules.NewFilter(logRules, hashKey)
+	if err != nil {
+		log.Info("Invalid logger rules", "error", err)
+		os.Exit(-1)
+	}
+
+	_, err = url.Parse(sinkUrl)
+	if err != nil {
+		log.Info("Malformed log-url", "URL", sinkUrl)
 		os.Exit(-1)
 	}
 	loggingMode := merlinlogger.LogMode(*logMode)
@@ -129,23 +215,60 @@ func main() {
 		MaxBatchSize: QueueMaxBatchSize,
 	}
 
-	logSink, err := getLogSink(*logUrl, log)
+	// Additional sinks are configured per model in the logger rules or for all models in the sinks config file
+	var sinks []rules.Sink
+	if logRules != nil {
+		sinks = append(sinks, logRules.Sinks...)
+	}
+	if *sinksConfigPath != "" {
+		fileSinks, err := readSinksConfig(*sinksConfigPath)
+		if err != nil {
+			log.Infof("Failed reading sinks config: %v", err)
+			os.Exit(1)
+		}
+		sinks = append(sinks, fileSinks...)
+	}
+
+	// Prediction log schema is used by model observability sink of models not following the standard model payloads
+	var predictionLogSchema *rules.PredictionLogSchema
+	if logRules != nil {
+		predictionLogSchema = logRules.PredictionLogSchema
+	}
+
+	routes, logSinks, err := createLoggerRoutes(workerConfig, sinkUrl, loggingMode, logFilter, sinks, hashKey, httpSinkAuthToken, predictionLogSchema, log)
 	if err != nil {
-		log.Infof("Failed initializing logSink for %s: %v", *logUrl, err)
+		log.Infof("Failed initializing log sinks: %v", err)
 		os.Exit(1)
 	}
+	for _, route := range routes {
+		route.Dispatcher.Start()
+	}
 
-	dispatcher := createLoggerDispatcher(workerConfig, logSink, log)
-	dispatcher.Start()
+	if *failReadinessOnSaturation {
+		queues := make([]liveness.Saturable, 0, len(routes))
+		for _, route := range routes {
+			queues = append(queues, route)
+		}
+		probe = liveness.NewReadinessProbe(probe, queues...)
+	}
 
 	// Create handler chain.
 	// Note: innermost handlers are specified first, ie. the last handler in the chain will be executed first.
-	drainer, mainServer := buildServer(target, dispatcher, loggingMode, probe, log)
+	modelConn, err := grpc.Dial(target.Host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Infof("Failed creating gRPC connection to %s: %v", target.Host, err)
+		os.Exit(1)
+	}
+
+	drainer, mainServer := buildServer(target, upiv1.NewUniversalPredictionServiceClient(modelConn), routes, *maxBodyBytes, probe, log)
 
 	ctx := signals.NewContext()
 	servers := map[string]*http.Server{
 		"main": mainServer,
 	}
+	if *metricsPort != "" {
+		servers["metrics"] = buildMetricsServer()
+	}
 	errCh := make(chan error)
 	listenCh := make(chan struct{})
 	log.Infof("Listening at :%s", *port)
@@ -203,7 +326,17 @@ func main() {
 		log.Info("Received TERM signal, attempting to gracefully shutdown servers.")
 		log.Infof("Sleeping %v to allow K8s propagation of non-ready state", drainSleepDuration)
 		drainer.Drain()
-		dispatcher.Stop()
+		for _, route := range routes {
+			route.Dispatcher.Stop()
+		}
+		// Flush log sinks which buffer log entries, e.g. file sink
+		for _, logSink := range logSinks {
+			if closer, ok := logSink.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					log.Errorw("Failed to close log sink", zap.Error(err))
+				}
+			}
+		}
 
 		for serverName, srv := range servers {
 			log.Info("Shutting down server: ", serverName)
@@ -215,7 +348,7 @@ func main() {
 	}
 }
 
-func buildServer(target *url.URL, dispatcher *merlinlogger.Dispatcher, loggingMode merlinlogger.LogMode, probe func() bool, log *zap.SugaredLogger) (*pkghandler.Drainer, *http.Server) {
+func buildServer(target *url.URL, upiClient upiv1.UniversalPredictionServiceClient, routes []*merlinlogger.Route, maxBodyBytes int, probe func() bool, log *zap.SugaredLogger) (*pkghandler.Drainer, *http.Server) {
 	maxIdleConns := 1000 // TODO: somewhat arbitrary value for CC=0, needs experimental validation.
 
 	httpProxy := httputil.NewSingleHostReverseProxy(target)
@@ -225,7 +358,12 @@ func buildServer(target *url.URL, dispatcher *merlinlogger.Dispatcher, loggingMo
 	httpProxy.FlushInterval = proxy.FlushInterval
 
 	var composedHandler http.Handler = httpProxy
-	composedHandler = merlinlogger.NewLoggerHandler(dispatcher, loggingMode, composedHandler, log)
+	composedHandler = merlinlogger.NewLoggerHandler(routes, maxBodyBytes, composedHandler, log)
+
+	// UPI_V1 models receive gRPC requests on the same port, which are captured by the UPI logger server
+	grpcServer := grpc.NewServer()
+	upiv1.RegisterUniversalPredictionServiceServer(grpcServer, merlinlogger.NewUPILoggerServer(routes, maxBodyBytes, upiClient, log))
+	composedHandler = merlinlogger.NewGrpcHandler(grpcServer, composedHandler)
 
 	inner := queue.ForwardedShimHandler(composedHandler)
 	composedHandler = inner
@@ -242,14 +380,156 @@ func buildServer(target *url.URL, dispatcher *merlinlogger.Dispatcher, loggingMo
 	return drainer, mainServer
 }
 
-func createLoggerDispatcher(
+func buildMetricsServer() *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return pkgnet.NewServer(":"+*metricsPort, mux)
+}
+
+// createLoggerRoutes creates route of the log-url sink and a route for each additional sink, each with its own dispatcher
+func createLoggerRoutes(
 	workerConfig *merlinlogger.WorkerConfig,
-	logSink merlinlogger.LogSink,
+	sinkUrl string,
+	loggingMode merlinlogger.LogMode,
+	logFilter *rules.Filter,
+	sinks []rules.Sink,
+	hashKey string,
+	httpSinkAuthToken string,
+	predictionLogSchema *rules.PredictionLogSchema,
 	log *zap.SugaredLogger,
-) *merlinlogger.Dispatcher {
+) ([]*merlinlogger.Route, []merlinlogger.LogSink, error) {
+	logSink, err := getLogSink(sinkUrl, httpSinkAuthToken, predictionLogSchema, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed initializing logSink for %s: %w", sinkUrl, err)
+	}
 	// TODO: Remove default console logging
-	dispatcher := merlinlogger.NewDispatcher(*workers, QueueCapacity, workerConfig, log, logSink, merlinlogger.NewConsoleSink(log))
-	return dispatcher
+	dispatcher, err := createLoggerDispatcher(workerConfig, *spoolDir, log, logSink, merlinlogger.NewConsoleSink(log))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed initializing dispatcher: %w", err)
+	}
+
+	routes := []*merlinlogger.Route{
+		{Name: defaultRouteName, Dispatcher: dispatcher, LogMode: loggingMode, Filter: logFilter},
+	}
+	logSinks := []merlinlogger.LogSink{logSink}
+	for _, sink := range sinks {
+		filter, err := rules.NewFilter(&sink.Rules, hashKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid rules of sink %s: %w", sink.Name, err)
+		}
+		sinkLogSink, err := getLogSink(sink.Url, httpSinkAuthToken, predictionLogSchema, log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed initializing logSink %s: %w", sink.Name, err)
+		}
+
+		sinkSpoolDir := ""
+		if *spoolDir != "" {
+			sinkSpoolDir = filepath.Join(*spoolDir, "sinks", sink.Name)
+		}
+		sinkDispatcher, err := createLoggerDispatcher(workerConfig, sinkSpoolDir, log, sinkLogSink)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed initializing dispatcher of sink %s: %w", sink.Name, err)
+		}
+
+		routes = append(routes, &merlinlogger.Route{
+			Name:       sink.Name,
+			Dispatcher: sinkDispatcher,
+			LogMode:    merlinlogger.LogMode(sink.LogMode()),
+			Filter:     filter,
+		})
+		logSinks = append(logSinks, sinkLogSink)
+	}
+	return routes, logSinks, nil
+}
+
+// readSinksConfig reads and validates the sinks config file
+func readSinksConfig(path string) ([]rules.Sink, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sinks config file: %w", err)
+	}
+
+	sinksConfig := &rules.SinksConfig{}
+	if err := yaml.Unmarshal(data, sinksConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse sinks config file: %w", err)
+	}
+	if err := (&rules.Rules{Sinks: sinksConfig.Sinks}).Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sinks config file: %w", err)
+	}
+	return sinksConfig.Sinks, nil
+}
+
+// loadLoggerConfig reads the config of the component from the logger config secret of the inference service, the
+// config is nil if the logger isn't running in a kubernetes cluster
+func loadLoggerConfig(namespace string, inferenceService string, component string) (*rules.Config, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		if errors.Is(err, rest.ErrNotInCluster) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	client, err := corev1client.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), loggerConfigTimeout)
+	defer cancel()
+	return readLoggerConfig(ctx, client, namespace, inferenceService, component)
+}
+
+// readLoggerConfig reads the config of the component from the logger config secret of the inference service, the
+// config is nil if the inference service has no logger config secret or the component has no config
+func readLoggerConfig(
+	ctx context.Context,
+	client corev1client.SecretsGetter,
+	namespace string,
+	inferenceService string,
+	component string,
+) (*rules.Config, error) {
+	secretName := rules.ConfigSecretName(inferenceService)
+	secret, err := client.Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		// the role allowing the logger to read the secret only exists together with the secret
+		if kerrors.IsNotFound(err) || kerrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get logger config secret %s: %w", secretName, err)
+	}
+
+	data, ok := secret.Data[component]
+	if !ok {
+		return nil, nil
+	}
+	return rules.ParseConfig(data)
+}
+
+// createLoggerDispatcher creates dispatcher sending to the log sinks, log entries are spooled in spoolDir if it's not empty
+func createLoggerDispatcher(
+	workerConfig *merlinlogger.WorkerConfig,
+	spoolDir string,
+	log *zap.SugaredLogger,
+	logSinks ...merlinlogger.LogSink,
+) (*merlinlogger.Dispatcher, error) {
+	if spoolDir != "" {
+		spool, err := merlinlogger.OpenSpool(merlinlogger.SpoolConfig{
+			Dir:                 spoolDir,
+			MaxSegmentBytes:     *spoolMaxSegmentBytes,
+			MaxSegmentAge:       *spoolMaxSegmentAge,
+			MaxBytes:            *spoolMaxBytes,
+			RetryInitialBackoff: *spoolRetryInitialBackoff,
+			RetryMaxBackoff:     *spoolRetryMaxBackoff,
+		}, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open spool: %w", err)
+		}
+		return merlinlogger.NewSpoolDispatcher(*workers, spool, workerConfig, log, logSinks...), nil
+	}
+
+	dispatcher := merlinlogger.NewDispatcher(*workers, QueueCapacity, workerConfig, log, logSinks...)
+	return dispatcher, nil
 }
 
 func buildProbe(logger *zap.SugaredLogger, probeJSON string) *readiness.Probe {
@@ -309,6 +589,8 @@ func getNewRelicAPIKey(newRelicUrl string) (string, error) {
 
 func getLogSink(
 	logUrl string,
+	httpSinkAuthToken string,
+	predictionLogSchema *rules.PredictionLogSchema,
 	log *zap.SugaredLogger,
 ) (merlinlogger.LogSink, error) {
 	sinkKind, url := merlinlogger.ParseSinkKindAndUrl(logUrl)
@@ -341,9 +623,11 @@ func getLogSink(
 		// Initialize the producer
 		var kafkaProducer merlinlogger.KafkaProducer
 		kafkaCfg := &kafka.ConfigMap{
-			"bootstrap.servers": url,
-			"message.max.bytes": merlinlogger.MaxMessageBytes,
-			"compression.type":  merlinlogger.CompressionType,
+			"bootstrap.servers":  url,
+			"message.max.bytes":  merlinlogger.MaxMessageBytes,
+			"compression.type":   merlinlogger.CompressionType,
+			"enable.idempotence": merlinlogger.EnableIdempotence,
+			"acks":               merlinlogger.Acks,
 		}
 
 		if kafkaConfigPath != nil && *kafkaConfigPath != "" {
@@ -352,6 +636,9 @@ func getLogSink(
 				return nil, err
 			}
 		}
+		if err := boundKafkaMessageTimeout(kafkaCfg, *kafkaRetryBudget); err != nil {
+			return nil, err
+		}
 
 		// Create Kafka Producer
 		kafkaProducer, err := kafka.NewProducer(kafkaCfg)
@@ -368,14 +655,20 @@ func getLogSink(
 			log.Info(err)
 			return nil, fmt.Errorf("failed to create new kafka admin: %w", err)
 		}
-		return merlinlogger.NewKafkaSink(log, kafkaProducer, kafkaAdmin, projectName, modelName, modelVersion)
+		kafkaSinkConfig, err := newKafkaSinkConfig()
+		if err != nil {
+			return nil, err
+		}
+		return merlinlogger.NewKafkaSink(log, kafkaProducer, kafkaAdmin, kafkaSinkConfig, projectName, modelName, modelVersion)
 	case merlinlogger.MLObs:
 		// Initialize kafka clients
 		var kafkaProducer merlinlogger.KafkaProducer
 		kafkaCfg := &kafka.ConfigMap{
-			"bootstrap.servers": url,
-			"message.max.bytes": merlinlogger.MaxMessageBytes,
-			"compression.type":  merlinlogger.CompressionType,
+			"bootstrap.servers":  url,
+			"message.max.bytes":  merlinlogger.MaxMessageBytes,
+			"compression.type":   merlinlogger.CompressionType,
+			"enable.idempotence": merlinlogger.EnableIdempotence,
+			"acks":               merlinlogger.Acks,
 		}
 
 		if kafkaConfigPath != nil && *kafkaConfigPath != "" {
@@ -384,6 +677,9 @@ func getLogSink(
 				return nil, err
 			}
 		}
+		if err := boundKafkaMessageTimeout(kafkaCfg, *kafkaRetryBudget); err != nil {
+			return nil, err
+		}
 
 		// Create Kafka Producer
 		kafkaProducer, err := kafka.NewProducer(kafkaCfg)
@@ -400,12 +696,107 @@ func getLogSink(
 			return nil, err
 		}
 
-		return merlinlogger.NewMLObsSink(log, kafkaProducer, kafkaAdmin, projectName, modelName, modelVersion)
+		kafkaSinkConfig, err := newKafkaSinkConfig()
+		if err != nil {
+			return nil, err
+		}
+		return merlinlogger.NewMLObsSink(log, kafkaProducer, kafkaAdmin, kafkaSinkConfig, projectName, modelName, modelVersion, predictionLogSchema)
+	case merlinlogger.Webhook, merlinlogger.OTLP:
+		httpSinkConfig := merlinlogger.HTTPSinkConfig{
+			Url:            url,
+			AuthHeader:     *httpSinkAuthHeader,
+			AuthToken:      httpSinkAuthToken,
+			Gzip:           *httpSinkGzip,
+			MaxRetries:     *httpSinkMaxRetries,
+			InitialBackoff: *httpSinkInitialBackoff,
+			MaxBackoff:     *httpSinkMaxBackoff,
+		}
+		httpClient := &http.Client{Timeout: *httpSinkTimeout}
+		if sinkKind == merlinlogger.OTLP {
+			return merlinlogger.NewOTLPSink(log, httpClient, httpSinkConfig, serviceName, projectName, modelName, modelVersion), nil
+		}
+		return merlinlogger.NewHTTPSink(log, httpClient, httpSinkConfig, projectName, modelName, modelVersion), nil
+	case merlinlogger.File:
+		location, fileSinkConfig, err := merlinlogger.ParseFileSinkUrl(url)
+		if err != nil {
+			return nil, err
+		}
+		objectWriter, err := newObjectWriter(location)
+		if err != nil {
+			return nil, err
+		}
+		return merlinlogger.NewFileSink(log, objectWriter, *fileSinkConfig, projectName, modelName, modelVersion), nil
 	default:
 		return merlinlogger.NewConsoleSink(log), nil
 	}
 }
 
+// newObjectWriter creates writer for local directory or S3 compatible bucket,
+// endpoint and region query params of S3 location are used to connect to S3 compatible storage such as MinIO
+func newObjectWriter(location *url.URL) (merlinlogger.ObjectWriter, error) {
+	if location.Scheme != "s3" {
+		return merlinlogger.NewLocalObjectWriter(location.Path), nil
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if region := location.Query().Get("region"); region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	s3Client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if endpoint := location.Query().Get("endpoint"); endpoint != "" {
+			o.BaseEndpoint = &endpoint
+			o.UsePathStyle = true
+		}
+	})
+	return merlinlogger.NewS3ObjectWriter(s3Client, location.Host, location.Path), nil
+}
+
+// newKafkaSinkConfig creates the kafka sink config from the kafka flags
+func newKafkaSinkConfig() (merlinlogger.KafkaSinkConfig, error) {
+	config := merlinlogger.KafkaSinkConfig{
+		MessageKey: merlinlogger.KafkaMessageKey(*kafkaMessageKey),
+	}
+	switch config.MessageKey {
+	case merlinlogger.KafkaMessageKeyLogKey, merlinlogger.KafkaMessageKeyRequestId:
+	default:
+		return config, fmt.Errorf("unsupported kafka message key %q", *kafkaMessageKey)
+	}
+
+	if *kafkaRegistryUrl != "" {
+		config.SchemaRegistry = merlinlogger.NewSchemaRegistryClient(*kafkaRegistryUrl, &http.Client{Timeout: 10 * time.Second})
+	}
+	return config, nil
+}
+
+// boundKafkaMessageTimeout caps the message.timeout.ms of the producer so that every delivery attempt of a log entry
+// fits in the retry budget, since the worker blocks on the delivery reports of a batch before retrying it
+func boundKafkaMessageTimeout(cfg *kafka.ConfigMap, retryBudget time.Duration) error {
+	maxTimeoutMS := merlinlogger.MessageTimeoutMS(retryBudget)
+	if maxTimeoutMS <= 0 {
+		return fmt.Errorf("kafka delivery retry budget %v is too small for %d delivery attempts", retryBudget, merlinlogger.MaxDeliveryAttempts)
+	}
+
+	value, err := cfg.Get("message.timeout.ms", nil)
+	if err != nil {
+		return fmt.Errorf("invalid kafka message.timeout.ms: %w", err)
+	}
+	if value != nil {
+		timeoutMS, err := strconv.Atoi(fmt.Sprint(value))
+		if err != nil {
+			return fmt.Errorf("invalid kafka message.timeout.ms %v: %w", value, err)
+		}
+		// 0 disables the message timeout of librdkafka
+		if timeoutMS > 0 && timeoutMS <= maxTimeoutMS {
+			return nil
+		}
+	}
+	return cfg.SetKey("message.timeout.ms", maxTimeoutMS)
+}
+
 func addKafkaConfig(cfg *kafka.ConfigMap, kafkaConfig string) error {
 	file, err := os.Open(kafkaConfig)
 	if err != nil {
@@ -1,7 +1,18 @@
 package main
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func Test_getNewRelicAPIKey(t *testing.T) {
@@ -100,3 +111,183 @@ func Test_getModelNameAndVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_readSinksConfig(t *testing.T) {
+	samplingRate := 0.1
+	tests := []struct {
+		name    string
+		content string
+		want    []rules.Sink
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			content: `
+sinks:
+  - name: audit
+    url: file:/var/log/audit?format=parquet
+    mode: request
+    header_denylist:
+      - Authorization
+  - name: otel
+    url: otlp:http://otel-collector:4318/v1/logs
+    sampling_rate: 0.1
+    always_log_errors: true
+`,
+			want: []rules.Sink{
+				{
+					Name:  "audit",
+					Url:   "file:/var/log/audit?format=parquet",
+					Mode:  "request",
+					Rules: rules.Rules{HeaderDenylist: []string{"Authorization"}},
+				},
+				{
+					Name:  "otel",
+					Url:   "otlp:http://otel-collector:4318/v1/logs",
+					Rules: rules.Rules{SamplingRate: &samplingRate, AlwaysLogErrors: true},
+				},
+			},
+		},
+		{
+			name: "invalid config",
+			content: `
+sinks:
+  - name: audit
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "sinks.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readSinksConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("readSinksConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readSinksConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readLoggerConfig(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model-1-r1-logger", Namespace: "my-project"},
+		Data: map[string][]byte{
+			"predictor":   []byte(`{"spool":{"dir":"/tmp/spool","max_bytes":1024}}`),
+			"transformer": []byte(`{"spool":{"max_bytes":1024}}`),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		inferenceService string
+		component        string
+		want             *rules.Config
+		wantErr          bool
+	}{
+		{
+			name:             "component config",
+			inferenceService: "my-model-1-r1",
+			component:        "predictor",
+			want:             &rules.Config{Spool: &rules.SpoolConfig{Dir: "/tmp/spool", MaxBytes: 1024}},
+		},
+		{
+			name:             "invalid component config",
+			inferenceService: "my-model-1-r1",
+			component:        "transformer",
+			wantErr:          true,
+		},
+		{
+			name:             "component without config",
+			inferenceService: "my-model-1-r1",
+			component:        "explainer",
+		},
+		{
+			name:             "inference service without logger config",
+			inferenceService: "my-model-2-r1",
+			component:        "predictor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(secret).CoreV1()
+			got, err := readLoggerConfig(context.Background(), client, "my-project", tt.inferenceService, tt.component)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("readLoggerConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readLoggerConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_boundKafkaMessageTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         kafka.ConfigMap
+		retryBudget time.Duration
+		want        kafka.ConfigValue
+		wantErr     bool
+	}{
+		{
+			name:        "default message timeout",
+			cfg:         kafka.ConfigMap{},
+			retryBudget: 90 * time.Second,
+			want:        30000,
+		},
+		{
+			name:        "configured message timeout within budget",
+			cfg:         kafka.ConfigMap{"message.timeout.ms": "10000"},
+			retryBudget: 90 * time.Second,
+			want:        "10000",
+		},
+		{
+			name:        "configured message timeout exceeding budget",
+			cfg:         kafka.ConfigMap{"message.timeout.ms": "300000"},
+			retryBudget: 90 * time.Second,
+			want:        30000,
+		},
+		{
+			name:        "disabled message timeout",
+			cfg:         kafka.ConfigMap{"message.timeout.ms": "0"},
+			retryBudget: 90 * time.Second,
+			want:        30000,
+		},
+		{
+			name:        "invalid message timeout",
+			cfg:         kafka.ConfigMap{"message.timeout.ms": "1m"},
+			retryBudget: 90 * time.Second,
+			wantErr:     true,
+		},
+		{
+			name:        "retry budget too small",
+			cfg:         kafka.ConfigMap{},
+			retryBudget: time.Millisecond,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := boundKafkaMessageTimeout(&tt.cfg, tt.retryBudget)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("boundKafkaMessageTimeout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := tt.cfg["message.timeout.ms"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("boundKafkaMessageTimeout() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -87,6 +87,10 @@ func main() {
 		log.Fatal(errors.Wrap(err, "Error processing environment variables"))
 	}
 
+	if err := appConfig.Server.Validate(); err != nil {
+		log.Fatal(errors.Wrap(err, "Invalid server configuration"))
+	}
+
 	if appConfig.InitHeapSizeInMB > 0 {
 		initialHeapAllocation = make([]byte, appConfig.InitHeapSizeInMB<<20)
 	}
@@ -157,11 +161,16 @@ func main() {
 		logger.Fatal("got error when creating handler", zap.Error(err))
 	}
 
+	predictionCache, err := createPredictionCache(&appConfig.Server)
+	if err != nil {
+		logger.Fatal("invalid prediction cache configuration", zap.Error(err))
+	}
+
 	if appConfig.Server.Protocol == protocol.UpiV1 {
 		instRouter := rest.NewInstrumentationRouter()
-		runGrpcServer(&appConfig.Server, handler, instRouter, logger)
+		runGrpcServer(&appConfig.Server, handler, predictionCache, instRouter, logger)
 	} else {
-		runHTTPServer(&appConfig.Server, handler, logger)
+		runHTTPServer(&appConfig.Server, handler, predictionCache, logger)
 	}
 }
 
@@ -262,16 +271,31 @@ func createPipelineHandler(appConfig AppConfig, transformerConfig *spec.Standard
 	return handler, nil
 }
 
-func runHTTPServer(opts *serverConf.Options, handler *pipeline.Handler, logger *zap.Logger) {
+// createPredictionCache returns nil if prediction cache is not enabled
+func createPredictionCache(opts *serverConf.Options) (*pipeline.PredictionCache, error) {
+	if !opts.PredictionCacheEnabled {
+		return nil, nil
+	}
+	return pipeline.NewPredictionCache(pipeline.PredictionCacheOptions{
+		KeyJSONPaths: opts.PredictionCacheKeyJSONPaths,
+		KeyVariables: opts.PredictionCacheKeyVariables,
+		TTL:          opts.PredictionCacheTTL,
+		SizeInMB:     opts.PredictionCacheSizeInMB,
+	}, opts.Protocol)
+}
+
+func runHTTPServer(opts *serverConf.Options, handler *pipeline.Handler, predictionCache *pipeline.PredictionCache, logger *zap.Logger) {
 	s := rest.NewWithHandler(opts, handler, logger)
+	s.PredictionCache = predictionCache
 	s.Run()
 }
 
-func runGrpcServer(opts *serverConf.Options, handler *pipeline.Handler, instrumentationRouter *mux.Router, logger *zap.Logger) {
+func runGrpcServer(opts *serverConf.Options, handler *pipeline.Handler, predictionCache *pipeline.PredictionCache, instrumentationRouter *mux.Router, logger *zap.Logger) {
 	s, err := grpc.NewUPIServer(opts, handler, instrumentationRouter, logger)
 	if err != nil {
 		panic(err)
 	}
+	s.PredictionCache = predictionCache
 	s.Run()
 }
 
@@ -71,6 +71,7 @@ type Config struct {
 	MlflowConfig              MlflowConfig
 	PyFuncPublisherConfig     PyFuncPublisherConfig
 	InferenceServiceDefaults  InferenceServiceDefaults
+	InferenceLoggerConfig     InferenceLoggerConfig
 	ObservabilityPublisher    ObservabilityPublisher
 	WebhooksConfig            webhooks.Config
 }
@@ -260,9 +261,13 @@ type InMemoryCacheConfig struct {
 }
 
 type FeatureToggleConfig struct {
-	MonitoringConfig    MonitoringConfig
-	AlertConfig         AlertConfig
-	ModelDeletionConfig ModelDeletionConfig
+	MonitoringConfig      MonitoringConfig
+	AlertConfig           AlertConfig
+	ModelDeletionConfig   ModelDeletionConfig
+	CanaryRolloutConfig   CanaryRolloutConfig
+	ExperimentConfig      ExperimentConfig
+	ScalingScheduleConfig ScalingScheduleConfig
+	ModelManifestConfig   ModelManifestConfig
 }
 
 type MonitoringConfig struct {
@@ -281,6 +286,49 @@ type ModelDeletionConfig struct {
 	Enabled bool `default:"false"`
 }
 
+// CanaryRolloutConfig configures the controller progressing the canary rollouts of model endpoints
+type CanaryRolloutConfig struct {
+	Enabled bool `default:"false"`
+	// SyncInterval is how often the rollouts in progress are evaluated and progressed
+	SyncInterval  time.Duration `default:"30s"`
+	PrometheusURL string        `validate:"required_if=Enabled true"`
+	// ErrorRateQuery and LatencyQuery are the PromQL templates of the canary metrics,
+	// the default Istio queries are used if empty
+	ErrorRateQuery string
+	LatencyQuery   string
+}
+
+// ExperimentConfig configures the experiments of model endpoints and the controller adjusting the weights of their bandits
+type ExperimentConfig struct {
+	Enabled bool `default:"false"`
+	// BanditSyncInterval is how often the weights of the bandit experiments are recomputed from their rewards
+	BanditSyncInterval time.Duration `default:"10m"`
+	// BanditMinWeightChange is the minimum change of a variant's weight for the recomputed weights to be applied,
+	// so that the sampling noise doesn't reassign the units of the buckets on every sync
+	BanditMinWeightChange int32 `default:"5"`
+	// EnvoyFilterNamespace is the namespace of the ingress gateway, where the experiments' EnvoyFilters are created
+	EnvoyFilterNamespace string `default:"istio-system"`
+	// GatewaySelector selects the ingress gateway pods of the EnvoyFilters, istio=ingressgateway if empty
+	GatewaySelector map[string]string
+	// GatewayPorts are the ports of the ingress gateway servers exposing the model endpoints
+	GatewayPorts []int `default:"80"`
+}
+
+// ScalingScheduleConfig configures the controller applying the scaling schedules of version endpoints
+type ScalingScheduleConfig struct {
+	Enabled bool `default:"false"`
+	// SyncInterval is how often the effective scaling of the scheduled version endpoints is evaluated and applied
+	SyncInterval time.Duration `default:"1m"`
+}
+
+// ModelManifestConfig configures the commit-back of the model manifests converged by the apply API,
+// the manifests are committed with the GitLab client of AlertConfig
+type ModelManifestConfig struct {
+	// GitlabRepository is the repository the applied manifests are committed to, the commit-back is disabled if empty
+	GitlabRepository string
+	GitlabBranch     string `default:"master"`
+}
+
 type GitlabConfig struct {
 	BaseURL             string
 	Token               string
@@ -371,6 +419,26 @@ type InferenceServiceDefaults struct {
 	DefaultEnvVarsWithoutCPULimits        []v1.EnvVar `json:"defaultEnvVarsWithoutCPULimits"`
 }
 
+// InferenceLoggerConfig is the configuration of the inference logger sidecar of the model deployments.
+// The sidecar is injected by KServe, so that it is passed to the sidecar through the logger config secret of the deployment.
+type InferenceLoggerConfig struct {
+	// SpoolEnabled stores the log entries on the disk of the sidecar before they are sent to the log sinks,
+	// so that they are not lost when the sinks are unavailable
+	SpoolEnabled bool `json:"spoolEnabled" default:"false"`
+	// SpoolDir is the directory of the spool in the sidecar container
+	SpoolDir string `json:"spoolDir" default:"/tmp/merlin-inference-logger/spool"`
+	// SpoolMaxBytes is the maximum size of the spool, 0 means unlimited
+	SpoolMaxBytes int64 `json:"spoolMaxBytes" default:"1073741824"`
+	// MetricsPort is the port of the prometheus metrics of the sidecar, the metrics are not exposed if 0
+	MetricsPort int `json:"metricsPort" default:"9095"`
+	// AllowedSinkKinds are the kinds of the additional sinks users can add to their model loggers, e.g. kafka or
+	// webhook. Users can't add any additional sink if empty
+	AllowedSinkKinds []string `json:"allowedSinkKinds"`
+	// AllowedSinkHosts are the hosts the additional sinks can send the logs to, either a host name or a wildcard
+	// matching its sub domains, e.g. *.example.com
+	AllowedSinkHosts []string `json:"allowedSinkHosts"`
+}
+
 // SimulationFeastConfig feast config that aimed to be used only for simulation of standard transformer
 type SimulationFeastConfig struct {
 	FeastRedisURL    string `validate:"required"`
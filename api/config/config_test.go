@@ -500,6 +500,22 @@ func TestLoad(t *testing.T) {
 					ModelDeletionConfig: ModelDeletionConfig{
 						Enabled: false,
 					},
+					CanaryRolloutConfig: CanaryRolloutConfig{
+						SyncInterval: 30 * time.Second,
+					},
+					ExperimentConfig: ExperimentConfig{
+						BanditSyncInterval:    10 * time.Minute,
+						BanditMinWeightChange: 5,
+						EnvoyFilterNamespace:  "istio-system",
+						GatewaySelector:       map[string]string{},
+						GatewayPorts:          []int{80},
+					},
+					ScalingScheduleConfig: ScalingScheduleConfig{
+						SyncInterval: time.Minute,
+					},
+					ModelManifestConfig: ModelManifestConfig{
+						GitlabBranch: "master",
+					},
 				},
 				ReactAppConfig: ReactAppConfig{
 					DocURL: []Documentation{
@@ -596,6 +612,14 @@ func TestLoad(t *testing.T) {
 						},
 					},
 				},
+				InferenceLoggerConfig: InferenceLoggerConfig{
+					SpoolEnabled:     false,
+					SpoolDir:         "/tmp/merlin-inference-logger/spool",
+					SpoolMaxBytes:    1073741824,
+					MetricsPort:      9095,
+					AllowedSinkKinds: []string{},
+					AllowedSinkHosts: []string{},
+				},
 				ObservabilityPublisher: ObservabilityPublisher{
 					KafkaConsumer: KafkaConsumer{
 						AdditionalConsumerConfig: map[string]string{},
@@ -27,6 +27,8 @@ type DeploymentConfig struct {
 	DeploymentTimeout time.Duration
 	// Duration to wait for namespaceResource creation
 	NamespaceTimeout time.Duration
+	// Duration to wait for the new revision of a blue/green deployment to be ready before reverting to the current revision
+	BlueGreenReadinessTimeout time.Duration
 	// Default resource request for model deployment
 	DefaultModelResourceRequests *ResourceRequests
 	// Default resource request for transformer deployment
@@ -45,12 +47,16 @@ type DeploymentConfig struct {
 	PyfuncGRPCOptions string
 	// PDB config to be applied on models and transformers
 	PodDisruptionBudget PodDisruptionBudgetConfig
+	// Custom metrics used to autoscale raw deployments on rps and concurrency
+	AutoscalingCustomMetrics AutoscalingCustomMetricsConfig
 	// GPU Config
 	GPUs []GPUConfig
 	// PyFunc publisher Config
 	PyFuncPublisher PyFuncPublisherConfig
 	// Standard Transformer Config
 	StandardTransformer StandardTransformerConfig
+	// Inference logger sidecar config
+	InferenceLogger InferenceLoggerConfig
 	// Resource properties
 	UserContainerCPUDefaultLimit          string
 	UserContainerCPULimitRequestFactor    float64
@@ -69,6 +75,13 @@ type ResourceRequests struct {
 	MemoryRequest resource.Quantity
 }
 
+// AutoscalingCustomMetricsConfig are the names of the pod metrics, served by the custom metrics API of the cluster,
+// used by the horizontal pod autoscalers of raw deployments autoscaling on rps and concurrency
+type AutoscalingCustomMetricsConfig struct {
+	RPSMetricName         string `yaml:"rps_metric_name"`
+	ConcurrencyMetricName string `yaml:"concurrency_metric_name"`
+}
+
 // PodDisruptionBudgetConfig are the configuration for PodDisruptionBudgetConfig for
 // Turing services.
 type PodDisruptionBudgetConfig struct {
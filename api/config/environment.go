@@ -39,6 +39,9 @@ type EnvironmentConfig struct {
 	GcpProject        string        `yaml:"gcp_project"`
 	DeploymentTimeout time.Duration `yaml:"deployment_timeout"`
 	NamespaceTimeout  time.Duration `yaml:"namespace_timeout"`
+	// BlueGreenReadinessTimeout is the duration to wait for the new revision of a blue/green deployment to be ready,
+	// the deployment timeout is used if it is not set
+	BlueGreenReadinessTimeout time.Duration `yaml:"blue_green_readiness_timeout"`
 
 	GPUs []GPUConfig `yaml:"gpus"`
 
@@ -47,6 +50,8 @@ type EnvironmentConfig struct {
 	MaxAllowedReplica         int                       `yaml:"max_allowed_replica"`
 	TopologySpreadConstraints TopologySpreadConstraints `yaml:"topology_spread_constraints"`
 	PodDisruptionBudget       PodDisruptionBudgetConfig `yaml:"pod_disruption_budget"`
+	// AutoscalingCustomMetrics are the pod metrics raw deployments autoscale on for rps and concurrency
+	AutoscalingCustomMetrics AutoscalingCustomMetricsConfig `yaml:"autoscaling_custom_metrics"`
 
 	QueueResourcePercentage string `yaml:"queue_resource_percentage"`
 
@@ -162,8 +167,9 @@ func InitEnvironmentConfigs(path string) ([]*EnvironmentConfig, error) {
 
 func ParseDeploymentConfig(envCfg *EnvironmentConfig, cfg *Config) DeploymentConfig {
 	return DeploymentConfig{
-		DeploymentTimeout: envCfg.DeploymentTimeout,
-		NamespaceTimeout:  envCfg.NamespaceTimeout,
+		DeploymentTimeout:         envCfg.DeploymentTimeout,
+		NamespaceTimeout:          envCfg.NamespaceTimeout,
+		BlueGreenReadinessTimeout: envCfg.BlueGreenReadinessTimeout,
 		DefaultModelResourceRequests: &ResourceRequests{
 			MinReplica:    envCfg.DefaultDeploymentConfig.MinReplica,
 			MaxReplica:    envCfg.DefaultDeploymentConfig.MaxReplica,
@@ -183,9 +189,11 @@ func ParseDeploymentConfig(envCfg *EnvironmentConfig, cfg *Config) DeploymentCon
 		QueueResourcePercentage:               envCfg.QueueResourcePercentage,
 		PyfuncGRPCOptions:                     cfg.PyfuncGRPCOptions,
 		PodDisruptionBudget:                   envCfg.PodDisruptionBudget,
+		AutoscalingCustomMetrics:              envCfg.AutoscalingCustomMetrics,
 		GPUs:                                  envCfg.GPUs,
 		StandardTransformer:                   cfg.StandardTransformerConfig,
 		PyFuncPublisher:                       cfg.PyFuncPublisherConfig,
+		InferenceLogger:                       cfg.InferenceLoggerConfig,
 		UserContainerCPUDefaultLimit:          cfg.InferenceServiceDefaults.UserContainerCPUDefaultLimit,
 		UserContainerCPULimitRequestFactor:    cfg.InferenceServiceDefaults.UserContainerCPULimitRequestFactor,
 		UserContainerMemoryLimitRequestFactor: cfg.InferenceServiceDefaults.UserContainerMemoryLimitRequestFactor,
@@ -19,8 +19,11 @@ import (
 	"encoding/json"
 
 	mlpcluster "github.com/caraml-dev/mlp/api/pkg/cluster"
+	istiov1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	networkingv1alpha3 "istio.io/client-go/pkg/clientset/versioned/typed/networking/v1alpha3"
 	networkingv1beta1 "istio.io/client-go/pkg/clientset/versioned/typed/networking/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -44,6 +47,9 @@ type Client interface {
 	CreateVirtualService(ctx context.Context, namespace string, vs *istiov1beta1.VirtualService) (*istiov1beta1.VirtualService, error)
 	PatchVirtualService(ctx context.Context, namespace string, vs *istiov1beta1.VirtualService) (*istiov1beta1.VirtualService, error)
 	DeleteVirtualService(ctx context.Context, namespace, name string) error
+	// ApplyEnvoyFilter creates the EnvoyFilter or replaces the spec of the existing one
+	ApplyEnvoyFilter(ctx context.Context, namespace string, ef *istiov1alpha3.EnvoyFilter) (*istiov1alpha3.EnvoyFilter, error)
+	DeleteEnvoyFilter(ctx context.Context, namespace, name string) error
 }
 
 // NewClient returns an initialized Istio's client.
@@ -58,16 +64,23 @@ func NewClient(config Config) (Client, error) {
 		return nil, err
 	}
 
-	return newClient(networking)
+	networkingAlpha, err := networkingv1alpha3.NewForConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(networking, networkingAlpha)
 }
 
 type client struct {
-	networking networkingv1beta1.NetworkingV1beta1Interface
+	networking      networkingv1beta1.NetworkingV1beta1Interface
+	networkingAlpha networkingv1alpha3.NetworkingV1alpha3Interface
 }
 
-func newClient(networking networkingv1beta1.NetworkingV1beta1Interface) (*client, error) {
+func newClient(networking networkingv1beta1.NetworkingV1beta1Interface, networkingAlpha networkingv1alpha3.NetworkingV1alpha3Interface) (*client, error) {
 	return &client{
-		networking: networking,
+		networking:      networking,
+		networkingAlpha: networkingAlpha,
 	}, nil
 }
 
@@ -87,3 +100,20 @@ func (c *client) PatchVirtualService(ctx context.Context, namespace string, vs *
 func (c *client) DeleteVirtualService(ctx context.Context, namespace, name string) error {
 	return c.networking.VirtualServices(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
+
+func (c *client) ApplyEnvoyFilter(ctx context.Context, namespace string, ef *istiov1alpha3.EnvoyFilter) (*istiov1alpha3.EnvoyFilter, error) {
+	existing, err := c.networkingAlpha.EnvoyFilters(namespace).Get(ctx, ef.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return c.networkingAlpha.EnvoyFilters(namespace).Create(ctx, ef, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ef.ResourceVersion = existing.ResourceVersion
+	return c.networkingAlpha.EnvoyFilters(namespace).Update(ctx, ef, metav1.UpdateOptions{})
+}
+
+func (c *client) DeleteEnvoyFilter(ctx context.Context, namespace, name string) error {
+	return c.networkingAlpha.EnvoyFilters(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
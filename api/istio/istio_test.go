@@ -21,7 +21,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	istionetv1alpha3 "istio.io/api/networking/v1alpha3"
 	istionetv1beta1 "istio.io/api/networking/v1beta1"
+	istiov1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
 	istiocliv1beta1 "istio.io/client-go/pkg/clientset/versioned/typed/networking/v1beta1"
@@ -137,7 +139,7 @@ func Test_client_CreateVirtualService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c, _ := newClient(tt.fields.networking)
+			c, _ := newClient(tt.fields.networking, clientSet.NetworkingV1alpha3())
 
 			tt.mockFunc(c.networking)
 
@@ -214,7 +216,7 @@ func Test_client_PatchVirtualService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c, _ := newClient(tt.fields.networking)
+			c, _ := newClient(tt.fields.networking, clientSet.NetworkingV1alpha3())
 
 			tt.mockFunc(c.networking)
 
@@ -229,3 +231,29 @@ func Test_client_PatchVirtualService(t *testing.T) {
 		})
 	}
 }
+
+func Test_client_ApplyEnvoyFilter(t *testing.T) {
+	clientSet := istiofake.NewSimpleClientset()
+	c, _ := newClient(clientSet.NetworkingV1beta1(), clientSet.NetworkingV1alpha3())
+
+	ef := &istiov1alpha3.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "istio-system"},
+		Spec: istionetv1alpha3.EnvoyFilter{
+			WorkloadSelector: &istionetv1alpha3.WorkloadSelector{Labels: map[string]string{"istio": "ingressgateway"}},
+		},
+	}
+	_, err := c.ApplyEnvoyFilter(context.Background(), "istio-system", ef)
+	assert.NoError(t, err)
+
+	updated := ef.DeepCopy()
+	updated.Spec.WorkloadSelector.Labels = map[string]string{"istio": "other-gateway"}
+	_, err = c.ApplyEnvoyFilter(context.Background(), "istio-system", updated)
+	assert.NoError(t, err)
+
+	got, err := clientSet.NetworkingV1alpha3().EnvoyFilters("istio-system").Get(context.Background(), "valid", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "other-gateway", got.Spec.WorkloadSelector.Labels["istio"])
+
+	assert.NoError(t, c.DeleteEnvoyFilter(context.Background(), "istio-system", "valid"))
+	assert.Error(t, c.DeleteEnvoyFilter(context.Background(), "istio-system", "valid"))
+}
@@ -7,6 +7,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
 	"istio.io/client-go/pkg/apis/networking/v1beta1"
 )
 
@@ -15,6 +16,29 @@ type Client struct {
 	mock.Mock
 }
 
+// ApplyEnvoyFilter provides a mock function with given fields: ctx, namespace, ef
+func (_m *Client) ApplyEnvoyFilter(ctx context.Context, namespace string, ef *v1alpha3.EnvoyFilter) (*v1alpha3.EnvoyFilter, error) {
+	ret := _m.Called(ctx, namespace, ef)
+
+	var r0 *v1alpha3.EnvoyFilter
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1alpha3.EnvoyFilter) *v1alpha3.EnvoyFilter); ok {
+		r0 = rf(ctx, namespace, ef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha3.EnvoyFilter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1alpha3.EnvoyFilter) error); ok {
+		r1 = rf(ctx, namespace, ef)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateVirtualService provides a mock function with given fields: ctx, namespace, vs
 func (_m *Client) CreateVirtualService(ctx context.Context, namespace string, vs *v1beta1.VirtualService) (*v1beta1.VirtualService, error) {
 	ret := _m.Called(ctx, namespace, vs)
@@ -38,6 +62,20 @@ func (_m *Client) CreateVirtualService(ctx context.Context, namespace string, vs
 	return r0, r1
 }
 
+// DeleteEnvoyFilter provides a mock function with given fields: ctx, namespace, name
+func (_m *Client) DeleteEnvoyFilter(ctx context.Context, namespace string, name string) error {
+	ret := _m.Called(ctx, namespace, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, namespace, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteVirtualService provides a mock function with given fields: ctx, namespace, name
 func (_m *Client) DeleteVirtualService(ctx context.Context, namespace string, name string) error {
 	ret := _m.Called(ctx, namespace, name)
@@ -25,6 +25,8 @@ type Deployment struct {
 	VersionEndpointID uuid.UUID      `json:"version_endpoint_id"`
 	Status            EndpointStatus `json:"status"`
 	Error             string         `json:"error"`
+	// Spec is the version endpoint configuration deployed, it is empty for the deployments made before it was recorded
+	Spec *DeploymentSpec `json:"spec,omitempty" gorm:"spec"`
 	CreatedUpdated
 }
 
@@ -0,0 +1,59 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "fmt"
+
+// DeploymentPlan is the result of a dry-run deployment of a version endpoint: the version endpoint configuration
+// that would be deployed, the Kubernetes resources rendered for it and the warnings found while validating them.
+type DeploymentPlan struct {
+	Endpoint  *VersionEndpoint      `json:"endpoint"`
+	Manifests []*DeploymentManifest `json:"manifests"`
+	Warnings  []string              `json:"warnings"`
+}
+
+// DeploymentManifest is a Kubernetes resource rendered by a dry-run deployment
+type DeploymentManifest struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Verified is true if the resource has been accepted by a server-side dry-run of the cluster
+	Verified bool        `json:"verified"`
+	Manifest interface{} `json:"manifest"`
+}
+
+// NewDeploymentPlan returns an empty deployment plan
+func NewDeploymentPlan() *DeploymentPlan {
+	return &DeploymentPlan{
+		Manifests: []*DeploymentManifest{},
+		Warnings:  []string{},
+	}
+}
+
+// AddManifest adds a rendered resource to the deployment plan
+func (p *DeploymentPlan) AddManifest(kind, name, namespace string, verified bool, manifest interface{}) {
+	p.Manifests = append(p.Manifests, &DeploymentManifest{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Verified:  verified,
+		Manifest:  manifest,
+	})
+}
+
+// AddWarning adds a warning to the deployment plan
+func (p *DeploymentPlan) AddWarning(format string, a ...interface{}) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, a...))
+}
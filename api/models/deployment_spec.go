@@ -0,0 +1,272 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/caraml-dev/merlin/pkg/autoscaling"
+	"github.com/caraml-dev/merlin/pkg/deployment"
+	"github.com/caraml-dev/merlin/pkg/protocol"
+)
+
+// DeploymentSpec is the snapshot of the version endpoint configuration deployed by a deployment
+type DeploymentSpec struct {
+	DeploymentMode              deployment.Mode                `json:"deployment_mode"`
+	DeploymentStrategy          deployment.Strategy            `json:"deployment_strategy,omitempty"`
+	Protocol                    protocol.Protocol              `json:"protocol"`
+	ResourceRequest             *ResourceRequest               `json:"resource_request"`
+	ImageBuilderResourceRequest *ResourceRequest               `json:"image_builder_resource_request"`
+	AutoscalingPolicy           *autoscaling.AutoscalingPolicy `json:"autoscaling_policy"`
+	ScalingSchedules            ScalingSchedules               `json:"scaling_schedules,omitempty"`
+	EnvVars                     EnvVars                        `json:"env_vars"`
+	Secrets                     Secrets                        `json:"secrets"`
+	Transformer                 *Transformer                   `json:"transformer"`
+	Logger                      *Logger                        `json:"logger"`
+	ModelObservability          *ModelObservability            `json:"model_observability"`
+}
+
+// DeploymentSpecChange is a field whose value differs between two deployment specs.
+// Field is the dot separated json path of the field, env vars are identified by their name, e.g. env_vars[WORKERS].
+type DeploymentSpecChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// DeploymentDiff is the list of changes between the specs of two deployments of a version endpoint
+type DeploymentDiff struct {
+	FromDeploymentID ID                      `json:"from_deployment_id"`
+	ToDeploymentID   ID                      `json:"to_deployment_id"`
+	Changes          []*DeploymentSpecChange `json:"changes"`
+}
+
+// NewDeploymentSpec snapshots the deployment configuration of the version endpoint
+func NewDeploymentSpec(endpoint *VersionEndpoint) *DeploymentSpec {
+	spec := &DeploymentSpec{
+		DeploymentMode:              endpoint.DeploymentMode,
+		DeploymentStrategy:          endpoint.DeploymentStrategy,
+		Protocol:                    endpoint.Protocol,
+		ResourceRequest:             endpoint.ResourceRequest,
+		ImageBuilderResourceRequest: endpoint.ImageBuilderResourceRequest,
+		AutoscalingPolicy:           endpoint.AutoscalingPolicy,
+		ScalingSchedules:            endpoint.ScalingSchedules,
+		EnvVars:                     endpoint.EnvVars,
+		Secrets:                     endpoint.Secrets,
+		Logger:                      endpoint.Logger,
+		ModelObservability:          endpoint.ModelObservability,
+	}
+
+	// the identity of the transformer record is not part of the configuration
+	if endpoint.Transformer != nil {
+		transformer := *endpoint.Transformer
+		transformer.ID = ""
+		transformer.VersionEndpointID = uuid.Nil
+		transformer.CreatedUpdated = CreatedUpdated{}
+		spec.Transformer = &transformer
+	}
+	return spec
+}
+
+// VersionEndpoint returns the version endpoint deploying the snapshotted configuration, to be used to redeploy it
+func (s *DeploymentSpec) VersionEndpoint() *VersionEndpoint {
+	endpoint := &VersionEndpoint{
+		DeploymentMode:              s.DeploymentMode,
+		DeploymentStrategy:          s.DeploymentStrategy,
+		Protocol:                    s.Protocol,
+		ResourceRequest:             s.ResourceRequest,
+		ImageBuilderResourceRequest: s.ImageBuilderResourceRequest,
+		AutoscalingPolicy:           s.AutoscalingPolicy,
+		ScalingSchedules:            s.ScalingSchedules,
+		EnvVars:                     s.EnvVars,
+		Secrets:                     s.Secrets,
+		Logger:                      s.Logger,
+		ModelObservability:          s.ModelObservability,
+		EnableModelObservability:    s.ModelObservability.IsEnabled(),
+	}
+	if s.Transformer != nil {
+		transformer := *s.Transformer
+		endpoint.Transformer = &transformer
+	}
+	return endpoint
+}
+
+// RollbackVersionEndpoint returns the version endpoint redeploying the spec in place of the current version endpoint.
+//
+// Unset fields keep their current value when deploying, they are reset to roll back to their absence. The transformer
+// record of the current version endpoint is reused, it is disabled if the spec was deployed without transformer.
+func (s *DeploymentSpec) RollbackVersionEndpoint(current *VersionEndpoint) *VersionEndpoint {
+	endpoint := s.VersionEndpoint()
+	endpoint.EnvironmentName = current.EnvironmentName
+	endpoint.Status = EndpointRunning
+	if current.IsServing() {
+		endpoint.Status = EndpointServing
+	}
+
+	if endpoint.EnvVars == nil {
+		endpoint.EnvVars = EnvVars{}
+	}
+	if endpoint.Secrets == nil {
+		endpoint.Secrets = Secrets{}
+	}
+	if endpoint.Logger == nil {
+		endpoint.Logger = &Logger{}
+	}
+	if endpoint.ModelObservability == nil {
+		endpoint.ModelObservability = &ModelObservability{}
+	}
+	if endpoint.ScalingSchedules == nil {
+		endpoint.ScalingSchedules = ScalingSchedules{}
+	}
+
+	if current.Transformer != nil {
+		if endpoint.Transformer == nil {
+			transformer := *current.Transformer
+			transformer.Enabled = false
+			endpoint.Transformer = &transformer
+		}
+		endpoint.Transformer.ID = current.Transformer.ID
+	}
+	return endpoint
+}
+
+func (s DeploymentSpec) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *DeploymentSpec) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &s)
+}
+
+// DiffDeploymentSpecs returns the fields changed from one deployment spec to the other, sorted by field
+func DiffDeploymentSpecs(from *DeploymentSpec, to *DeploymentSpec) ([]*DeploymentSpecChange, error) {
+	return DiffValues(from, to)
+}
+
+// DiffValues returns the fields changed from one value to the other following their json representation, sorted by field
+func DiffValues(from interface{}, to interface{}) ([]*DeploymentSpecChange, error) {
+	fromValue, err := toJSONValue(from)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := toJSONValue(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*DeploymentSpecChange{}
+	diffJSONValues("", fromValue, toValue, &changes)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes, nil
+}
+
+// toJSONValue converts the value to its generic json representation, so that the diff follows the json field names
+func toJSONValue(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func diffJSONValues(field string, from interface{}, to interface{}, changes *[]*DeploymentSpecChange) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+
+	fromObject, fromIsObject := from.(map[string]interface{})
+	toObject, toIsObject := to.(map[string]interface{})
+	if fromIsObject && toIsObject {
+		for key := range unionKeys(fromObject, toObject) {
+			diffJSONValues(joinField(field, key), fromObject[key], toObject[key], changes)
+		}
+		return
+	}
+
+	fromNamed, fromIsNamed := namedElements(from)
+	toNamed, toIsNamed := namedElements(to)
+	if fromIsNamed && toIsNamed {
+		for name := range unionKeys(fromNamed, toNamed) {
+			diffJSONValues(fmt.Sprintf("%s[%s]", field, name), fromNamed[name], toNamed[name], changes)
+		}
+		return
+	}
+
+	*changes = append(*changes, &DeploymentSpecChange{Field: field, From: from, To: to})
+}
+
+// namedElements indexes the elements of a list of objects by their name, like env vars, it returns false for other values
+func namedElements(value interface{}) (map[string]interface{}, bool) {
+	if value == nil {
+		return map[string]interface{}{}, true
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	result := map[string]interface{}{}
+	for _, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		if _, duplicate := result[name]; duplicate {
+			return nil, false
+		}
+		result[name] = element
+	}
+	return result, true
+}
+
+func unionKeys(left map[string]interface{}, right map[string]interface{}) map[string]bool {
+	keys := map[string]bool{}
+	for key := range left {
+		keys[key] = true
+	}
+	for key := range right {
+		keys[key] = true
+	}
+	return keys
+}
+
+func joinField(parent string, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
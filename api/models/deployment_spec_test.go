@@ -0,0 +1,158 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/caraml-dev/merlin/pkg/deployment"
+)
+
+func TestNewDeploymentSpec(t *testing.T) {
+	endpoint := &VersionEndpoint{
+		ID:             uuid.New(),
+		DeploymentMode: deployment.RawDeploymentMode,
+		EnvVars:        EnvVars{{Name: "WORKERS", Value: "2"}},
+		Transformer: &Transformer{
+			ID:                "1",
+			Enabled:           true,
+			VersionEndpointID: uuid.New(),
+			Image:             "ghcr.io/caraml-dev/transformer:1",
+			CreatedUpdated:    CreatedUpdated{CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		},
+		ModelObservability: &ModelObservability{Enabled: true},
+	}
+
+	spec := NewDeploymentSpec(endpoint)
+	assert.Equal(t, deployment.RawDeploymentMode, spec.DeploymentMode)
+	assert.Equal(t, endpoint.EnvVars, spec.EnvVars)
+	assert.Equal(t, &Transformer{Enabled: true, Image: "ghcr.io/caraml-dev/transformer:1"}, spec.Transformer)
+	// the endpoint's transformer is left untouched
+	assert.Equal(t, "1", endpoint.Transformer.ID)
+
+	redeployed := spec.VersionEndpoint()
+	assert.Equal(t, endpoint.EnvVars, redeployed.EnvVars)
+	assert.Equal(t, "ghcr.io/caraml-dev/transformer:1", redeployed.Transformer.Image)
+	assert.True(t, redeployed.EnableModelObservability)
+}
+
+func TestDeploymentSpec_RollbackVersionEndpoint(t *testing.T) {
+	current := &VersionEndpoint{
+		ID:              uuid.New(),
+		EnvironmentName: "env-1",
+		Status:          EndpointServing,
+		DeploymentMode:  deployment.ServerlessDeploymentMode,
+		EnvVars:         EnvVars{{Name: "WORKERS", Value: "2"}},
+		Transformer:     &Transformer{ID: "1", Enabled: true, Image: "ghcr.io/caraml-dev/transformer:2"},
+	}
+	spec := &DeploymentSpec{DeploymentMode: deployment.ServerlessDeploymentMode}
+
+	endpoint := spec.RollbackVersionEndpoint(current)
+	assert.Equal(t, "env-1", endpoint.EnvironmentName)
+	assert.Equal(t, EndpointServing, endpoint.Status)
+	// the fields unset in the spec are reset
+	assert.Equal(t, EnvVars{}, endpoint.EnvVars)
+	assert.Equal(t, &Logger{}, endpoint.Logger)
+	// the transformer record is reused and disabled
+	require.NotNil(t, endpoint.Transformer)
+	assert.Equal(t, "1", endpoint.Transformer.ID)
+	assert.False(t, endpoint.Transformer.Enabled)
+	assert.True(t, current.Transformer.Enabled)
+
+	current.Status = EndpointTerminated
+	assert.Equal(t, EndpointRunning, spec.RollbackVersionEndpoint(current).Status)
+}
+
+func TestDiffDeploymentSpecs(t *testing.T) {
+	base := func() *DeploymentSpec {
+		return &DeploymentSpec{
+			DeploymentMode: deployment.ServerlessDeploymentMode,
+			ResourceRequest: &ResourceRequest{
+				MinReplica:    1,
+				MaxReplica:    2,
+				CPURequest:    resource.MustParse("1"),
+				MemoryRequest: resource.MustParse("1Gi"),
+			},
+			EnvVars: EnvVars{{Name: "WORKERS", Value: "2"}, {Name: "LOG_LEVEL", Value: "INFO"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(spec *DeploymentSpec)
+		want   []*DeploymentSpecChange
+	}{
+		{
+			name:   "no changes",
+			modify: func(spec *DeploymentSpec) {},
+			want:   []*DeploymentSpecChange{},
+		},
+		{
+			name: "resource request and env vars changes",
+			modify: func(spec *DeploymentSpec) {
+				spec.ResourceRequest.MaxReplica = 4
+				spec.ResourceRequest.CPURequest = resource.MustParse("500m")
+				spec.EnvVars = EnvVars{{Name: "WORKERS", Value: "4"}, {Name: "TIMEOUT", Value: "10"}}
+			},
+			want: []*DeploymentSpecChange{
+				{Field: "env_vars[LOG_LEVEL]", From: map[string]interface{}{"name": "LOG_LEVEL", "value": "INFO"}, To: nil},
+				{Field: "env_vars[TIMEOUT]", From: nil, To: map[string]interface{}{"name": "TIMEOUT", "value": "10"}},
+				{Field: "env_vars[WORKERS].value", From: "2", To: "4"},
+				{Field: "resource_request.cpu_request", From: "1", To: "500m"},
+				{Field: "resource_request.max_replica", From: float64(2), To: float64(4)},
+			},
+		},
+		{
+			name: "transformer added",
+			modify: func(spec *DeploymentSpec) {
+				spec.Transformer = &Transformer{Enabled: true, Image: "ghcr.io/caraml-dev/transformer:1"}
+			},
+			want: []*DeploymentSpecChange{
+				{
+					Field: "transformer",
+					From:  nil,
+					To: map[string]interface{}{
+						"id":                  "",
+						"enabled":             true,
+						"version_endpoint_id": uuid.Nil.String(),
+						"transformer_type":    "",
+						"image":               "ghcr.io/caraml-dev/transformer:1",
+						"resource_request":    nil,
+						"env_vars":            nil,
+						"secrets":             nil,
+						"created_at":          "0001-01-01T00:00:00Z",
+						"updated_at":          "0001-01-01T00:00:00Z",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := base()
+			tt.modify(to)
+
+			changes, err := DiffDeploymentSpecs(base(), to)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, changes)
+		})
+	}
+}
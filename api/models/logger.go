@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"github.com/caraml-dev/merlin/pkg/transformer/spec"
 	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 )
@@ -74,6 +75,29 @@ func (plc *PredictionLoggerConfig) ToPredictionLogConfig() *spec.PredictionLogCo
 type LoggerConfig struct {
 	Enabled bool       `json:"enabled"`
 	Mode    LoggerMode `json:"mode"`
+	// SamplingRate is the fraction of requests to be logged, all requests are logged if not set
+	SamplingRate *float64 `json:"sampling_rate,omitempty"`
+	// AlwaysLogErrors logs failed requests even if they are not sampled
+	AlwaysLogErrors bool                  `json:"always_log_errors,omitempty"`
+	HeaderAllowlist []string              `json:"header_allowlist,omitempty"`
+	HeaderDenylist  []string              `json:"header_denylist,omitempty"`
+	RedactionRules  []rules.RedactionRule `json:"redaction_rules,omitempty"`
+	// Sinks are additional destinations the payloads are fanned out to, each with its own mode and rules
+	Sinks []rules.Sink `json:"sinks,omitempty"`
+	// HTTPSinkAuthSecretName is the name of the MLP secret holding the auth token of the webhook and otlp sinks
+	HTTPSinkAuthSecretName string `json:"http_sink_auth_secret_name,omitempty"`
+}
+
+// PayloadRules returns sampling, filtering and redaction rules applied by the inference logger
+func (lc *LoggerConfig) PayloadRules() *rules.Rules {
+	return &rules.Rules{
+		SamplingRate:    lc.SamplingRate,
+		AlwaysLogErrors: lc.AlwaysLogErrors,
+		HeaderAllowlist: lc.HeaderAllowlist,
+		HeaderDenylist:  lc.HeaderDenylist,
+		RedactionRules:  lc.RedactionRules,
+		Sinks:           lc.Sinks,
+	}
 }
 
 func (lc *LoggerConfig) SanitizeMode() {
@@ -34,6 +34,10 @@ type ModelEndpoint struct {
 	Environment     *Environment       `json:"environment" gorm:"references:Name"`
 	EnvironmentName string             `json:"environment_name"`
 	Protocol        protocol.Protocol  `json:"protocol" gorm:"protocol"`
+	// Rollout is the latest canary rollout of the model endpoint, if any
+	Rollout *ModelEndpointRollout `json:"rollout,omitempty" gorm:"rollout"`
+	// Experiment assigns the requests to version endpoints by their unit id, if any
+	Experiment *ModelEndpointExperiment `json:"experiment,omitempty" gorm:"experiment"`
 	CreatedUpdated
 }
 
@@ -52,7 +56,31 @@ func (me *ModelEndpoint) GetVersionEndpoint() *VersionEndpoint {
 // ModelEndpointRule describes model's endpoint traffic rule.
 type ModelEndpointRule struct {
 	Destination []*ModelEndpointRuleDestination `json:"destinations"`
-	Mirror      *VersionEndpoint                `json:"mirror,omitempty"`
+	// Routes are evaluated in order before the weighted destinations, the first matching route wins
+	Routes []*ModelEndpointRuleRoute `json:"routes,omitempty"`
+	// Mirror is the version endpoint receiving a copy of the requests, its responses are discarded
+	Mirror *VersionEndpoint `json:"mirror,omitempty"`
+	// MirrorPercentage is the percentage of the requests copied to the mirror, all requests are copied if not set
+	MirrorPercentage *float64 `json:"mirror_percentage,omitempty"`
+}
+
+// VersionEndpointIDs returns the IDs of the version endpoints receiving traffic from the rule
+func (rule *ModelEndpointRule) VersionEndpointIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, destination := range rule.Destination {
+		if !seen[destination.VersionEndpointID] {
+			seen[destination.VersionEndpointID] = true
+			ids = append(ids, destination.VersionEndpointID)
+		}
+	}
+	for _, route := range rule.Routes {
+		if !seen[route.VersionEndpointID] {
+			seen[route.VersionEndpointID] = true
+			ids = append(ids, route.VersionEndpointID)
+		}
+	}
+	return ids
 }
 
 func (rule ModelEndpointRule) Value() (driver.Value, error) {
@@ -0,0 +1,266 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+const (
+	// ExperimentBucketHeader is set by the ingress gateway to the bucket of the request's unit id
+	ExperimentBucketHeader = "x-merlin-experiment-bucket"
+	// ExperimentBuckets is the number of buckets the unit ids are hashed into, one per percent of traffic
+	ExperimentBuckets = 100
+
+	// thompsonSamples is the number of draws used to estimate the probability of each variant being the best
+	thompsonSamples = 10000
+)
+
+// ModelEndpointExperiment assigns the requests of the model endpoint to the variants of an experiment.
+//
+// The unit id of the request, read from UnitIDHeader, is hashed into one of ExperimentBuckets buckets and each variant
+// owns a contiguous range of buckets sized by its weight, so that a unit is always served by the same variant as long
+// as the weights don't change. Requests without unit id are split randomly by weight.
+type ModelEndpointExperiment struct {
+	Name         string               `json:"name"`
+	UnitIDHeader string               `json:"unit_id_header"`
+	Variants     []*ExperimentVariant `json:"variants"`
+	// Bandit adjusts the weights of the variants from their rewards, the weights are static if not set
+	Bandit *ExperimentBandit `json:"bandit,omitempty"`
+}
+
+// ExperimentVariant is a version endpoint receiving the weight percentage of the experiment's units
+type ExperimentVariant struct {
+	Name              string    `json:"name"`
+	VersionEndpointID uuid.UUID `json:"version_endpoint_id"`
+	Weight            int32     `json:"weight"`
+}
+
+// ExperimentBandit configures the Thompson sampling of the variants' weights
+type ExperimentBandit struct {
+	// MinWeight is the weight every variant keeps to continue exploring
+	MinWeight int32 `json:"min_weight"`
+	// UpdatedAt is set by the server when the weights were last adjusted
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ExperimentReward is the number of successes and failures reported for a variant of an experiment
+type ExperimentReward struct {
+	ModelEndpointID ID     `json:"model_endpoint_id" gorm:"primary_key"`
+	ExperimentName  string `json:"experiment_name" gorm:"primary_key"`
+	Variant         string `json:"variant" gorm:"primary_key"`
+	Successes       int64  `json:"successes"`
+	Failures        int64  `json:"failures"`
+	CreatedUpdated
+}
+
+func (ExperimentReward) TableName() string {
+	return "model_endpoint_experiment_rewards"
+}
+
+// ExperimentRewards are the rewards reported for the variants of a model endpoint's experiment
+type ExperimentRewards struct {
+	Rewards []*ExperimentReward `json:"rewards"`
+}
+
+func (e ModelEndpointExperiment) Value() (driver.Value, error) {
+	return json.Marshal(e)
+}
+
+func (e *ModelEndpointExperiment) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &e)
+}
+
+// Validate validates the experiment definition
+func (e *ModelEndpointExperiment) Validate() error {
+	if e.Name == "" {
+		return errors.New("name is required")
+	}
+	if e.UnitIDHeader == "" {
+		return errors.New("unit_id_header is required")
+	}
+	if len(e.Variants) < 2 {
+		return errors.New("experiment must have at least two variants")
+	}
+
+	var totalWeight int32
+	names := map[string]bool{}
+	versionEndpointIDs := map[uuid.UUID]bool{}
+	for i, variant := range e.Variants {
+		if variant.Name == "" {
+			return fmt.Errorf("variant %d: name is required", i)
+		}
+		if names[variant.Name] {
+			return fmt.Errorf("variant %d: duplicate name %q", i, variant.Name)
+		}
+		names[variant.Name] = true
+
+		if variant.VersionEndpointID == uuid.Nil {
+			return fmt.Errorf("variant %d: version_endpoint_id is required", i)
+		}
+		if versionEndpointIDs[variant.VersionEndpointID] {
+			return fmt.Errorf("variant %d: version endpoint %s is already a variant", i, variant.VersionEndpointID)
+		}
+		versionEndpointIDs[variant.VersionEndpointID] = true
+
+		if variant.Weight < 0 || variant.Weight > 100 {
+			return fmt.Errorf("variant %d: weight must be between 0 and 100", i)
+		}
+		totalWeight += variant.Weight
+	}
+	if totalWeight != 100 {
+		return fmt.Errorf("total weight of the variants must be 100, got %d", totalWeight)
+	}
+
+	if e.Bandit != nil {
+		if e.Bandit.MinWeight < 0 || int(e.Bandit.MinWeight)*len(e.Variants) > 100 {
+			return fmt.Errorf("min_weight must be between 0 and %d", 100/len(e.Variants))
+		}
+	}
+	return nil
+}
+
+// Variant returns the variant with the given name, nil if there is none
+func (e *ModelEndpointExperiment) Variant(name string) *ExperimentVariant {
+	for _, variant := range e.Variants {
+		if variant.Name == name {
+			return variant
+		}
+	}
+	return nil
+}
+
+// Weights returns the weights of the variants in order
+func (e *ModelEndpointExperiment) Weights() []int32 {
+	weights := make([]int32, 0, len(e.Variants))
+	for _, variant := range e.Variants {
+		weights = append(weights, variant.Weight)
+	}
+	return weights
+}
+
+// ThompsonWeights returns the weights of the variants proportional to the probability of each variant having the
+// highest success rate, estimated by sampling Beta(1 + successes, 1 + failures). Every variant keeps the bandit's
+// MinWeight and the weights sum up to 100.
+func (e *ModelEndpointExperiment) ThompsonWeights(rewards []*ExperimentReward, src rand.Source) []int32 {
+	rewardByVariant := map[string]*ExperimentReward{}
+	for _, reward := range rewards {
+		rewardByVariant[reward.Variant] = reward
+	}
+
+	distributions := make([]distuv.Beta, 0, len(e.Variants))
+	for _, variant := range e.Variants {
+		distribution := distuv.Beta{Alpha: 1, Beta: 1, Src: src}
+		if reward, ok := rewardByVariant[variant.Name]; ok {
+			distribution.Alpha += float64(reward.Successes)
+			distribution.Beta += float64(reward.Failures)
+		}
+		distributions = append(distributions, distribution)
+	}
+
+	wins := make([]float64, len(distributions))
+	for i := 0; i < thompsonSamples; i++ {
+		best, bestSample := 0, math.Inf(-1)
+		for j, distribution := range distributions {
+			if sample := distribution.Rand(); sample > bestSample {
+				best, bestSample = j, sample
+			}
+		}
+		wins[best]++
+	}
+
+	var minWeight int32
+	if e.Bandit != nil {
+		minWeight = e.Bandit.MinWeight
+	}
+	explorable := 100 - minWeight*int32(len(e.Variants))
+	shares := make([]float64, len(wins))
+	for i := range wins {
+		shares[i] = wins[i] / thompsonSamples * float64(explorable)
+	}
+
+	weights := apportion(shares, explorable)
+	for i := range weights {
+		weights[i] += minWeight
+	}
+	return weights
+}
+
+// apportion rounds the shares down and hands out the remaining units by the largest remainders, the result sums up to total
+func apportion(shares []float64, total int32) []int32 {
+	result := make([]int32, len(shares))
+	order := make([]int, len(shares))
+	remaining := total
+	for i, share := range shares {
+		result[i] = int32(math.Floor(share))
+		remaining -= result[i]
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return shares[order[a]]-math.Floor(shares[order[a]]) > shares[order[b]]-math.Floor(shares[order[b]])
+	})
+	for i := 0; remaining > 0; i = (i + 1) % len(order) {
+		result[order[i]]++
+		remaining--
+	}
+	return result
+}
+
+// ExperimentBucket returns the bucket of the unit id, the 32-bit FNV-1a hash of the unit id modulo ExperimentBuckets.
+// It must stay in sync with the hash computed by the ingress gateway.
+func ExperimentBucket(unitID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(unitID))
+	return int(h.Sum32() % ExperimentBuckets)
+}
+
+// ExperimentBucketRanges returns the [start, end) range of buckets owned by each weight, weights must sum up to ExperimentBuckets
+func ExperimentBucketRanges(weights []int32) [][2]int {
+	ranges := make([][2]int, 0, len(weights))
+	start := 0
+	for _, weight := range weights {
+		ranges = append(ranges, [2]int{start, start + int(weight)})
+		start += int(weight)
+	}
+	return ranges
+}
+
+// ExperimentBucketRegex returns the regex matching the buckets in [start, end)
+func ExperimentBucketRegex(start int, end int) string {
+	buckets := make([]string, 0, end-start)
+	for bucket := start; bucket < end; bucket++ {
+		buckets = append(buckets, strconv.Itoa(bucket))
+	}
+	return fmt.Sprintf("^(%s)$", strings.Join(buckets, "|"))
+}
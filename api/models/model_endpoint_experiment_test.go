@@ -0,0 +1,200 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/rand"
+)
+
+func TestModelEndpointExperiment_Validate(t *testing.T) {
+	controlID := uuid.New()
+	treatmentID := uuid.New()
+
+	tests := []struct {
+		name       string
+		experiment *ModelEndpointExperiment
+		wantErr    bool
+	}{
+		{
+			name: "valid",
+			experiment: &ModelEndpointExperiment{
+				Name:         "exp",
+				UnitIDHeader: "X-User-Id",
+				Variants: []*ExperimentVariant{
+					{Name: "control", VersionEndpointID: controlID, Weight: 50},
+					{Name: "treatment", VersionEndpointID: treatmentID, Weight: 50},
+				},
+				Bandit: &ExperimentBandit{MinWeight: 10},
+			},
+		},
+		{
+			name: "missing unit id header",
+			experiment: &ModelEndpointExperiment{
+				Name: "exp",
+				Variants: []*ExperimentVariant{
+					{Name: "control", VersionEndpointID: controlID, Weight: 50},
+					{Name: "treatment", VersionEndpointID: treatmentID, Weight: 50},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "single variant",
+			experiment: &ModelEndpointExperiment{
+				Name:         "exp",
+				UnitIDHeader: "X-User-Id",
+				Variants:     []*ExperimentVariant{{Name: "control", VersionEndpointID: controlID, Weight: 100}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate variant name",
+			experiment: &ModelEndpointExperiment{
+				Name:         "exp",
+				UnitIDHeader: "X-User-Id",
+				Variants: []*ExperimentVariant{
+					{Name: "control", VersionEndpointID: controlID, Weight: 50},
+					{Name: "control", VersionEndpointID: treatmentID, Weight: 50},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate version endpoint",
+			experiment: &ModelEndpointExperiment{
+				Name:         "exp",
+				UnitIDHeader: "X-User-Id",
+				Variants: []*ExperimentVariant{
+					{Name: "control", VersionEndpointID: controlID, Weight: 50},
+					{Name: "treatment", VersionEndpointID: controlID, Weight: 50},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "weights don't sum up to 100",
+			experiment: &ModelEndpointExperiment{
+				Name:         "exp",
+				UnitIDHeader: "X-User-Id",
+				Variants: []*ExperimentVariant{
+					{Name: "control", VersionEndpointID: controlID, Weight: 50},
+					{Name: "treatment", VersionEndpointID: treatmentID, Weight: 40},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "min weight too large",
+			experiment: &ModelEndpointExperiment{
+				Name:         "exp",
+				UnitIDHeader: "X-User-Id",
+				Variants: []*ExperimentVariant{
+					{Name: "control", VersionEndpointID: controlID, Weight: 50},
+					{Name: "treatment", VersionEndpointID: treatmentID, Weight: 50},
+				},
+				Bandit: &ExperimentBandit{MinWeight: 51},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.experiment.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestModelEndpointExperiment_ThompsonWeights(t *testing.T) {
+	experiment := &ModelEndpointExperiment{
+		Variants: []*ExperimentVariant{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Bandit:   &ExperimentBandit{MinWeight: 5},
+	}
+
+	tests := []struct {
+		name    string
+		rewards []*ExperimentReward
+		check   func(t *testing.T, weights []int32)
+	}{
+		{
+			name: "no rewards explores evenly",
+			check: func(t *testing.T, weights []int32) {
+				for _, weight := range weights {
+					assert.InDelta(t, 33, weight, 3)
+				}
+			},
+		},
+		{
+			name: "best variant gets the most traffic",
+			rewards: []*ExperimentReward{
+				{Variant: "a", Successes: 100, Failures: 900},
+				{Variant: "b", Successes: 300, Failures: 700},
+				{Variant: "c", Successes: 100, Failures: 900},
+			},
+			check: func(t *testing.T, weights []int32) {
+				assert.Equal(t, []int32{5, 90, 5}, weights)
+			},
+		},
+		{
+			name: "rewards of unknown variants are ignored",
+			rewards: []*ExperimentReward{
+				{Variant: "d", Successes: 1000},
+				{Variant: "c", Successes: 1000, Failures: 10},
+			},
+			check: func(t *testing.T, weights []int32) {
+				assert.GreaterOrEqual(t, weights[2], int32(85))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights := experiment.ThompsonWeights(tt.rewards, rand.NewSource(1))
+			var total int32
+			for _, weight := range weights {
+				assert.GreaterOrEqual(t, weight, experiment.Bandit.MinWeight)
+				total += weight
+			}
+			assert.Equal(t, int32(100), total)
+			tt.check(t, weights)
+		})
+	}
+}
+
+func TestApportion(t *testing.T) {
+	assert.Equal(t, []int32{34, 33, 33}, apportion([]float64{100.0 / 3, 100.0 / 3, 100.0 / 3}, 100))
+	assert.Equal(t, []int32{1, 2, 7}, apportion([]float64{0.6, 2.3, 7.1}, 10))
+	assert.Equal(t, []int32{0, 0}, apportion([]float64{0, 0}, 0))
+}
+
+func TestExperimentBucketRanges(t *testing.T) {
+	assert.Equal(t, [][2]int{{0, 20}, {20, 20}, {20, 100}}, ExperimentBucketRanges([]int32{20, 0, 80}))
+	assert.Equal(t, "^(3|4|5)$", ExperimentBucketRegex(3, 6))
+}
+
+func TestExperimentBucket(t *testing.T) {
+	assert.Equal(t, ExperimentBucket("user-1"), ExperimentBucket("user-1"))
+	// FNV-1a 32 of the empty string is the offset basis 2166136261
+	assert.Equal(t, 61, ExperimentBucket(""))
+	// FNV-1a 32 of "a" is 0xe40c292c
+	assert.Equal(t, 0xe40c292c%ExperimentBuckets, ExperimentBucket("a"))
+}
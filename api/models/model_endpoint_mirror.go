@@ -0,0 +1,259 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// MirroredLogHeader is set on mirrored requests by the mirror routes of the model endpoint, and added by the inference
+	// logger to the request headers of the inference log of a mirrored request
+	MirroredLogHeader = "X-Merlin-Mirrored"
+
+	// DefaultMirrorAgreementTolerance is the default maximum absolute difference of two scores that agree
+	DefaultMirrorAgreementTolerance = 1e-6
+)
+
+// ModelEndpointMirrorLog is the logged response of a request served by a model endpoint, either by the primary
+// version endpoint or by the mirror. The responses of a request and its mirror share the same request id.
+type ModelEndpointMirrorLog struct {
+	RequestID      string
+	ModelVersion   string
+	Mirrored       bool
+	StatusCode     int
+	ResponseBody   string
+	EventTimestamp time.Time
+}
+
+// IsSuccess returns true if the response isn't an error, status code 0 is either gRPC OK or an unlogged HTTP response
+func (l *ModelEndpointMirrorLog) IsSuccess() bool {
+	return l.StatusCode == 0 || (l.StatusCode >= 200 && l.StatusCode < 300)
+}
+
+// MirrorReport compares the responses of the mirrors of a model endpoint with the primary responses of the same requests
+type MirrorReport struct {
+	ModelEndpointID ID                  `json:"model_endpoint_id"`
+	StartTime       time.Time           `json:"start_time"`
+	EndTime         time.Time           `json:"end_time"`
+	Tolerance       float64             `json:"tolerance"`
+	Mirrors         []*MirrorComparison `json:"mirrors"`
+}
+
+// MirrorComparison compares the responses of a mirrored model version with the primary responses.
+//
+// The primary error rate and the agreement rate are computed over the matched requests, i.e. the mirrored requests
+// whose primary response is logged, while the mirror error rate is computed over all mirrored requests. Two successful
+// responses agree if their predictions have the same structure, the same non-numeric values and all the scores differ
+// by at most the tolerance.
+type MirrorComparison struct {
+	ModelVersion     string                  `json:"model_version"`
+	MirroredRequests int                     `json:"mirrored_requests"`
+	MatchedRequests  int                     `json:"matched_requests"`
+	AgreementRate    *float64                `json:"agreement_rate,omitempty"`
+	PrimaryErrorRate *float64                `json:"primary_error_rate,omitempty"`
+	MirrorErrorRate  *float64                `json:"mirror_error_rate,omitempty"`
+	ScoreDelta       *ScoreDeltaDistribution `json:"score_delta,omitempty"`
+}
+
+// ScoreDeltaDistribution is the distribution of the absolute differences of the primary and mirrored scores
+type ScoreDeltaDistribution struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max"`
+}
+
+// NewMirrorComparisons compares the mirrored logs with the primary logs of the same request ids, per mirrored model version
+func NewMirrorComparisons(mirroredLogs []*ModelEndpointMirrorLog, primaryLogs []*ModelEndpointMirrorLog, tolerance float64) []*MirrorComparison {
+	primaryByRequestID := map[string]*ModelEndpointMirrorLog{}
+	for _, primaryLog := range primaryLogs {
+		primaryByRequestID[primaryLog.RequestID] = primaryLog
+	}
+
+	type counts struct {
+		mirrored, matched, bothSucceeded, agreed, primaryErrors, mirrorErrors int
+		deltas                                                                []float64
+	}
+	countsByVersion := map[string]*counts{}
+	for _, mirroredLog := range mirroredLogs {
+		c, ok := countsByVersion[mirroredLog.ModelVersion]
+		if !ok {
+			c = &counts{}
+			countsByVersion[mirroredLog.ModelVersion] = c
+		}
+
+		c.mirrored++
+		if !mirroredLog.IsSuccess() {
+			c.mirrorErrors++
+		}
+
+		primaryLog, ok := primaryByRequestID[mirroredLog.RequestID]
+		if !ok {
+			continue
+		}
+		c.matched++
+		if !primaryLog.IsSuccess() {
+			c.primaryErrors++
+		}
+		if !primaryLog.IsSuccess() || !mirroredLog.IsSuccess() {
+			continue
+		}
+
+		c.bothSucceeded++
+		agree, deltas := ComparePredictions(primaryLog.ResponseBody, mirroredLog.ResponseBody, tolerance)
+		if agree {
+			c.agreed++
+		}
+		c.deltas = append(c.deltas, deltas...)
+	}
+
+	comparisons := make([]*MirrorComparison, 0, len(countsByVersion))
+	for modelVersion, c := range countsByVersion {
+		comparisons = append(comparisons, &MirrorComparison{
+			ModelVersion:     modelVersion,
+			MirroredRequests: c.mirrored,
+			MatchedRequests:  c.matched,
+			AgreementRate:    ratio(c.agreed, c.bothSucceeded),
+			PrimaryErrorRate: ratio(c.primaryErrors, c.matched),
+			MirrorErrorRate:  ratio(c.mirrorErrors, c.mirrored),
+			ScoreDelta:       newScoreDeltaDistribution(c.deltas),
+		})
+	}
+	sort.Slice(comparisons, func(i, j int) bool {
+		return comparisons[i].ModelVersion < comparisons[j].ModelVersion
+	})
+	return comparisons
+}
+
+// ComparePredictions compares the predictions of two response bodies and returns whether they agree and the absolute
+// differences of their scores.
+//
+// The predictions are the "predictions" field of HTTP JSON responses, the "predictionResultTable" field of UPI responses
+// or otherwise the whole body. Bodies which aren't JSON agree only if they're equal.
+func ComparePredictions(primaryBody string, mirroredBody string, tolerance float64) (bool, []float64) {
+	primary, primaryOk := predictions(primaryBody)
+	mirrored, mirroredOk := predictions(mirroredBody)
+	if !primaryOk || !mirroredOk {
+		return primaryBody == mirroredBody, nil
+	}
+
+	var deltas []float64
+	agree := compareValues(primary, mirrored, tolerance, &deltas)
+	return agree, deltas
+}
+
+func predictions(body string) (interface{}, bool) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return nil, false
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		for _, field := range []string{"predictions", "predictionResultTable"} {
+			if predictions, ok := object[field]; ok {
+				return predictions, true
+			}
+		}
+	}
+	return value, true
+}
+
+// compareValues walks both JSON values and appends the absolute differences of the numbers at the same position to deltas
+func compareValues(primary interface{}, mirrored interface{}, tolerance float64, deltas *[]float64) bool {
+	switch primaryValue := primary.(type) {
+	case float64:
+		mirroredValue, ok := mirrored.(float64)
+		if !ok {
+			return false
+		}
+		delta := math.Abs(primaryValue - mirroredValue)
+		*deltas = append(*deltas, delta)
+		return delta <= tolerance
+
+	case []interface{}:
+		mirroredValue, ok := mirrored.([]interface{})
+		if !ok || len(primaryValue) != len(mirroredValue) {
+			return false
+		}
+		agree := true
+		for i := range primaryValue {
+			agree = compareValues(primaryValue[i], mirroredValue[i], tolerance, deltas) && agree
+		}
+		return agree
+
+	case map[string]interface{}:
+		mirroredValue, ok := mirrored.(map[string]interface{})
+		if !ok || len(primaryValue) != len(mirroredValue) {
+			return false
+		}
+		agree := true
+		for key, value := range primaryValue {
+			other, ok := mirroredValue[key]
+			if !ok {
+				return false
+			}
+			agree = compareValues(value, other, tolerance, deltas) && agree
+		}
+		return agree
+
+	default:
+		return primary == mirrored
+	}
+}
+
+func newScoreDeltaDistribution(deltas []float64) *ScoreDeltaDistribution {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	sorted := append([]float64(nil), deltas...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, delta := range sorted {
+		sum += delta
+	}
+
+	return &ScoreDeltaDistribution{
+		Count: len(sorted),
+		Mean:  sum / float64(len(sorted)),
+		P50:   percentile(sorted, 0.5),
+		P90:   percentile(sorted, 0.9),
+		P99:   percentile(sorted, 0.99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func ratio(numerator int, denominator int) *float64 {
+	if denominator == 0 {
+		return nil
+	}
+	r := float64(numerator) / float64(denominator)
+	return &r
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComparePredictions(t *testing.T) {
+	tests := []struct {
+		name       string
+		primary    string
+		mirrored   string
+		tolerance  float64
+		wantAgree  bool
+		wantDeltas []float64
+	}{
+		{
+			name:       "same predictions",
+			primary:    `{"predictions":[0.5,0.25]}`,
+			mirrored:   `{"predictions":[0.5,0.25],"model_version":"2"}`,
+			wantAgree:  true,
+			wantDeltas: []float64{0, 0},
+		},
+		{
+			name:       "scores within tolerance",
+			primary:    `{"predictions":[[0.5,1]]}`,
+			mirrored:   `{"predictions":[[0.625,1]]}`,
+			tolerance:  0.2,
+			wantAgree:  true,
+			wantDeltas: []float64{0.125, 0},
+		},
+		{
+			name:       "scores differ",
+			primary:    `{"predictions":[0.5,0.25]}`,
+			mirrored:   `{"predictions":[0.75,0.25]}`,
+			wantDeltas: []float64{0.25, 0},
+		},
+		{
+			name:       "labels differ",
+			primary:    `{"predictions":[{"label":"cat","score":0.9}]}`,
+			mirrored:   `{"predictions":[{"label":"dog","score":0.9}]}`,
+			wantDeltas: []float64{0},
+		},
+		{
+			name:     "different number of predictions",
+			primary:  `{"predictions":[0.5,0.25]}`,
+			mirrored: `{"predictions":[0.5]}`,
+		},
+		{
+			name:       "upi prediction result table",
+			primary:    `{"predictionResultTable":{"rows":[{"values":[{"doubleValue":0.5}]}]},"metadata":{"models":[{"version":"1"}]}}`,
+			mirrored:   `{"predictionResultTable":{"rows":[{"values":[{"doubleValue":0.5}]}]},"metadata":{"models":[{"version":"2"}]}}`,
+			wantAgree:  true,
+			wantDeltas: []float64{0},
+		},
+		{
+			name:      "same non json body",
+			primary:   "ok",
+			mirrored:  "ok",
+			wantAgree: true,
+		},
+		{
+			name:     "non json body",
+			primary:  `{"predictions":[0.5]}`,
+			mirrored: "<html>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agree, deltas := ComparePredictions(tt.primary, tt.mirrored, tt.tolerance)
+			assert.Equal(t, tt.wantAgree, agree)
+			assert.Equal(t, tt.wantDeltas, deltas)
+		})
+	}
+}
+
+func TestNewMirrorComparisons(t *testing.T) {
+	primaryLogs := []*ModelEndpointMirrorLog{
+		{RequestID: "1", ModelVersion: "1", StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+		{RequestID: "2", ModelVersion: "1", StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+		{RequestID: "3", ModelVersion: "1", StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+		{RequestID: "4", ModelVersion: "1", StatusCode: 500, ResponseBody: "internal error"},
+		{RequestID: "5", ModelVersion: "1", StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+	}
+	mirroredLogs := []*ModelEndpointMirrorLog{
+		{RequestID: "1", ModelVersion: "2", Mirrored: true, StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+		{RequestID: "2", ModelVersion: "2", Mirrored: true, StatusCode: 200, ResponseBody: `{"predictions":[0.7]}`},
+		{RequestID: "3", ModelVersion: "2", Mirrored: true, StatusCode: 503, ResponseBody: "unavailable"},
+		{RequestID: "4", ModelVersion: "2", Mirrored: true, StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+		{RequestID: "6", ModelVersion: "2", Mirrored: true, StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+		{RequestID: "5", ModelVersion: "3", Mirrored: true, StatusCode: 200, ResponseBody: `{"predictions":[0.5]}`},
+	}
+
+	comparisons := NewMirrorComparisons(mirroredLogs, primaryLogs, DefaultMirrorAgreementTolerance)
+	require.Len(t, comparisons, 2)
+
+	version2 := comparisons[0]
+	assert.Equal(t, "2", version2.ModelVersion)
+	assert.Equal(t, 5, version2.MirroredRequests)
+	assert.Equal(t, 4, version2.MatchedRequests)
+	assert.Equal(t, 0.5, *version2.AgreementRate)
+	assert.Equal(t, 0.25, *version2.PrimaryErrorRate)
+	assert.Equal(t, 0.2, *version2.MirrorErrorRate)
+	require.NotNil(t, version2.ScoreDelta)
+	assert.Equal(t, 2, version2.ScoreDelta.Count)
+	assert.Equal(t, 0.0, version2.ScoreDelta.P50)
+	assert.InDelta(t, 0.2, version2.ScoreDelta.Max, 1e-9)
+	assert.InDelta(t, 0.1, version2.ScoreDelta.Mean, 1e-9)
+
+	version3 := comparisons[1]
+	assert.Equal(t, "3", version3.ModelVersion)
+	assert.Equal(t, 1, version3.MatchedRequests)
+	assert.Equal(t, 1.0, *version3.AgreementRate)
+
+	assert.Empty(t, NewMirrorComparisons(nil, primaryLogs, DefaultMirrorAgreementTolerance))
+}
+
+func TestNewScoreDeltaDistribution(t *testing.T) {
+	assert.Nil(t, newScoreDeltaDistribution(nil))
+
+	deltas := make([]float64, 0, 100)
+	for i := 100; i > 0; i-- {
+		deltas = append(deltas, float64(i))
+	}
+	assert.Equal(t, &ScoreDeltaDistribution{Count: 100, Mean: 50.5, P50: 50, P90: 90, P99: 99, Max: 100}, newScoreDeltaDistribution(deltas))
+}
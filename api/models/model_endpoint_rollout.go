@@ -0,0 +1,167 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RolloutPhase string
+
+const (
+	RolloutPhaseProgressing RolloutPhase = "progressing"
+	RolloutPhasePromoted    RolloutPhase = "promoted"
+	RolloutPhaseRolledBack  RolloutPhase = "rolled_back"
+)
+
+// DefaultRolloutLatencyPercentile is the latency percentile compared against MaxLatencyMs if not specified
+const DefaultRolloutLatencyPercentile = 0.99
+
+// ModelEndpointRollout describes a progressive canary rollout of a version endpoint behind the model endpoint.
+//
+// The canary receives the weight of each step for the step's duration while the rest of the traffic stays on the
+// stable version endpoint. The canary is promoted to 100% of the traffic after the last step, or rolled back to the
+// stable version endpoint as soon as the success criteria are breached.
+type ModelEndpointRollout struct {
+	// StableVersionEndpointID is the version endpoint serving the model endpoint when the rollout started
+	StableVersionEndpointID uuid.UUID `json:"stable_version_endpoint_id"`
+	// CanaryVersionEndpointID is the version endpoint being rolled out
+	CanaryVersionEndpointID uuid.UUID               `json:"canary_version_endpoint_id"`
+	Steps                   []*RolloutStep          `json:"steps"`
+	SuccessCriteria         *RolloutSuccessCriteria `json:"success_criteria,omitempty"`
+	// Status is set by the server once the rollout has started
+	Status *RolloutStatus `json:"status,omitempty"`
+}
+
+// RolloutStep is the percentage of the traffic routed to the canary and for how long, e.g. "10m"
+type RolloutStep struct {
+	Weight   int32  `json:"weight"`
+	Duration string `json:"duration"`
+}
+
+// RolloutSuccessCriteria are the thresholds of the canary metrics, the rollout is rolled back if any is exceeded
+type RolloutSuccessCriteria struct {
+	// MaxErrorRate is the maximum ratio of 5xx responses, between 0 and 1
+	MaxErrorRate *float64 `json:"max_error_rate,omitempty"`
+	// MaxLatencyMs is the maximum latency at LatencyPercentile in milliseconds
+	MaxLatencyMs      *float64 `json:"max_latency_ms,omitempty"`
+	LatencyPercentile float64  `json:"latency_percentile,omitempty"`
+}
+
+// RolloutStatus is the progress of the rollout
+type RolloutStatus struct {
+	Phase         RolloutPhase `json:"phase"`
+	CurrentStep   int          `json:"current_step"`
+	StepStartedAt time.Time    `json:"step_started_at"`
+	Message       string       `json:"message,omitempty"`
+}
+
+// RolloutAnalysis is the canary metrics over the current step, nil if there is no data
+type RolloutAnalysis struct {
+	ErrorRate *float64
+	LatencyMs *float64
+}
+
+func (r ModelEndpointRollout) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *ModelEndpointRollout) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &r)
+}
+
+// InProgress returns true if the rollout has started and is neither promoted nor rolled back
+func (r *ModelEndpointRollout) InProgress() bool {
+	return r != nil && r.Status != nil && r.Status.Phase == RolloutPhaseProgressing
+}
+
+// Validate validates the rollout plan
+func (r *ModelEndpointRollout) Validate() error {
+	if r.CanaryVersionEndpointID == uuid.Nil {
+		return errors.New("canary_version_endpoint_id is required")
+	}
+	if len(r.Steps) == 0 {
+		return errors.New("rollout must have at least one step")
+	}
+	for i, step := range r.Steps {
+		if step.Weight <= 0 || step.Weight >= 100 {
+			return fmt.Errorf("step %d: weight must be between 1 and 99", i)
+		}
+		if i > 0 && step.Weight <= r.Steps[i-1].Weight {
+			return fmt.Errorf("step %d: weight must be greater than the previous step", i)
+		}
+		if _, err := step.ParseDuration(); err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+	}
+
+	if criteria := r.SuccessCriteria; criteria != nil {
+		if criteria.MaxErrorRate != nil && (*criteria.MaxErrorRate < 0 || *criteria.MaxErrorRate > 1) {
+			return errors.New("max_error_rate must be between 0 and 1")
+		}
+		if criteria.MaxLatencyMs != nil && *criteria.MaxLatencyMs <= 0 {
+			return errors.New("max_latency_ms must be positive")
+		}
+		if criteria.LatencyPercentile < 0 || criteria.LatencyPercentile >= 1 {
+			return errors.New("latency_percentile must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// ParseDuration returns the duration of the step
+func (s *RolloutStep) ParseDuration() (time.Duration, error) {
+	duration, err := time.ParseDuration(s.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s.Duration, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %q", s.Duration)
+	}
+	return duration, nil
+}
+
+// Percentile returns the latency percentile, defaulting to DefaultRolloutLatencyPercentile
+func (c *RolloutSuccessCriteria) Percentile() float64 {
+	if c.LatencyPercentile == 0 {
+		return DefaultRolloutLatencyPercentile
+	}
+	return c.LatencyPercentile
+}
+
+// Breach returns the reason the analysis breaches the success criteria, empty if it doesn't
+func (c *RolloutSuccessCriteria) Breach(analysis *RolloutAnalysis) string {
+	if c == nil || analysis == nil {
+		return ""
+	}
+	if c.MaxErrorRate != nil && analysis.ErrorRate != nil && *analysis.ErrorRate > *c.MaxErrorRate {
+		return fmt.Sprintf("error rate %.4f exceeds %.4f", *analysis.ErrorRate, *c.MaxErrorRate)
+	}
+	if c.MaxLatencyMs != nil && analysis.LatencyMs != nil && *analysis.LatencyMs > *c.MaxLatencyMs {
+		return fmt.Sprintf("p%g latency %.1fms exceeds %.1fms", c.Percentile()*100, *analysis.LatencyMs, *c.MaxLatencyMs)
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelEndpointRollout_Validate(t *testing.T) {
+	canaryID := uuid.New()
+	negative := -0.1
+
+	tests := []struct {
+		name    string
+		rollout *ModelEndpointRollout
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			rollout: &ModelEndpointRollout{
+				CanaryVersionEndpointID: canaryID,
+				Steps:                   []*RolloutStep{{Weight: 10, Duration: "5m"}, {Weight: 50, Duration: "1h"}},
+			},
+		},
+		{
+			name:    "missing canary",
+			rollout: &ModelEndpointRollout{Steps: []*RolloutStep{{Weight: 10, Duration: "5m"}}},
+			wantErr: true,
+		},
+		{
+			name:    "no steps",
+			rollout: &ModelEndpointRollout{CanaryVersionEndpointID: canaryID},
+			wantErr: true,
+		},
+		{
+			name: "weight out of range",
+			rollout: &ModelEndpointRollout{
+				CanaryVersionEndpointID: canaryID,
+				Steps:                   []*RolloutStep{{Weight: 100, Duration: "5m"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight not increasing",
+			rollout: &ModelEndpointRollout{
+				CanaryVersionEndpointID: canaryID,
+				Steps:                   []*RolloutStep{{Weight: 50, Duration: "5m"}, {Weight: 10, Duration: "5m"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid duration",
+			rollout: &ModelEndpointRollout{
+				CanaryVersionEndpointID: canaryID,
+				Steps:                   []*RolloutStep{{Weight: 10, Duration: "5 minutes"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid success criteria",
+			rollout: &ModelEndpointRollout{
+				CanaryVersionEndpointID: canaryID,
+				Steps:                   []*RolloutStep{{Weight: 10, Duration: "5m"}},
+				SuccessCriteria:         &RolloutSuccessCriteria{MaxErrorRate: &negative},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rollout.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRolloutSuccessCriteria_Breach(t *testing.T) {
+	maxErrorRate := 0.05
+	maxLatencyMs := 100.0
+	lowErrorRate := 0.01
+	highErrorRate := 0.1
+	highLatencyMs := 250.0
+
+	criteria := &RolloutSuccessCriteria{MaxErrorRate: &maxErrorRate, MaxLatencyMs: &maxLatencyMs}
+
+	tests := []struct {
+		name     string
+		criteria *RolloutSuccessCriteria
+		analysis *RolloutAnalysis
+		want     string
+	}{
+		{
+			name:     "within criteria",
+			criteria: criteria,
+			analysis: &RolloutAnalysis{ErrorRate: &lowErrorRate},
+		},
+		{
+			name:     "no data",
+			criteria: criteria,
+			analysis: &RolloutAnalysis{},
+		},
+		{
+			name:     "no criteria",
+			analysis: &RolloutAnalysis{ErrorRate: &highErrorRate},
+		},
+		{
+			name:     "error rate exceeded",
+			criteria: criteria,
+			analysis: &RolloutAnalysis{ErrorRate: &highErrorRate},
+			want:     "error rate 0.1000 exceeds 0.0500",
+		},
+		{
+			name:     "latency exceeded",
+			criteria: criteria,
+			analysis: &RolloutAnalysis{ErrorRate: &lowErrorRate, LatencyMs: &highLatencyMs},
+			want:     "p99 latency 250.0ms exceeds 100.0ms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.criteria.Breach(tt.analysis))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/caraml-dev/merlin/pkg/protocol"
+)
+
+// CookieHeader is the header the cookie matches are applied to
+const CookieHeader = "cookie"
+
+// ModelEndpointRuleRoute pins the requests matching all of its conditions to a single version endpoint.
+//
+// Header names are case-insensitive. Source labels match the labels of the workload sending the request and only
+// apply to requests coming through the mesh.
+type ModelEndpointRuleRoute struct {
+	Name         string                  `json:"name,omitempty"`
+	Headers      map[string]*StringMatch `json:"headers,omitempty"`
+	Cookies      map[string]string       `json:"cookies,omitempty"`
+	QueryParams  map[string]*StringMatch `json:"query_params,omitempty"`
+	SourceLabels map[string]string       `json:"source_labels,omitempty"`
+
+	VersionEndpointID uuid.UUID        `json:"version_endpoint_id"`
+	VersionEndpoint   *VersionEndpoint `json:"version_endpoint"`
+}
+
+// StringMatch matches a string value, exactly one of its fields must be set
+type StringMatch struct {
+	Exact  string `json:"exact,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Regex  string `json:"regex,omitempty"`
+}
+
+// Validate validates the route's conditions against the protocol of the model endpoint
+func (r *ModelEndpointRuleRoute) Validate(protocolValue protocol.Protocol) error {
+	if r.VersionEndpointID == uuid.Nil {
+		return errors.New("version_endpoint_id is required")
+	}
+	if len(r.Headers) == 0 && len(r.Cookies) == 0 && len(r.QueryParams) == 0 && len(r.SourceLabels) == 0 {
+		return errors.New("route must have at least one condition")
+	}
+
+	for name, match := range r.Headers {
+		if name == "" {
+			return errors.New("header name must not be empty")
+		}
+		if err := match.Validate(); err != nil {
+			return fmt.Errorf("header %s: %w", name, err)
+		}
+	}
+
+	if len(r.Cookies) > 0 {
+		if protocolValue == protocol.UpiV1 {
+			return fmt.Errorf("cookies are not supported by %s protocol", protocolValue)
+		}
+		// all the matches of a header are rendered into a single regex which can't express multiple cookies in any order
+		if len(r.Cookies) > 1 {
+			return errors.New("only one cookie can be matched per route")
+		}
+		for name := range r.Headers {
+			if strings.EqualFold(name, CookieHeader) {
+				return errors.New("cookies can't be matched together with the cookie header")
+			}
+		}
+		for name := range r.Cookies {
+			if name == "" {
+				return errors.New("cookie name must not be empty")
+			}
+		}
+	}
+
+	if len(r.QueryParams) > 0 {
+		if protocolValue == protocol.UpiV1 {
+			return fmt.Errorf("query params are not supported by %s protocol", protocolValue)
+		}
+		for name, match := range r.QueryParams {
+			if name == "" {
+				return errors.New("query param name must not be empty")
+			}
+			if err := match.Validate(); err != nil {
+				return fmt.Errorf("query param %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate validates that exactly one of the fields is set and the regex compiles
+func (m *StringMatch) Validate() error {
+	if m == nil {
+		return errors.New("match must be set")
+	}
+
+	set := 0
+	for _, value := range []string{m.Exact, m.Prefix, m.Regex} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of exact, prefix or regex must be set")
+	}
+
+	if m.Regex != "" {
+		if _, err := regexp.Compile(m.Regex); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+	return nil
+}
+
+// CookieRegex returns the regex matching the cookie header containing the cookie with the exact value
+func CookieRegex(name string, value string) string {
+	return fmt.Sprintf("^(.*?;\\s*)?%s=%s(;.*)?$", regexp.QuoteMeta(name), regexp.QuoteMeta(value))
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caraml-dev/merlin/pkg/protocol"
+)
+
+func TestModelEndpointRuleRoute_Validate(t *testing.T) {
+	versionEndpointID := uuid.New()
+
+	tests := []struct {
+		name     string
+		route    *ModelEndpointRuleRoute
+		protocol protocol.Protocol
+		wantErr  bool
+	}{
+		{
+			name: "header, cookie and query param",
+			route: &ModelEndpointRuleRoute{
+				Headers:           map[string]*StringMatch{"x-tester": {Exact: "true"}},
+				Cookies:           map[string]string{"beta": "1"},
+				QueryParams:       map[string]*StringMatch{"variant": {Regex: "^b"}},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.HttpJson,
+		},
+		{
+			name: "upi header",
+			route: &ModelEndpointRuleRoute{
+				Headers:           map[string]*StringMatch{"x-client": {Prefix: "internal-"}},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.UpiV1,
+		},
+		{
+			name: "source labels",
+			route: &ModelEndpointRuleRoute{
+				SourceLabels:      map[string]string{"app": "tester"},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.HttpJson,
+		},
+		{
+			name:     "missing version endpoint",
+			route:    &ModelEndpointRuleRoute{SourceLabels: map[string]string{"app": "tester"}},
+			protocol: protocol.HttpJson,
+			wantErr:  true,
+		},
+		{
+			name:     "no condition",
+			route:    &ModelEndpointRuleRoute{VersionEndpointID: versionEndpointID},
+			protocol: protocol.HttpJson,
+			wantErr:  true,
+		},
+		{
+			name: "multiple string matches",
+			route: &ModelEndpointRuleRoute{
+				Headers:           map[string]*StringMatch{"x-tester": {Exact: "true", Prefix: "t"}},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.HttpJson,
+			wantErr:  true,
+		},
+		{
+			name: "invalid regex",
+			route: &ModelEndpointRuleRoute{
+				Headers:           map[string]*StringMatch{"x-tester": {Regex: "(true"}},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.HttpJson,
+			wantErr:  true,
+		},
+		{
+			name: "upi query param",
+			route: &ModelEndpointRuleRoute{
+				QueryParams:       map[string]*StringMatch{"variant": {Exact: "b"}},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.UpiV1,
+			wantErr:  true,
+		},
+		{
+			name: "upi cookie",
+			route: &ModelEndpointRuleRoute{
+				Cookies:           map[string]string{"beta": "1"},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.UpiV1,
+			wantErr:  true,
+		},
+		{
+			name: "multiple cookies",
+			route: &ModelEndpointRuleRoute{
+				Cookies:           map[string]string{"beta": "1", "group": "a"},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.HttpJson,
+			wantErr:  true,
+		},
+		{
+			name: "cookie with cookie header",
+			route: &ModelEndpointRuleRoute{
+				Headers:           map[string]*StringMatch{"Cookie": {Regex: "beta"}},
+				Cookies:           map[string]string{"beta": "1"},
+				VersionEndpointID: versionEndpointID,
+			},
+			protocol: protocol.HttpJson,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.route.Validate(tt.protocol)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCookieRegex(t *testing.T) {
+	cookieRegex := regexp.MustCompile(CookieRegex("beta", "1.0"))
+
+	assert.True(t, cookieRegex.MatchString("beta=1.0"))
+	assert.True(t, cookieRegex.MatchString("session=abc; beta=1.0"))
+	assert.True(t, cookieRegex.MatchString("session=abc;beta=1.0; theme=dark"))
+	assert.False(t, cookieRegex.MatchString("beta=1x0"))
+	assert.False(t, cookieRegex.MatchString("notbeta=1.0"))
+	assert.False(t, cookieRegex.MatchString("beta=1.0.1"))
+}
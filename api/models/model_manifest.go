@@ -0,0 +1,203 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ModelManifest declares the desired schemas, version endpoints, model endpoints and alerts of a model.
+// It is converged by the apply API, typically from a file kept in git.
+type ModelManifest struct {
+	// Model is the name of the model the manifest belongs to
+	Model            string                     `json:"model"`
+	Schemas          []*ModelSchemaManifest     `json:"schemas,omitempty"`
+	VersionEndpoints []*VersionEndpointManifest `json:"version_endpoints,omitempty"`
+	ModelEndpoints   []*ModelEndpointManifest   `json:"model_endpoints,omitempty"`
+}
+
+// ModelSchemaManifest declares the schema of a model version
+type ModelSchemaManifest struct {
+	VersionID ID          `json:"version_id"`
+	Spec      *SchemaSpec `json:"spec"`
+}
+
+// VersionEndpointManifest declares the deployment of a model version in an environment.
+// The configuration not set in the spec keeps its current value, or the environment default for a new endpoint.
+type VersionEndpointManifest struct {
+	VersionID       ID              `json:"version_id"`
+	EnvironmentName string          `json:"environment_name"`
+	Spec            *DeploymentSpec `json:"spec,omitempty"`
+}
+
+// ModelEndpointManifest declares the model endpoint of an environment, the traffic is split between the version
+// endpoints of the destinations' versions in the same environment
+type ModelEndpointManifest struct {
+	EnvironmentName string                              `json:"environment_name"`
+	Destinations    []*ModelEndpointDestinationManifest `json:"destinations"`
+	Alert           *ModelEndpointAlertManifest         `json:"alert,omitempty"`
+}
+
+// ModelEndpointDestinationManifest declares the weight of the traffic routed to a model version
+type ModelEndpointDestinationManifest struct {
+	VersionID ID    `json:"version_id"`
+	Weight    int32 `json:"weight"`
+}
+
+// ModelEndpointAlertManifest declares the alert of a model endpoint
+type ModelEndpointAlertManifest struct {
+	TeamName        string          `json:"team_name"`
+	AlertConditions AlertConditions `json:"alert_conditions"`
+}
+
+// ParseModelManifest parses a model manifest from YAML or JSON, unknown fields are rejected to catch typos early
+func ParseModelManifest(data []byte) (*ModelManifest, error) {
+	manifest := &ModelManifest{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Validate checks that the manifest is complete and declares each resource at most once
+func (m *ModelManifest) Validate() error {
+	if m.Model == "" {
+		return fmt.Errorf("model name is required")
+	}
+
+	schemaVersions := map[ID]bool{}
+	for _, schema := range m.Schemas {
+		if schema.Spec == nil {
+			return fmt.Errorf("schema of version %d: spec is required", schema.VersionID)
+		}
+		if schemaVersions[schema.VersionID] {
+			return fmt.Errorf("schema of version %d is declared more than once", schema.VersionID)
+		}
+		schemaVersions[schema.VersionID] = true
+	}
+
+	versionEndpoints := map[string]bool{}
+	for _, endpoint := range m.VersionEndpoints {
+		if endpoint.EnvironmentName == "" {
+			return fmt.Errorf("version endpoint of version %d: environment name is required", endpoint.VersionID)
+		}
+		key := versionEndpointKey(endpoint.VersionID, endpoint.EnvironmentName)
+		if versionEndpoints[key] {
+			return fmt.Errorf("version endpoint of version %d in %s is declared more than once", endpoint.VersionID, endpoint.EnvironmentName)
+		}
+		versionEndpoints[key] = true
+	}
+
+	modelEndpoints := map[string]bool{}
+	for _, endpoint := range m.ModelEndpoints {
+		if endpoint.EnvironmentName == "" {
+			return fmt.Errorf("model endpoint: environment name is required")
+		}
+		if modelEndpoints[endpoint.EnvironmentName] {
+			return fmt.Errorf("model endpoint in %s is declared more than once", endpoint.EnvironmentName)
+		}
+		modelEndpoints[endpoint.EnvironmentName] = true
+
+		if len(endpoint.Destinations) == 0 {
+			return fmt.Errorf("model endpoint in %s: at least one destination is required", endpoint.EnvironmentName)
+		}
+		totalWeight := int32(0)
+		for _, destination := range endpoint.Destinations {
+			// the destinations are declared so that a prune doesn't undeploy the version endpoints serving the traffic
+			if !versionEndpoints[versionEndpointKey(destination.VersionID, endpoint.EnvironmentName)] {
+				return fmt.Errorf("model endpoint in %s: version endpoint of version %d in %s is not declared", endpoint.EnvironmentName, destination.VersionID, endpoint.EnvironmentName)
+			}
+			totalWeight += destination.Weight
+		}
+		if totalWeight != 100 {
+			return fmt.Errorf("model endpoint in %s: the weights of the destinations must add up to 100, got %d", endpoint.EnvironmentName, totalWeight)
+		}
+
+		if endpoint.Alert != nil && endpoint.Alert.TeamName == "" {
+			return fmt.Errorf("alert of model endpoint in %s: team name is required", endpoint.EnvironmentName)
+		}
+	}
+	return nil
+}
+
+func versionEndpointKey(versionID ID, environmentName string) string {
+	return fmt.Sprintf("%d/%s", versionID, environmentName)
+}
+
+// ManifestResourceKind is the kind of a resource declared by a model manifest
+type ManifestResourceKind string
+
+const (
+	ManifestSchema             ManifestResourceKind = "schema"
+	ManifestVersionEndpoint    ManifestResourceKind = "version_endpoint"
+	ManifestModelEndpoint      ManifestResourceKind = "model_endpoint"
+	ManifestModelEndpointAlert ManifestResourceKind = "model_endpoint_alert"
+)
+
+// ManifestAction is the action converging a resource to its model manifest
+type ManifestAction string
+
+const (
+	ManifestActionCreate ManifestAction = "create"
+	ManifestActionUpdate ManifestAction = "update"
+	ManifestActionDelete ManifestAction = "delete"
+	ManifestActionNone   ManifestAction = "none"
+)
+
+// ApplyPlan is the list of changes converging the current state of a model to its manifest.
+// A dry-run returns the plan without applying it.
+type ApplyPlan struct {
+	DryRun  bool              `json:"dry_run"`
+	Changes []*ManifestChange `json:"changes"`
+	// Committed is true if the applied manifest has been committed to the manifest repository
+	Committed bool     `json:"committed"`
+	Warnings  []string `json:"warnings"`
+	// Error is the error of the change which failed to be applied, the changes after it are not applied
+	Error string `json:"error,omitempty"`
+}
+
+// ManifestChange is the change of a resource declared by a model manifest.
+// Diff lists the changed fields of an update, following the json field names of the resource.
+type ManifestChange struct {
+	Kind            ManifestResourceKind    `json:"kind"`
+	Action          ManifestAction          `json:"action"`
+	VersionID       ID                      `json:"version_id,omitempty"`
+	EnvironmentName string                  `json:"environment_name,omitempty"`
+	Diff            []*DeploymentSpecChange `json:"diff,omitempty"`
+	// Applied is true once the change has been applied, a change which can't be applied yet is explained by a warning
+	Applied bool `json:"applied"`
+}
+
+// NewApplyPlan returns an empty apply plan
+func NewApplyPlan(dryRun bool) *ApplyPlan {
+	return &ApplyPlan{
+		DryRun:   dryRun,
+		Changes:  []*ManifestChange{},
+		Warnings: []string{},
+	}
+}
+
+// AddChange adds the change of a resource to the apply plan
+func (p *ApplyPlan) AddChange(change *ManifestChange) *ManifestChange {
+	p.Changes = append(p.Changes, change)
+	return change
+}
+
+// AddWarning adds a warning to the apply plan
+func (p *ApplyPlan) AddWarning(format string, a ...interface{}) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, a...))
+}
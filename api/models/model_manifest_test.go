@@ -0,0 +1,177 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/caraml-dev/merlin/pkg/deployment"
+)
+
+func TestParseModelManifest(t *testing.T) {
+	manifest, err := ParseModelManifest([]byte(`
+model: my-model
+schemas:
+  - version_id: 2
+    spec:
+      session_id_column: session_id
+      row_id_column: row_id
+      tag_columns: [tag]
+version_endpoints:
+  - version_id: 2
+    environment_name: production
+    spec:
+      deployment_mode: raw_deployment
+      resource_request:
+        min_replica: 1
+        max_replica: 4
+        cpu_request: 500m
+        memory_request: 1Gi
+      env_vars:
+        - name: WORKERS
+          value: "2"
+model_endpoints:
+  - environment_name: production
+    destinations:
+      - version_id: 2
+        weight: 100
+    alert:
+      team_name: my-team
+      alert_conditions:
+        - enabled: true
+          metric_type: throughput
+          severity: WARNING
+          target: 10
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-model", manifest.Model)
+	assert.Equal(t, "session_id", manifest.Schemas[0].Spec.SessionIDColumn)
+
+	spec := manifest.VersionEndpoints[0].Spec
+	assert.Equal(t, deployment.RawDeploymentMode, spec.DeploymentMode)
+	assert.Equal(t, 4, spec.ResourceRequest.MaxReplica)
+	assert.Equal(t, resource.MustParse("500m"), spec.ResourceRequest.CPURequest)
+	assert.Equal(t, EnvVars{{Name: "WORKERS", Value: "2"}}, spec.EnvVars)
+
+	assert.Equal(t, int32(100), manifest.ModelEndpoints[0].Destinations[0].Weight)
+	assert.Equal(t, "my-team", manifest.ModelEndpoints[0].Alert.TeamName)
+	assert.Equal(t, float64(10), manifest.ModelEndpoints[0].Alert.AlertConditions[0].Target)
+
+	_, err = ParseModelManifest([]byte("model: my-model\nversion_endpoint:\n  - version_id: 2\n"))
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestModelManifest_Validate(t *testing.T) {
+	versionEndpoints := []*VersionEndpointManifest{
+		{VersionID: 1, EnvironmentName: "production"},
+		{VersionID: 2, EnvironmentName: "production"},
+	}
+
+	tests := []struct {
+		name     string
+		manifest *ModelManifest
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			manifest: &ModelManifest{
+				Model:            "my-model",
+				Schemas:          []*ModelSchemaManifest{{VersionID: 1, Spec: &SchemaSpec{}}},
+				VersionEndpoints: versionEndpoints,
+				ModelEndpoints: []*ModelEndpointManifest{
+					{
+						EnvironmentName: "production",
+						Destinations:    []*ModelEndpointDestinationManifest{{VersionID: 1, Weight: 90}, {VersionID: 2, Weight: 10}},
+						Alert:           &ModelEndpointAlertManifest{TeamName: "my-team"},
+					},
+				},
+			},
+		},
+		{
+			name:     "model name is missing",
+			manifest: &ModelManifest{},
+			wantErr:  "model name is required",
+		},
+		{
+			name: "schema is declared twice",
+			manifest: &ModelManifest{
+				Model:   "my-model",
+				Schemas: []*ModelSchemaManifest{{VersionID: 1, Spec: &SchemaSpec{}}, {VersionID: 1, Spec: &SchemaSpec{}}},
+			},
+			wantErr: "schema of version 1 is declared more than once",
+		},
+		{
+			name: "version endpoint is declared twice",
+			manifest: &ModelManifest{
+				Model:            "my-model",
+				VersionEndpoints: append(versionEndpoints, &VersionEndpointManifest{VersionID: 1, EnvironmentName: "production"}),
+			},
+			wantErr: "version endpoint of version 1 in production is declared more than once",
+		},
+		{
+			name: "destination is not declared",
+			manifest: &ModelManifest{
+				Model:            "my-model",
+				VersionEndpoints: versionEndpoints,
+				ModelEndpoints: []*ModelEndpointManifest{
+					{EnvironmentName: "production", Destinations: []*ModelEndpointDestinationManifest{{VersionID: 3, Weight: 100}}},
+				},
+			},
+			wantErr: "version endpoint of version 3 in production is not declared",
+		},
+		{
+			name: "weights don't add up to 100",
+			manifest: &ModelManifest{
+				Model:            "my-model",
+				VersionEndpoints: versionEndpoints,
+				ModelEndpoints: []*ModelEndpointManifest{
+					{EnvironmentName: "production", Destinations: []*ModelEndpointDestinationManifest{{VersionID: 1, Weight: 50}, {VersionID: 2, Weight: 20}}},
+				},
+			},
+			wantErr: "the weights of the destinations must add up to 100, got 70",
+		},
+		{
+			name: "alert team is missing",
+			manifest: &ModelManifest{
+				Model:            "my-model",
+				VersionEndpoints: versionEndpoints,
+				ModelEndpoints: []*ModelEndpointManifest{
+					{
+						EnvironmentName: "production",
+						Destinations:    []*ModelEndpointDestinationManifest{{VersionID: 1, Weight: 100}},
+						Alert:           &ModelEndpointAlertManifest{},
+					},
+				},
+			},
+			wantErr: "alert of model endpoint in production: team name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
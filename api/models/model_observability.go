@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -14,6 +15,8 @@ type ModelObservability struct {
 	GroundTruthSource                     *GroundTruthSource     `json:"ground_truth_source"`
 	GroundTruthJob                        *GroundTruthJob        `json:"ground_truth_job"`
 	PredictionLogIngestionResourceRequest *WorkerResourceRequest `json:"prediction_log_ingestion_resource_request"`
+	// PredictionLogMapping locates the prediction log fields in the payloads of models not following the standard model request and response
+	PredictionLogMapping *rules.PredictionLogMapping `json:"prediction_log_mapping,omitempty"`
 }
 
 func (mo *ModelObservability) IsEnabled() bool {
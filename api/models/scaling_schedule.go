@@ -0,0 +1,202 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/robfig/cron"
+
+	"github.com/caraml-dev/merlin/pkg/deployment"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+)
+
+// ScalingSchedule overrides the replicas of a version endpoint during the windows starting at each activation of its cron schedule
+type ScalingSchedule struct {
+	Name string `json:"name"`
+	// Schedule is the standard cron expression of the start of the windows, e.g. "0 8 * * 1-5"
+	Schedule string `json:"schedule"`
+	// Duration is the length of the windows, e.g. "10h"
+	Duration string `json:"duration"`
+	// Timezone is the IANA time zone the schedule is evaluated in, UTC if empty
+	Timezone string `json:"timezone,omitempty"`
+	// MinReplica and MaxReplica override the replicas of the model during the windows
+	MinReplica *int `json:"min_replica,omitempty"`
+	MaxReplica *int `json:"max_replica,omitempty"`
+	// ScaleToZero allows serverless endpoints to scale down to zero replicas during the windows
+	ScaleToZero bool `json:"scale_to_zero,omitempty"`
+}
+
+type ScalingSchedules []*ScalingSchedule
+
+// EffectiveScaling is the scaling of a version endpoint at a point in time
+type EffectiveScaling struct {
+	// Schedule is the name of the active scaling schedule, empty if the endpoint scales with its resource request
+	Schedule    string     `json:"schedule,omitempty"`
+	MinReplica  int        `json:"min_replica"`
+	MaxReplica  int        `json:"max_replica"`
+	ScaleToZero bool       `json:"scale_to_zero"`
+	ActiveUntil *time.Time `json:"active_until,omitempty"`
+	// NextSchedule and NextScheduleAt are the schedule whose window starts next
+	NextSchedule   string     `json:"next_schedule,omitempty"`
+	NextScheduleAt *time.Time `json:"next_schedule_at,omitempty"`
+}
+
+func (s ScalingSchedules) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *ScalingSchedules) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &s)
+}
+
+// Validate validates the scaling schedules of a version endpoint deployed with the given deployment mode
+func (s ScalingSchedules) Validate(mode deployment.Mode) error {
+	names := map[string]bool{}
+	for _, schedule := range s {
+		if schedule.Name == "" {
+			return merror.NewInvalidInputError("scaling schedule name must not be empty")
+		}
+		if names[schedule.Name] {
+			return merror.NewInvalidInputErrorf("scaling schedule %s is defined more than once", schedule.Name)
+		}
+		names[schedule.Name] = true
+
+		if err := schedule.validate(mode); err != nil {
+			return merror.NewInvalidInputErrorf("invalid scaling schedule %s: %v", schedule.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *ScalingSchedule) validate(mode deployment.Mode) error {
+	if _, err := cron.ParseStandard(s.Schedule); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s.Duration)
+	if err != nil {
+		return err
+	}
+	if duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return err
+	}
+
+	if s.MinReplica == nil && s.MaxReplica == nil && !s.ScaleToZero {
+		return errors.New("one of min_replica, max_replica or scale_to_zero must be set")
+	}
+	if s.MinReplica != nil && *s.MinReplica < 0 {
+		return errors.New("min_replica must not be negative")
+	}
+	if s.MaxReplica != nil && *s.MaxReplica < 1 {
+		return errors.New("max_replica must be at least 1")
+	}
+	if s.MinReplica != nil && s.MaxReplica != nil && *s.MinReplica > *s.MaxReplica {
+		return errors.New("min_replica must not be greater than max_replica")
+	}
+	if s.ScaleToZero && mode == deployment.RawDeploymentMode {
+		return errors.New("scale_to_zero is only supported by serverless deployment mode")
+	}
+	return nil
+}
+
+// activeWindow returns the end of the window containing t, or false if t is outside of the windows of the schedule
+func (s *ScalingSchedule) activeWindow(t time.Time) (time.Time, bool) {
+	schedule, duration, location, err := s.parse()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// the first window starting after t - duration is the one containing t, if it started already
+	start := schedule.Next(t.In(location).Add(-duration))
+	if start.IsZero() || start.After(t) {
+		return time.Time{}, false
+	}
+	return start.Add(duration), true
+}
+
+// nextWindow returns the start of the first window after t
+func (s *ScalingSchedule) nextWindow(t time.Time) (time.Time, bool) {
+	schedule, _, location, err := s.parse()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	start := schedule.Next(t.In(location))
+	return start, !start.IsZero()
+}
+
+func (s *ScalingSchedule) parse() (cron.Schedule, time.Duration, *time.Location, error) {
+	schedule, err := cron.ParseStandard(s.Schedule)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	duration, err := time.ParseDuration(s.Duration)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return schedule, duration, location, nil
+}
+
+// NewEffectiveScaling returns the scaling of the version endpoint at t, the first scaling schedule whose window contains t
+// overrides the replicas of the endpoint's resource request
+func NewEffectiveScaling(endpoint *VersionEndpoint, t time.Time) *EffectiveScaling {
+	scaling := &EffectiveScaling{}
+	if endpoint.ResourceRequest != nil {
+		scaling.MinReplica = endpoint.ResourceRequest.MinReplica
+		scaling.MaxReplica = endpoint.ResourceRequest.MaxReplica
+	}
+
+	for _, schedule := range endpoint.ScalingSchedules {
+		if end, active := schedule.activeWindow(t); active && scaling.Schedule == "" {
+			scaling.Schedule = schedule.Name
+			scaling.ActiveUntil = &end
+			if schedule.MinReplica != nil {
+				scaling.MinReplica = *schedule.MinReplica
+			}
+			if schedule.MaxReplica != nil {
+				scaling.MaxReplica = *schedule.MaxReplica
+			}
+			scaling.ScaleToZero = schedule.ScaleToZero
+		}
+
+		if start, ok := schedule.nextWindow(t); ok && (scaling.NextScheduleAt == nil || start.Before(*scaling.NextScheduleAt)) {
+			scaling.NextSchedule = schedule.Name
+			scaling.NextScheduleAt = &start
+		}
+	}
+
+	if scaling.ScaleToZero {
+		scaling.MinReplica = 0
+	}
+	if scaling.MinReplica > scaling.MaxReplica {
+		scaling.MaxReplica = scaling.MinReplica
+	}
+	return scaling
+}
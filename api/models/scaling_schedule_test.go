@@ -0,0 +1,192 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caraml-dev/merlin/pkg/deployment"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+)
+
+func TestScalingSchedules_Validate(t *testing.T) {
+	one := 1
+	two := 2
+	negative := -1
+
+	tests := []struct {
+		name      string
+		schedules ScalingSchedules
+		mode      deployment.Mode
+		wantErr   bool
+	}{
+		{
+			name: "valid",
+			schedules: ScalingSchedules{
+				{Name: "business-hours", Schedule: "0 8 * * 1-5", Duration: "10h", Timezone: "Asia/Jakarta", MinReplica: &one, MaxReplica: &two},
+				{Name: "night", Schedule: "0 0 * * *", Duration: "6h", ScaleToZero: true},
+			},
+			mode: deployment.ServerlessDeploymentMode,
+		},
+		{
+			name:      "empty name",
+			schedules: ScalingSchedules{{Schedule: "0 8 * * *", Duration: "1h", MinReplica: &one}},
+			wantErr:   true,
+		},
+		{
+			name: "duplicate name",
+			schedules: ScalingSchedules{
+				{Name: "a", Schedule: "0 8 * * *", Duration: "1h", MinReplica: &one},
+				{Name: "a", Schedule: "0 9 * * *", Duration: "1h", MinReplica: &one},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "invalid cron",
+			schedules: ScalingSchedules{{Name: "a", Schedule: "every day", Duration: "1h", MinReplica: &one}},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid duration",
+			schedules: ScalingSchedules{{Name: "a", Schedule: "0 8 * * *", Duration: "-1h", MinReplica: &one}},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid timezone",
+			schedules: ScalingSchedules{{Name: "a", Schedule: "0 8 * * *", Duration: "1h", Timezone: "Mars/Olympus", MinReplica: &one}},
+			wantErr:   true,
+		},
+		{
+			name:      "no override",
+			schedules: ScalingSchedules{{Name: "a", Schedule: "0 8 * * *", Duration: "1h"}},
+			wantErr:   true,
+		},
+		{
+			name:      "negative min replica",
+			schedules: ScalingSchedules{{Name: "a", Schedule: "0 8 * * *", Duration: "1h", MinReplica: &negative}},
+			wantErr:   true,
+		},
+		{
+			name:      "min replica greater than max replica",
+			schedules: ScalingSchedules{{Name: "a", Schedule: "0 8 * * *", Duration: "1h", MinReplica: &two, MaxReplica: &one}},
+			wantErr:   true,
+		},
+		{
+			name:      "scale to zero in raw deployment",
+			schedules: ScalingSchedules{{Name: "a", Schedule: "0 8 * * *", Duration: "1h", ScaleToZero: true}},
+			mode:      deployment.RawDeploymentMode,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedules.Validate(tt.mode)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, merror.ErrInvalidInput)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewEffectiveScaling(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	five := 5
+	ten := 10
+
+	endpoint := &VersionEndpoint{
+		ResourceRequest: &ResourceRequest{MinReplica: 1, MaxReplica: 2},
+		ScalingSchedules: ScalingSchedules{
+			{Name: "business-hours", Schedule: "0 8 * * 1-5", Duration: "10h", Timezone: "Asia/Jakarta", MinReplica: &five, MaxReplica: &ten},
+			{Name: "night", Schedule: "0 0 * * *", Duration: "6h", Timezone: "Asia/Jakarta", ScaleToZero: true},
+		},
+	}
+
+	// 2024-01-01 is a Monday
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 1, day, hour, 0, 0, 0, jakarta)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want *EffectiveScaling
+	}{
+		{
+			name: "business hours",
+			t:    at(1, 9),
+			want: &EffectiveScaling{
+				Schedule:       "business-hours",
+				MinReplica:     5,
+				MaxReplica:     10,
+				ActiveUntil:    ptr(at(1, 18)),
+				NextSchedule:   "night",
+				NextScheduleAt: ptr(at(2, 0)),
+			},
+		},
+		{
+			name: "night",
+			t:    at(2, 3),
+			want: &EffectiveScaling{
+				MinReplica:     0,
+				MaxReplica:     2,
+				Schedule:       "night",
+				ScaleToZero:    true,
+				ActiveUntil:    ptr(at(2, 6)),
+				NextSchedule:   "business-hours",
+				NextScheduleAt: ptr(at(2, 8)),
+			},
+		},
+		{
+			name: "outside of windows",
+			t:    at(6, 20),
+			want: &EffectiveScaling{
+				MinReplica:     1,
+				MaxReplica:     2,
+				NextSchedule:   "night",
+				NextScheduleAt: ptr(at(7, 0)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewEffectiveScaling(endpoint, tt.t)
+			assert.Equal(t, tt.want.Schedule, got.Schedule)
+			assert.Equal(t, tt.want.MinReplica, got.MinReplica)
+			assert.Equal(t, tt.want.MaxReplica, got.MaxReplica)
+			assert.Equal(t, tt.want.ScaleToZero, got.ScaleToZero)
+			assert.Equal(t, tt.want.NextSchedule, got.NextSchedule)
+			assertTimeEqual(t, tt.want.ActiveUntil, got.ActiveUntil)
+			assertTimeEqual(t, tt.want.NextScheduleAt, got.NextScheduleAt)
+		})
+	}
+}
+
+func assertTimeEqual(t *testing.T, want, got *time.Time) {
+	if want == nil {
+		assert.Nil(t, got)
+		return
+	}
+	if assert.NotNil(t, got) {
+		assert.True(t, want.Equal(*got), "want %v, got %v", *want, *got)
+	}
+}
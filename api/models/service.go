@@ -24,6 +24,7 @@ import (
 	"github.com/caraml-dev/merlin/mlp"
 	"github.com/caraml-dev/merlin/pkg/autoscaling"
 	"github.com/caraml-dev/merlin/pkg/deployment"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	transformerpkg "github.com/caraml-dev/merlin/pkg/transformer"
 	"knative.dev/pkg/apis"
@@ -53,10 +54,13 @@ type Service struct {
 	DeploymentMode    deployment.Mode
 	AutoscalingPolicy *autoscaling.AutoscalingPolicy
 	Protocol          protocol.Protocol
+	// DeploymentStrategy is the strategy used to replace the current InferenceService's revision
+	DeploymentStrategy deployment.Strategy
 	// CurrentIsvcName is the name of the current running/serving InferenceService's revision
 	CurrentIsvcName             string
 	EnabledModelObservability   bool
 	ModelSchema                 *ModelSchema
+	PredictionLogMapping        *rules.PredictionLogMapping
 	PredictorUPIOverHTTPEnabled bool
 }
 
@@ -83,15 +87,24 @@ func NewService(model *Model, version *Version, modelOpt *ModelOption, endpoint
 		Transformer:                 endpoint.Transformer,
 		Logger:                      endpoint.Logger,
 		DeploymentMode:              endpoint.DeploymentMode,
+		DeploymentStrategy:          endpoint.DeploymentStrategy,
 		AutoscalingPolicy:           endpoint.AutoscalingPolicy,
 		Protocol:                    endpoint.Protocol,
 		CurrentIsvcName:             endpoint.InferenceServiceName,
 		EnabledModelObservability:   endpoint.IsModelMonitoringEnabled(),
 		ModelSchema:                 version.ModelSchema,
+		PredictionLogMapping:        predictionLogMapping(endpoint),
 		PredictorUPIOverHTTPEnabled: predictorUPIOverHTTPEnabled(endpoint.Transformer, endpoint.Protocol),
 	}
 }
 
+func predictionLogMapping(endpoint *VersionEndpoint) *rules.PredictionLogMapping {
+	if !endpoint.IsModelMonitoringEnabled() {
+		return nil
+	}
+	return endpoint.ModelObservability.PredictionLogMapping
+}
+
 func predictorUPIOverHTTPEnabled(transformer *Transformer, modelProtocol protocol.Protocol) bool {
 	if transformer == nil || !transformer.Enabled || modelProtocol != protocol.UpiV1 {
 		return false
@@ -115,6 +128,30 @@ func (svc *Service) PredictorProtocol() protocol.Protocol {
 	return svc.Protocol
 }
 
+// PredictionLogSchema returns the schema used by the model observability sink of the inference logger to build
+// prediction logs from the model's payloads. It is nil if model observability is disabled or no mapping is configured.
+func (svc *Service) PredictionLogSchema() *rules.PredictionLogSchema {
+	if !svc.EnabledModelObservability || svc.PredictionLogMapping == nil || svc.ModelSchema == nil || svc.ModelSchema.Spec == nil {
+		return nil
+	}
+
+	schema := &rules.PredictionLogSchema{
+		PredictionLogMapping: *svc.PredictionLogMapping,
+		FeatureOrders:        svc.ModelSchema.Spec.FeatureOrders,
+	}
+	if output := svc.ModelSchema.Spec.ModelPredictionOutput; output != nil {
+		switch {
+		case output.BinaryClassificationOutput != nil:
+			schema.PredictionScoreColumn = output.BinaryClassificationOutput.PredictionScoreColumn
+		case output.RegressionOutput != nil:
+			schema.PredictionScoreColumn = output.RegressionOutput.PredictionScoreColumn
+		case output.RankingOutput != nil:
+			schema.PredictionScoreColumn = output.RankingOutput.RankScoreColumn
+		}
+	}
+	return schema
+}
+
 func (svc *Service) GetPredictionLogTopic() string {
 	return fmt.Sprintf("caraml-%s-%s-prediction-log", svc.Namespace, svc.ModelName)
 }
@@ -71,8 +71,13 @@ type VersionEndpoint struct {
 	Logger *Logger `json:"logger,omitempty" gorm:"logger"`
 	// DeploymentMode deployment mode of the version endpoint, it can be raw_deployment or serverless
 	DeploymentMode deployment.Mode `json:"deployment_mode" gorm:"deployment_mode"`
+	// DeploymentStrategy strategy used to replace the running revision on redeployment, it can be empty or blue_green.
+	// Requesting the default strategy resets it to empty
+	DeploymentStrategy deployment.Strategy `json:"deployment_strategy,omitempty" gorm:"deployment_strategy"`
 	// AutoscalingPolicy controls the conditions when autoscaling should be triggered
 	AutoscalingPolicy *autoscaling.AutoscalingPolicy `json:"autoscaling_policy" gorm:"autoscaling_policy"`
+	// ScalingSchedules override the replicas of the resource request during their time windows
+	ScalingSchedules ScalingSchedules `json:"scaling_schedules,omitempty" gorm:"scaling_schedules"`
 	// Protocol to be used when deploying the model
 	Protocol protocol.Protocol `json:"protocol" gorm:"protocol"`
 	// EnableModelObservability flag indicate whether the version endpoint should enable model observability
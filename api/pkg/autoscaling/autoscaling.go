@@ -41,6 +41,21 @@ type AutoscalingPolicy struct {
 	MetricsType MetricsType `json:"metrics_type"`
 	// TargetValue specifies the policy value
 	TargetValue float64 `json:"target_value"`
+	// AdditionalMetrics are scaled on together with the metrics above, the number of replicas is the highest
+	// one required by any of the metrics. Only supported by raw_deployment
+	AdditionalMetrics []AutoscalingMetric `json:"additional_metrics,omitempty"`
+}
+
+// AutoscalingMetric specify a metrics type and its target value
+type AutoscalingMetric struct {
+	MetricsType MetricsType `json:"metrics_type"`
+	TargetValue float64     `json:"target_value"`
+}
+
+// Metrics returns all the metrics of the policy, starting with its main metrics
+func (r *AutoscalingPolicy) Metrics() []AutoscalingMetric {
+	metrics := []AutoscalingMetric{{MetricsType: r.MetricsType, TargetValue: r.TargetValue}}
+	return append(metrics, r.AdditionalMetrics...)
 }
 
 func (r AutoscalingPolicy) Value() (driver.Value, error) {
@@ -58,21 +73,40 @@ func (r *AutoscalingPolicy) Scan(value interface{}) error {
 
 // ValidateAutoscalingPolicy check autoscaling policy is valid and supported by the given deployment mode
 func ValidateAutoscalingPolicy(target *AutoscalingPolicy, mode deployment.Mode) error {
-	// raw deployment only support cpu utilization
-	if mode == deployment.RawDeploymentMode && target.MetricsType != CPUUtilization {
-		return merror.NewInvalidInputErrorf("raw_deployment doesn't support %v autoscaling metrics", target)
+	// knative autoscales on a single metrics
+	if mode != deployment.RawDeploymentMode && len(target.AdditionalMetrics) > 0 {
+		return merror.NewInvalidInputErrorf("%s doesn't support autoscaling on multiple metrics", mode)
 	}
 
-	// boundary check for cpu and memory utilization
-	if (target.MetricsType == CPUUtilization || target.MetricsType == MemoryUtilization) &&
-		(target.TargetValue <= 0 || target.TargetValue > 100) {
-		return merror.NewInvalidInputErrorf("policy %v is outside 0-100 range", target.MetricsType)
+	metricsTypes := map[MetricsType]bool{}
+	for _, metric := range target.Metrics() {
+		if metricsTypes[metric.MetricsType] {
+			return merror.NewInvalidInputErrorf("policy %v is specified more than once", metric.MetricsType)
+		}
+		metricsTypes[metric.MetricsType] = true
+
+		if err := validateAutoscalingMetric(metric); err != nil {
+			return err
+		}
 	}
 
-	// boundary check for rps and concurrency
-	if (target.MetricsType == Concurrency || target.MetricsType == RPS) &&
-		target.TargetValue <= 0 {
-		return merror.NewInvalidInputErrorf("policy %v is less than or equal to 0", target.MetricsType)
+	return nil
+}
+
+func validateAutoscalingMetric(target AutoscalingMetric) error {
+	switch target.MetricsType {
+	case CPUUtilization, MemoryUtilization:
+		// boundary check for cpu and memory utilization
+		if target.TargetValue <= 0 || target.TargetValue > 100 {
+			return merror.NewInvalidInputErrorf("policy %v is outside 0-100 range", target.MetricsType)
+		}
+	case Concurrency, RPS:
+		// boundary check for rps and concurrency
+		if target.TargetValue <= 0 {
+			return merror.NewInvalidInputErrorf("policy %v is less than or equal to 0", target.MetricsType)
+		}
+	default:
+		return merror.NewInvalidInputErrorf("unsupported autoscaling metrics %v", target.MetricsType)
 	}
 
 	return nil
@@ -47,6 +47,71 @@ func TestValidateAutoscalingPolicy(t *testing.T) {
 				},
 				mode: deployment.RawDeploymentMode,
 			},
+			wantErr: false,
+		},
+		{
+			name: "raw_deployment using rps",
+			args: args{
+				policy: &AutoscalingPolicy{
+					MetricsType: RPS,
+					TargetValue: 100,
+				},
+				mode: deployment.RawDeploymentMode,
+			},
+			wantErr: false,
+		},
+		{
+			name: "raw_deployment using multiple metrics",
+			args: args{
+				policy: &AutoscalingPolicy{
+					MetricsType: CPUUtilization,
+					TargetValue: 50,
+					AdditionalMetrics: []AutoscalingMetric{
+						{MetricsType: MemoryUtilization, TargetValue: 80},
+						{MetricsType: Concurrency, TargetValue: 2},
+					},
+				},
+				mode: deployment.RawDeploymentMode,
+			},
+			wantErr: false,
+		},
+		{
+			name: "raw_deployment using duplicated metrics",
+			args: args{
+				policy: &AutoscalingPolicy{
+					MetricsType: CPUUtilization,
+					TargetValue: 50,
+					AdditionalMetrics: []AutoscalingMetric{
+						{MetricsType: CPUUtilization, TargetValue: 80},
+					},
+				},
+				mode: deployment.RawDeploymentMode,
+			},
+			wantErr: true,
+		},
+		{
+			name: "raw_deployment using invalid additional metrics",
+			args: args{
+				policy: &AutoscalingPolicy{
+					MetricsType: CPUUtilization,
+					TargetValue: 50,
+					AdditionalMetrics: []AutoscalingMetric{
+						{MetricsType: RPS, TargetValue: 0},
+					},
+				},
+				mode: deployment.RawDeploymentMode,
+			},
+			wantErr: true,
+		},
+		{
+			name: "raw_deployment using unknown metrics",
+			args: args{
+				policy: &AutoscalingPolicy{
+					MetricsType: "gpu_utilization",
+					TargetValue: 50,
+				},
+				mode: deployment.RawDeploymentMode,
+			},
 			wantErr: true,
 		},
 		{
@@ -93,6 +158,20 @@ func TestValidateAutoscalingPolicy(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "serverless using multiple metrics",
+			args: args{
+				policy: &AutoscalingPolicy{
+					MetricsType: Concurrency,
+					TargetValue: 10,
+					AdditionalMetrics: []AutoscalingMetric{
+						{MetricsType: CPUUtilization, TargetValue: 80},
+					},
+				},
+				mode: deployment.ServerlessDeploymentMode,
+			},
+			wantErr: true,
+		},
 		{
 			name: "serverless using cpu invalid policy",
 			args: args{
@@ -0,0 +1,31 @@
+package deployment
+
+import merror "github.com/caraml-dev/merlin/pkg/errors"
+
+// Strategy strategy used to replace the running revision of a deployment
+type Strategy string
+
+const (
+	// EmptyDeploymentStrategy replaces the running revision once the new revision is ready
+	EmptyDeploymentStrategy Strategy = ""
+	// DefaultDeploymentStrategy explicitly requests the default strategy, it resets a previously set strategy
+	DefaultDeploymentStrategy Strategy = "default"
+	// BlueGreenDeploymentStrategy keeps the running revision serving until the new revision is ready and receiving the traffic,
+	// and reverts to the running revision if the new revision fails to be ready
+	BlueGreenDeploymentStrategy Strategy = "blue_green"
+)
+
+// ValidateStrategy validates that the strategy is known and supported by the deployment mode
+func ValidateStrategy(strategy Strategy, mode Mode) error {
+	switch strategy {
+	case EmptyDeploymentStrategy, DefaultDeploymentStrategy:
+		return nil
+	case BlueGreenDeploymentStrategy:
+		if mode != RawDeploymentMode {
+			return merror.NewInvalidInputErrorf("%s deployment strategy is only supported by %s deployment mode", strategy, RawDeploymentMode)
+		}
+		return nil
+	default:
+		return merror.NewInvalidInputErrorf("unknown deployment strategy %s", strategy)
+	}
+}
@@ -0,0 +1,56 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+)
+
+func TestValidateStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+		mode     Mode
+		wantErr  bool
+	}{
+		{
+			name:     "empty strategy",
+			strategy: EmptyDeploymentStrategy,
+			mode:     ServerlessDeploymentMode,
+		},
+		{
+			name:     "default strategy",
+			strategy: DefaultDeploymentStrategy,
+			mode:     RawDeploymentMode,
+		},
+		{
+			name:     "blue green raw deployment",
+			strategy: BlueGreenDeploymentStrategy,
+			mode:     RawDeploymentMode,
+		},
+		{
+			name:     "blue green serverless",
+			strategy: BlueGreenDeploymentStrategy,
+			mode:     ServerlessDeploymentMode,
+			wantErr:  true,
+		},
+		{
+			name:     "unknown strategy",
+			strategy: Strategy("canary"),
+			mode:     RawDeploymentMode,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStrategy(tt.strategy, tt.mode)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, merror.ErrInvalidInput)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -15,9 +15,16 @@
 package gitlab
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+
 	"github.com/xanzy/go-gitlab"
 )
 
+// ErrFileNotFound is returned when the file doesn't exist in the branch of the repository
+var ErrFileNotFound = errors.New("file not found")
+
 // Client for GitLab interface.
 type Client interface {
 	GetFileContent(opt GetFileContentOptions) (string, error)
@@ -52,8 +59,11 @@ func (c *client) GetFileContent(opt GetFileContentOptions) (string, error) {
 		Ref: &opt.Branch,
 	}
 
-	f, _, err := c.git.RepositoryFiles.GetFile(opt.Repository, opt.FileName, getFile)
+	f, resp, err := c.git.RepositoryFiles.GetFile(opt.Repository, opt.FileName, getFile)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("%s: %w", opt.FileName, ErrFileNotFound)
+		}
 		return "", err
 	}
 
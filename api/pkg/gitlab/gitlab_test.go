@@ -34,6 +34,9 @@ func Test_client(t *testing.T) {
 				"file_path": "README.md",
 				"content": "README"
 			}`)
+		case "/api/v4/projects/test/repository/files/MISSING.md":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"message": "404 File Not Found"}`)
 		case "/api/v4/projects/test/repository/files/.gitignore":
 			if r.Method == http.MethodPost || r.Method == http.MethodPut {
 				fmt.Fprintf(w, `{
@@ -64,6 +67,10 @@ func Test_client(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotEmpty(t, content)
 
+	getOpt.FileName = "MISSING.md"
+	_, err = client.GetFileContent(getOpt)
+	assert.ErrorIs(t, err, ErrFileNotFound)
+
 	createOpt := CreateFileOptions{
 		Repository:    "test",
 		Branch:        "master",
@@ -0,0 +1,19 @@
+package liveness
+
+// Saturable is a queue of log entries that can report whether it is saturated
+type Saturable interface {
+	Saturated() bool
+}
+
+// NewReadinessProbe returns a probe that fails if the user container probe fails or any of the queues is saturated,
+// so that the pod stops receiving traffic instead of silently dropping the request logs
+func NewReadinessProbe(probe func() bool, queues ...Saturable) func() bool {
+	return func() bool {
+		for _, queue := range queues {
+			if queue.Saturated() {
+				return false
+			}
+		}
+		return probe()
+	}
+}
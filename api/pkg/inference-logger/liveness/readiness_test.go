@@ -0,0 +1,52 @@
+package liveness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeQueue bool
+
+func (q fakeQueue) Saturated() bool {
+	return bool(q)
+}
+
+func TestNewReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name       string
+		probeReady bool
+		queues     []Saturable
+		want       bool
+	}{
+		{
+			name:       "ready",
+			probeReady: true,
+			queues:     []Saturable{fakeQueue(false), fakeQueue(false)},
+			want:       true,
+		},
+		{
+			name:       "no queues",
+			probeReady: true,
+			want:       true,
+		},
+		{
+			name:       "user container not ready",
+			probeReady: false,
+			queues:     []Saturable{fakeQueue(false)},
+			want:       false,
+		},
+		{
+			name:       "queue saturated",
+			probeReady: true,
+			queues:     []Saturable{fakeQueue(false), fakeQueue(true)},
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := NewReadinessProbe(func() bool { return tt.probeReady }, tt.queues...)
+			assert.Equal(t, tt.want, probe())
+		})
+	}
+}
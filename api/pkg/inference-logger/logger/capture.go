@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+)
+
+const (
+	// DefaultMaxBodyBytes is the maximum size of captured request and response body if none is configured, larger body
+	// can't be produced to Kafka anyway
+	DefaultMaxBodyBytes = MaxMessageBytes
+	// UnlimitedBodyBytes captures the whole request and response body, which are buffered in memory until they're sent
+	UnlimitedBodyBytes = -1
+)
+
+// capturedBodyLimit returns the maximum size of captured body, 0 means DefaultMaxBodyBytes and negative means unlimited
+func capturedBodyLimit(maxBodyBytes int) int {
+	if maxBodyBytes == 0 {
+		return DefaultMaxBodyBytes
+	}
+	return maxBodyBytes
+}
+
+// truncationMarkerFormat is appended to the captured body which exceeds the maximum captured body size
+const truncationMarkerFormat = "...[truncated %d bytes]"
+
+// redactedTruncatedBody replaces the truncated body of the log entries with redaction rules, since the matching fields
+// can't be found in a body which can't be parsed
+const redactedTruncatedBody = rules.RedactedValue + "...[truncated]"
+
+// captureBuffer keeps the first max bytes written to it while counting all written bytes, max <= 0 means unlimited
+type captureBuffer struct {
+	max  int
+	buf  bytes.Buffer
+	size int64
+}
+
+func newCaptureBuffer(max int) *captureBuffer {
+	return &captureBuffer{max: max}
+}
+
+// Write never fails so that capturing doesn't interrupt the proxied stream
+func (c *captureBuffer) Write(p []byte) (int, error) {
+	c.size += int64(len(p))
+	if c.max <= 0 {
+		return c.buf.Write(p)
+	}
+
+	if remaining := c.max - c.buf.Len(); remaining > 0 {
+		if len(p) > remaining {
+			c.buf.Write(p[:remaining])
+		} else {
+			c.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// Truncated returns true if some of the written bytes are not captured
+func (c *captureBuffer) Truncated() bool {
+	return int64(c.buf.Len()) < c.size
+}
+
+// Bytes returns the captured bytes followed by truncation marker if the body is truncated
+func (c *captureBuffer) Bytes() []byte {
+	if !c.Truncated() {
+		return c.buf.Bytes()
+	}
+	body := make([]byte, c.buf.Len(), c.buf.Len()+32)
+	copy(body, c.buf.Bytes())
+	return append(body, fmt.Sprintf(truncationMarkerFormat, c.size-int64(c.buf.Len()))...)
+}
+
+// truncateBody limits the body to max bytes, it returns the captured body and whether the body is truncated
+func truncateBody(body []byte, max int) ([]byte, bool) {
+	capture := newCaptureBuffer(max)
+	_, _ = capture.Write(body)
+	return capture.Bytes(), capture.Truncated()
+}
+
+// captureReadCloser captures the bytes read from the request body
+type captureReadCloser struct {
+	io.ReadCloser
+	capture *captureBuffer
+}
+
+func (c *captureReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = c.capture.Write(p[:n])
+	}
+	return n, err
+}
+
+// captureResponseWriter streams the response to the client while capturing the status code and body
+//
+// The body is not captured if capture is nil.
+type captureResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	capture     *captureBuffer
+}
+
+func newCaptureResponseWriter(w http.ResponseWriter, capture *captureBuffer) *captureResponseWriter {
+	return &captureResponseWriter{
+		ResponseWriter: w,
+		statusCode:     http.StatusOK,
+		capture:        capture,
+	}
+}
+
+func (c *captureResponseWriter) WriteHeader(statusCode int) {
+	if !c.wroteHeader {
+		c.statusCode = statusCode
+		c.wroteHeader = true
+	}
+	c.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (c *captureResponseWriter) Write(p []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	n, err := c.ResponseWriter.Write(p)
+	if c.capture != nil {
+		_, _ = c.capture.Write(p[:n])
+	}
+	return n, err
+}
+
+// Flush sends the buffered data to the client, so that streaming responses are not delayed by the logger
+func (c *captureResponseWriter) Flush() {
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original ResponseWriter for http.ResponseController
+func (c *captureResponseWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureBuffer(t *testing.T) {
+	tests := []struct {
+		name          string
+		max           int
+		writes        []string
+		wantBody      string
+		wantTruncated bool
+	}{
+		{
+			name:     "unlimited",
+			max:      0,
+			writes:   []string{"hello ", "world"},
+			wantBody: "hello world",
+		},
+		{
+			name:     "within limit",
+			max:      11,
+			writes:   []string{"hello ", "world"},
+			wantBody: "hello world",
+		},
+		{
+			name:          "truncated in the middle of a write",
+			max:           8,
+			writes:        []string{"hello ", "world"},
+			wantBody:      "hello wo...[truncated 3 bytes]",
+			wantTruncated: true,
+		},
+		{
+			name:          "truncated after the limit is reached",
+			max:           5,
+			writes:        []string{"hello", " ", "world"},
+			wantBody:      "hello...[truncated 6 bytes]",
+			wantTruncated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capture := newCaptureBuffer(tt.max)
+			for _, w := range tt.writes {
+				n, err := capture.Write([]byte(w))
+				assert.NoError(t, err)
+				assert.Equal(t, len(w), n)
+			}
+			assert.Equal(t, tt.wantBody, string(capture.Bytes()))
+			assert.Equal(t, tt.wantTruncated, capture.Truncated())
+		})
+	}
+}
@@ -17,10 +17,16 @@ func (c *ConsoleSink) Sink(entries []*LogEntry) error {
 		kv := make([]interface{}, 0)
 		if message.RequestPayload != nil {
 			kv = append(kv, "request", string(message.RequestPayload.Body))
+			if message.RequestPayload.Truncated {
+				kv = append(kv, "requestTruncated", true)
+			}
 		}
 
 		if message.ResponsePayload != nil {
 			kv = append(kv, "response", string(message.ResponsePayload.Body))
+			if message.ResponsePayload.Truncated {
+				kv = append(kv, "responseTruncated", true)
+			}
 			kv = append(kv, "statusCode", message.ResponsePayload.StatusCode)
 		}
 
@@ -8,6 +8,7 @@ import (
 
 type Dispatcher struct {
 	workerQueue *BatchQueue
+	spool       *Spool
 	workers     []*Worker
 }
 
@@ -31,6 +32,25 @@ func NewDispatcher(nworkers int,
 	}
 }
 
+// NewSpoolDispatcher creates dispatcher that writes the log entries to the on-disk spool before they are sent to the log sinks
+func NewSpoolDispatcher(nworkers int,
+	spool *Spool,
+	workerConfig *WorkerConfig,
+	logger *zap.SugaredLogger,
+	logSinks ...LogSink) *Dispatcher {
+
+	workers := make([]*Worker, 0)
+	for i := 0; i < nworkers; i++ {
+		worker := NewSpoolWorker(spool, workerConfig, logger, logSinks...)
+		workers = append(workers, worker)
+	}
+
+	return &Dispatcher{
+		spool:   spool,
+		workers: workers,
+	}
+}
+
 func (d *Dispatcher) Start() {
 	for _, worker := range d.workers {
 		worker.Start()
@@ -38,10 +58,28 @@ func (d *Dispatcher) Start() {
 }
 
 func (d *Dispatcher) Submit(logEntry *LogEntry) error {
+	if d.spool != nil {
+		return d.spool.Put(logEntry)
+	}
 	return d.workerQueue.Put(logEntry)
 }
 
+// Saturated returns true if the queue or spool of the dispatcher is close to full and log entries are about to be dropped
+func (d *Dispatcher) Saturated() bool {
+	if d.spool != nil {
+		return d.spool.Saturated(QueueSaturationThreshold)
+	}
+	return d.workerQueue.Saturated(QueueSaturationThreshold)
+}
+
 func (d *Dispatcher) Stop() {
+	if d.spool != nil {
+		if err := d.spool.Close(); err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+
 	err := d.workerQueue.Close()
 	if err != nil {
 		fmt.Println(err)
@@ -0,0 +1,430 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type FileFormat string
+
+const (
+	FileFormatNDJSON  FileFormat = "ndjson"
+	FileFormatParquet FileFormat = "parquet"
+)
+
+const (
+	// DefaultFileRollInterval is the maximum duration log entries are buffered before they are written to a file
+	DefaultFileRollInterval = 5 * time.Minute
+	// DefaultFileMaxEntries is the maximum number of log entries written to a single file
+	DefaultFileMaxEntries = 10000
+	// DefaultFileMaxBufferedEntries is the maximum number of log entries buffered in memory while files fail to be written
+	DefaultFileMaxBufferedEntries = 100000
+	// fileWriteTimeout is the timeout of writing a single file to the object writer
+	fileWriteTimeout = 30 * time.Second
+)
+
+const inferenceLogParquetSchema = `message inference_log {
+	required binary request_id (STRING);
+	required int64 event_timestamp (TIMESTAMP(MILLIS, true));
+	required binary project_name (STRING);
+	required binary model_name (STRING);
+	required binary model_version (STRING);
+	optional binary request_headers (STRING);
+	optional binary request_body (STRING);
+	optional boolean request_truncated;
+	optional int32 response_status_code;
+	optional binary response_body (STRING);
+	optional boolean response_truncated;
+}`
+
+type FileSinkConfig struct {
+	Format       FileFormat
+	RollInterval time.Duration
+	MaxEntries   int
+	// MaxBufferedEntries is the maximum number of log entries kept for retrying the files which failed to be written,
+	// the oldest entries are dropped once it is reached. DefaultFileMaxBufferedEntries is used if not set.
+	MaxBufferedEntries int
+}
+
+// ParseFileSinkUrl parses the location and configuration of the file sink from the url
+//
+// The url is either a local directory, e.g. /var/log/inference?format=parquet, or a S3 compatible bucket,
+// e.g. s3://my-bucket/inference-log?format=ndjson&roll_interval=1m&max_entries=1000&max_buffered_entries=100000&endpoint=http://minio:9000.
+func ParseFileSinkUrl(rawUrl string) (*url.URL, *FileSinkConfig, error) {
+	location, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid file sink url %s: %w", rawUrl, err)
+	}
+	switch location.Scheme {
+	case "", "file", "s3":
+	default:
+		return nil, nil, fmt.Errorf("unsupported file sink scheme %s, must be either file or s3", location.Scheme)
+	}
+
+	query := location.Query()
+	config := &FileSinkConfig{
+		Format:             FileFormatNDJSON,
+		RollInterval:       DefaultFileRollInterval,
+		MaxEntries:         DefaultFileMaxEntries,
+		MaxBufferedEntries: DefaultFileMaxBufferedEntries,
+	}
+	if format := query.Get("format"); format != "" {
+		config.Format = FileFormat(format)
+		if config.Format != FileFormatNDJSON && config.Format != FileFormatParquet {
+			return nil, nil, fmt.Errorf("unsupported file format %s, must be either ndjson or parquet", format)
+		}
+	}
+	if rollInterval := query.Get("roll_interval"); rollInterval != "" {
+		config.RollInterval, err = time.ParseDuration(rollInterval)
+		if err != nil || config.RollInterval <= 0 {
+			return nil, nil, fmt.Errorf("invalid roll_interval %s", rollInterval)
+		}
+	}
+	if maxEntries := query.Get("max_entries"); maxEntries != "" {
+		config.MaxEntries, err = strconv.Atoi(maxEntries)
+		if err != nil || config.MaxEntries <= 0 {
+			return nil, nil, fmt.Errorf("invalid max_entries %s", maxEntries)
+		}
+	}
+	if maxBufferedEntries := query.Get("max_buffered_entries"); maxBufferedEntries != "" {
+		config.MaxBufferedEntries, err = strconv.Atoi(maxBufferedEntries)
+		if err != nil || config.MaxBufferedEntries <= 0 {
+			return nil, nil, fmt.Errorf("invalid max_buffered_entries %s", maxBufferedEntries)
+		}
+	}
+
+	return location, config, nil
+}
+
+// filePartition buffers the log entries which will be written to the same file
+type filePartition struct {
+	dir     string
+	entries []*LogEntry
+}
+
+// FileSink writes rolling, compressed NDJSON or Parquet files partitioned by model, version and hour
+//
+// Log entries are buffered in memory and written when the partition reaches MaxEntries, every RollInterval and on Close.
+// Files which fail to be written are retried on the next roll, as long as the buffered entries don't exceed
+// MaxBufferedEntries.
+type FileSink struct {
+	logger *zap.SugaredLogger
+	writer ObjectWriter
+	config FileSinkConfig
+
+	projectName  string
+	modelName    string
+	modelVersion string
+
+	mu         sync.Mutex
+	partitions map[string]*filePartition
+	closed     bool
+
+	quitChan chan struct{}
+	doneChan chan struct{}
+}
+
+func NewFileSink(
+	logger *zap.SugaredLogger,
+	writer ObjectWriter,
+	config FileSinkConfig,
+	projectName string,
+	modelName string,
+	modelVersion string,
+) *FileSink {
+	if config.MaxBufferedEntries <= 0 {
+		config.MaxBufferedEntries = DefaultFileMaxBufferedEntries
+	}
+	sink := &FileSink{
+		logger:       logger,
+		writer:       writer,
+		config:       config,
+		projectName:  projectName,
+		modelName:    modelName,
+		modelVersion: modelVersion,
+		partitions:   make(map[string]*filePartition),
+		quitChan:     make(chan struct{}),
+		doneChan:     make(chan struct{}),
+	}
+	go sink.roll()
+	return sink
+}
+
+func (f *FileSink) Sink(rawLogEntries []*LogEntry) error {
+	f.mu.Lock()
+	full := make([]*filePartition, 0)
+	for _, logEntry := range rawLogEntries {
+		dir := f.partitionDir(logEntry)
+		partition, ok := f.partitions[dir]
+		if !ok {
+			partition = &filePartition{dir: dir}
+			f.partitions[dir] = partition
+		}
+		partition.entries = append(partition.entries, logEntry)
+
+		if len(partition.entries) >= f.config.MaxEntries {
+			full = append(full, partition)
+			delete(f.partitions, dir)
+		}
+	}
+	if f.closed {
+		full = append(full, f.detachAll()...)
+	}
+	f.mu.Unlock()
+
+	f.writePartitions(full)
+	return nil
+}
+
+// Close stops rolling and writes all buffered log entries
+func (f *FileSink) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	f.mu.Unlock()
+
+	close(f.quitChan)
+	<-f.doneChan
+
+	f.mu.Lock()
+	partitions := f.detachAll()
+	f.mu.Unlock()
+	if failed := f.writePartitions(partitions); failed > 0 {
+		return fmt.Errorf("failed to write %d inference log files", failed)
+	}
+	return nil
+}
+
+func (f *FileSink) roll() {
+	defer close(f.doneChan)
+
+	ticker := time.NewTicker(f.config.RollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.quitChan:
+			return
+		case <-ticker.C:
+			f.mu.Lock()
+			partitions := f.detachAll()
+			f.mu.Unlock()
+			f.writePartitions(partitions)
+		}
+	}
+}
+
+// detachAll removes all buffered partitions, caller must hold the lock
+func (f *FileSink) detachAll() []*filePartition {
+	partitions := make([]*filePartition, 0, len(f.partitions))
+	for dir, partition := range f.partitions {
+		partitions = append(partitions, partition)
+		delete(f.partitions, dir)
+	}
+	return partitions
+}
+
+// writePartitions writes each partition to a new file and returns the number of files which failed to be written
+func (f *FileSink) writePartitions(partitions []*filePartition) int {
+	failed := 0
+	for _, partition := range partitions {
+		if err := f.writePartition(partition); err != nil {
+			f.logger.Errorf("failed to write %d log entries to %s: %v", len(partition.entries), partition.dir, err)
+			failed++
+			f.requeue(partition)
+		}
+	}
+	return failed
+}
+
+func (f *FileSink) writePartition(partition *filePartition) error {
+	var body []byte
+	var err error
+	switch f.config.Format {
+	case FileFormatParquet:
+		body, err = f.encodeParquet(partition.entries)
+	default:
+		body, err = f.encodeNDJSON(partition.entries)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fileWriteTimeout)
+	defer cancel()
+	return f.writer.WriteObject(ctx, path.Join(partition.dir, f.fileName()), body)
+}
+
+// requeue puts back the entries of a partition which failed to be written, unless the sink is closed. The oldest
+// entries of the partition are dropped if the buffered entries would exceed MaxBufferedEntries.
+func (f *FileSink) requeue(partition *filePartition) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		sinkDroppedEntries.WithLabelValues(sinkName(f), dropReasonSinkClosed).Add(float64(len(partition.entries)))
+		return
+	}
+
+	entries := partition.entries
+	if existing, ok := f.partitions[partition.dir]; ok {
+		entries = append(entries, existing.entries...)
+	}
+	available := f.config.MaxBufferedEntries - f.bufferedEntries(partition.dir)
+	if available < 0 {
+		available = 0
+	}
+	if dropped := len(entries) - available; dropped > 0 {
+		f.logger.Warnf("dropping %d log entries of %s, the file sink buffer is full", dropped, partition.dir)
+		sinkDroppedEntries.WithLabelValues(sinkName(f), dropReasonBufferFull).Add(float64(dropped))
+		entries = entries[dropped:]
+	}
+
+	if len(entries) == 0 {
+		delete(f.partitions, partition.dir)
+		return
+	}
+	f.partitions[partition.dir] = &filePartition{dir: partition.dir, entries: entries}
+}
+
+// bufferedEntries returns the number of buffered log entries of all partitions except the excluded one, caller must
+// hold the lock
+func (f *FileSink) bufferedEntries(excludedDir string) int {
+	total := 0
+	for dir, partition := range f.partitions {
+		if dir != excludedDir {
+			total += len(partition.entries)
+		}
+	}
+	return total
+}
+
+func (f *FileSink) partitionDir(logEntry *LogEntry) string {
+	eventTime := time.Now()
+	if logEntry.EventTimestamp != nil {
+		eventTime = logEntry.EventTimestamp.AsTime()
+	}
+	eventTime = eventTime.UTC()
+	return fmt.Sprintf("project=%s/model=%s/version=%s/date=%s/hour=%02d",
+		f.projectName, f.modelName, f.modelVersion, eventTime.Format("2006-01-02"), eventTime.Hour())
+}
+
+func (f *FileSink) fileName() string {
+	extension := "ndjson.gz"
+	if f.config.Format == FileFormatParquet {
+		extension = "parquet"
+	}
+	return fmt.Sprintf("%s-%s.%s", time.Now().UTC().Format("20060102T150405Z"), uuid.New().String(), extension)
+}
+
+type fileLogRecord struct {
+	RequestId          string            `json:"request_id"`
+	EventTimestamp     time.Time         `json:"event_timestamp"`
+	ProjectName        string            `json:"project_name"`
+	ModelName          string            `json:"model_name"`
+	ModelVersion       string            `json:"model_version"`
+	RequestHeaders     map[string]string `json:"request_headers,omitempty"`
+	RequestBody        string            `json:"request_body,omitempty"`
+	RequestTruncated   bool              `json:"request_truncated,omitempty"`
+	ResponseStatusCode int               `json:"response_status_code,omitempty"`
+	ResponseBody       string            `json:"response_body,omitempty"`
+	ResponseTruncated  bool              `json:"response_truncated,omitempty"`
+}
+
+func (f *FileSink) newFileLogRecord(logEntry *LogEntry) *fileLogRecord {
+	record := &fileLogRecord{
+		RequestId:    logEntry.RequestId,
+		ProjectName:  f.projectName,
+		ModelName:    f.modelName,
+		ModelVersion: f.modelVersion,
+	}
+	if logEntry.EventTimestamp != nil {
+		record.EventTimestamp = logEntry.EventTimestamp.AsTime()
+	}
+	if logEntry.RequestPayload != nil {
+		record.RequestHeaders = logEntry.RequestPayload.Headers
+		record.RequestBody = string(logEntry.RequestPayload.Body)
+		record.RequestTruncated = logEntry.RequestPayload.Truncated
+	}
+	if logEntry.ResponsePayload != nil {
+		record.ResponseStatusCode = logEntry.ResponsePayload.StatusCode
+		record.ResponseBody = string(logEntry.ResponsePayload.Body)
+		record.ResponseTruncated = logEntry.ResponsePayload.Truncated
+	}
+	return record
+}
+
+func (f *FileSink) encodeNDJSON(logEntries []*LogEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gzipWriter)
+	for _, logEntry := range logEntries {
+		if err := encoder.Encode(f.newFileLogRecord(logEntry)); err != nil {
+			return nil, fmt.Errorf("failed to encode log entry %s: %w", logEntry.RequestId, err)
+		}
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (f *FileSink) encodeParquet(logEntries []*LogEntry) ([]byte, error) {
+	schema, err := parquetschema.ParseSchemaDefinition(inferenceLogParquetSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fileWriter := goparquet.NewFileWriter(&buf,
+		goparquet.WithSchemaDefinition(schema),
+		goparquet.WithCompressionCodec(parquet.CompressionCodec_SNAPPY),
+		goparquet.WithCreator("merlin-inference-logger"),
+	)
+	for _, logEntry := range logEntries {
+		record := f.newFileLogRecord(logEntry)
+		row := map[string]interface{}{
+			"request_id":      []byte(record.RequestId),
+			"event_timestamp": record.EventTimestamp.UnixMilli(),
+			"project_name":    []byte(record.ProjectName),
+			"model_name":      []byte(record.ModelName),
+			"model_version":   []byte(record.ModelVersion),
+		}
+		if logEntry.RequestPayload != nil {
+			headers, err := json.Marshal(record.RequestHeaders)
+			if err != nil {
+				return nil, err
+			}
+			row["request_headers"] = headers
+			row["request_body"] = []byte(record.RequestBody)
+			row["request_truncated"] = record.RequestTruncated
+		}
+		if logEntry.ResponsePayload != nil {
+			row["response_status_code"] = int32(record.ResponseStatusCode)
+			row["response_body"] = []byte(record.ResponseBody)
+			row["response_truncated"] = record.ResponseTruncated
+		}
+		if err := fileWriter.AddData(row); err != nil {
+			return nil, fmt.Errorf("failed to encode log entry %s: %w", logEntry.RequestId, err)
+		}
+	}
+	if err := fileWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
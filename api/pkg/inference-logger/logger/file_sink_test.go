@@ -0,0 +1,299 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var fileSinkEventTime = time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+
+func newFileSinkLogEntry(id string) *LogEntry {
+	return &LogEntry{
+		RequestId:      id,
+		EventTimestamp: timestamppb.New(fileSinkEventTime),
+		RequestPayload: &RequestPayload{
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    []byte(`{"instances":[[1,2]]}`),
+		},
+		ResponsePayload: &ResponsePayload{
+			StatusCode: http.StatusOK,
+			Body:       []byte(`{"predictions":[1]}`),
+		},
+	}
+}
+
+// fakeS3Server is a minimal S3 compatible server which stores the objects put with path style addressing
+type fakeS3Server struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	f.mu.Lock()
+	f.objects[strings.TrimPrefix(r.URL.Path, "/")] = body
+	f.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeS3Server) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+type failingObjectWriter struct {
+	mu       sync.Mutex
+	failures int
+	objects  map[string][]byte
+}
+
+func (f *failingObjectWriter) WriteObject(_ context.Context, key string, body []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("storage unavailable")
+	}
+	f.objects[key] = body
+	return nil
+}
+
+func TestParseFileSinkUrl(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantScheme string
+		wantConfig *FileSinkConfig
+		wantErr    bool
+	}{
+		{
+			name:       "local directory with defaults",
+			url:        "/var/log/inference",
+			wantScheme: "",
+			wantConfig: &FileSinkConfig{Format: FileFormatNDJSON, RollInterval: DefaultFileRollInterval, MaxEntries: DefaultFileMaxEntries, MaxBufferedEntries: DefaultFileMaxBufferedEntries},
+		},
+		{
+			name:       "s3 bucket",
+			url:        "s3://my-bucket/inference-log?format=parquet&roll_interval=1m&max_entries=100&max_buffered_entries=1000&endpoint=http://minio:9000",
+			wantScheme: "s3",
+			wantConfig: &FileSinkConfig{Format: FileFormatParquet, RollInterval: time.Minute, MaxEntries: 100, MaxBufferedEntries: 1000},
+		},
+		{
+			name:    "unsupported scheme",
+			url:     "gs://my-bucket/inference-log",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			url:     "/var/log/inference?format=csv",
+			wantErr: true,
+		},
+		{
+			name:    "invalid max entries",
+			url:     "/var/log/inference?max_entries=0",
+			wantErr: true,
+		},
+		{
+			name:    "invalid max buffered entries",
+			url:     "/var/log/inference?max_buffered_entries=-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, config, err := ParseFileSinkUrl(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantScheme, location.Scheme)
+			assert.Equal(t, tt.wantConfig, config)
+		})
+	}
+}
+
+func TestFileSink_NDJSONToLocalDirectory(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewFileSink(logger, NewLocalObjectWriter(dir), FileSinkConfig{
+		Format:       FileFormatNDJSON,
+		RollInterval: time.Hour,
+		MaxEntries:   2,
+	}, "my-project", "my-model", "1")
+
+	// the first two entries fill up the partition and are written immediately
+	require.NoError(t, sink.Sink([]*LogEntry{newFileSinkLogEntry("1"), newFileSinkLogEntry("2"), newFileSinkLogEntry("3")}))
+	partitionDir := filepath.Join(dir, "project=my-project", "model=my-model", "version=1", "date=2024-03-15", "hour=09")
+	files, err := filepath.Glob(filepath.Join(partitionDir, "*.ndjson.gz"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// the remaining entry is written on close
+	require.NoError(t, sink.Close())
+	files, err = filepath.Glob(filepath.Join(partitionDir, "*.ndjson.gz"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	requestIds := make([]string, 0)
+	for _, file := range files {
+		for _, record := range readNDJSONFile(t, file) {
+			assert.Equal(t, "my-model", record.ModelName)
+			assert.Equal(t, fileSinkEventTime, record.EventTimestamp)
+			assert.Equal(t, http.StatusOK, record.ResponseStatusCode)
+			assert.Equal(t, `{"predictions":[1]}`, record.ResponseBody)
+			requestIds = append(requestIds, record.RequestId)
+		}
+	}
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, requestIds)
+}
+
+func TestFileSink_ParquetToS3(t *testing.T) {
+	server := &fakeS3Server{objects: map[string][]byte{}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	s3Client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: &httpServer.URL,
+		UsePathStyle: true,
+	})
+	sink := NewFileSink(logger, NewS3ObjectWriter(s3Client, "my-bucket", "/inference-log/"), FileSinkConfig{
+		Format:       FileFormatParquet,
+		RollInterval: 10 * time.Millisecond,
+		MaxEntries:   100,
+	}, "my-project", "my-model", "1")
+	defer sink.Close() //nolint:errcheck
+
+	responseOnly := newFileSinkLogEntry("2")
+	responseOnly.RequestPayload = nil
+	require.NoError(t, sink.Sink([]*LogEntry{newFileSinkLogEntry("1"), responseOnly}))
+
+	// entries are written on the next roll
+	require.Eventually(t, func() bool {
+		return len(server.keys()) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	key := server.keys()[0]
+	assert.True(t, strings.HasPrefix(key, "my-bucket/inference-log/project=my-project/model=my-model/version=1/date=2024-03-15/hour=09/"))
+	assert.True(t, strings.HasSuffix(key, ".parquet"))
+
+	server.mu.Lock()
+	reader, err := goparquet.NewFileReader(bytes.NewReader(server.objects[key]))
+	server.mu.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), reader.NumRows())
+
+	row, err := reader.NextRow()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), row["request_id"])
+	assert.Equal(t, fileSinkEventTime.UnixMilli(), row["event_timestamp"])
+	assert.Equal(t, []byte(`{"instances":[[1,2]]}`), row["request_body"])
+	assert.Equal(t, int32(http.StatusOK), row["response_status_code"])
+
+	row, err = reader.NextRow()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), row["request_id"])
+	assert.NotContains(t, row, "request_body")
+}
+
+func TestFileSink_RetryFailedWrite(t *testing.T) {
+	writer := &failingObjectWriter{failures: 1, objects: map[string][]byte{}}
+	sink := NewFileSink(logger, writer, FileSinkConfig{
+		Format:       FileFormatNDJSON,
+		RollInterval: 10 * time.Millisecond,
+		MaxEntries:   1,
+	}, "my-project", "my-model", "1")
+	defer sink.Close() //nolint:errcheck
+
+	require.NoError(t, sink.Sink([]*LogEntry{newFileSinkLogEntry("1")}))
+
+	require.Eventually(t, func() bool {
+		writer.mu.Lock()
+		defer writer.mu.Unlock()
+		return len(writer.objects) == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestFileSink_RequeueDropsOverflow(t *testing.T) {
+	writer := &failingObjectWriter{failures: 100, objects: map[string][]byte{}}
+	sink := NewFileSink(logger, writer, FileSinkConfig{
+		Format:             FileFormatNDJSON,
+		RollInterval:       time.Hour,
+		MaxEntries:         2,
+		MaxBufferedEntries: 3,
+	}, "my-project", "my-model", "1")
+
+	droppedBefore := testutil.ToFloat64(sinkDroppedEntries.WithLabelValues("FileSink", dropReasonBufferFull))
+
+	// every full partition fails to be written and is requeued until the buffer is full
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sink.Sink([]*LogEntry{newFileSinkLogEntry(fmt.Sprintf("%d-1", i)), newFileSinkLogEntry(fmt.Sprintf("%d-2", i))}))
+	}
+
+	sink.mu.Lock()
+	assert.Equal(t, 3, sink.bufferedEntries(""))
+	sink.mu.Unlock()
+	assert.Equal(t, droppedBefore+3, testutil.ToFloat64(sinkDroppedEntries.WithLabelValues("FileSink", dropReasonBufferFull)))
+
+	writer.mu.Lock()
+	writer.failures = 0
+	writer.mu.Unlock()
+	require.NoError(t, sink.Close())
+
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+	assert.Len(t, writer.objects, 1)
+}
+
+func readNDJSONFile(t *testing.T, file string) []*fileLogRecord {
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+
+	gzipReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	records := make([]*fileLogRecord, 0)
+	scanner := bufio.NewScanner(gzipReader)
+	for scanner.Scan() {
+		record := &fileLogRecord{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const grpcContentType = "application/grpc"
+
+// merlinLogIdMetadataKey, requestIdMetadataKey and mirroredMetadataKey are the gRPC metadata keys of the log id, request
+// id and mirrored flag, gRPC metadata keys are lowercase
+var (
+	merlinLogIdMetadataKey = strings.ToLower(MerlinLogIdHeader)
+	requestIdMetadataKey   = strings.ToLower(RequestIdHeader)
+	mirroredMetadataKey    = strings.ToLower(MirroredHeader)
+)
+
+// authorityMetadataKey is the metadata key of the request's authority, i.e. its host
+const authorityMetadataKey = ":authority"
+
+// UPILoggerServer proxies UPI PredictValues calls to the model and logs the request and response
+type UPILoggerServer struct {
+	upiv1.UnimplementedUniversalPredictionServiceServer
+
+	routes []*Route
+	// maxBodyBytes is the maximum size of captured request and response body, negative means unlimited
+	maxBodyBytes int
+	client       upiv1.UniversalPredictionServiceClient
+	logger       *zap.SugaredLogger
+}
+
+// NewUPILoggerServer creates gRPC server which forwards UPI calls to the model server using client
+// maxBodyBytes of 0 captures up to DefaultMaxBodyBytes, whereas UnlimitedBodyBytes captures the whole body
+func NewUPILoggerServer(routes []*Route, maxBodyBytes int, client upiv1.UniversalPredictionServiceClient, logger *zap.SugaredLogger) *UPILoggerServer {
+	return &UPILoggerServer{
+		routes:       routes,
+		maxBodyBytes: capturedBodyLimit(maxBodyBytes),
+		client:       client,
+		logger:       logger,
+	}
+}
+
+// PredictValues forwards the request and its metadata to the model and submits the captured request and response to the dispatcher
+func (s *UPILoggerServer) PredictValues(ctx context.Context, request *upiv1.PredictValuesRequest) (*upiv1.PredictValuesResponse, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	md = md.Copy()
+
+	id := getOrCreateGrpcID(md)
+	md.Set(merlinLogIdMetadataKey, id)
+	logEntry := &LogEntry{
+		RequestId:      id,
+		EventTimestamp: timestamppb.Now(),
+		Mirrored:       isMirroredGrpcRequest(md),
+		UPI:            true,
+	}
+
+	if routesLogRequest(s.routes) {
+		body, truncated := truncateBody(marshalUPIMessage(request, s.logger), s.maxBodyBytes)
+		logEntry.RequestPayload = &RequestPayload{
+			Headers:   formatMetadata(md),
+			Body:      body,
+			Truncated: truncated,
+		}
+	}
+
+	var err error
+	defer func() {
+		submitToRoutes(s.routes, logEntry, err != nil, s.logger)
+	}()
+
+	var header, trailer metadata.MD
+	var response *upiv1.PredictValuesResponse
+	response, err = s.client.PredictValues(metadata.NewOutgoingContext(ctx, md), request, grpc.Header(&header), grpc.Trailer(&trailer))
+	if len(header) > 0 {
+		if headerErr := grpc.SetHeader(ctx, header); headerErr != nil {
+			s.logger.Warnf("failed to set response header: %v", headerErr)
+		}
+	}
+	if len(trailer) > 0 {
+		grpc.SetTrailer(ctx, trailer) //nolint:errcheck
+	}
+
+	if routesLogResponse(s.routes) {
+		logEntry.ResponsePayload = &ResponsePayload{
+			StatusCode: int(status.Code(err)),
+		}
+		var body []byte
+		if err != nil {
+			body = []byte(status.Convert(err).Message())
+		} else {
+			body = marshalUPIMessage(response, s.logger)
+		}
+		logEntry.ResponsePayload.Body, logEntry.ResponsePayload.Truncated = truncateBody(body, s.maxBodyBytes)
+	}
+
+	return response, err
+}
+
+// NewGrpcHandler routes gRPC requests to grpcServer and the rest of requests to next
+//
+// Both handlers can then share the same port, e.g. so that the HTTP readiness probe keeps working for UPI models.
+func NewGrpcHandler(grpcServer *grpc.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), grpcContentType) {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func getOrCreateGrpcID(md metadata.MD) string {
+	for _, key := range []string{merlinLogIdMetadataKey, requestIdMetadataKey} {
+		if ids := md.Get(key); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return uuid.New().String()
+}
+
+func isMirroredGrpcRequest(md metadata.MD) bool {
+	if mirrored := md.Get(mirroredMetadataKey); len(mirrored) > 0 && mirrored[0] == "true" {
+		return true
+	}
+	authorities := md.Get(authorityMetadataKey)
+	return len(authorities) > 0 && isMirroredHost(authorities[0])
+}
+
+func formatMetadata(md metadata.MD) map[string]string {
+	formatted := map[string]string{}
+	for k, v := range md {
+		formatted[k] = strings.Join(v, ",")
+	}
+	return formatted
+}
+
+// marshalUPIMessage encodes the message as JSON so that the payload is readable by all log sinks
+func marshalUPIMessage(message proto.Message, logger *zap.SugaredLogger) []byte {
+	body, err := protojson.Marshal(message)
+	if err != nil {
+		logger.Warnf("failed to marshal %T: %v", message, err)
+	}
+	return body
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeUPIModelServer struct {
+	upiv1.UnimplementedUniversalPredictionServiceServer
+	err error
+}
+
+func (f *fakeUPIModelServer) PredictValues(ctx context.Context, request *upiv1.PredictValuesRequest) (*upiv1.PredictValuesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("model-header", "model"))
+	return &upiv1.PredictValuesResponse{
+		TargetName: request.TargetName,
+		Metadata: &upiv1.ResponseMetadata{
+			PredictionId: md.Get(merlinLogIdMetadataKey)[0],
+		},
+	}, nil
+}
+
+func startBufconnServer(t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	register(server)
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+	return conn
+}
+
+func TestUPILoggerServer_PredictValues(t *testing.T) {
+	tests := []struct {
+		name           string
+		logMode        LogMode
+		modelErr       error
+		requestId      string
+		wantStatusCode codes.Code
+		wantRequest    bool
+		wantResponse   bool
+		wantBody       string
+	}{
+		{
+			name:           "log request and response",
+			logMode:        LogModeAll,
+			requestId:      "my-request-id",
+			wantStatusCode: codes.OK,
+			wantRequest:    true,
+			wantResponse:   true,
+			wantBody:       `{"targetName":"probability","metadata":{"predictionId":"my-request-id"}}`,
+		},
+		{
+			name:           "log request only",
+			logMode:        LogModeRequestOnly,
+			wantStatusCode: codes.OK,
+			wantRequest:    true,
+		},
+		{
+			name:           "model returns error",
+			logMode:        LogModeResponseOnly,
+			modelErr:       status.Error(codes.InvalidArgument, "invalid prediction table"),
+			wantStatusCode: codes.InvalidArgument,
+			wantResponse:   true,
+			wantBody:       "invalid prediction table",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelConn := startBufconnServer(t, func(s *grpc.Server) {
+				upiv1.RegisterUniversalPredictionServiceServer(s, &fakeUPIModelServer{err: tt.modelErr})
+			})
+
+			sink := &flakySink{}
+			dispatcher := NewDispatcher(1, workQueueSize, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 1}, logger, sink)
+			dispatcher.Start()
+			defer dispatcher.Stop()
+
+			loggerConn := startBufconnServer(t, func(s *grpc.Server) {
+				upiv1.RegisterUniversalPredictionServiceServer(s, NewUPILoggerServer([]*Route{{Dispatcher: dispatcher, LogMode: tt.logMode}}, 0, upiv1.NewUniversalPredictionServiceClient(modelConn), logger))
+			})
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-client-id", "my-client")
+			if tt.requestId != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, merlinLogIdMetadataKey, tt.requestId)
+			}
+
+			var header metadata.MD
+			response, err := upiv1.NewUniversalPredictionServiceClient(loggerConn).PredictValues(ctx, &upiv1.PredictValuesRequest{
+				TargetName: "probability",
+			}, grpc.Header(&header))
+			assert.Equal(t, tt.wantStatusCode, status.Code(err))
+			if err == nil {
+				assert.Equal(t, "probability", response.TargetName)
+				assert.Equal(t, []string{"model"}, header.Get("model-header"))
+			}
+
+			require.Eventually(t, func() bool {
+				return len(sink.sunkEntries()) == 1
+			}, time.Second, 5*time.Millisecond)
+			logEntry := sink.sunkEntries()[0]
+
+			assert.NotEmpty(t, logEntry.RequestId)
+			assert.True(t, logEntry.UPI)
+			if tt.requestId != "" {
+				assert.Equal(t, tt.requestId, logEntry.RequestId)
+			}
+
+			if tt.wantRequest {
+				require.NotNil(t, logEntry.RequestPayload)
+				assert.JSONEq(t, `{"targetName":"probability"}`, string(logEntry.RequestPayload.Body))
+				assert.Equal(t, "my-client", logEntry.RequestPayload.Headers["x-client-id"])
+			} else {
+				assert.Nil(t, logEntry.RequestPayload)
+			}
+
+			if tt.wantResponse {
+				require.NotNil(t, logEntry.ResponsePayload)
+				assert.Equal(t, int(tt.wantStatusCode), logEntry.ResponsePayload.StatusCode)
+				if tt.modelErr == nil {
+					assert.JSONEq(t, tt.wantBody, string(logEntry.ResponsePayload.Body))
+				} else {
+					assert.Equal(t, tt.wantBody, string(logEntry.ResponsePayload.Body))
+				}
+			} else {
+				assert.Nil(t, logEntry.ResponsePayload)
+			}
+		})
+	}
+}
+
+func TestNewGrpcHandler_NonGrpcRequest(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := NewGrpcHandler(grpc.NewServer(), next)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/models/my-model:predict", nil))
+	assert.Equal(t, http.StatusTeapot, rr.Code)
+}
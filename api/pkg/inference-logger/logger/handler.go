@@ -1,12 +1,11 @@
 package logger
 
 import (
-	"bytes"
-	"io"
+	"net"
 	"net/http"
-	"net/http/httptest"
 	"strings"
 
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -24,21 +23,39 @@ const (
 	KubeletProbeHeaderName = "K-Kubelet-Probe"
 
 	MerlinLogIdHeader = "X-Merlin-Log-Id"
+
+	// RequestIdHeader is set by Envoy and propagated to mirrored requests, so that a request and its mirror share the log id
+	RequestIdHeader = "X-Request-Id"
+
+	// MirroredHeader is set by the mirror route of the model endpoint on mirrored requests, and added to the logged
+	// request headers of mirrored requests
+	MirroredHeader = "X-Merlin-Mirrored"
+
+	// RequestTruncatedHeader and ResponseTruncatedHeader are added to the logged request headers if the request or
+	// response body exceeds the maximum captured body size
+	RequestTruncatedHeader  = "X-Merlin-Request-Truncated"
+	ResponseTruncatedHeader = "X-Merlin-Response-Truncated"
+
+	// mirroredHostSuffix is appended by Istio to the host of mirrored requests
+	mirroredHostSuffix = "-shadow"
 )
 
 type LoggerHandler struct {
-	dispatcher *Dispatcher
-	logMode    LogMode
-	next       http.Handler
-	logger     *zap.SugaredLogger
+	routes []*Route
+	// maxBodyBytes is the maximum size of captured request and response body, negative means unlimited
+	maxBodyBytes int
+	next         http.Handler
+	logger       *zap.SugaredLogger
 }
 
-func NewLoggerHandler(dispatcher *Dispatcher, logMode LogMode, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+// NewLoggerHandler creates handler that logs request and response proxied to next to every route
+// maxBodyBytes of 0 captures up to DefaultMaxBodyBytes, whereas UnlimitedBodyBytes captures the whole body
+func NewLoggerHandler(routes []*Route, maxBodyBytes int, next http.Handler, logger *zap.SugaredLogger) http.Handler {
 	return &LoggerHandler{
-		dispatcher: dispatcher,
-		logMode:    logMode,
-		next:       next,
-		logger:     logger,
+		routes:       routes,
+		maxBodyBytes: capturedBodyLimit(maxBodyBytes),
+		next:         next,
+		logger:       logger,
 	}
 }
 
@@ -55,65 +72,92 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	logEntry := &LogEntry{
 		RequestId:      id,
 		EventTimestamp: timestamppb.Now(),
+		Mirrored:       isMirroredRequest(r),
 	}
 
-	requestBody, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, "can't read body", http.StatusBadRequest)
-		return
-	}
+	logRequest := routesLogRequest(eh.routes)
+	logResponse := routesLogResponse(eh.routes)
 
-	// Read Request Payload
-	if eh.logMode == LogModeAll || eh.logMode == LogModeRequestOnly {
-		logEntry.RequestPayload = &RequestPayload{
-			Headers: formatHeader(r.Header),
-			Body:    requestBody,
-		}
+	// Request and response are streamed while being captured, so that the logger doesn't add latency to streaming responses
+	var requestCapture, responseCapture *captureBuffer
+	if logRequest {
+		requestCapture = newCaptureBuffer(eh.maxBodyBytes)
+		r.Body = &captureReadCloser{ReadCloser: r.Body, capture: requestCapture}
 	}
+	if logResponse {
+		responseCapture = newCaptureBuffer(eh.maxBodyBytes)
+	}
+	requestHeaders := formatHeader(r.Header)
+	cw := newCaptureResponseWriter(w, responseCapture)
 
 	defer func() {
-		if err := eh.dispatcher.Submit(logEntry); err != nil {
-			eh.logger.Errorf("error submitting log entry: %v", err)
+		if logRequest {
+			logEntry.RequestPayload = &RequestPayload{
+				Headers:   requestHeaders,
+				Body:      requestCapture.Bytes(),
+				Truncated: requestCapture.Truncated(),
+			}
+		}
+		if logResponse {
+			logEntry.ResponsePayload = &ResponsePayload{
+				StatusCode: cw.statusCode,
+				Body:       responseCapture.Bytes(),
+				Truncated:  responseCapture.Truncated(),
+			}
 		}
+
+		submitToRoutes(eh.routes, logEntry, cw.statusCode >= http.StatusBadRequest, eh.logger)
 	}()
 
 	r.Header.Set(MerlinLogIdHeader, id)
 	// Proxy Request
-	r.Body = io.NopCloser(bytes.NewBuffer(requestBody))
-	rr := httptest.NewRecorder()
-	eh.next.ServeHTTP(rr, r)
-
-	copyHeader(w.Header(), rr.Header())
-	respBody, err := io.ReadAll(rr.Body)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	eh.next.ServeHTTP(cw, r)
+}
 
-	// Read Response Payload
-	if eh.logMode == LogModeAll || eh.logMode == LogModeResponseOnly {
-		logEntry.ResponsePayload = &ResponsePayload{
-			StatusCode: rr.Code,
-			Body:       respBody,
-		}
+// applyFilter filters headers and redacts body of the log entry
+//
+// Truncated body can't be parsed, hence it is replaced with redactedTruncatedBody if there is any redaction rule.
+func applyFilter(logEntry *LogEntry, filter *rules.Filter) {
+	if logEntry.RequestPayload != nil {
+		logEntry.RequestPayload.Headers = filter.FilterHeaders(logEntry.RequestPayload.Headers)
+		logEntry.RequestPayload.Body = redactBody(logEntry.RequestPayload.Body, logEntry.RequestPayload.Truncated, filter)
 	}
+	if logEntry.ResponsePayload != nil {
+		logEntry.ResponsePayload.Body = redactBody(logEntry.ResponsePayload.Body, logEntry.ResponsePayload.Truncated, filter)
+	}
+}
 
-	w.WriteHeader(rr.Code)
-	_, err = w.Write(respBody)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+func redactBody(body []byte, truncated bool, filter *rules.Filter) []byte {
+	if truncated && filter.HasRedactionRules() {
+		return []byte(redactedTruncatedBody)
 	}
+	return filter.RedactBody(body)
 }
 
 func getOrCreateID(r *http.Request) string {
 	id := r.Header.Get(MerlinLogIdHeader)
+	if id == "" {
+		id = r.Header.Get(RequestIdHeader)
+	}
 	if id == "" {
 		id = uuid.New().String()
 	}
 	return id
 }
 
+// isMirroredRequest returns true if the request is flagged with MirroredHeader or is sent to the host of a mirrored request
+func isMirroredRequest(r *http.Request) bool {
+	return r.Header.Get(MirroredHeader) == "true" || isMirroredHost(r.Host)
+}
+
+// isMirroredHost returns true if the host, with or without port, is the host of a request mirrored by Istio
+func isMirroredHost(host string) bool {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return strings.HasSuffix(host, mirroredHostSuffix)
+}
+
 func formatHeader(header http.Header) map[string]string {
 	formatted := map[string]string{}
 	for k, v := range header {
@@ -131,11 +175,3 @@ func isKubeletProbe(r *http.Request) bool {
 	return strings.HasPrefix(r.Header.Get("User-Agent"), KubeProbeUAPrefix) ||
 		r.Header.Get(KubeletProbeHeaderName) != ""
 }
-
-func copyHeader(dst, src http.Header) {
-	for k, vv := range src {
-		for _, v := range vv {
-			dst.Set(k, v)
-		}
-	}
-}
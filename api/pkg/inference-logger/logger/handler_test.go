@@ -9,6 +9,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,9 +17,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/caraml-dev/merlin/pkg/inference-logger/mocks"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 )
 
 func Test(t *testing.T) {
@@ -163,7 +166,7 @@ func Test(t *testing.T) {
 					dispatcher := NewDispatcher(10, 100, workerConfig, logger, NewNewRelicSink(zapLogger, mockNewRelicLogsClient, serviceName, projectName, modelName, modelVersion), NewConsoleSink(logger))
 					dispatcher.Start()
 					httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-					oh := NewLoggerHandler(dispatcher, LogModeAll, httpProxy, logger)
+					oh := NewLoggerHandler([]*Route{{Dispatcher: dispatcher, LogMode: LogModeAll}}, 0, httpProxy, logger)
 
 					oh.ServeHTTP(w, r)
 
@@ -190,21 +193,21 @@ func Test(t *testing.T) {
 
 					mockKafkaProducer := &mocks.KafkaProducer{}
 					mockKafkaProducer.On("Events", mock.Anything, mock.Anything).Return(nil)
-					mockKafkaProducer.On("Produce", mock.Anything, mock.Anything).Return(nil)
+					mockKafkaProducer.On("Produce", mock.Anything, mock.Anything).Run(deliverKafkaMessage(nil)).Return(nil)
 					mockKafkaAdmin := &mocks.KafkaAdmin{}
 					mockKafkaAdmin.On("CreateTopics", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]kafka.TopicResult{{Error: kafka.NewError(kafka.ErrNoError, "", false)}}, nil)
 					workerConfig := &WorkerConfig{
 						MinBatchSize: 1,
 						MaxBatchSize: 5,
 					}
-					kafkaSink, err := NewKafkaSink(zapLogger, mockKafkaProducer, mockKafkaAdmin, projectName, modelName, modelVersion)
+					kafkaSink, err := NewKafkaSink(zapLogger, mockKafkaProducer, mockKafkaAdmin, KafkaSinkConfig{}, projectName, modelName, modelVersion)
 					if err != nil {
 						t.Errorf("failed to create kafka sink: %v", err)
 					}
 					dispatcher := NewDispatcher(10, 100, workerConfig, logger, kafkaSink, NewConsoleSink(logger))
 					dispatcher.Start()
 					httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-					oh := NewLoggerHandler(dispatcher, LogModeAll, httpProxy, logger)
+					oh := NewLoggerHandler([]*Route{{Dispatcher: dispatcher, LogMode: LogModeAll}}, 0, httpProxy, logger)
 
 					oh.ServeHTTP(w, r)
 
@@ -235,3 +238,249 @@ func IsValidUUID(uuid string) bool {
 	r := regexp.MustCompile("^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}$")
 	return r.MatchString(uuid)
 }
+
+func TestLoggerHandler_WithRules(t *testing.T) {
+	zero := 0.0
+	filter, err := rules.NewFilter(&rules.Rules{
+		SamplingRate:    &zero,
+		AlwaysLogErrors: true,
+		HeaderDenylist:  []string{"Authorization"},
+		RedactionRules: []rules.RedactionRule{
+			{JsonPath: "$.customer.email", Action: rules.ActionRedact},
+		},
+	}, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantLogged bool
+	}{
+		{
+			name:       "successful request is not sampled",
+			statusCode: http.StatusOK,
+			wantLogged: false,
+		},
+		{
+			name:       "failed request is always logged",
+			statusCode: http.StatusInternalServerError,
+			wantLogged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tt.statusCode)
+				_, _ = rw.Write([]byte(`{"customer":{"email":"john@example.com"}}`))
+			}))
+			defer predictor.Close()
+			targetUri, err := url.Parse(predictor.URL)
+			require.NoError(t, err)
+
+			sink := &flakySink{}
+			dispatcher := NewDispatcher(1, workQueueSize, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 1}, logger, sink)
+			dispatcher.Start()
+			defer dispatcher.Stop()
+
+			r := httptest.NewRequest("POST", "http://a", bytes.NewReader([]byte(`{"customer":{"email":"john@example.com"}}`)))
+			r.Header.Set("Authorization", "Bearer token")
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			NewLoggerHandler([]*Route{{Dispatcher: dispatcher, LogMode: LogModeAll, Filter: filter}}, 0, httputil.NewSingleHostReverseProxy(targetUri), logger).ServeHTTP(w, r)
+
+			// response returned to the client must not be redacted
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, `{"customer":{"email":"john@example.com"}}`, w.Body.String())
+
+			if !tt.wantLogged {
+				time.Sleep(10 * time.Millisecond)
+				assert.Empty(t, sink.sunkEntries())
+				return
+			}
+
+			require.Eventually(t, func() bool {
+				return len(sink.sunkEntries()) == 1
+			}, time.Second, 5*time.Millisecond)
+			logEntry := sink.sunkEntries()[0]
+			assert.Equal(t, `{"customer":{"email":"[REDACTED]"}}`, string(logEntry.RequestPayload.Body))
+			assert.Equal(t, `{"customer":{"email":"[REDACTED]"}}`, string(logEntry.ResponsePayload.Body))
+			assert.NotContains(t, logEntry.RequestPayload.Headers, "Authorization")
+			assert.Equal(t, "application/json", logEntry.RequestPayload.Headers["Content-Type"])
+		})
+	}
+}
+
+func TestLoggerHandler_StreamingResponse(t *testing.T) {
+	firstChunkReceived := make(chan struct{})
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = io.ReadAll(req.Body)
+		_, _ = rw.Write([]byte("chunk-1;"))
+		rw.(http.Flusher).Flush()
+
+		// the next chunk is only sent after the client received the first one
+		select {
+		case <-firstChunkReceived:
+		case <-time.After(time.Second):
+			t.Error("first chunk was not streamed to the client")
+		}
+		_, _ = rw.Write([]byte("chunk-2;"))
+	}))
+	defer predictor.Close()
+	targetUri, err := url.Parse(predictor.URL)
+	require.NoError(t, err)
+
+	sink := &flakySink{}
+	dispatcher := NewDispatcher(1, workQueueSize, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 1}, logger, sink)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	loggerServer := httptest.NewServer(NewLoggerHandler([]*Route{{Dispatcher: dispatcher, LogMode: LogModeAll}}, 10, httputil.NewSingleHostReverseProxy(targetUri), logger))
+	defer loggerServer.Close()
+
+	resp, err := http.Post(loggerServer.URL, "text/plain", strings.NewReader("request-body"))
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+
+	chunk := make([]byte, len("chunk-1;"))
+	_, err = io.ReadFull(resp.Body, chunk)
+	require.NoError(t, err)
+	assert.Equal(t, "chunk-1;", string(chunk))
+	close(firstChunkReceived)
+
+	rest, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "chunk-2;", string(rest))
+
+	require.Eventually(t, func() bool {
+		return len(sink.sunkEntries()) == 1
+	}, time.Second, 5*time.Millisecond)
+	logEntry := sink.sunkEntries()[0]
+
+	assert.Equal(t, "request-bo...[truncated 2 bytes]", string(logEntry.RequestPayload.Body))
+	assert.True(t, logEntry.RequestPayload.Truncated)
+	assert.Equal(t, http.StatusOK, logEntry.ResponsePayload.StatusCode)
+	assert.Equal(t, "chunk-1;ch...[truncated 6 bytes]", string(logEntry.ResponsePayload.Body))
+	assert.True(t, logEntry.ResponsePayload.Truncated)
+}
+
+func TestLoggerHandler_DefaultMaxBodyBytes(t *testing.T) {
+	responseBody := strings.Repeat("a", DefaultMaxBodyBytes+1)
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(responseBody))
+	}))
+	defer predictor.Close()
+	targetUri, err := url.Parse(predictor.URL)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		maxBodyBytes  int
+		wantTruncated bool
+	}{
+		{
+			name:          "default max body bytes",
+			maxBodyBytes:  0,
+			wantTruncated: true,
+		},
+		{
+			name:          "unlimited body bytes",
+			maxBodyBytes:  UnlimitedBodyBytes,
+			wantTruncated: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &flakySink{}
+			dispatcher := NewDispatcher(1, workQueueSize, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 1}, logger, sink)
+			dispatcher.Start()
+			defer dispatcher.Stop()
+
+			r := httptest.NewRequest("POST", "http://a", strings.NewReader("request-body"))
+			w := httptest.NewRecorder()
+			NewLoggerHandler([]*Route{{Dispatcher: dispatcher, LogMode: LogModeAll}}, tt.maxBodyBytes, httputil.NewSingleHostReverseProxy(targetUri), logger).ServeHTTP(w, r)
+			assert.Equal(t, responseBody, w.Body.String())
+
+			require.Eventually(t, func() bool {
+				return len(sink.sunkEntries()) == 1
+			}, time.Second, 5*time.Millisecond)
+			logEntry := sink.sunkEntries()[0]
+
+			assert.Equal(t, "request-body", string(logEntry.RequestPayload.Body))
+			assert.Equal(t, tt.wantTruncated, logEntry.ResponsePayload.Truncated)
+			if tt.wantTruncated {
+				assert.Equal(t, responseBody[:DefaultMaxBodyBytes]+"...[truncated 1 bytes]", string(logEntry.ResponsePayload.Body))
+			} else {
+				assert.Equal(t, responseBody, string(logEntry.ResponsePayload.Body))
+			}
+		})
+	}
+}
+
+func TestApplyFilter_TruncatedBody(t *testing.T) {
+	filter, err := rules.NewFilter(&rules.Rules{
+		RedactionRules: []rules.RedactionRule{
+			{JsonPath: "$.customer.email", Action: rules.ActionRedact},
+		},
+	}, "")
+	require.NoError(t, err)
+
+	logEntry := &LogEntry{
+		RequestPayload:  &RequestPayload{Body: []byte(`{"customer":{"em...[truncated 20 bytes]`), Truncated: true},
+		ResponsePayload: &ResponsePayload{Body: []byte(`{"customer":{"email":"a@b.c"}}`)},
+	}
+	applyFilter(logEntry, filter)
+
+	assert.Equal(t, "[REDACTED]...[truncated]", string(logEntry.RequestPayload.Body))
+	assert.True(t, logEntry.RequestPayload.Truncated)
+	assert.Equal(t, `{"customer":{"email":"[REDACTED]"}}`, string(logEntry.ResponsePayload.Body))
+	assert.False(t, logEntry.ResponsePayload.Truncated)
+}
+
+func TestGetOrCreateID(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name:    "merlin log id",
+			headers: map[string]string{MerlinLogIdHeader: "log-id", RequestIdHeader: "request-id"},
+			want:    "log-id",
+		},
+		{
+			name:    "envoy request id",
+			headers: map[string]string{RequestIdHeader: "request-id"},
+			want:    "request-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/models/model:predict", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			assert.Equal(t, tt.want, getOrCreateID(r))
+		})
+	}
+
+	assert.NotEmpty(t, getOrCreateID(httptest.NewRequest(http.MethodPost, "/v1/models/model:predict", nil)))
+}
+
+func TestIsMirroredHost(t *testing.T) {
+	assert.True(t, isMirroredHost("model-1-shadow"))
+	assert.True(t, isMirroredHost("model-1.project.models.example.com-shadow:8080"))
+	assert.False(t, isMirroredHost("model-1.project.models.example.com"))
+	assert.False(t, isMirroredHost("localhost:8080"))
+}
+
+func TestIsMirroredRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "http://model-1.project.models.example.com/v1/models/model-1:predict", nil)
+	assert.False(t, isMirroredRequest(r))
+
+	r.Header.Set(MirroredHeader, "true")
+	assert.True(t, isMirroredRequest(r))
+
+	r = httptest.NewRequest(http.MethodPost, "http://model-1.project.models.example.com-shadow/v1/models/model-1:predict", nil)
+	assert.True(t, isMirroredRequest(r))
+}
@@ -0,0 +1,232 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	mlogs "github.com/caraml-dev/merlin/pkg/log"
+)
+
+const (
+	// DefaultHTTPSinkMaxRetries is the default number of retries of a failed batch
+	DefaultHTTPSinkMaxRetries = 3
+	// DefaultHTTPSinkInitialBackoff is the default upper bound of the jittered backoff before the first retry
+	DefaultHTTPSinkInitialBackoff = 200 * time.Millisecond
+	// DefaultHTTPSinkMaxBackoff is the default upper bound of the jittered backoff between retries
+	DefaultHTTPSinkMaxBackoff = 5 * time.Second
+	// DefaultHTTPSinkAuthHeader is the default header carrying the auth token
+	DefaultHTTPSinkAuthHeader = "Authorization"
+)
+
+var ErrNonRetryableResponse = errors.New("non retryable response")
+
+type HTTPSinkConfig struct {
+	// Url is the endpoint the batches are POSTed to
+	Url string
+	// AuthHeader and AuthToken are added to every request if AuthToken is not empty
+	AuthHeader string
+	AuthToken  string
+	// Gzip compresses the request body
+	Gzip bool
+	// MaxRetries is the number of retries of a batch on connection error, 429 or 5xx response
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// httpPoster POSTs the batches with retries and exponential backoff with full jitter
+type httpPoster struct {
+	logger *zap.SugaredLogger
+	client *http.Client
+	config HTTPSinkConfig
+}
+
+func (p *httpPoster) post(body []byte, contentType string) error {
+	var contentEncoding string
+	if p.config.Gzip {
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		if _, err := gzipWriter.Write(body); err != nil {
+			return err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+		contentEncoding = "gzip"
+	}
+
+	var err error
+	for attempt := 0; attempt <= p.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(p.backoff(attempt))
+		}
+
+		err = p.postOnce(body, contentType, contentEncoding)
+		if err == nil || errors.Is(err, ErrNonRetryableResponse) {
+			return err
+		}
+		p.logger.Warnf("failed to post %d bytes to %s (attempt %d): %v", len(body), p.config.Url, attempt+1, err)
+	}
+	return err
+}
+
+func (p *httpPoster) postOnce(body []byte, contentType string, contentEncoding string) error {
+	req, err := http.NewRequest(http.MethodPost, p.config.Url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNonRetryableResponse, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	if p.config.AuthToken != "" {
+		req.Header.Set(p.config.AuthHeader, p.config.AuthToken)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, respBody)
+	default:
+		return fmt.Errorf("%w: status code %d: %s", ErrNonRetryableResponse, resp.StatusCode, respBody)
+	}
+}
+
+// backoff returns random duration between 0 and the exponential backoff of the attempt
+func (p *httpPoster) backoff(attempt int) time.Duration {
+	backoff := p.config.InitialBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > p.config.MaxBackoff {
+		backoff = p.config.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// HTTPSink POSTs each batch of log entries as JSON array of InferenceLogMessage
+type HTTPSink struct {
+	poster *httpPoster
+
+	projectName  string
+	modelName    string
+	modelVersion string
+}
+
+func NewHTTPSink(
+	logger *zap.SugaredLogger,
+	client *http.Client,
+	config HTTPSinkConfig,
+	projectName string,
+	modelName string,
+	modelVersion string,
+) LogSink {
+	return &HTTPSink{
+		poster:       &httpPoster{logger: logger, client: client, config: config},
+		projectName:  projectName,
+		modelName:    modelName,
+		modelVersion: modelVersion,
+	}
+}
+
+func (h *HTTPSink) Sink(rawLogEntries []*LogEntry) error {
+	if len(rawLogEntries) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, inferenceLog := range newInferenceLogMessages(rawLogEntries, h.projectName, h.modelName, h.modelVersion) {
+		message, err := protojson.Marshal(inferenceLog)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(message)
+	}
+	buf.WriteByte(']')
+
+	return h.poster.post(buf.Bytes(), "application/json")
+}
+
+func newInferenceLogMessages(logEntries []*LogEntry, projectName string, modelName string, modelVersion string) []*mlogs.InferenceLogMessage {
+	messages := make([]*mlogs.InferenceLogMessage, 0, len(logEntries))
+	for _, logEntry := range logEntries {
+		request := newInferenceLogRequest(logEntry)
+
+		response := &mlogs.Response{}
+		if logEntry.ResponsePayload != nil {
+			response = &mlogs.Response{
+				StatusCode: int32(logEntry.ResponsePayload.StatusCode),
+				Body:       string(logEntry.ResponsePayload.Body),
+			}
+		}
+
+		messages = append(messages, &mlogs.InferenceLogMessage{
+			RequestId:      logEntry.RequestId,
+			EventTimestamp: logEntry.EventTimestamp,
+			ProjectName:    projectName,
+			ModelName:      modelName,
+			ModelVersion:   modelVersion,
+			Request:        request,
+			Response:       response,
+		})
+	}
+	return messages
+}
+
+// newInferenceLogRequest returns the logged request of the log entry, MirroredHeader is added to the headers of mirrored
+// requests and RequestTruncatedHeader or ResponseTruncatedHeader to the headers of requests with truncated payloads
+func newInferenceLogRequest(logEntry *LogEntry) *mlogs.Request {
+	request := &mlogs.Request{}
+	if logEntry.RequestPayload != nil {
+		request = &mlogs.Request{
+			Header: logEntry.RequestPayload.Headers,
+			Body:   string(logEntry.RequestPayload.Body),
+		}
+	}
+
+	extraHeader := map[string]string{}
+	if logEntry.Mirrored {
+		extraHeader[MirroredHeader] = "true"
+	}
+	if logEntry.RequestPayload != nil && logEntry.RequestPayload.Truncated {
+		extraHeader[RequestTruncatedHeader] = "true"
+	}
+	if logEntry.ResponsePayload != nil && logEntry.ResponsePayload.Truncated {
+		extraHeader[ResponseTruncatedHeader] = "true"
+	}
+
+	if len(extraHeader) > 0 {
+		// the headers are copied since the log entry is shared by all routes
+		header := make(map[string]string, len(request.Header)+len(extraHeader))
+		for k, v := range request.Header {
+			header[k] = v
+		}
+		for k, v := range extraHeader {
+			header[k] = v
+		}
+		request.Header = header
+	}
+	return request
+}
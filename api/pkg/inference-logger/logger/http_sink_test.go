@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCollector responds with the given status codes in order and records the decoded request bodies
+type recordingCollector struct {
+	mu          sync.Mutex
+	statusCodes []int
+	requests    []*http.Request
+	bodies      [][]byte
+}
+
+func (c *recordingCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var reader io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reader = gzipReader
+	}
+	body, _ := io.ReadAll(reader)
+	c.requests = append(c.requests, r)
+	c.bodies = append(c.bodies, body)
+
+	statusCode := http.StatusOK
+	if len(c.statusCodes) > 0 {
+		statusCode = c.statusCodes[0]
+		c.statusCodes = c.statusCodes[1:]
+	}
+	w.WriteHeader(statusCode)
+}
+
+func TestHTTPSink_Sink(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCodes  []int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			wantAttempts: 1,
+		},
+		{
+			name:         "retry on 503 and 429",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries exhausted",
+			statusCodes:  []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "no retry on 400",
+			statusCodes:  []int{http.StatusBadRequest},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &recordingCollector{statusCodes: tt.statusCodes}
+			server := httptest.NewServer(collector)
+			defer server.Close()
+
+			sink := NewHTTPSink(logger, server.Client(), HTTPSinkConfig{
+				Url:            server.URL,
+				AuthHeader:     "X-Api-Key",
+				AuthToken:      "secret",
+				Gzip:           true,
+				MaxRetries:     2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     5 * time.Millisecond,
+			}, "my-project", "my-model", "1")
+
+			err := sink.Sink([]*LogEntry{newFileSinkLogEntry("1"), newFileSinkLogEntry("2")})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			collector.mu.Lock()
+			defer collector.mu.Unlock()
+			require.Len(t, collector.requests, tt.wantAttempts)
+			assert.Equal(t, "secret", collector.requests[0].Header.Get("X-Api-Key"))
+			assert.Equal(t, "application/json", collector.requests[0].Header.Get("Content-Type"))
+
+			var messages []map[string]interface{}
+			require.NoError(t, json.Unmarshal(collector.bodies[0], &messages))
+			require.Len(t, messages, 2)
+			assert.Equal(t, "1", messages[0]["requestId"])
+			assert.Equal(t, "my-model", messages[0]["modelName"])
+			assert.Equal(t, `{"predictions":[1]}`, messages[0]["response"].(map[string]interface{})["body"])
+		})
+	}
+}
+
+func TestNewInferenceLogRequest(t *testing.T) {
+	logEntry := newFileSinkLogEntry("1")
+	assert.Equal(t, logEntry.RequestPayload.Headers, newInferenceLogRequest(logEntry).Header)
+
+	logEntry.Mirrored = true
+	request := newInferenceLogRequest(logEntry)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json", MirroredHeader: "true"}, request.Header)
+	assert.Equal(t, `{"instances":[[1,2]]}`, request.Body)
+	assert.NotContains(t, logEntry.RequestPayload.Headers, MirroredHeader)
+
+	request = newInferenceLogRequest(&LogEntry{RequestId: "2", Mirrored: true})
+	assert.Equal(t, map[string]string{MirroredHeader: "true"}, request.Header)
+
+	request = newInferenceLogRequest(&LogEntry{
+		RequestId:       "3",
+		RequestPayload:  &RequestPayload{Body: []byte("req...[truncated 2 bytes]"), Truncated: true},
+		ResponsePayload: &ResponsePayload{Body: []byte("resp...[truncated 2 bytes]"), Truncated: true},
+	})
+	assert.Equal(t, map[string]string{RequestTruncatedHeader: "true", ResponseTruncatedHeader: "true"}, request.Header)
+}
+
+func TestHTTPPoster_Backoff(t *testing.T) {
+	poster := &httpPoster{config: HTTPSinkConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}}
+	for i := 0; i < 100; i++ {
+		assert.Less(t, poster.backoff(1), 100*time.Millisecond)
+		assert.Less(t, poster.backoff(2), 200*time.Millisecond)
+		assert.Less(t, poster.backoff(10), 300*time.Millisecond)
+	}
+}
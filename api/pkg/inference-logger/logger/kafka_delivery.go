@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// MaxDeliveryAttempts is the number of times a log entry is sent to a log sink before it is dropped
+const MaxDeliveryAttempts = 3
+
+// DeliveryError is returned by a log sink when only some of the log entries were not delivered,
+// so that the worker retries those instead of the whole batch
+type DeliveryError struct {
+	Failed []*LogEntry
+	Err    error
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("failed to deliver %d log entries: %v", len(e.Failed), e.Err)
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// produceAndWait produces the messages and waits for their delivery reports.
+// The Opaque of every message must be the log entry it's built from, which is returned in DeliveryError if the message
+// is not delivered. The producer guarantees a delivery report within message.timeout.ms of every produced message.
+func produceAndWait(producer KafkaProducer, messages []*kafka.Message) error {
+	deliveryChan := make(chan kafka.Event, len(messages))
+
+	var failed []*LogEntry
+	var lastErr error
+	pending := 0
+	for _, message := range messages {
+		if err := producer.Produce(message, deliveryChan); err != nil {
+			failed = append(failed, message.Opaque.(*LogEntry))
+			lastErr = err
+			continue
+		}
+		pending++
+	}
+
+	for pending > 0 {
+		message, ok := (<-deliveryChan).(*kafka.Message)
+		if !ok {
+			continue
+		}
+		pending--
+		if message.TopicPartition.Error != nil {
+			failed = append(failed, message.Opaque.(*LogEntry))
+			lastErr = message.TopicPartition.Error
+		}
+	}
+
+	if len(failed) > 0 {
+		return &DeliveryError{Failed: failed, Err: lastErr}
+	}
+	return nil
+}
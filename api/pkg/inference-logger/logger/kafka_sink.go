@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 	"fmt"
+	"time"
 
 	mlogs "github.com/caraml-dev/merlin/pkg/log"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
@@ -30,11 +31,45 @@ const (
 	CompressionType = "snappy"
 	// The maximum duration (ms) the Kafka Producer will block for to get Metadata, before timing out
 	ConnectTimeoutMS = 1000
+	// Enable idempotent producer so that retries by the producer don't write duplicates or reorder messages
+	EnableIdempotence = true
+	// Acknowledgement required by idempotent producer
+	Acks = "all"
+	// The default duration the worker may spend delivering a log entry to a Kafka log sink over all of its attempts
+	DefaultDeliveryRetryBudget = 90 * time.Second
 )
 
+// MessageTimeoutMS returns the message.timeout.ms of the Kafka Producer so that a log entry is either delivered or
+// dropped within retryBudget after MaxDeliveryAttempts attempts
+func MessageTimeoutMS(retryBudget time.Duration) int {
+	return int((retryBudget / MaxDeliveryAttempts).Milliseconds())
+}
+
+// KafkaMessageKey is how the key of the inference log message is derived
+type KafkaMessageKey string
+
+const (
+	// KafkaMessageKeyLogKey is the serialized InferenceLogKey
+	KafkaMessageKeyLogKey KafkaMessageKey = "log_key"
+	// KafkaMessageKeyRequestId is the request id, so that the messages of a request are in the same partition
+	KafkaMessageKeyRequestId KafkaMessageKey = "request_id"
+)
+
+// KafkaSinkConfig is the configuration of the messages produced by the kafka sinks
+type KafkaSinkConfig struct {
+	// MessageKey is how the key of the inference log message is derived,
+	// the prediction log messages of the model observability sink are always keyed by session id
+	MessageKey KafkaMessageKey
+	// SchemaRegistry frames the message values in the Confluent schema registry wire format if it's not nil,
+	// the schema must be registered under <topic>-value subject
+	SchemaRegistry *SchemaRegistryClient
+}
+
 type KafkaSink struct {
-	logger   *zap.SugaredLogger
-	producer KafkaProducer
+	logger      *zap.SugaredLogger
+	producer    KafkaProducer
+	messageKey  KafkaMessageKey
+	valueFramer *protobufFramer
 
 	projectName  string
 	modelName    string
@@ -45,6 +80,7 @@ func NewKafkaSink(
 	logger *zap.SugaredLogger,
 	producer KafkaProducer,
 	adminClient KafkaAdmin,
+	config KafkaSinkConfig,
 	projectName string,
 	modelName string,
 	modelVersion string,
@@ -52,6 +88,7 @@ func NewKafkaSink(
 	sink := &KafkaSink{
 		logger:       logger,
 		producer:     producer,
+		messageKey:   config.MessageKey,
 		projectName:  projectName,
 		modelName:    modelName,
 		modelVersion: modelVersion,
@@ -72,6 +109,13 @@ func NewKafkaSink(
 		}
 	}
 
+	if config.SchemaRegistry != nil {
+		sink.valueFramer, err = newProtobufFramer(config.SchemaRegistry, sink.topicName()+"-value", &mlogs.InferenceLogMessage{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	go sink.handleMessageDelivery()
 
 	return sink, nil
@@ -84,31 +128,31 @@ func (k *KafkaSink) Sink(rawLogEntries []*LogEntry) error {
 		return err
 	}
 
-	for _, inferenceLog := range inferenceLogs {
+	topicName := k.topicName()
+	messages := make([]*kafka.Message, 0, len(inferenceLogs))
+	for i, inferenceLog := range inferenceLogs {
 		keyBytes, valueBytes, err := k.buildNewKafkaMessage(inferenceLog)
 		if err != nil {
 			return err
 		}
-		topicName := k.topicName()
-		err = k.producer.Produce(&kafka.Message{
+		messages = append(messages, &kafka.Message{
 			TopicPartition: kafka.TopicPartition{
 				Topic:     &topicName,
 				Partition: kafka.PartitionAny},
-			Value: valueBytes,
-			Key:   keyBytes,
-		}, nil)
-		if err != nil {
-			k.logger.Errorf("Produce failed: %v\n", err)
-		}
+			Value:  valueBytes,
+			Key:    keyBytes,
+			Opaque: rawLogEntries[i],
+		})
 	}
 
-	return nil
+	return produceAndWait(k.producer, messages)
 }
 
 func (k *KafkaSink) topicName() string {
 	return fmt.Sprintf("merlin-%s-%s-inference-log", k.projectName, k.modelName)
 }
 
+// handleMessageDelivery logs the producer errors, the delivery reports of the messages are handled by produceAndWait
 func (k *KafkaSink) handleMessageDelivery() {
 	for e := range k.producer.Events() {
 		switch ev := e.(type) {
@@ -116,6 +160,8 @@ func (k *KafkaSink) handleMessageDelivery() {
 			if ev.TopicPartition.Error != nil {
 				k.logger.Errorf("Delivery failed: %v\n", ev.TopicPartition.Error)
 			}
+		case kafka.Error:
+			k.logger.Errorf("Producer error: %v\n", ev)
 		}
 	}
 }
@@ -124,18 +170,20 @@ func (k *KafkaSink) buildNewKafkaMessage(
 	merlinLog *mlogs.InferenceLogMessage,
 ) (keyBytes []byte, valueBytes []byte, err error) {
 	// Create the Kafka key
-	key := &mlogs.InferenceLogKey{
-		RequestId:      merlinLog.RequestId,
-		EventTimestamp: merlinLog.EventTimestamp,
-		ProjectName:    merlinLog.ProjectName,
-		ModelName:      merlinLog.ModelName,
-		ModelVersion:   merlinLog.ModelVersion,
-	}
-
-	// Marshal the key
-	keyBytes, err = proto.Marshal(key)
-	if err != nil {
-		return nil, nil, fmt.Errorf("unable to marshal kafka key, %w", err)
+	if k.messageKey == KafkaMessageKeyRequestId {
+		keyBytes = []byte(merlinLog.RequestId)
+	} else {
+		key := &mlogs.InferenceLogKey{
+			RequestId:      merlinLog.RequestId,
+			EventTimestamp: merlinLog.EventTimestamp,
+			ProjectName:    merlinLog.ProjectName,
+			ModelName:      merlinLog.ModelName,
+			ModelVersion:   merlinLog.ModelVersion,
+		}
+		keyBytes, err = proto.Marshal(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to marshal kafka key, %w", err)
+		}
 	}
 
 	// Marshal the message
@@ -143,6 +191,9 @@ func (k *KafkaSink) buildNewKafkaMessage(
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to marshal kafka value, %w", err)
 	}
+	if k.valueFramer != nil {
+		valueBytes = k.valueFramer.frame(valueBytes)
+	}
 
 	return keyBytes, valueBytes, nil
 }
@@ -151,13 +202,7 @@ func (k *KafkaSink) newLogMessages(logEntries []*LogEntry) ([]*mlogs.InferenceLo
 	messages := make([]*mlogs.InferenceLogMessage, 0)
 	for _, logEntry := range logEntries {
 
-		request := &mlogs.Request{}
-		if logEntry.RequestPayload != nil {
-			request = &mlogs.Request{
-				Header: logEntry.RequestPayload.Headers,
-				Body:   string(logEntry.RequestPayload.Body),
-			}
-		}
+		request := newInferenceLogRequest(logEntry)
 
 		response := &mlogs.Response{}
 		if logEntry.ResponsePayload != nil {
@@ -1,11 +1,58 @@
 package logger
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/caraml-dev/merlin/pkg/inference-logger/mocks"
+	mlogs "github.com/caraml-dev/merlin/pkg/log"
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
 )
 
+// deliverKafkaMessage sends the delivery report of the produced message, failing it if the request id is in failedRequestIds
+func deliverKafkaMessage(failedRequestIds map[string]bool) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		message := args.Get(0).(*kafka.Message)
+		deliveryChan := args.Get(1).(chan kafka.Event)
+
+		delivered := *message
+		if failedRequestIds[message.Opaque.(*LogEntry).RequestId] {
+			delivered.TopicPartition.Error = kafka.NewError(kafka.ErrMsgTimedOut, "message timed out", false)
+		}
+		deliveryChan <- &delivered
+	}
+}
+
+// newMockSchemaRegistry serves the latest schema id of the subjects
+func newMockSchemaRegistry(schemaIds map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for subject, id := range schemaIds {
+			if r.URL.Path == fmt.Sprintf("/subjects/%s/versions/latest", subject) {
+				_, _ = fmt.Fprintf(w, `{"subject":%q,"version":1,"id":%d,"schemaType":"PROTOBUF"}`, subject, id)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+	}))
+}
+
+func newMockKafkaAdmin() *mocks.KafkaAdmin {
+	mockKafkaAdmin := &mocks.KafkaAdmin{}
+	mockKafkaAdmin.On("CreateTopics", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]kafka.TopicResult{{Error: kafka.NewError(kafka.ErrNoError, "", false)}}, nil)
+	return mockKafkaAdmin
+}
+
 func TestGetTopicName(t *testing.T) {
 	kafkaSink := &KafkaSink{
 		logger:       nil,
@@ -16,3 +63,104 @@ func TestGetTopicName(t *testing.T) {
 	}
 	assert.Equal(t, "merlin-my-project-my-model-inference-log", kafkaSink.topicName())
 }
+
+func TestKafkaSink_Sink(t *testing.T) {
+	registry := newMockSchemaRegistry(map[string]int{"merlin-my-project-my-model-inference-log-value": 42})
+	defer registry.Close()
+
+	tests := []struct {
+		name             string
+		config           KafkaSinkConfig
+		failedRequestIds map[string]bool
+		wantFailed       []string
+		wantKey          func(t *testing.T, key []byte)
+		wantValuePrefix  []byte
+	}{
+		{
+			name:   "inference log key",
+			config: KafkaSinkConfig{MessageKey: KafkaMessageKeyLogKey},
+			wantKey: func(t *testing.T, key []byte) {
+				logKey := &mlogs.InferenceLogKey{}
+				require.NoError(t, proto.Unmarshal(key, logKey))
+				assert.Equal(t, "1", logKey.RequestId)
+				assert.Equal(t, "my-model", logKey.ModelName)
+			},
+		},
+		{
+			name:   "request id key and schema registry framing",
+			config: KafkaSinkConfig{MessageKey: KafkaMessageKeyRequestId, SchemaRegistry: NewSchemaRegistryClient(registry.URL, registry.Client())},
+			wantKey: func(t *testing.T, key []byte) {
+				assert.Equal(t, "1", string(key))
+			},
+			// magic byte, schema id 42 and message index of InferenceLogMessage, the second message of its file
+			wantValuePrefix: []byte{0, 0, 0, 0, 42, 2, 2},
+		},
+		{
+			name:             "failed delivery",
+			config:           KafkaSinkConfig{MessageKey: KafkaMessageKeyRequestId},
+			failedRequestIds: map[string]bool{"2": true},
+			wantFailed:       []string{"2"},
+			wantKey: func(t *testing.T, key []byte) {
+				assert.Equal(t, "1", string(key))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockKafkaProducer := &mocks.KafkaProducer{}
+			mockKafkaProducer.On("Events").Return(nil)
+			var produced []*kafka.Message
+			deliver := deliverKafkaMessage(tt.failedRequestIds)
+			mockKafkaProducer.On("Produce", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				produced = append(produced, args.Get(0).(*kafka.Message))
+				deliver(args)
+			}).Return(nil)
+
+			sink, err := NewKafkaSink(logger, mockKafkaProducer, newMockKafkaAdmin(), tt.config, "my-project", "my-model", "1")
+			require.NoError(t, err)
+
+			err = sink.Sink([]*LogEntry{newFileSinkLogEntry("1"), newFileSinkLogEntry("2")})
+			if len(tt.wantFailed) > 0 {
+				var deliveryErr *DeliveryError
+				require.True(t, errors.As(err, &deliveryErr))
+				failed := make([]string, 0)
+				for _, logEntry := range deliveryErr.Failed {
+					failed = append(failed, logEntry.RequestId)
+				}
+				assert.Equal(t, tt.wantFailed, failed)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, produced, 2)
+			message := produced[0]
+			assert.Equal(t, "merlin-my-project-my-model-inference-log", *message.TopicPartition.Topic)
+			tt.wantKey(t, message.Key)
+
+			value := message.Value
+			if tt.wantValuePrefix != nil {
+				require.Equal(t, tt.wantValuePrefix, value[:len(tt.wantValuePrefix)])
+				value = value[len(tt.wantValuePrefix):]
+			}
+			inferenceLog := &mlogs.InferenceLogMessage{}
+			require.NoError(t, proto.Unmarshal(value, inferenceLog))
+			assert.Equal(t, "1", inferenceLog.RequestId)
+		})
+	}
+}
+
+func TestNewKafkaSink_SchemaNotRegistered(t *testing.T) {
+	registry := newMockSchemaRegistry(nil)
+	defer registry.Close()
+
+	mockKafkaProducer := &mocks.KafkaProducer{}
+	_, err := NewKafkaSink(logger, mockKafkaProducer, newMockKafkaAdmin(),
+		KafkaSinkConfig{SchemaRegistry: NewSchemaRegistryClient(registry.URL, registry.Client())}, "my-project", "my-model", "1")
+	assert.ErrorContains(t, err, "failed to get schema of subject merlin-my-project-my-model-inference-log-value: status code 404")
+}
+
+func TestMessageIndexes(t *testing.T) {
+	assert.Equal(t, []byte{0}, messageIndexes((&mlogs.InferenceLogKey{}).ProtoReflect().Descriptor()))
+	assert.Equal(t, binary.AppendVarint(binary.AppendVarint(nil, 1), 1), messageIndexes((&mlogs.InferenceLogMessage{}).ProtoReflect().Descriptor()))
+	assert.Equal(t, []byte{0}, messageIndexes((&upiv1.PredictionLog{}).ProtoReflect().Descriptor()))
+}
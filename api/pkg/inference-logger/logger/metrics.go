@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// QueueSaturationThreshold is the fraction of the queue or spool capacity after which a route is considered saturated
+const QueueSaturationThreshold = 0.9
+
+// Reasons of dropping a log entry submitted to a route
+const (
+	dropReasonQueueFull   = "queue_full"
+	dropReasonQueueClosed = "queue_closed"
+	dropReasonError       = "error"
+	dropReasonMaxAttempts = "max_attempts"
+	dropReasonBufferFull  = "buffer_full"
+	dropReasonSinkClosed  = "sink_closed"
+)
+
+// Status of sending a batch of log entries to a log sink
+const (
+	sinkStatusSuccess = "success"
+	sinkStatusError   = "error"
+)
+
+var (
+	queueEntries = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: PromNamespace,
+		Name:      "queue_entries",
+		Help:      "Number of log entries waiting in the in-memory queues",
+	})
+
+	routeSubmittedEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "route_submitted_entries_total",
+		Help:      "Number of log entries submitted to the route's dispatcher",
+	}, []string{"route"})
+
+	routeDroppedEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "route_dropped_entries_total",
+		Help:      "Number of log entries the route's dispatcher failed to accept",
+	}, []string{"route", "reason"})
+
+	routeSaturated = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: PromNamespace,
+		Name:      "route_saturated",
+		Help:      "Whether the route's queue or spool is saturated (1) or not (0)",
+	}, []string{"route"})
+
+	workerBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: PromNamespace,
+		Name:      "worker_batch_size",
+		Help:      "Number of log entries in the batches sent by the workers",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	})
+
+	workerRequeuedEntries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "worker_requeued_entries_total",
+		Help:      "Number of log entries put back to the queue after the log sink failed to deliver them",
+	})
+
+	workerDroppedEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "worker_dropped_entries_total",
+		Help:      "Number of log entries the log sink failed to deliver that are dropped instead of requeued",
+	}, []string{"reason"})
+
+	sinkDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: PromNamespace,
+		Name:      "sink_duration_seconds",
+		Help:      "Time taken by the log sink to send a batch of log entries",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"sink", "status"})
+
+	sinkEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "sink_entries_total",
+		Help:      "Number of log entries sent to the log sink",
+	}, []string{"sink", "status"})
+
+	sinkDroppedEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "sink_dropped_entries_total",
+		Help:      "Number of log entries accepted by the log sink that are dropped before being delivered",
+	}, []string{"sink", "reason"})
+)
+
+// dropReason maps the error returned when submitting a log entry to the metric label
+func dropReason(err error) string {
+	switch {
+	case errors.Is(err, ErrFullQueue), errors.Is(err, ErrTooManyMessages):
+		return dropReasonQueueFull
+	case errors.Is(err, ErrFullSpool):
+		return dropReasonSpoolFull
+	case errors.Is(err, ErrClosed):
+		return dropReasonQueueClosed
+	default:
+		return dropReasonError
+	}
+}
+
+// sinkName returns the type name of the log sink, e.g. KafkaSink, used as metric label
+func sinkName(logSink LogSink) string {
+	name := fmt.Sprintf("%T", logSink)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// observeSink records the outcome of sending a batch of log entries to the log sink
+func observeSink(logSink LogSink, entries int, start time.Time, err error) {
+	status := sinkStatusSuccess
+	if err != nil {
+		status = sinkStatusError
+	}
+	name := sinkName(logSink)
+	sinkDuration.WithLabelValues(name, status).Observe(time.Since(start).Seconds())
+	sinkEntries.WithLabelValues(name, status).Add(float64(entries))
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: ErrFullQueue, want: dropReasonQueueFull},
+		{err: ErrTooManyMessages, want: dropReasonQueueFull},
+		{err: ErrFullSpool, want: dropReasonSpoolFull},
+		{err: ErrClosed, want: dropReasonQueueClosed},
+		{err: fmt.Errorf("wrapped: %w", ErrFullSpool), want: dropReasonSpoolFull},
+		{err: errors.New("disk error"), want: dropReasonError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, dropReason(tt.err))
+		})
+	}
+}
+
+func TestSinkName(t *testing.T) {
+	assert.Equal(t, "ConsoleSink", sinkName(NewConsoleSink(logger)))
+	assert.Equal(t, "flakySink", sinkName(&flakySink{}))
+}
+
+func TestBatchQueue_Saturated(t *testing.T) {
+	q := NewBatchQueue(10)
+	for i := 0; i < 8; i++ {
+		require.NoError(t, q.Put(i))
+	}
+	assert.False(t, q.Saturated(QueueSaturationThreshold))
+	require.NoError(t, q.Put(8))
+	assert.True(t, q.Saturated(QueueSaturationThreshold))
+
+	q.GetAll()
+	assert.False(t, q.Saturated(QueueSaturationThreshold))
+	assert.False(t, NewBatchQueue(0).Saturated(QueueSaturationThreshold))
+}
+
+func TestRoute_SubmitMetrics(t *testing.T) {
+	blocked := &blockingSink{release: make(chan struct{})}
+	defer close(blocked.release)
+
+	dispatcher := NewDispatcher(1, 2, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 1}, logger, blocked)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+	route := &Route{Name: "metrics-test", Dispatcher: dispatcher, LogMode: LogModeAll}
+	submittedBefore := testutil.ToFloat64(routeSubmittedEntries.WithLabelValues("metrics-test"))
+	droppedBefore := testutil.ToFloat64(routeDroppedEntries.WithLabelValues("metrics-test", dropReasonQueueFull))
+
+	// the worker takes the first entry and blocks, the next two fill the queue and the last is dropped
+	require.NoError(t, route.submit(newFileSinkLogEntry("1"), false))
+	require.Eventually(t, func() bool { return dispatcher.workerQueue.Len() == 0 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, route.submit(newFileSinkLogEntry("2"), false))
+	assert.False(t, route.Saturated())
+	require.NoError(t, route.submit(newFileSinkLogEntry("3"), false))
+	assert.ErrorIs(t, route.submit(newFileSinkLogEntry("4"), false), ErrFullQueue)
+
+	assert.Equal(t, submittedBefore+3, testutil.ToFloat64(routeSubmittedEntries.WithLabelValues("metrics-test")))
+	assert.Equal(t, droppedBefore+1, testutil.ToFloat64(routeDroppedEntries.WithLabelValues("metrics-test", dropReasonQueueFull)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(routeSaturated.WithLabelValues("metrics-test")))
+	assert.True(t, route.Saturated())
+}
+
+func TestWorker_SendMetrics(t *testing.T) {
+	sink := &flakySink{failures: 1}
+	worker := NewWorker(NewBatchQueue(0), &WorkerConfig{}, logger, sink)
+
+	successBefore := testutil.ToFloat64(sinkEntries.WithLabelValues("flakySink", sinkStatusSuccess))
+	errorBefore := testutil.ToFloat64(sinkEntries.WithLabelValues("flakySink", sinkStatusError))
+
+	entries := []*LogEntry{newFileSinkLogEntry("1"), newFileSinkLogEntry("2")}
+	assert.Error(t, worker.Send(entries))
+	assert.NoError(t, worker.Send(entries))
+
+	assert.Equal(t, successBefore+2, testutil.ToFloat64(sinkEntries.WithLabelValues("flakySink", sinkStatusSuccess)))
+	assert.Equal(t, errorBefore+2, testutil.ToFloat64(sinkEntries.WithLabelValues("flakySink", sinkStatusError)))
+}
@@ -10,9 +10,12 @@ import (
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -28,6 +31,9 @@ const (
 type MLObsSink struct {
 	logger   *zap.SugaredLogger
 	producer KafkaProducer
+	// mapper is set if the model's payloads don't follow the standard model request and response
+	mapper      *predictionLogMapper
+	valueFramer *protobufFramer
 
 	projectName  string
 	modelName    string
@@ -48,9 +54,11 @@ func NewMLObsSink(
 	logger *zap.SugaredLogger,
 	producer KafkaProducer,
 	adminClient KafkaAdmin,
+	config KafkaSinkConfig,
 	projectName string,
 	modelName string,
 	modelVersion string,
+	schema *rules.PredictionLogSchema,
 ) (LogSink, error) {
 	sink := &MLObsSink{
 		logger:       logger,
@@ -59,6 +67,13 @@ func NewMLObsSink(
 		modelName:    modelName,
 		modelVersion: modelVersion,
 	}
+	if schema != nil {
+		mapper, err := newPredictionLogMapper(schema)
+		if err != nil {
+			return nil, err
+		}
+		sink.mapper = mapper
+	}
 	topicResults, err := adminClient.CreateTopics(context.Background(), []kafka.TopicSpecification{
 		{
 			Topic:             sink.topicName(),
@@ -71,36 +86,192 @@ func NewMLObsSink(
 			return nil, err
 		}
 	}
+	if config.SchemaRegistry != nil {
+		sink.valueFramer, err = newProtobufFramer(config.SchemaRegistry, sink.topicName()+"-value", &upiv1.PredictionLog{})
+		if err != nil {
+			return nil, err
+		}
+	}
 	go sink.handleMessageDelivery()
 
 	return sink, nil
 }
 
-func (m *MLObsSink) newPredictionLog(rawLogEntry *LogEntry) (*upiv1.PredictionLog, error) {
-	predictionLog := &upiv1.PredictionLog{}
-	predictionLog.RequestTimestamp = timestamppb.Now()
+// predictionLogRows are the rows of the features and prediction results tables of a prediction log
+type predictionLogRows struct {
+	sessionId   string
+	rowIds      []string
+	features    []*structpb.ListValue
+	predictions []*structpb.Value
+
+	// featureColumns and predictionColumns are the column names of the tables, if known
+	featureColumns    []string
+	predictionColumns []string
+}
+
+func newStandardPredictionLogRows(requestBody []byte, responseBody []byte) (*predictionLogRows, error) {
 	standardModelRequest := &StandardModelRequest{}
-	err := json.Unmarshal(rawLogEntry.RequestPayload.Body, standardModelRequest)
+	err := json.Unmarshal(requestBody, standardModelRequest)
 	if err != nil {
 		return nil, err
 	}
 	standardModelResponse := &StandardModelResponse{}
-	err = json.Unmarshal(rawLogEntry.ResponsePayload.Body, standardModelResponse)
+	err = json.Unmarshal(responseBody, standardModelResponse)
 	if err != nil {
 		return nil, err
 	}
+
+	rows := &predictionLogRows{
+		sessionId: standardModelRequest.SessionId,
+		rowIds:    standardModelRequest.RowIds,
+	}
+	for _, instance := range standardModelRequest.Instances {
+		featureTableRow := &structpb.ListValue{
+			Values: make([]*structpb.Value, len(instance)),
+		}
+		for j, value := range instance {
+			if value != nil {
+				featureTableRow.Values[j] = structpb.NewNumberValue(*value)
+			} else {
+				featureTableRow.Values[j] = structpb.NewNullValue()
+			}
+		}
+		rows.features = append(rows.features, featureTableRow)
+	}
+	for _, prediction := range standardModelResponse.Predictions {
+		rows.predictions = append(rows.predictions, structpb.NewNumberValue(prediction))
+	}
+	return rows, nil
+}
+
+// newUPIPredictionLogRows extracts the rows of the prediction log from the protojson encoded UPI PredictValues request
+// and response. The prediction results are joined with the prediction table rows by row id, since their order may differ.
+func newUPIPredictionLogRows(requestBody []byte, responseBody []byte) (*predictionLogRows, error) {
+	request := &upiv1.PredictValuesRequest{}
+	if err := protojson.Unmarshal(requestBody, request); err != nil {
+		return nil, err
+	}
+	response := &upiv1.PredictValuesResponse{}
+	if err := protojson.Unmarshal(responseBody, response); err != nil {
+		return nil, err
+	}
+
+	predictionTable := request.GetPredictionTable()
+	resultTable := response.GetPredictionResultTable()
+	if predictionTable == nil || resultTable == nil {
+		return nil, fmt.Errorf("%w: missing prediction table or prediction result table", ErrMalformedLogEntry)
+	}
+
+	rows := &predictionLogRows{
+		sessionId: request.GetMetadata().GetPredictionId(),
+	}
+	if rows.sessionId == "" {
+		rows.sessionId = response.GetMetadata().GetPredictionId()
+	}
+	for _, column := range predictionTable.Columns {
+		rows.featureColumns = append(rows.featureColumns, column.Name)
+	}
+	for _, column := range resultTable.Columns {
+		rows.predictionColumns = append(rows.predictionColumns, column.Name)
+	}
+
+	resultRows := make(map[string]*upiv1.Row, len(resultTable.Rows))
+	for _, row := range resultTable.Rows {
+		resultRows[row.RowId] = row
+	}
+	for _, row := range predictionTable.Rows {
+		resultRow, ok := resultRows[row.RowId]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing prediction result of row %s", ErrMalformedLogEntry, row.RowId)
+		}
+
+		rows.rowIds = append(rows.rowIds, row.RowId)
+		rows.features = append(rows.features, newUPIListValue(row.Values, predictionTable.Columns))
+		predictions := newUPIListValue(resultRow.Values, resultTable.Columns)
+		if len(predictions.Values) == 1 {
+			rows.predictions = append(rows.predictions, predictions.Values[0])
+		} else {
+			rows.predictions = append(rows.predictions, structpb.NewListValue(predictions))
+		}
+	}
+	return rows, nil
+}
+
+// newUPIListValue converts the values of a UPI table row according to the types of the table columns
+func newUPIListValue(values []*upiv1.Value, columns []*upiv1.Column) *structpb.ListValue {
+	list := &structpb.ListValue{
+		Values: make([]*structpb.Value, len(values)),
+	}
+	for i, value := range values {
+		list.Values[i] = structpb.NewNullValue()
+		if value == nil || value.IsNull || i >= len(columns) {
+			continue
+		}
+		switch columns[i].Type {
+		case upiv1.Type_TYPE_DOUBLE:
+			list.Values[i] = structpb.NewNumberValue(value.DoubleValue)
+		case upiv1.Type_TYPE_INTEGER:
+			list.Values[i] = structpb.NewNumberValue(float64(value.IntegerValue))
+		case upiv1.Type_TYPE_STRING:
+			list.Values[i] = structpb.NewStringValue(value.StringValue)
+		}
+	}
+	return list
+}
+
+// checkLogEntryPayloads returns ErrMalformedLogEntry if the log entry misses its request or response payload,
+// the prediction log is built from both of them
+func checkLogEntryPayloads(logEntry *LogEntry) error {
+	if logEntry.RequestPayload == nil || logEntry.ResponsePayload == nil {
+		return fmt.Errorf("%w: missing request or response payload", ErrMalformedLogEntry)
+	}
+	return nil
+}
+
+func (m *MLObsSink) newPredictionLog(rawLogEntry *LogEntry) (*upiv1.PredictionLog, error) {
+	if err := checkLogEntryPayloads(rawLogEntry); err != nil {
+		return nil, err
+	}
+	if rawLogEntry.RequestPayload.Truncated || rawLogEntry.ResponsePayload.Truncated {
+		return nil, fmt.Errorf("%w: truncated payload", ErrMalformedLogEntry)
+	}
+
+	var rows *predictionLogRows
+	var err error
+	switch {
+	case rawLogEntry.UPI:
+		// prediction log schema only applies to JSON payloads
+		rows, err = newUPIPredictionLogRows(rawLogEntry.RequestPayload.Body, rawLogEntry.ResponsePayload.Body)
+	case m.mapper != nil:
+		rows, err = m.mapper.rows(rawLogEntry.RequestPayload.Body, rawLogEntry.ResponsePayload.Body)
+		if err == nil {
+			rows.featureColumns, rows.predictionColumns = m.mapper.columns()
+		}
+	default:
+		rows, err = newStandardPredictionLogRows(rawLogEntry.RequestPayload.Body, rawLogEntry.ResponsePayload.Body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	// If there is only one prediction, session id alone is enough to for unique prediction id
-	if len(standardModelRequest.Instances) == 1 && standardModelRequest.RowIds == nil {
-		standardModelRequest.RowIds = []string{""}
+	if len(rows.features) == 1 && rows.rowIds == nil {
+		rows.rowIds = []string{""}
 	}
 
-	if len(standardModelRequest.RowIds) != len(standardModelResponse.Predictions) {
+	if len(rows.rowIds) != len(rows.predictions) {
 		return nil, fmt.Errorf("%w: number of row ids and predictions do not match", ErrMalformedLogEntry)
 	}
-	if standardModelRequest.SessionId == "" {
+	if len(rows.features) != len(rows.predictions) {
+		return nil, fmt.Errorf("%w: number of instances and predictions do not match", ErrMalformedLogEntry)
+	}
+	if rows.sessionId == "" {
 		return nil, fmt.Errorf("%w: missing session id", ErrMalformedLogEntry)
 	}
-	predictionLog.PredictionId = standardModelRequest.SessionId
+
+	predictionLog := &upiv1.PredictionLog{}
+	predictionLog.RequestTimestamp = timestamppb.Now()
+	predictionLog.PredictionId = rows.sessionId
 	predictionLog.ModelName = m.modelName
 	predictionLog.ModelVersion = m.modelVersion
 	predictionLog.ProjectName = m.projectName
@@ -111,34 +282,28 @@ func (m *MLObsSink) newPredictionLog(rawLogEntry *LogEntry) (*upiv1.PredictionLo
 		Fields: make(map[string]*structpb.Value),
 	}
 	featureTableData := &structpb.ListValue{
-		Values: make([]*structpb.Value, len(standardModelRequest.Instances)),
+		Values: make([]*structpb.Value, len(rows.features)),
 	}
 	predictionTableData := &structpb.ListValue{
-		Values: make([]*structpb.Value, len(standardModelResponse.Predictions)),
+		Values: rows.predictions,
 	}
 	rowIds := &structpb.ListValue{
-		Values: make([]*structpb.Value, len(standardModelRequest.RowIds)),
+		Values: make([]*structpb.Value, len(rows.rowIds)),
 	}
-	for i, instance := range standardModelRequest.Instances {
-		featureTableRow := &structpb.ListValue{
-			Values: make([]*structpb.Value, len(instance)),
-		}
-		for j, value := range instance {
-			if value != nil {
-				featureTableRow.Values[j] = structpb.NewNumberValue(*value)
-			} else {
-				featureTableRow.Values[j] = structpb.NewNullValue()
-			}
-		}
-
+	for i, featureTableRow := range rows.features {
 		featureTableData.Values[i] = structpb.NewListValue(featureTableRow)
-		predictionTableData.Values[i] = structpb.NewNumberValue(standardModelResponse.Predictions[i])
-		rowIds.Values[i] = structpb.NewStringValue(standardModelRequest.RowIds[i])
+		rowIds.Values[i] = structpb.NewStringValue(rows.rowIds[i])
 	}
 	featuresTable.Fields["data"] = structpb.NewListValue(featureTableData)
 	featuresTable.Fields["row_ids"] = structpb.NewListValue(rowIds)
 	predictionTable.Fields["data"] = structpb.NewListValue(predictionTableData)
 	predictionTable.Fields["row_ids"] = structpb.NewListValue(rowIds)
+	if len(rows.featureColumns) > 0 {
+		featuresTable.Fields["columns"] = newStringListValue(rows.featureColumns)
+	}
+	if len(rows.predictionColumns) > 0 {
+		predictionTable.Fields["columns"] = newStringListValue(rows.predictionColumns)
+	}
 
 	predictionLog.Input = &upiv1.ModelInput{
 		FeaturesTable: featuresTable,
@@ -149,27 +314,50 @@ func (m *MLObsSink) newPredictionLog(rawLogEntry *LogEntry) (*upiv1.PredictionLo
 	return predictionLog, nil
 }
 
+func newStringListValue(values []string) *structpb.Value {
+	list := &structpb.ListValue{
+		Values: make([]*structpb.Value, len(values)),
+	}
+	for i, value := range values {
+		list.Values[i] = structpb.NewStringValue(value)
+	}
+	return structpb.NewListValue(list)
+}
+
 func (m *MLObsSink) topicName() string {
 	return fmt.Sprintf("caraml-%s-%s-%s-prediction-log", m.projectName, m.modelName, m.modelVersion)
 }
 
+// buildNewKafkaMessage creates message keyed by the session id so that the predictions of a session are in the same partition
 func (m *MLObsSink) buildNewKafkaMessage(predictionLog *upiv1.PredictionLog) (*kafka.Message, error) {
 	logBytes, err := proto.Marshal(predictionLog)
 	if err != nil {
 		return nil, err
 	}
-	topic := fmt.Sprintf("caraml-%s-%s-%s-prediction-log", m.projectName, m.modelName, m.modelVersion)
+	if m.valueFramer != nil {
+		logBytes = m.valueFramer.frame(logBytes)
+	}
+	topic := m.topicName()
 	return &kafka.Message{
 		TopicPartition: kafka.TopicPartition{
-			Topic: &topic,
+			Topic:     &topic,
+			Partition: kafka.PartitionAny,
 		},
+		Key:   []byte(predictionLog.PredictionId),
 		Value: logBytes,
 	}, nil
 }
 
 func (m *MLObsSink) Sink(rawLogEntries []*LogEntry) error {
+	messages := make([]*kafka.Message, 0)
 	for _, rawLogEntry := range rawLogEntries {
-		if rawLogEntry.ResponsePayload.StatusCode != http.StatusOK || rand.Float64() >= SamplingRate {
+		// Log entry being retried was already sampled
+		sampled := rawLogEntry.deliveryAttempts > 0 || rand.Float64() < SamplingRate
+		if err := checkLogEntryPayloads(rawLogEntry); err != nil {
+			m.logger.Errorf("unable to convert log entry: %v", err)
+			continue
+		}
+		if !isSuccessfulLogEntry(rawLogEntry) || !sampled {
 			continue
 		}
 		predictionLog, err := m.newPredictionLog(rawLogEntry)
@@ -182,12 +370,21 @@ func (m *MLObsSink) Sink(rawLogEntries []*LogEntry) error {
 			m.logger.Errorf("unable to build kafka message: %v", err)
 			continue
 		}
-		err = m.producer.Produce(kafkaMessage, m.producer.Events())
-		if err != nil {
-			m.logger.Errorf("unable to produce kafka message: %v", err)
-		}
+		kafkaMessage.Opaque = rawLogEntry
+		messages = append(messages, kafkaMessage)
 	}
-	return nil
+	return produceAndWait(m.producer, messages)
+}
+
+// isSuccessfulLogEntry returns true if the model responded successfully, the status code of UPI log entries is the gRPC status code
+func isSuccessfulLogEntry(logEntry *LogEntry) bool {
+	if logEntry.ResponsePayload == nil {
+		return false
+	}
+	if logEntry.UPI {
+		return logEntry.ResponsePayload.StatusCode == int(codes.OK)
+	}
+	return logEntry.ResponsePayload.StatusCode == http.StatusOK
 }
 
 func (m *MLObsSink) handleMessageDelivery() {
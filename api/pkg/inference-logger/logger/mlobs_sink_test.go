@@ -4,9 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/caraml-dev/merlin/pkg/inference-logger/mocks"
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
 )
 
 func asInstanceValue(value float64) *float64 {
@@ -162,3 +169,277 @@ func TestLogEntryToPredictionLogConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestLogEntryToPredictionLogConversion_TruncatedPayload(t *testing.T) {
+	sink := &MLObsSink{modelName: "test-model", modelVersion: "1", projectName: "test-project"}
+	logEntry := newTestLogEntry(
+		newTestStandardModelRequest([][]*float64{{asInstanceValue(1.0)}}),
+		newTestStandardModelResponse([]float64{0.5}),
+	)
+	logEntry.RequestPayload.Truncated = true
+
+	_, err := sink.newPredictionLog(logEntry)
+	assert.ErrorIs(t, err, ErrMalformedLogEntry)
+}
+
+// notifyingSink sends the result of each call of the wrapped sink
+type notifyingSink struct {
+	sink LogSink
+	sunk chan error
+}
+
+func (n *notifyingSink) Sink(logEntries []*LogEntry) error {
+	err := n.sink.Sink(logEntries)
+	n.sunk <- err
+	return err
+}
+
+func TestMLObsSink_RequestOnlyLogEntry(t *testing.T) {
+	producer := &mocks.KafkaProducer{}
+	sink := &MLObsSink{logger: logger, producer: producer, modelName: "test-model", modelVersion: "1", projectName: "test-project"}
+
+	logEntry := newTestLogEntry(
+		newTestStandardModelRequest([][]*float64{{asInstanceValue(1.0)}}),
+		newTestStandardModelResponse([]float64{0.5}),
+	)
+	_, err := sink.newPredictionLog(&LogEntry{RequestId: logEntry.RequestId, RequestPayload: logEntry.RequestPayload})
+	assert.ErrorIs(t, err, ErrMalformedLogEntry)
+
+	// the request only log entry is dropped by the sink instead of crashing the worker
+	sunk := make(chan error, 1)
+	dispatcher := NewDispatcher(1, 1, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 1}, logger, &notifyingSink{sink: sink, sunk: sunk})
+	dispatcher.Start()
+	defer dispatcher.Stop()
+	route := &Route{Name: "mlobs", Dispatcher: dispatcher, LogMode: LogModeRequestOnly}
+
+	require.NoError(t, route.submit(logEntry, false))
+	select {
+	case err := <-sunk:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("log entry was not sunk")
+	}
+	producer.AssertNotCalled(t, "Produce", mock.Anything, mock.Anything)
+}
+
+func TestLogEntryToPredictionLogConversion_WithSchema(t *testing.T) {
+	schema := &rules.PredictionLogSchema{
+		PredictionLogMapping: rules.PredictionLogMapping{
+			InstancesPath:        "$.data.customers",
+			RowIdsPath:           "$.data.customers[*].customer_id",
+			SessionIdPath:        "$.request_id",
+			PredictionScoresPath: "$.results[*].score",
+		},
+		FeatureOrders:         []string{"age", "city"},
+		PredictionScoreColumn: "score",
+	}
+
+	tests := []struct {
+		name             string
+		schema           *rules.PredictionLogSchema
+		request          string
+		response         string
+		expectedFeatures []interface{}
+		expectedScores   []interface{}
+		expectedRowIds   []interface{}
+		expectedError    error
+	}{
+		{
+			name:             "object instances ordered by feature orders",
+			schema:           schema,
+			request:          `{"request_id": "req-1", "data": {"customers": [{"customer_id": 1, "city": "jakarta", "age": 30}, {"customer_id": 2, "age": 40}]}}`,
+			response:         `{"results": [{"score": 0.3}, {"score": 0.8}]}`,
+			expectedFeatures: []interface{}{[]interface{}{float64(30), "jakarta"}, []interface{}{float64(40), nil}},
+			expectedScores:   []interface{}{0.3, 0.8},
+			expectedRowIds:   []interface{}{"1", "2"},
+		},
+		{
+			name: "list instances without row ids",
+			schema: &rules.PredictionLogSchema{
+				PredictionLogMapping: rules.PredictionLogMapping{
+					InstancesPath:        "$.inputs",
+					SessionIdPath:        "$.session",
+					PredictionScoresPath: "$.outputs",
+				},
+			},
+			request:          `{"session": "s-1", "inputs": [[1.5, "a"]]}`,
+			response:         `{"outputs": [0.9]}`,
+			expectedFeatures: []interface{}{[]interface{}{1.5, "a"}},
+			expectedScores:   []interface{}{0.9},
+			expectedRowIds:   []interface{}{""},
+		},
+		{
+			name:          "missing session id",
+			schema:        schema,
+			request:       `{"data": {"customers": [{"customer_id": 1, "age": 30}]}}`,
+			response:      `{"results": [{"score": 0.3}]}`,
+			expectedError: ErrMalformedLogEntry,
+		},
+		{
+			name:          "number of instances and predictions do not match",
+			schema:        schema,
+			request:       `{"request_id": "req-1", "data": {"customers": [{"customer_id": 1, "age": 30}]}}`,
+			response:      `{"results": [{"score": 0.3}, {"score": 0.8}]}`,
+			expectedError: ErrMalformedLogEntry,
+		},
+		{
+			name:          "scalar instance",
+			schema:        schema,
+			request:       `{"request_id": "req-1", "data": {"customers": [1]}}`,
+			response:      `{"results": [{"score": 0.3}]}`,
+			expectedError: ErrMalformedLogEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper, err := newPredictionLogMapper(tt.schema)
+			require.NoError(t, err)
+			sink := &MLObsSink{
+				mapper:       mapper,
+				modelName:    "test-model",
+				modelVersion: "1",
+				projectName:  "test-project",
+			}
+			logEntry := &LogEntry{
+				RequestId:       uuid.New().String(),
+				RequestPayload:  &RequestPayload{Body: []byte(tt.request)},
+				ResponsePayload: &ResponsePayload{StatusCode: 200, Body: []byte(tt.response)},
+			}
+
+			predictionLog, err := sink.newPredictionLog(logEntry)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			featuresTable := predictionLog.GetInput().GetFeaturesTable().AsMap()
+			predictionTable := predictionLog.GetOutput().GetPredictionResultsTable().AsMap()
+			assert.Equal(t, tt.expectedFeatures, featuresTable["data"])
+			assert.Equal(t, tt.expectedRowIds, featuresTable["row_ids"])
+			assert.Equal(t, tt.expectedScores, predictionTable["data"])
+			assert.Equal(t, tt.expectedRowIds, predictionTable["row_ids"])
+			if len(tt.schema.FeatureOrders) > 0 {
+				assert.Equal(t, []interface{}{"age", "city"}, featuresTable["columns"])
+				assert.Equal(t, []interface{}{"score"}, predictionTable["columns"])
+			}
+			assert.Equal(t, "test-project", predictionLog.ProjectName)
+		})
+	}
+}
+
+func TestLogEntryToPredictionLogConversion_UPI(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              string
+		response             string
+		expectedFeatures     []interface{}
+		expectedScores       []interface{}
+		expectedRowIds       []interface{}
+		expectedPredictionId string
+		expectedError        error
+	}{
+		{
+			name: "prediction results joined by row id",
+			request: `{
+				"prediction_table": {
+					"columns": [{"name": "age", "type": "TYPE_INTEGER"}, {"name": "city", "type": "TYPE_STRING"}, {"name": "score", "type": "TYPE_DOUBLE"}],
+					"rows": [
+						{"row_id": "1", "values": [{"integer_value": 30}, {"string_value": "jakarta"}, {"double_value": 0.5}]},
+						{"row_id": "2", "values": [{"integer_value": 40}, {"is_null": true}, {"double_value": 1.5}]}
+					]
+				},
+				"metadata": {"prediction_id": "prediction-1"}
+			}`,
+			response: `{
+				"prediction_result_table": {
+					"columns": [{"name": "probability", "type": "TYPE_DOUBLE"}],
+					"rows": [
+						{"row_id": "2", "values": [{"double_value": 0.8}]},
+						{"row_id": "1", "values": [{"double_value": 0.3}]}
+					]
+				}
+			}`,
+			expectedFeatures:     []interface{}{[]interface{}{float64(30), "jakarta", 0.5}, []interface{}{float64(40), nil, 1.5}},
+			expectedScores:       []interface{}{0.3, 0.8},
+			expectedRowIds:       []interface{}{"1", "2"},
+			expectedPredictionId: "prediction-1",
+		},
+		{
+			name: "multiple prediction result columns",
+			request: `{
+				"prediction_table": {
+					"columns": [{"name": "age", "type": "TYPE_INTEGER"}],
+					"rows": [{"row_id": "1", "values": [{"integer_value": 30}]}]
+				}
+			}`,
+			response: `{
+				"prediction_result_table": {
+					"columns": [{"name": "label", "type": "TYPE_STRING"}, {"name": "probability", "type": "TYPE_DOUBLE"}],
+					"rows": [{"row_id": "1", "values": [{"string_value": "churn"}, {"double_value": 0.3}]}]
+				},
+				"metadata": {"prediction_id": "prediction-2"}
+			}`,
+			expectedFeatures:     []interface{}{[]interface{}{float64(30)}},
+			expectedScores:       []interface{}{[]interface{}{"churn", 0.3}},
+			expectedRowIds:       []interface{}{"1"},
+			expectedPredictionId: "prediction-2",
+		},
+		{
+			name: "missing prediction result",
+			request: `{
+				"prediction_table": {
+					"columns": [{"name": "age", "type": "TYPE_INTEGER"}],
+					"rows": [{"row_id": "1", "values": [{"integer_value": 30}]}]
+				},
+				"metadata": {"prediction_id": "prediction-1"}
+			}`,
+			response: `{
+				"prediction_result_table": {
+					"columns": [{"name": "probability", "type": "TYPE_DOUBLE"}],
+					"rows": [{"row_id": "2", "values": [{"double_value": 0.3}]}]
+				}
+			}`,
+			expectedError: ErrMalformedLogEntry,
+		},
+		{
+			name:          "missing prediction table",
+			request:       `{"metadata": {"prediction_id": "prediction-1"}}`,
+			response:      `{"prediction_result_table": {}}`,
+			expectedError: ErrMalformedLogEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &MLObsSink{
+				modelName:    "test-model",
+				modelVersion: "1",
+				projectName:  "test-project",
+			}
+			logEntry := &LogEntry{
+				RequestId:       uuid.New().String(),
+				RequestPayload:  &RequestPayload{Body: []byte(tt.request)},
+				ResponsePayload: &ResponsePayload{StatusCode: 0, Body: []byte(tt.response)},
+				UPI:             true,
+			}
+			assert.True(t, isSuccessfulLogEntry(logEntry))
+
+			predictionLog, err := sink.newPredictionLog(logEntry)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			featuresTable := predictionLog.GetInput().GetFeaturesTable().AsMap()
+			predictionTable := predictionLog.GetOutput().GetPredictionResultsTable().AsMap()
+			assert.Equal(t, tt.expectedPredictionId, predictionLog.PredictionId)
+			assert.Equal(t, tt.expectedFeatures, featuresTable["data"])
+			assert.Equal(t, tt.expectedRowIds, featuresTable["row_ids"])
+			assert.Equal(t, tt.expectedScores, predictionTable["data"])
+			assert.Equal(t, tt.expectedRowIds, predictionTable["row_ids"])
+		})
+	}
+}
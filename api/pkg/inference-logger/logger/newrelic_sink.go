@@ -107,13 +107,7 @@ func (n *NewRelicSink) newLogMessages(logEntries []*LogEntry) ([]*mlogs.Inferenc
 	messages := make([]*mlogs.InferenceLogMessage, 0)
 	for _, logEntry := range logEntries {
 
-		request := &mlogs.Request{}
-		if logEntry.RequestPayload != nil {
-			request = &mlogs.Request{
-				Header: logEntry.RequestPayload.Headers,
-				Body:   string(logEntry.RequestPayload.Body),
-			}
-		}
+		request := newInferenceLogRequest(logEntry)
 
 		response := &mlogs.Response{}
 		if logEntry.ResponsePayload != nil {
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// ObjectWriter writes the files produced by FileSink to a local directory or an object storage bucket
+type ObjectWriter interface {
+	WriteObject(ctx context.Context, key string, body []byte) error
+}
+
+// S3Client is the subset of S3 API used by S3ObjectWriter
+type S3Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// LocalObjectWriter writes objects as files under a local directory
+type LocalObjectWriter struct {
+	dir string
+}
+
+func NewLocalObjectWriter(dir string) *LocalObjectWriter {
+	return &LocalObjectWriter{dir: dir}
+}
+
+// WriteObject writes the object to a temporary file first, so that readers never see partially written files
+func (l *LocalObjectWriter) WriteObject(_ context.Context, key string, body []byte) error {
+	filePath := filepath.Join(l.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", filePath, err)
+	}
+
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", tmpPath, err)
+	}
+	return nil
+}
+
+// S3ObjectWriter writes objects to a S3 compatible bucket under the given prefix
+type S3ObjectWriter struct {
+	client S3Client
+	bucket string
+	prefix string
+}
+
+func NewS3ObjectWriter(client S3Client, bucket string, prefix string) *S3ObjectWriter {
+	return &S3ObjectWriter{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+func (s *S3ObjectWriter) WriteObject(ctx context.Context, key string, body []byte) error {
+	objectKey := path.Join(s.prefix, key)
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: &s.bucket,
+		Key:    &objectKey,
+		Body:   bytes.NewReader(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put s3://%s/%s: %w", s.bucket, objectKey, err)
+	}
+	return nil
+}
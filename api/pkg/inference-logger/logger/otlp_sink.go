@@ -0,0 +1,180 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	otlpScopeName = "merlin-inference-logger"
+
+	// https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber
+	otlpSeverityNumberInfo  = 9
+	otlpSeverityNumberError = 17
+)
+
+// OTLP/HTTP JSON encoding of the logs export request
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+type otlpExportLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	// 64 bit integers are encoded as decimal strings
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+func otlpString(key string, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpInt(key string, value int64) otlpKeyValue {
+	formatted := strconv.FormatInt(value, 10)
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &formatted}}
+}
+
+func otlpBool(key string, value bool) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{BoolValue: &value}}
+}
+
+// OTLPSink exports InferenceLogMessage as OpenTelemetry logs over OTLP/HTTP
+//
+// The log record body is the JSON encoded InferenceLogMessage and the model identity is set as resource attributes.
+type OTLPSink struct {
+	poster *httpPoster
+
+	serviceName string
+
+	projectName  string
+	modelName    string
+	modelVersion string
+}
+
+func NewOTLPSink(
+	logger *zap.SugaredLogger,
+	client *http.Client,
+	config HTTPSinkConfig,
+	serviceName string,
+	projectName string,
+	modelName string,
+	modelVersion string,
+) LogSink {
+	return &OTLPSink{
+		poster:       &httpPoster{logger: logger, client: client, config: config},
+		serviceName:  serviceName,
+		projectName:  projectName,
+		modelName:    modelName,
+		modelVersion: modelVersion,
+	}
+}
+
+func (o *OTLPSink) Sink(rawLogEntries []*LogEntry) error {
+	if len(rawLogEntries) == 0 {
+		return nil
+	}
+
+	request, err := o.buildExportLogsRequest(rawLogEntries)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return o.poster.post(body, "application/json")
+}
+
+func (o *OTLPSink) buildExportLogsRequest(rawLogEntries []*LogEntry) (*otlpExportLogsRequest, error) {
+	observedTime := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	logRecords := make([]otlpLogRecord, 0, len(rawLogEntries))
+	for _, inferenceLog := range newInferenceLogMessages(rawLogEntries, o.projectName, o.modelName, o.modelVersion) {
+		message, err := protojson.Marshal(inferenceLog)
+		if err != nil {
+			return nil, err
+		}
+
+		severityNumber, severityText := otlpSeverityNumberInfo, "INFO"
+		if inferenceLog.Response.StatusCode >= http.StatusInternalServerError {
+			severityNumber, severityText = otlpSeverityNumberError, "ERROR"
+		}
+
+		attributes := []otlpKeyValue{otlpString("merlin.request_id", inferenceLog.RequestId)}
+		if inferenceLog.Response.StatusCode != 0 {
+			attributes = append(attributes, otlpInt("http.response.status_code", int64(inferenceLog.Response.StatusCode)))
+		}
+		if inferenceLog.Request.Header[MirroredHeader] == "true" {
+			attributes = append(attributes, otlpBool("merlin.mirrored", true))
+		}
+
+		body := string(message)
+		logRecords = append(logRecords, otlpLogRecord{
+			TimeUnixNano:         strconv.FormatInt(inferenceLog.EventTimestamp.AsTime().UnixNano(), 10),
+			ObservedTimeUnixNano: observedTime,
+			SeverityNumber:       severityNumber,
+			SeverityText:         severityText,
+			Body:                 otlpAnyValue{StringValue: &body},
+			Attributes:           attributes,
+		})
+	}
+
+	return &otlpExportLogsRequest{
+		ResourceLogs: []otlpResourceLogs{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{
+						otlpString("service.name", o.serviceName),
+						otlpString("merlin.project", o.projectName),
+						otlpString("merlin.model.name", o.modelName),
+						otlpString("merlin.model.version", o.modelVersion),
+					},
+				},
+				ScopeLogs: []otlpScopeLogs{
+					{
+						Scope:      otlpScope{Name: otlpScopeName},
+						LogRecords: logRecords,
+					},
+				},
+			},
+		},
+	}, nil
+}
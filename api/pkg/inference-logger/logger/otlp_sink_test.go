@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTLPSink_Sink(t *testing.T) {
+	collector := &recordingCollector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	sink := NewOTLPSink(logger, server.Client(), HTTPSinkConfig{Url: server.URL + "/v1/logs"},
+		"my-project-my-model", "my-project", "my-model", "1")
+
+	failed := newFileSinkLogEntry("2")
+	failed.ResponsePayload.StatusCode = http.StatusInternalServerError
+	require.NoError(t, sink.Sink([]*LogEntry{newFileSinkLogEntry("1"), failed}))
+
+	require.Len(t, collector.requests, 1)
+	assert.Equal(t, "/v1/logs", collector.requests[0].URL.Path)
+	assert.Empty(t, collector.requests[0].Header.Get("Content-Encoding"))
+
+	request := &otlpExportLogsRequest{}
+	require.NoError(t, json.Unmarshal(collector.bodies[0], request))
+	require.Len(t, request.ResourceLogs, 1)
+
+	resourceAttributes := map[string]string{}
+	for _, attribute := range request.ResourceLogs[0].Resource.Attributes {
+		resourceAttributes[attribute.Key] = *attribute.Value.StringValue
+	}
+	assert.Equal(t, map[string]string{
+		"service.name":         "my-project-my-model",
+		"merlin.project":       "my-project",
+		"merlin.model.name":    "my-model",
+		"merlin.model.version": "1",
+	}, resourceAttributes)
+
+	require.Len(t, request.ResourceLogs[0].ScopeLogs, 1)
+	logRecords := request.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, logRecords, 2)
+
+	assert.Equal(t, strconv.FormatInt(fileSinkEventTime.UnixNano(), 10), logRecords[0].TimeUnixNano)
+	assert.Equal(t, "INFO", logRecords[0].SeverityText)
+	assert.Equal(t, "merlin.request_id", logRecords[0].Attributes[0].Key)
+	assert.Equal(t, "1", *logRecords[0].Attributes[0].Value.StringValue)
+	assert.Equal(t, "200", *logRecords[0].Attributes[1].Value.IntValue)
+
+	body := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(*logRecords[0].Body.StringValue), &body))
+	assert.Equal(t, "1", body["requestId"])
+	assert.Equal(t, "my-project", body["projectName"])
+
+	assert.Equal(t, "ERROR", logRecords[1].SeverityText)
+	assert.Equal(t, otlpSeverityNumberError, logRecords[1].SeverityNumber)
+}
@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+)
+
+// predictionLogMapper extracts the prediction log rows from arbitrary JSON payloads
+// using the json paths of the prediction log schema
+type predictionLogMapper struct {
+	schema *rules.PredictionLogSchema
+
+	instances        *rules.JsonPath
+	rowIds           *rules.JsonPath
+	sessionId        *rules.JsonPath
+	predictionScores *rules.JsonPath
+}
+
+func newPredictionLogMapper(schema *rules.PredictionLogSchema) (*predictionLogMapper, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	mapper := &predictionLogMapper{schema: schema}
+	var err error
+	if mapper.instances, err = rules.CompileJsonPath(schema.InstancesPath); err != nil {
+		return nil, err
+	}
+	if mapper.sessionId, err = rules.CompileJsonPath(schema.SessionIdPath); err != nil {
+		return nil, err
+	}
+	if mapper.predictionScores, err = rules.CompileJsonPath(schema.PredictionScoresPath); err != nil {
+		return nil, err
+	}
+	if schema.RowIdsPath != "" {
+		if mapper.rowIds, err = rules.CompileJsonPath(schema.RowIdsPath); err != nil {
+			return nil, err
+		}
+	}
+	return mapper, nil
+}
+
+func (p *predictionLogMapper) rows(requestBody []byte, responseBody []byte) (*predictionLogRows, error) {
+	var request, response interface{}
+	if err := json.Unmarshal(requestBody, &request); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, err
+	}
+
+	rows := &predictionLogRows{}
+
+	sessionIds := p.sessionId.Select(request)
+	if len(sessionIds) != 1 {
+		return nil, fmt.Errorf("%w: missing session id", ErrMalformedLogEntry)
+	}
+	rows.sessionId = fmt.Sprint(sessionIds[0])
+
+	instances, err := p.instances.SelectList(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: instances: %v", ErrMalformedLogEntry, err)
+	}
+	for _, instance := range instances {
+		features, err := p.features(instance)
+		if err != nil {
+			return nil, err
+		}
+		rows.features = append(rows.features, features)
+	}
+
+	if p.rowIds != nil {
+		rowIds, err := p.rowIds.SelectList(request)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row ids: %v", ErrMalformedLogEntry, err)
+		}
+		for _, rowId := range rowIds {
+			rows.rowIds = append(rows.rowIds, fmt.Sprint(rowId))
+		}
+	}
+
+	predictionScores, err := p.predictionScores.SelectList(response)
+	if err != nil {
+		return nil, fmt.Errorf("%w: prediction scores: %v", ErrMalformedLogEntry, err)
+	}
+	for _, score := range predictionScores {
+		value, err := structpb.NewValue(score)
+		if err != nil {
+			return nil, fmt.Errorf("%w: prediction score: %v", ErrMalformedLogEntry, err)
+		}
+		rows.predictions = append(rows.predictions, value)
+	}
+
+	return rows, nil
+}
+
+// features returns the feature values of an instance, which is either a list of values in the order of the schema's
+// feature orders or an object keyed by feature name. Features missing from the object are logged as null.
+func (p *predictionLogMapper) features(instance interface{}) (*structpb.ListValue, error) {
+	switch instance := instance.(type) {
+	case []interface{}:
+		features, err := structpb.NewList(instance)
+		if err != nil {
+			return nil, fmt.Errorf("%w: instance: %v", ErrMalformedLogEntry, err)
+		}
+		return features, nil
+	case map[string]interface{}:
+		if len(p.schema.FeatureOrders) == 0 {
+			return nil, fmt.Errorf("%w: object instance requires feature orders", ErrMalformedLogEntry)
+		}
+		features := &structpb.ListValue{Values: make([]*structpb.Value, len(p.schema.FeatureOrders))}
+		for i, featureName := range p.schema.FeatureOrders {
+			value, err := structpb.NewValue(instance[featureName])
+			if err != nil {
+				return nil, fmt.Errorf("%w: feature %s: %v", ErrMalformedLogEntry, featureName, err)
+			}
+			features.Values[i] = value
+		}
+		return features, nil
+	default:
+		return nil, fmt.Errorf("%w: instance must be either list or object", ErrMalformedLogEntry)
+	}
+}
+
+// columns returns the column names of the features and prediction results tables, if known from the schema
+func (p *predictionLogMapper) columns() (featureColumns []string, predictionColumns []string) {
+	if p.schema.PredictionScoreColumn != "" {
+		predictionColumns = []string{p.schema.PredictionScoreColumn}
+	}
+	return p.schema.FeatureOrders, predictionColumns
+}
@@ -78,6 +78,7 @@ func (q *BatchQueue) GetMinMax(min, max int) (msgs []interface{}) {
 		msgs = q.messages
 		q.messages = make([]interface{}, 0)
 	}
+	queueEntries.Sub(float64(len(msgs)))
 	q.unlockAdd()
 	q.cond.L.Unlock()
 	return
@@ -89,6 +90,7 @@ func (q *BatchQueue) GetAll() (msgs []interface{}) {
 	q.cond.L.Lock()
 	msgs = q.messages
 	q.messages = make([]interface{}, 0)
+	queueEntries.Sub(float64(len(msgs)))
 	q.unlockAdd()
 	q.cond.L.Unlock()
 	return
@@ -123,6 +125,7 @@ func (q *BatchQueue) Put(msgs ...interface{}) (err error) {
 	}
 
 	q.messages = append(q.messages, msgs...)
+	queueEntries.Add(float64(len(msgs)))
 	q.cond.L.Unlock()
 	q.cond.Signal()
 	return
@@ -145,6 +148,14 @@ func (q *BatchQueue) Len() (l int) {
 	return
 }
 
+// Saturated returns true if the queue is bounded and its length reached the threshold fraction of its size
+func (q *BatchQueue) Saturated(threshold float64) bool {
+	if q.size <= 0 {
+		return false
+	}
+	return float64(q.Len()) >= threshold*float64(q.size)
+}
+
 // Close closes the queue
 // All added messages will be available for Get
 // When queue paused messages do not be released for Get (use GetAll for fetching them)
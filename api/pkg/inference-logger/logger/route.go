@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+	"go.uber.org/zap"
+)
+
+// Route sends the log entries to a dispatcher after applying its own log mode and filter
+//
+// Every route has its own dispatcher, hence its own queue and workers, so that a slow sink doesn't block the others.
+type Route struct {
+	Name       string
+	Dispatcher *Dispatcher
+	LogMode    LogMode
+	// Filter is optional
+	Filter *rules.Filter
+}
+
+func (r *Route) logRequest() bool {
+	return r.LogMode == LogModeAll || r.LogMode == LogModeRequestOnly
+}
+
+func (r *Route) logResponse() bool {
+	return r.LogMode == LogModeAll || r.LogMode == LogModeResponseOnly
+}
+
+// submit sends a copy of the log entry containing the payloads of the route's log mode, filtered by the route's filter
+func (r *Route) submit(logEntry *LogEntry, failed bool) error {
+	if !r.Filter.ShouldLog(r.Filter.IsSampled(logEntry.RequestId), failed) {
+		return nil
+	}
+
+	routed := &LogEntry{
+		RequestId:      logEntry.RequestId,
+		EventTimestamp: logEntry.EventTimestamp,
+		Mirrored:       logEntry.Mirrored,
+		UPI:            logEntry.UPI,
+	}
+	if r.logRequest() && logEntry.RequestPayload != nil {
+		requestPayload := *logEntry.RequestPayload
+		routed.RequestPayload = &requestPayload
+	}
+	if r.logResponse() && logEntry.ResponsePayload != nil {
+		responsePayload := *logEntry.ResponsePayload
+		routed.ResponsePayload = &responsePayload
+	}
+
+	applyFilter(routed, r.Filter)
+	err := r.Dispatcher.Submit(routed)
+	if err != nil {
+		routeDroppedEntries.WithLabelValues(r.Name, dropReason(err)).Inc()
+	} else {
+		routeSubmittedEntries.WithLabelValues(r.Name).Inc()
+	}
+	r.Saturated()
+	return err
+}
+
+// Saturated returns true if the route's dispatcher is saturated and records it in the route_saturated metric
+func (r *Route) Saturated() bool {
+	saturated := r.Dispatcher.Saturated()
+	routeSaturated.WithLabelValues(r.Name).Set(boolToFloat64(saturated))
+	return saturated
+}
+
+// routesLogRequest returns whether any of the routes logs request
+func routesLogRequest(routes []*Route) bool {
+	for _, route := range routes {
+		if route.logRequest() {
+			return true
+		}
+	}
+	return false
+}
+
+// routesLogResponse returns whether any of the routes logs response
+func routesLogResponse(routes []*Route) bool {
+	for _, route := range routes {
+		if route.logResponse() {
+			return true
+		}
+	}
+	return false
+}
+
+// submitToRoutes submits the log entry to all routes, failure of a route doesn't prevent submitting to the others
+func submitToRoutes(routes []*Route, logEntry *LogEntry, failed bool, logger *zap.SugaredLogger) {
+	for _, route := range routes {
+		if err := route.submit(logEntry, failed); err != nil {
+			logger.Errorf("error submitting log entry to %s: %v", route.Name, err)
+		}
+	}
+}
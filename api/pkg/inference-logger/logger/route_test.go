@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/caraml-dev/merlin/pkg/inference-logger/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingSink never returns until release is closed
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (b *blockingSink) Sink(_ []*LogEntry) error {
+	<-b.release
+	return nil
+}
+
+func TestLoggerHandler_MultipleRoutes(t *testing.T) {
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"predictions":[1]}`))
+	}))
+	defer predictor.Close()
+	targetUri, err := url.Parse(predictor.URL)
+	require.NoError(t, err)
+
+	auditFilter, err := rules.NewFilter(&rules.Rules{HeaderDenylist: []string{"Authorization"}}, "")
+	require.NoError(t, err)
+	zero := 0.0
+	unsampledFilter, err := rules.NewFilter(&rules.Rules{SamplingRate: &zero}, "")
+	require.NoError(t, err)
+
+	newRoute := func(name string, logMode LogMode, filter *rules.Filter, sink LogSink) *Route {
+		// queue of size 1 so that the entries of a blocked sink are dropped instead of blocking the handler
+		dispatcher := NewDispatcher(1, 1, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 1}, logger, sink)
+		dispatcher.Start()
+		t.Cleanup(dispatcher.Stop)
+		return &Route{Name: name, Dispatcher: dispatcher, LogMode: logMode, Filter: filter}
+	}
+
+	blocked := &blockingSink{release: make(chan struct{})}
+	defer close(blocked.release)
+	defaultSink, auditSink, unsampledSink := &flakySink{}, &flakySink{}, &flakySink{}
+	routes := []*Route{
+		newRoute("blocked", LogModeAll, nil, blocked),
+		newRoute("default", LogModeResponseOnly, nil, defaultSink),
+		newRoute("audit", LogModeRequestOnly, auditFilter, auditSink),
+		newRoute("unsampled", LogModeAll, unsampledFilter, unsampledSink),
+	}
+	handler := NewLoggerHandler(routes, 0, httputil.NewSingleHostReverseProxy(targetUri), logger)
+
+	for i := 0; i < 3; i++ {
+		r := httptest.NewRequest("POST", "http://a", bytes.NewReader([]byte(`{"instances":[[1,2]]}`)))
+		r.Header.Set("Authorization", "Bearer token")
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set(MirroredHeader, "true")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, `{"predictions":[1]}`, w.Body.String())
+	}
+
+	// blocked sink doesn't prevent the other sinks from receiving the log entries
+	require.Eventually(t, func() bool {
+		return len(defaultSink.sunkEntries()) == 3 && len(auditSink.sunkEntries()) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	defaultEntry := defaultSink.sunkEntries()[0]
+	assert.Nil(t, defaultEntry.RequestPayload)
+	require.NotNil(t, defaultEntry.ResponsePayload)
+	assert.Equal(t, `{"predictions":[1]}`, string(defaultEntry.ResponsePayload.Body))
+
+	auditEntry := auditSink.sunkEntries()[0]
+	assert.Nil(t, auditEntry.ResponsePayload)
+	require.NotNil(t, auditEntry.RequestPayload)
+	assert.Equal(t, `{"instances":[[1,2]]}`, string(auditEntry.RequestPayload.Body))
+	assert.NotContains(t, auditEntry.RequestPayload.Headers, "Authorization")
+	assert.Equal(t, "application/json", auditEntry.RequestPayload.Headers["Content-Type"])
+	assert.Equal(t, defaultEntry.RequestId, auditEntry.RequestId)
+	assert.True(t, defaultEntry.Mirrored)
+	assert.True(t, auditEntry.Mirrored)
+
+	assert.Empty(t, unsampledSink.sunkEntries())
+}
@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaRegistryClient looks up the schemas registered in a Confluent compatible schema registry
+//
+// Basic auth credentials can be set as user info of the url.
+type SchemaRegistryClient struct {
+	url    string
+	client *http.Client
+}
+
+func NewSchemaRegistryClient(registryUrl string, client *http.Client) *SchemaRegistryClient {
+	return &SchemaRegistryClient{
+		url:    strings.TrimSuffix(registryUrl, "/"),
+		client: client,
+	}
+}
+
+// LatestSchemaId returns the id of the latest schema version registered under the subject
+func (c *SchemaRegistryClient) LatestSchemaId(subject string) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/subjects/%s/versions/latest", c.url, url.PathEscape(subject)), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema of subject %s: %w", subject, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("failed to get schema of subject %s: status code %d: %s", subject, resp.StatusCode, body)
+	}
+
+	schema := struct {
+		Id int `json:"id"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		return 0, fmt.Errorf("failed to decode schema of subject %s: %w", subject, err)
+	}
+	return schema.Id, nil
+}
+
+// protobufFramer frames serialized protobuf messages in the Confluent schema registry wire format,
+// i.e. magic byte 0, 4 bytes big endian schema id and the indexes of the message type in its .proto file
+// https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+type protobufFramer struct {
+	header []byte
+}
+
+// newProtobufFramer creates framer of the message type using the latest schema of the subject, the schema must be
+// registered beforehand
+func newProtobufFramer(registry *SchemaRegistryClient, subject string, message proto.Message) (*protobufFramer, error) {
+	schemaId, err := registry.LatestSchemaId(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header[1:], uint32(schemaId))
+	header = append(header, messageIndexes(message.ProtoReflect().Descriptor())...)
+	return &protobufFramer{header: header}, nil
+}
+
+func (f *protobufFramer) frame(data []byte) []byte {
+	framed := make([]byte, 0, len(f.header)+len(data))
+	framed = append(framed, f.header...)
+	return append(framed, data...)
+}
+
+// messageIndexes encodes the path of indexes of the message type from the top level of its file as zigzag varints
+// prefixed by the number of indexes. The first message type of the file is encoded as single 0.
+func messageIndexes(descriptor protoreflect.MessageDescriptor) []byte {
+	var indexes []int
+	var parent protoreflect.Descriptor = descriptor
+	for {
+		messageDescriptor, ok := parent.(protoreflect.MessageDescriptor)
+		if !ok {
+			break
+		}
+		indexes = append([]int{messageDescriptor.Index()}, indexes...)
+		parent = messageDescriptor.Parent()
+	}
+
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return []byte{0}
+	}
+	encoded := binary.AppendVarint(nil, int64(len(indexes)))
+	for _, index := range indexes {
+		encoded = binary.AppendVarint(encoded, int64(index))
+	}
+	return encoded
+}
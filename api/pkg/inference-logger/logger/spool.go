@@ -0,0 +1,466 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// PromNamespace is the prometheus namespace of inference logger metrics
+const PromNamespace = "merlin_inference_logger"
+
+const (
+	segmentFileExt = ".seg"
+	// extension of segments that can't be read, they are kept for inspection but never replayed
+	quarantinedFileExt = ".quarantined"
+)
+
+// ErrFullSpool means that the spool reached its maximum size and incoming request log will be dropped
+var ErrFullSpool = errors.New("Spool is full, request log is dropped")
+
+// Reasons of dropping a log entry from the spool
+const (
+	dropReasonSpoolFull  = "spool_full"
+	dropReasonWriteError = "write_error"
+	dropReasonCorrupted  = "corrupted"
+	dropReasonReadError  = "read_error"
+)
+
+var (
+	spoolEntries = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: PromNamespace,
+		Name:      "spool_entries",
+		Help:      "Number of log entries stored in the spool that are not yet sent to the log sinks",
+	})
+
+	spoolSegments = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: PromNamespace,
+		Name:      "spool_segments",
+		Help:      "Number of segment files in the spool",
+	})
+
+	spoolBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: PromNamespace,
+		Name:      "spool_bytes",
+		Help:      "Total size in bytes of segment files in the spool",
+	})
+
+	spoolDroppedEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "spool_dropped_entries_total",
+		Help:      "Number of log entries dropped by the spool",
+	}, []string{"reason"})
+
+	spoolSinkRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: PromNamespace,
+		Name:      "spool_sink_retries_total",
+		Help:      "Number of retries of sending spooled log entries to the log sinks",
+	})
+)
+
+// SpoolConfig is configuration of the on-disk spool
+type SpoolConfig struct {
+	// Dir is the directory where the segment files are stored
+	Dir string
+	// MaxSegmentBytes is the size after which the active segment is sealed and made available to the workers
+	MaxSegmentBytes int64
+	// MaxSegmentAge is the age after which the active segment is sealed and made available to the workers
+	MaxSegmentAge time.Duration
+	// MaxBytes is the maximum total size of the spool, log entries are dropped when it is reached. 0 means unlimited
+	MaxBytes int64
+	// RetryInitialBackoff is the initial wait time before retrying to send log entries to the log sinks
+	RetryInitialBackoff time.Duration
+	// RetryMaxBackoff is the maximum wait time between retries
+	RetryMaxBackoff time.Duration
+}
+
+// SpoolSegment is a sealed segment file of the spool
+type SpoolSegment struct {
+	path    string
+	seq     uint64
+	entries int
+	bytes   int64
+
+	createdAt time.Time
+	file      *os.File
+}
+
+// Spool is a write-ahead log of request logs stored on local disk
+//
+// Log entries are appended to the active segment file, which is sealed once it reaches MaxSegmentBytes or MaxSegmentAge.
+// Workers claim sealed segments, send the entries to the log sinks and commit the segment once all entries are sent,
+// which removes the segment file. Segments found in the directory when the spool is opened are replayed.
+type Spool struct {
+	config SpoolConfig
+	logger *zap.SugaredLogger
+
+	cond         *sync.Cond
+	sealed       []*SpoolSegment
+	active       *SpoolSegment
+	nextSeq      uint64
+	totalEntries int
+	totalBytes   int64
+	totalFiles   int
+
+	closed bool
+	done   chan struct{}
+}
+
+// OpenSpool opens the spool stored in config.Dir, creating the directory if it doesn't exist
+//
+// Existing segments are loaded so that the entries that were not sent before the restart are replayed.
+func OpenSpool(config SpoolConfig, logger *zap.SugaredLogger) (*Spool, error) {
+	if config.Dir == "" {
+		return nil, fmt.Errorf("spool directory must be set")
+	}
+	if config.MaxSegmentBytes <= 0 && config.MaxSegmentAge <= 0 {
+		return nil, fmt.Errorf("spool max segment bytes or max segment age must be positive, otherwise segments are never sealed")
+	}
+	if config.RetryInitialBackoff <= 0 {
+		return nil, fmt.Errorf("spool retry initial backoff must be positive, got %v", config.RetryInitialBackoff)
+	}
+	if config.RetryMaxBackoff < 0 || (config.RetryMaxBackoff > 0 && config.RetryMaxBackoff < config.RetryInitialBackoff) {
+		return nil, fmt.Errorf("spool retry max backoff must be either 0 or at least the initial backoff, got %v", config.RetryMaxBackoff)
+	}
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	s := &Spool{
+		config: config,
+		logger: logger,
+		cond:   sync.NewCond(&sync.Mutex{}),
+		done:   make(chan struct{}),
+	}
+
+	if err := s.loadSegments(); err != nil {
+		return nil, err
+	}
+	s.updateMetrics()
+
+	if config.MaxSegmentAge > 0 {
+		go s.sealExpiredSegments()
+	}
+	return s, nil
+}
+
+func (s *Spool) loadSegments() error {
+	files, err := os.ReadDir(s.config.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read spool directory: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), segmentFileExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), segmentFileExt), 10, 64)
+		if err != nil {
+			s.logger.Warnf("ignoring unknown file %s in spool directory", file.Name())
+			continue
+		}
+
+		segment := &SpoolSegment{
+			path: filepath.Join(s.config.Dir, file.Name()),
+			seq:  seq,
+		}
+		segment.entries, segment.bytes, err = countSegmentEntries(segment.path)
+		if err != nil {
+			return fmt.Errorf("failed to read segment %s: %w", segment.path, err)
+		}
+
+		s.sealed = append(s.sealed, segment)
+		s.totalEntries += segment.entries
+		s.totalBytes += segment.bytes
+		s.totalFiles++
+		if seq >= s.nextSeq {
+			s.nextSeq = seq + 1
+		}
+	}
+
+	sort.Slice(s.sealed, func(i, j int) bool {
+		return s.sealed[i].seq < s.sealed[j].seq
+	})
+
+	if len(s.sealed) > 0 {
+		s.logger.Infof("replaying %d log entries from %d spool segments", s.totalEntries, len(s.sealed))
+	}
+	return nil
+}
+
+// Put appends the log entry to the active segment
+func (s *Spool) Put(logEntry *LogEntry) error {
+	data, err := json.Marshal(logEntry)
+	if err != nil {
+		spoolDroppedEntries.WithLabelValues(dropReasonWriteError).Inc()
+		return fmt.Errorf("failed to encode log entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+
+	if s.config.MaxBytes > 0 && s.totalBytes+int64(len(data)) > s.config.MaxBytes {
+		spoolDroppedEntries.WithLabelValues(dropReasonSpoolFull).Inc()
+		return ErrFullSpool
+	}
+
+	if s.active == nil {
+		if err := s.createActiveSegment(); err != nil {
+			spoolDroppedEntries.WithLabelValues(dropReasonWriteError).Inc()
+			return err
+		}
+	}
+
+	n, err := s.active.file.Write(data)
+	s.active.bytes += int64(n)
+	s.totalBytes += int64(n)
+	if err != nil {
+		spoolDroppedEntries.WithLabelValues(dropReasonWriteError).Inc()
+		s.updateMetrics()
+		return fmt.Errorf("failed to write log entry to spool: %w", err)
+	}
+	s.active.entries++
+	s.totalEntries++
+
+	if s.config.MaxSegmentBytes > 0 && s.active.bytes >= s.config.MaxSegmentBytes {
+		s.sealActiveSegment()
+	}
+	s.updateMetrics()
+	return nil
+}
+
+// Claim waits until a sealed segment is available and removes it from the pending segments
+//
+// It returns nil when the spool is closed. The claimed segment must be passed to Commit once all entries are sent.
+func (s *Spool) Claim() *SpoolSegment {
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+
+	for len(s.sealed) == 0 {
+		if s.closed {
+			return nil
+		}
+		s.cond.Wait()
+	}
+	if s.closed {
+		return nil
+	}
+
+	segment := s.sealed[0]
+	s.sealed = s.sealed[1:]
+	return segment
+}
+
+// Commit removes the segment whose entries have been sent to the log sinks
+func (s *Spool) Commit(segment *SpoolSegment) error {
+	err := os.Remove(segment.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove segment %s: %w", segment.path, err)
+	}
+
+	s.cond.L.Lock()
+	s.totalEntries -= segment.entries
+	s.totalBytes -= segment.bytes
+	s.totalFiles--
+	s.updateMetrics()
+	s.cond.L.Unlock()
+	return nil
+}
+
+// Quarantine removes the segment that can't be read from the spool, so that it doesn't block the workers
+//
+// The segment file is renamed so that it's kept for inspection but not replayed once the spool is reopened,
+// its entries are counted as dropped.
+func (s *Spool) Quarantine(segment *SpoolSegment) error {
+	s.cond.L.Lock()
+	s.totalEntries -= segment.entries
+	s.totalBytes -= segment.bytes
+	s.totalFiles--
+	s.updateMetrics()
+	s.cond.L.Unlock()
+	spoolDroppedEntries.WithLabelValues(dropReasonReadError).Add(float64(segment.entries))
+
+	if err := os.Rename(segment.path, segment.path+quarantinedFileExt); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to quarantine segment %s: %w", segment.path, err)
+	}
+	return nil
+}
+
+// ReadSegment reads the log entries of the segment, corrupted entries are skipped
+func (s *Spool) ReadSegment(segment *SpoolSegment) ([]*LogEntry, error) {
+	file, err := os.Open(segment.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open segment %s: %w", segment.path, err)
+	}
+	defer file.Close()
+
+	logEntries := make([]*LogEntry, 0, segment.entries)
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			logEntry := &LogEntry{}
+			if decodeErr := json.Unmarshal(line, logEntry); decodeErr != nil {
+				s.logger.Warnf("dropping corrupted log entry in segment %s: %v", segment.path, decodeErr)
+				spoolDroppedEntries.WithLabelValues(dropReasonCorrupted).Inc()
+			} else {
+				logEntries = append(logEntries, logEntry)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return logEntries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read segment %s: %w", segment.path, err)
+		}
+	}
+}
+
+// Len returns number of log entries in the spool that are not yet committed
+func (s *Spool) Len() int {
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+	return s.totalEntries
+}
+
+// Saturated returns true if the spool is bounded and its size reached the threshold fraction of MaxBytes
+func (s *Spool) Saturated(threshold float64) bool {
+	if s.config.MaxBytes <= 0 {
+		return false
+	}
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+	return float64(s.totalBytes) >= threshold*float64(s.config.MaxBytes)
+}
+
+// Done returns a channel that is closed when the spool is closed
+func (s *Spool) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close seals the active segment and wakes up all waiting workers
+//
+// Segments that are not yet committed are kept on disk and replayed once the spool is reopened.
+func (s *Spool) Close() error {
+	s.cond.L.Lock()
+	if s.closed {
+		s.cond.L.Unlock()
+		return ErrClosed
+	}
+	s.closed = true
+	s.sealActiveSegment()
+	s.updateMetrics()
+	close(s.done)
+	s.cond.L.Unlock()
+	s.cond.Broadcast()
+	return nil
+}
+
+func (s *Spool) createActiveSegment() error {
+	seq := s.nextSeq
+	path := filepath.Join(s.config.Dir, fmt.Sprintf("%020d%s", seq, segmentFileExt))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create segment %s: %w", path, err)
+	}
+
+	s.nextSeq++
+	s.totalFiles++
+	s.active = &SpoolSegment{
+		path:      path,
+		seq:       seq,
+		createdAt: time.Now(),
+		file:      file,
+	}
+	return nil
+}
+
+// sealActiveSegment makes the active segment available for the workers, it must be called while holding the lock
+func (s *Spool) sealActiveSegment() {
+	if s.active == nil {
+		return
+	}
+
+	if err := s.active.file.Sync(); err != nil {
+		s.logger.Warnf("failed to sync segment %s: %v", s.active.path, err)
+	}
+	if err := s.active.file.Close(); err != nil {
+		s.logger.Warnf("failed to close segment %s: %v", s.active.path, err)
+	}
+	s.active.file = nil
+
+	s.sealed = append(s.sealed, s.active)
+	s.active = nil
+	s.cond.Signal()
+}
+
+// sealExpiredSegments periodically seals the active segment once it is older than MaxSegmentAge
+func (s *Spool) sealExpiredSegments() {
+	ticker := time.NewTicker(s.config.MaxSegmentAge / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.cond.L.Lock()
+			if s.active != nil && time.Since(s.active.createdAt) >= s.config.MaxSegmentAge {
+				s.sealActiveSegment()
+			}
+			s.cond.L.Unlock()
+		}
+	}
+}
+
+// updateMetrics must be called while holding the lock
+func (s *Spool) updateMetrics() {
+	spoolEntries.Set(float64(s.totalEntries))
+	spoolSegments.Set(float64(s.totalFiles))
+	spoolBytes.Set(float64(s.totalBytes))
+}
+
+func countSegmentEntries(path string) (int, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	entries := 0
+	var size int64
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		size += int64(len(line))
+		if len(bytes.TrimSpace(line)) > 0 {
+			entries++
+		}
+		if errors.Is(err, io.EOF) {
+			return entries, size, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+}
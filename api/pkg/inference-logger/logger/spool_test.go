@@ -0,0 +1,260 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type flakySink struct {
+	mu       sync.Mutex
+	failures int
+	entries  []*LogEntry
+}
+
+func (f *flakySink) Sink(rawLogEntries []*LogEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("sink is unavailable")
+	}
+	f.entries = append(f.entries, rawLogEntries...)
+	return nil
+}
+
+func (f *flakySink) sunkEntries() []*LogEntry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.entries
+}
+
+func newSpoolLogEntry(id int) *LogEntry {
+	return &LogEntry{
+		RequestId:      fmt.Sprint(id),
+		EventTimestamp: timestamppb.Now(),
+		RequestPayload: &RequestPayload{
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			Body: []byte(`{"instances" : [[1,2,3,4]]}`),
+		},
+		ResponsePayload: &ResponsePayload{
+			StatusCode: 200,
+			Body:       []byte(`{"predictions": [2]}`),
+		},
+	}
+}
+
+func TestSpool_PutAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	config := SpoolConfig{
+		Dir:                 dir,
+		MaxSegmentBytes:     1024,
+		RetryInitialBackoff: time.Millisecond,
+	}
+
+	spool, err := OpenSpool(config, logger)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, spool.Put(newSpoolLogEntry(i)))
+	}
+	assert.Equal(t, 20, spool.Len())
+
+	// the first segment is sealed once it reaches MaxSegmentBytes
+	segment := spool.Claim()
+	require.NotNil(t, segment)
+	logEntries, err := spool.ReadSegment(segment)
+	require.NoError(t, err)
+	require.NotEmpty(t, logEntries)
+	assert.Equal(t, "0", logEntries[0].RequestId)
+	assert.Equal(t, []byte(`{"predictions": [2]}`), logEntries[0].ResponsePayload.Body)
+	require.NoError(t, spool.Commit(segment))
+	assert.Equal(t, 20-len(logEntries), spool.Len())
+
+	require.NoError(t, spool.Close())
+	assert.Nil(t, spool.Claim())
+	assert.ErrorIs(t, spool.Put(newSpoolLogEntry(20)), ErrClosed)
+
+	// remaining entries are replayed after reopening the spool
+	reopened, err := OpenSpool(config, logger)
+	require.NoError(t, err)
+	assert.Equal(t, 20-len(logEntries), reopened.Len())
+
+	replayed := make([]*LogEntry, 0)
+	for reopened.Len() > 0 {
+		segment := reopened.Claim()
+		entries, err := reopened.ReadSegment(segment)
+		require.NoError(t, err)
+		replayed = append(replayed, entries...)
+		require.NoError(t, reopened.Commit(segment))
+	}
+	require.Len(t, replayed, 20-len(logEntries))
+	assert.Equal(t, fmt.Sprint(len(logEntries)), replayed[0].RequestId)
+	assert.Equal(t, "19", replayed[len(replayed)-1].RequestId)
+	require.NoError(t, reopened.Close())
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestSpool_Full(t *testing.T) {
+	spool, err := OpenSpool(SpoolConfig{
+		Dir:                 t.TempDir(),
+		MaxSegmentBytes:     1024,
+		MaxBytes:            400,
+		RetryInitialBackoff: time.Millisecond,
+	}, logger)
+	require.NoError(t, err)
+	defer spool.Close() //nolint:errcheck
+
+	require.NoError(t, spool.Put(newSpoolLogEntry(0)))
+	assert.ErrorIs(t, spool.Put(newSpoolLogEntry(1)), ErrFullSpool)
+	assert.Equal(t, 1, spool.Len())
+}
+
+func TestSpool_SkipCorruptedEntry(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"RequestId":"1"}` + "\n" + `{"RequestId":` + "\n" + `{"RequestId":"2"}` + "\n"
+	require.NoError(t, os.WriteFile(fmt.Sprintf("%s/%020d%s", dir, 3, segmentFileExt), []byte(content), 0o644))
+
+	spool, err := OpenSpool(SpoolConfig{Dir: dir, MaxSegmentBytes: 1024, RetryInitialBackoff: time.Millisecond}, logger)
+	require.NoError(t, err)
+	defer spool.Close() //nolint:errcheck
+	assert.Equal(t, 3, spool.Len())
+
+	segment := spool.Claim()
+	logEntries, err := spool.ReadSegment(segment)
+	require.NoError(t, err)
+	require.Len(t, logEntries, 2)
+	assert.Equal(t, "1", logEntries[0].RequestId)
+	assert.Equal(t, "2", logEntries[1].RequestId)
+
+	// new segment must not overwrite the replayed one
+	require.NoError(t, spool.Put(newSpoolLogEntry(4)))
+	_, err = os.Stat(fmt.Sprintf("%s/%020d%s", dir, 4, segmentFileExt))
+	assert.NoError(t, err)
+}
+
+func TestOpenSpool_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SpoolConfig
+		wantErr string
+	}{
+		{
+			name:    "no sealing threshold",
+			config:  SpoolConfig{Dir: t.TempDir(), RetryInitialBackoff: time.Second},
+			wantErr: "spool max segment bytes or max segment age must be positive, otherwise segments are never sealed",
+		},
+		{
+			name:    "zero initial backoff",
+			config:  SpoolConfig{Dir: t.TempDir(), MaxSegmentAge: time.Second},
+			wantErr: "spool retry initial backoff must be positive, got 0s",
+		},
+		{
+			name:    "max backoff less than initial backoff",
+			config:  SpoolConfig{Dir: t.TempDir(), MaxSegmentAge: time.Second, RetryInitialBackoff: time.Second, RetryMaxBackoff: time.Millisecond},
+			wantErr: "spool retry max backoff must be either 0 or at least the initial backoff, got 1ms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := OpenSpool(tt.config, logger)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSpoolDispatcher_QuarantineUnreadableSegment(t *testing.T) {
+	dir := t.TempDir()
+	sink := &flakySink{}
+	spool, err := OpenSpool(SpoolConfig{
+		Dir:                 dir,
+		MaxSegmentBytes:     1024 * 1024,
+		MaxBytes:            1024 * 1024,
+		RetryInitialBackoff: time.Millisecond,
+	}, logger)
+	require.NoError(t, err)
+
+	require.NoError(t, spool.Put(newSpoolLogEntry(0)))
+	spool.cond.L.Lock()
+	unreadable := spool.active
+	spool.sealActiveSegment()
+	spool.cond.L.Unlock()
+	// segment file is replaced by a directory, so that reading it always fails
+	require.NoError(t, os.Remove(unreadable.path))
+	require.NoError(t, os.Mkdir(unreadable.path, 0o755))
+
+	dispatcher := NewSpoolDispatcher(1, spool, workerConfig, logger, sink)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	assert.Eventually(t, func() bool {
+		return spool.Len() == 0 && !spool.Saturated(0.000001)
+	}, 2*time.Second, 10*time.Millisecond)
+	_, err = os.Stat(unreadable.path + quarantinedFileExt)
+	assert.NoError(t, err)
+
+	// following segments are still sent
+	require.NoError(t, dispatcher.Submit(newSpoolLogEntry(1)))
+	spool.cond.L.Lock()
+	spool.sealActiveSegment()
+	spool.cond.L.Unlock()
+	assert.Eventually(t, func() bool {
+		return len(sink.sunkEntries()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestSpoolDispatcher_RetryUntilSinkAvailable(t *testing.T) {
+	sink := &flakySink{failures: 3}
+	spool, err := OpenSpool(SpoolConfig{
+		Dir:                 t.TempDir(),
+		MaxSegmentBytes:     1024 * 1024,
+		MaxSegmentAge:       20 * time.Millisecond,
+		RetryInitialBackoff: time.Millisecond,
+		RetryMaxBackoff:     5 * time.Millisecond,
+	}, logger)
+	require.NoError(t, err)
+
+	dispatcher := NewSpoolDispatcher(2, spool, workerConfig, logger, sink)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, dispatcher.Submit(newSpoolLogEntry(i)))
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(sink.sunkEntries()) == 50 && spool.Len() == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestSpoolDispatcher_RetryCountsDeliveryAttempts(t *testing.T) {
+	sink := &flakySink{failures: 2}
+	spool, err := OpenSpool(SpoolConfig{
+		Dir:                 t.TempDir(),
+		MaxSegmentAge:       20 * time.Millisecond,
+		RetryInitialBackoff: time.Millisecond,
+	}, logger)
+	require.NoError(t, err)
+
+	dispatcher := NewSpoolDispatcher(1, spool, workerConfig, logger, sink)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	require.NoError(t, dispatcher.Submit(newSpoolLogEntry(0)))
+	require.Eventually(t, func() bool {
+		return len(sink.sunkEntries()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, sink.sunkEntries()[0].deliveryAttempts)
+}
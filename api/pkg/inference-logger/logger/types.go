@@ -12,16 +12,33 @@ type LogEntry struct {
 
 	RequestPayload  *RequestPayload
 	ResponsePayload *ResponsePayload
+
+	// Mirrored is true if the request is a copy of a request served by another model version, e.g. mirrored by Istio
+	Mirrored bool
+
+	// UPI is true if the payloads are protojson encoded UPI PredictValues request and response, in which case the
+	// response status code is the gRPC status code
+	UPI bool
+
+	// deliveryAttempts is the number of times the log sink failed to deliver the log entry
+	deliveryAttempts int
+	// pendingSinks are the indexes of the worker log sinks that failed to deliver the log entry, the log entry is sent
+	// to all log sinks of the worker if it is nil
+	pendingSinks map[int]bool
 }
 
 type RequestPayload struct {
 	Headers map[string]string
 	Body    []byte
+	// Truncated is true if Body exceeds the maximum captured body size and ends with truncation marker
+	Truncated bool
 }
 
 type ResponsePayload struct {
 	StatusCode int
 	Body       []byte
+	// Truncated is true if Body exceeds the maximum captured body size and ends with truncation marker
+	Truncated bool
 }
 
 type LogMode string
@@ -39,9 +56,12 @@ const (
 	NewRelic LoggerSinkKind = "newrelic"
 	Console  LoggerSinkKind = "console"
 	MLObs    LoggerSinkKind = "mlobs"
+	File     LoggerSinkKind = "file"
+	Webhook  LoggerSinkKind = "webhook"
+	OTLP     LoggerSinkKind = "otlp"
 )
 
-var LoggerSinkKinds = []LoggerSinkKind{Kafka, NewRelic, Console, MLObs}
+var LoggerSinkKinds = []LoggerSinkKind{Kafka, NewRelic, Console, MLObs, File, Webhook, OTLP}
 
 func ParseSinkKindAndUrl(logUrl string) (LoggerSinkKind, string) {
 	sinkKind := Console
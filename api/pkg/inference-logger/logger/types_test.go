@@ -39,6 +39,30 @@ func TestParseSinkKindAndUrl(t *testing.T) {
 			Console,
 			"localhost:8080",
 		},
+		{
+			"file",
+			args{
+				logUrl: "file:s3://my-bucket/inference-log?format=parquet",
+			},
+			File,
+			"s3://my-bucket/inference-log?format=parquet",
+		},
+		{
+			"webhook",
+			args{
+				logUrl: "webhook:https://collector.example.com/ingest",
+			},
+			Webhook,
+			"https://collector.example.com/ingest",
+		},
+		{
+			"otlp",
+			args{
+				logUrl: "otlp:http://otel-collector:4318/v1/logs",
+			},
+			OTLP,
+			"http://otel-collector:4318/v1/logs",
+		},
 		{
 			"invalid, fallback to console",
 			args{
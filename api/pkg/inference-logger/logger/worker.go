@@ -1,13 +1,20 @@
 package logger
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// maximum number of attempts to read a spool segment before it is quarantined
+const maxSegmentReadAttempts = 3
+
 type Worker struct {
 	id                         string
 	workerQueue                *BatchQueue
+	spool                      *Spool
 	minBatchSize, maxBatchSize int
 
 	quitChan chan bool
@@ -33,10 +40,29 @@ func NewWorker(workerQueue *BatchQueue, workerConfig *WorkerConfig, logger *zap.
 	}
 }
 
+// NewSpoolWorker creates a worker that sends log entries stored in the spool
+//
+// Sending to the log sinks is retried with exponential backoff until it succeeds or the spool is closed.
+func NewSpoolWorker(spool *Spool, workerConfig *WorkerConfig, logger *zap.SugaredLogger, logSinks ...LogSink) *Worker {
+	return &Worker{
+		id:           uuid.New().String(),
+		spool:        spool,
+		minBatchSize: workerConfig.MinBatchSize,
+		maxBatchSize: workerConfig.MaxBatchSize,
+		logger:       logger,
+		logSinks:     logSinks,
+	}
+}
+
 // Start start worker and listen to work submitted to the workerQueue
 //
 // Call Stop to stop the worker from performing further works
 func (w *Worker) Start() {
+	if w.spool != nil {
+		go w.drainSpool()
+		return
+	}
+
 	go func() {
 		w.logger.Infof("Starting worker: %s\n", w.id)
 		defer func() {
@@ -79,6 +105,7 @@ func (w *Worker) Start() {
 				err := w.Send(logEntries)
 				if err != nil {
 					w.logger.Errorf("error processing log entry: %v", err)
+					w.requeue(logEntries)
 				}
 			}
 		}
@@ -89,6 +116,9 @@ func (w *Worker) Start() {
 //
 // Note that the log entry might be buffered before being sent to the log sink.
 func (w *Worker) Submit(logEntry *LogEntry) error {
+	if w.spool != nil {
+		return w.spool.Put(logEntry)
+	}
 	return w.workerQueue.Put(logEntry)
 }
 
@@ -101,12 +131,197 @@ func (w *Worker) Stop() {
 	}()
 }
 
+// Send sends the log entries to the log sinks of the worker and returns the first error of the log sinks
+//
+// Log entries that were already sent are only sent to the log sinks that failed to deliver them.
 func (w *Worker) Send(rawLogEntries []*LogEntry) error {
-	for _, logSink := range w.logSinks {
-		err := logSink.Sink(rawLogEntries)
-		if err != nil {
-			return err
+	workerBatchSize.Observe(float64(len(rawLogEntries)))
+
+	pendingSinks := make(map[*LogEntry]map[int]bool)
+	var firstErr error
+	for i, logSink := range w.logSinks {
+		logEntries := make([]*LogEntry, 0, len(rawLogEntries))
+		for _, logEntry := range rawLogEntries {
+			if logEntry.pendingSinks == nil || logEntry.pendingSinks[i] {
+				logEntries = append(logEntries, logEntry)
+			}
+		}
+		if len(logEntries) == 0 {
+			continue
+		}
+
+		start := time.Now()
+		err := logSink.Sink(logEntries)
+		observeSink(logSink, len(logEntries), start, err)
+		if err == nil {
+			continue
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		for _, logEntry := range undeliveredEntries(logEntries, err) {
+			if pendingSinks[logEntry] == nil {
+				pendingSinks[logEntry] = make(map[int]bool)
+			}
+			pendingSinks[logEntry][i] = true
+		}
+	}
+
+	for _, logEntry := range rawLogEntries {
+		logEntry.pendingSinks = pendingSinks[logEntry]
+		if logEntry.pendingSinks == nil {
+			logEntry.pendingSinks = map[int]bool{}
+		}
+	}
+	return firstErr
+}
+
+// undeliveredEntries returns the log entries the log sink failed to deliver, which are all of the log entries unless
+// the log sink reports the failed ones with DeliveryError
+func undeliveredEntries(logEntries []*LogEntry, err error) []*LogEntry {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Failed
+	}
+	return logEntries
+}
+
+// pendingEntries returns the log entries that some of the log sinks failed to deliver
+func pendingEntries(logEntries []*LogEntry) []*LogEntry {
+	pending := make([]*LogEntry, 0, len(logEntries))
+	for _, logEntry := range logEntries {
+		if len(logEntry.pendingSinks) > 0 {
+			pending = append(pending, logEntry)
+		}
+	}
+	return pending
+}
+
+// requeue puts the log entries that some of the log sinks failed to deliver back to the queue until they reach
+// MaxDeliveryAttempts
+//
+// Note that the requeued log entries are only sent again to the log sinks that failed to deliver them.
+func (w *Worker) requeue(logEntries []*LogEntry) {
+	for _, logEntry := range pendingEntries(logEntries) {
+		logEntry.deliveryAttempts++
+		if logEntry.deliveryAttempts >= MaxDeliveryAttempts {
+			workerDroppedEntries.WithLabelValues(dropReasonMaxAttempts).Inc()
+			continue
+		}
+		if err := w.workerQueue.Put(logEntry); err != nil {
+			workerDroppedEntries.WithLabelValues(dropReason(err)).Inc()
+			continue
+		}
+		workerRequeuedEntries.Inc()
+	}
+}
+
+// drainSpool sends the entries of every sealed segment of the spool and commits the segment once all entries are sent
+//
+// Segment that is not completely sent when the spool is closed stays on disk and is replayed on restart.
+func (w *Worker) drainSpool() {
+	w.logger.Infof("Starting spool worker: %s\n", w.id)
+	defer func() {
+		w.logger.Infof("worker %s is stopped, exiting... \n", w.id)
+	}()
+
+	for {
+		select {
+		case <-w.quitChan:
+			return
+		default:
+		}
+
+		segment := w.spool.Claim()
+		if segment == nil {
+			w.logger.Infof("Spool has been closed")
+			return
+		}
+
+		logEntries, ok := w.readSegmentWithRetry(segment)
+		if !ok {
+			return
+		}
+		if logEntries == nil {
+			continue
+		}
+
+		batchSize := w.maxBatchSize
+		if batchSize <= 0 {
+			batchSize = len(logEntries)
+		}
+		for start := 0; start < len(logEntries); start += batchSize {
+			end := start + batchSize
+			if end > len(logEntries) {
+				end = len(logEntries)
+			}
+			if !w.sendWithRetry(logEntries[start:end]) {
+				return
+			}
+		}
+
+		if err := w.spool.Commit(segment); err != nil {
+			w.logger.Errorf("error committing spool segment: %v", err)
+		}
+	}
+}
+
+// readSegmentWithRetry reads the entries of the segment, retrying up to maxSegmentReadAttempts times
+//
+// The segment is quarantined if it still can't be read, in which case nil entries are returned.
+// It returns false if the spool is closed before the segment is read.
+func (w *Worker) readSegmentWithRetry(segment *SpoolSegment) ([]*LogEntry, bool) {
+	backoff := w.spool.config.RetryInitialBackoff
+	for attempt := 1; ; attempt++ {
+		logEntries, err := w.spool.ReadSegment(segment)
+		if err == nil {
+			return logEntries, true
+		}
+
+		if attempt >= maxSegmentReadAttempts {
+			w.logger.Errorf("error reading spool segment, quarantining it: %v", err)
+			if err := w.spool.Quarantine(segment); err != nil {
+				w.logger.Errorf("error quarantining spool segment: %v", err)
+			}
+			return nil, true
+		}
+		w.logger.Warnf("error reading spool segment, retrying in %v: %v", backoff, err)
+
+		select {
+		case <-w.spool.Done():
+			return nil, false
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// sendWithRetry sends the log entries until it succeeds, it returns false if the spool is closed before that
+func (w *Worker) sendWithRetry(logEntries []*LogEntry) bool {
+	backoff := w.spool.config.RetryInitialBackoff
+	for {
+		err := w.Send(logEntries)
+		if err == nil {
+			return true
+		}
+		w.logger.Errorf("error processing log entry, retrying in %v: %v", backoff, err)
+
+		// Only retry the log entries that were not delivered, to the log sinks that failed to deliver them
+		logEntries = pendingEntries(logEntries)
+		for _, logEntry := range logEntries {
+			logEntry.deliveryAttempts++
+		}
+		spoolSinkRetries.Inc()
+
+		select {
+		case <-w.spool.Done():
+			return false
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if maxBackoff := w.spool.config.RetryMaxBackoff; maxBackoff > 0 && backoff > maxBackoff {
+			backoff = maxBackoff
 		}
 	}
-	return nil
 }
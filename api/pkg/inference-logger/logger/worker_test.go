@@ -1,12 +1,15 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -254,3 +257,119 @@ func benchmarkSend(b *testing.B, n int) {
 
 	worker.Stop()
 }
+
+// partialDeliverySink fails to deliver the log entries of failedRequestIds until they are attempted maxFailures times
+type partialDeliverySink struct {
+	mu               sync.Mutex
+	failedRequestIds map[string]bool
+	maxFailures      int
+	attempts         map[string]int
+	delivered        []string
+}
+
+func (p *partialDeliverySink) Sink(rawLogEntries []*LogEntry) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var failed []*LogEntry
+	for _, logEntry := range rawLogEntries {
+		p.attempts[logEntry.RequestId]++
+		if p.failedRequestIds[logEntry.RequestId] && p.attempts[logEntry.RequestId] <= p.maxFailures {
+			failed = append(failed, logEntry)
+			continue
+		}
+		p.delivered = append(p.delivered, logEntry.RequestId)
+	}
+	if len(failed) > 0 {
+		return &DeliveryError{Failed: failed, Err: errors.New("message timed out")}
+	}
+	return nil
+}
+
+func (p *partialDeliverySink) state() ([]string, map[string]int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	attempts := make(map[string]int)
+	for k, v := range p.attempts {
+		attempts[k] = v
+	}
+	return append([]string{}, p.delivered...), attempts
+}
+
+func TestWorker_RequeueFailedDeliveries(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxFailures   int
+		wantDelivered []string
+		wantAttempts  int
+	}{
+		{
+			name:          "delivered after retry",
+			maxFailures:   1,
+			wantDelivered: []string{"1", "3", "2"},
+			wantAttempts:  2,
+		},
+		{
+			name:          "dropped after max attempts",
+			maxFailures:   MaxDeliveryAttempts,
+			wantDelivered: []string{"1", "3"},
+			wantAttempts:  MaxDeliveryAttempts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &partialDeliverySink{
+				failedRequestIds: map[string]bool{"2": true},
+				maxFailures:      tt.maxFailures,
+				attempts:         map[string]int{},
+			}
+			queue := NewBatchQueue(10)
+			worker := NewWorker(queue, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 3}, logger, sink)
+			require.NoError(t, queue.Put(newFileSinkLogEntry("1"), newFileSinkLogEntry("2"), newFileSinkLogEntry("3")))
+			worker.Start()
+			defer func() { _ = queue.Close() }()
+
+			require.Eventually(t, func() bool {
+				_, attempts := sink.state()
+				return attempts["2"] == tt.wantAttempts && queue.Len() == 0
+			}, time.Second, 5*time.Millisecond)
+			time.Sleep(20 * time.Millisecond)
+
+			delivered, attempts := sink.state()
+			assert.Equal(t, tt.wantDelivered, delivered)
+			assert.Equal(t, tt.wantAttempts, attempts["2"])
+			assert.Equal(t, 1, attempts["1"])
+		})
+	}
+}
+
+func TestWorker_RequeueOnlyFailedSinks(t *testing.T) {
+	failingSink := &partialDeliverySink{
+		failedRequestIds: map[string]bool{"2": true},
+		maxFailures:      1,
+		attempts:         map[string]int{},
+	}
+	healthySink := &partialDeliverySink{
+		failedRequestIds: map[string]bool{},
+		attempts:         map[string]int{},
+	}
+	queue := NewBatchQueue(10)
+	worker := NewWorker(queue, &WorkerConfig{MinBatchSize: 1, MaxBatchSize: 3}, logger, healthySink, failingSink)
+	require.NoError(t, queue.Put(newFileSinkLogEntry("1"), newFileSinkLogEntry("2"), newFileSinkLogEntry("3")))
+	worker.Start()
+	defer func() { _ = queue.Close() }()
+
+	require.Eventually(t, func() bool {
+		delivered, _ := failingSink.state()
+		return len(delivered) == 3 && queue.Len() == 0
+	}, time.Second, 5*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	delivered, attempts := healthySink.state()
+	assert.Equal(t, []string{"1", "2", "3"}, delivered)
+	assert.Equal(t, map[string]int{"1": 1, "2": 1, "3": 1}, attempts)
+
+	delivered, attempts = failingSink.state()
+	assert.Equal(t, []string{"1", "3", "2"}, delivered)
+	assert.Equal(t, map[string]int{"1": 1, "2": 2, "3": 1}, attempts)
+}
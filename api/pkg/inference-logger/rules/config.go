@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Config is the configuration of the inference logger of a model deployment component (predictor or transformer)
+//
+// The inference logger container is injected by KServe which only passes the log url, log mode and identity of the
+// inference service as arguments and doesn't mount any volume of the pod. Merlin stores the rest of the configuration
+// of each component in the logger config secret of the deployment, which is read by the inference logger on startup.
+type Config struct {
+	// Rules are the rules of the log-url sink, including the additional sinks
+	Rules *Rules `json:"rules,omitempty"`
+	// HashKey is the key of the HMAC of the values redacted by the hash action, it is generated for each model
+	// deployment so that the hashes can't be reversed by hashing guessed values
+	HashKey string `json:"hash_key,omitempty"`
+	// HTTPSinkAuthToken is the auth token of the webhook and otlp sinks, read from the MLP secret of the logger
+	HTTPSinkAuthToken string `json:"http_sink_auth_token,omitempty"`
+	// MetricsPort is the port of the prometheus metrics of the inference logger, the metrics are not exposed if 0
+	MetricsPort int `json:"metrics_port,omitempty"`
+	// Spool stores the log entries on disk before they are dispatched to the log sinks, the entries are only buffered
+	// in memory if not set
+	Spool *SpoolConfig `json:"spool,omitempty"`
+}
+
+// SpoolConfig is the configuration of the on-disk spool of the inference logger
+type SpoolConfig struct {
+	// Dir is the directory of the spool segments
+	Dir string `json:"dir"`
+	// MaxBytes is the maximum total size of the spool segments, 0 means unlimited
+	MaxBytes int64 `json:"max_bytes,omitempty"`
+}
+
+// ConfigSecretName returns the name of the secret storing the logger config of the inference service
+func ConfigSecretName(inferenceServiceName string) string {
+	return fmt.Sprintf("%s-logger", inferenceServiceName)
+}
+
+// IsEmpty returns true if nothing is configured
+func (c *Config) IsEmpty() bool {
+	return c == nil || (c.Rules.IsEmpty() && c.Spool == nil && c.HTTPSinkAuthToken == "" && c.MetricsPort == 0)
+}
+
+// Validate returns error if the config is invalid
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if err := c.Rules.Validate(); err != nil {
+		return err
+	}
+	if c.MetricsPort < 0 || c.MetricsPort > 65535 {
+		return fmt.Errorf("invalid metrics port %d", c.MetricsPort)
+	}
+	if c.Spool != nil {
+		if c.Spool.Dir == "" {
+			return fmt.Errorf("spool dir must be set")
+		}
+		if c.Spool.MaxBytes < 0 {
+			return fmt.Errorf("spool max bytes must not be negative, got %d", c.Spool.MaxBytes)
+		}
+	}
+	return nil
+}
+
+// ParseConfig decodes and validates the logger config stored in the logger config secret
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode logger config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid logger config: %w", err)
+	}
+	return &cfg, nil
+}
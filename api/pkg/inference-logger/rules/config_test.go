@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "empty config",
+			data: `{}`,
+			want: &Config{},
+		},
+		{
+			name: "spool config",
+			data: `{"spool":{"dir":"/tmp/spool","max_bytes":1024}}`,
+			want: &Config{Spool: &SpoolConfig{Dir: "/tmp/spool", MaxBytes: 1024}},
+		},
+		{
+			name:    "spool without dir",
+			data:    `{"spool":{"max_bytes":1024}}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative spool max bytes",
+			data:    `{"spool":{"dir":"/tmp/spool","max_bytes":-1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed config",
+			data:    `{"spool":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConfig([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
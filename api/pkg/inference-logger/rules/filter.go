@@ -0,0 +1,224 @@
+package rules
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+const samplingBuckets = 10000
+
+type compiledRedaction struct {
+	target    *jsonPathTarget
+	upiColumn string
+	action    Action
+	hashKey   []byte
+}
+
+// Filter applies the rules to the log entries, nil Filter logs everything as is
+type Filter struct {
+	samplingRate    *float64
+	alwaysLogErrors bool
+	headerAllowlist map[string]bool
+	headerDenylist  map[string]bool
+	redactions      []compiledRedaction
+}
+
+// NewFilter compiles the rules, it returns nil if no rule is configured. The hash key is the key of the HMAC of the
+// values redacted by the hash action, it is required if any redaction rule uses the hash action.
+func NewFilter(r *Rules, hashKey string) (*Filter, error) {
+	if r.IsEmpty() {
+		return nil, nil
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	f := &Filter{
+		samplingRate:    r.SamplingRate,
+		alwaysLogErrors: r.AlwaysLogErrors,
+		headerAllowlist: toLowerCaseSet(r.HeaderAllowlist),
+		headerDenylist:  toLowerCaseSet(r.HeaderDenylist),
+	}
+	for i, rule := range r.RedactionRules {
+		redaction := compiledRedaction{upiColumn: rule.UPIColumn, action: rule.Action}
+		if rule.JsonPath != "" {
+			redaction.target, _ = compileJsonPathTarget(rule.JsonPath)
+		}
+		if rule.Action == ActionHash {
+			if hashKey == "" {
+				return nil, fmt.Errorf("redaction rule %d uses %s action which requires a hash key", i, ActionHash)
+			}
+			redaction.hashKey = []byte(hashKey)
+		}
+		f.redactions = append(f.redactions, redaction)
+	}
+	return f, nil
+}
+
+// HasRedactionRules returns whether any redaction rule is configured
+func (f *Filter) HasRedactionRules() bool {
+	return f != nil && len(f.redactions) > 0
+}
+
+// IsSampled returns whether the request is sampled for logging
+//
+// Sampling is decided from the request id, so that the model and transformer loggers sample the same requests.
+func (f *Filter) IsSampled(requestId string) bool {
+	if f == nil || f.samplingRate == nil {
+		return true
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(requestId))
+	return float64(h.Sum32()%samplingBuckets) < *f.samplingRate*samplingBuckets
+}
+
+// ShouldLog returns whether the request is logged given its sampling decision and whether it failed
+func (f *Filter) ShouldLog(sampled bool, failed bool) bool {
+	if sampled {
+		return true
+	}
+	return f != nil && f.alwaysLogErrors && failed
+}
+
+// FilterHeaders removes headers that are not in the allowlist or are in the denylist
+func (f *Filter) FilterHeaders(headers map[string]string) map[string]string {
+	if f == nil || (len(f.headerAllowlist) == 0 && len(f.headerDenylist) == 0) {
+		return headers
+	}
+
+	filtered := make(map[string]string, len(headers))
+	for k, v := range headers {
+		name := strings.ToLower(k)
+		if len(f.headerAllowlist) > 0 && !f.headerAllowlist[name] {
+			continue
+		}
+		if f.headerDenylist[name] {
+			continue
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
+
+// RedactBody applies the redaction rules to JSON body, non JSON body is returned as is
+func (f *Filter) RedactBody(body []byte) []byte {
+	if f == nil || len(f.redactions) == 0 || len(body) == 0 {
+		return body
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+
+	for _, redaction := range f.redactions {
+		replace := redaction.replacer()
+		if redaction.target != nil {
+			redaction.target.replace(doc, replace)
+		} else {
+			redactUPIColumn(doc, redaction.upiColumn, replace)
+		}
+	}
+
+	redacted, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func (r compiledRedaction) replacer() func(interface{}) interface{} {
+	return func(value interface{}) interface{} {
+		if r.action == ActionHash {
+			return hashValue(r.hashKey, value)
+		}
+		return RedactedValue
+	}
+}
+
+// redactUPIColumn replaces the values of the column in every UPI table found in the JSON encoded UPI message
+//
+// UPI tables are encoded as {"columns": [{"name": ...}], "rows": [{"values": [...]}]} where every value is
+// an object holding a single typed value, the replaced value is stored as string value.
+func redactUPIColumn(doc interface{}, column string, replace func(interface{}) interface{}) {
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		if columnIdx := upiColumnIndex(node, column); columnIdx >= 0 {
+			rows, _ := node["rows"].([]interface{})
+			for _, row := range rows {
+				rowObj, ok := row.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				values, ok := rowObj["values"].([]interface{})
+				if !ok || columnIdx >= len(values) {
+					continue
+				}
+				values[columnIdx] = map[string]interface{}{"stringValue": replace(upiValue(values[columnIdx]))}
+			}
+		}
+		for _, v := range node {
+			redactUPIColumn(v, column, replace)
+		}
+	case []interface{}:
+		for _, v := range node {
+			redactUPIColumn(v, column, replace)
+		}
+	}
+}
+
+func upiColumnIndex(table map[string]interface{}, column string) int {
+	columns, ok := table["columns"].([]interface{})
+	if !ok {
+		return -1
+	}
+	if _, ok := table["rows"].([]interface{}); !ok {
+		return -1
+	}
+	for i, c := range columns {
+		if colObj, ok := c.(map[string]interface{}); ok && colObj["name"] == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// upiValue returns the value held by a JSON encoded UPI value
+func upiValue(value interface{}) interface{} {
+	valueObj, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for _, v := range valueObj {
+		return v
+	}
+	return nil
+}
+
+// hashValue returns the HMAC-SHA256 of the value keyed by the hash key, so that low entropy values such as phone
+// numbers can't be recovered from their hash without the key
+func hashValue(hashKey []byte, value interface{}) string {
+	var data []byte
+	if s, ok := value.(string); ok {
+		data = []byte(s)
+	} else {
+		data, _ = json.Marshal(value)
+	}
+	mac := hmac.New(sha256.New, hashKey)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toLowerCaseSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(v)] = true
+	}
+	return set
+}
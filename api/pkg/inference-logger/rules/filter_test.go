@@ -0,0 +1,160 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHashKey = "test-hash-key"
+
+func TestFilter_IsSampled(t *testing.T) {
+	var nilFilter *Filter
+	assert.True(t, nilFilter.IsSampled("any"))
+
+	filter, err := NewFilter(&Rules{SamplingRate: float64Ptr(0)}, "")
+	require.NoError(t, err)
+	assert.False(t, filter.IsSampled("request-1"))
+	assert.False(t, filter.ShouldLog(false, true))
+
+	filter, err = NewFilter(&Rules{SamplingRate: float64Ptr(0.25), AlwaysLogErrors: true}, "")
+	require.NoError(t, err)
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		if filter.IsSampled(fmt.Sprintf("request-%d", i)) {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 2500, sampled, 250)
+	// sampling decision is deterministic
+	assert.Equal(t, filter.IsSampled("request-1"), filter.IsSampled("request-1"))
+	assert.True(t, filter.ShouldLog(false, true))
+	assert.False(t, filter.ShouldLog(false, false))
+}
+
+func TestFilter_FilterHeaders(t *testing.T) {
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer token",
+		"X-Client-Id":   "my-client",
+	}
+
+	tests := []struct {
+		name  string
+		rules *Rules
+		want  map[string]string
+	}{
+		{
+			name:  "no header rules",
+			rules: &Rules{SamplingRate: float64Ptr(1)},
+			want:  headers,
+		},
+		{
+			name:  "allowlist",
+			rules: &Rules{HeaderAllowlist: []string{"content-type", "x-client-id"}},
+			want: map[string]string{
+				"Content-Type": "application/json",
+				"X-Client-Id":  "my-client",
+			},
+		},
+		{
+			name:  "denylist",
+			rules: &Rules{HeaderDenylist: []string{"authorization"}},
+			want: map[string]string{
+				"Content-Type": "application/json",
+				"X-Client-Id":  "my-client",
+			},
+		},
+		{
+			name: "allowlist and denylist",
+			rules: &Rules{
+				HeaderAllowlist: []string{"Content-Type", "Authorization"},
+				HeaderDenylist:  []string{"Authorization"},
+			},
+			want: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewFilter(tt.rules, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.FilterHeaders(headers))
+		})
+	}
+}
+
+func TestFilter_RedactBody(t *testing.T) {
+	tests := []struct {
+		name           string
+		redactionRules []RedactionRule
+		body           string
+		want           string
+	}{
+		{
+			name: "redact and hash json path",
+			redactionRules: []RedactionRule{
+				{JsonPath: "$.customer.email", Action: ActionRedact},
+				{JsonPath: "$.instances[*].phone", Action: ActionHash},
+			},
+			body: `{"customer":{"email":"john@example.com","id":1},"instances":[{"phone":"123"},{"phone":"456"}]}`,
+			want: `{"customer":{"email":"[REDACTED]","id":1},"instances":[{"phone":"100ccc395aa034e5bc7e470346cfebd73b1e6f43453da69c55901b8c31fdf1fe"},{"phone":"538c077e8f16eddb4ec646d0bb72775b35b300c4c1bf136473ca3900fae1ace0"}]}`,
+		},
+		{
+			name: "index and missing path",
+			redactionRules: []RedactionRule{
+				{JsonPath: "$.instances[0][1]", Action: ActionRedact},
+				{JsonPath: "$.unknown.field", Action: ActionRedact},
+			},
+			body: `{"instances":[[1,2],[3,4]]}`,
+			want: `{"instances":[[1,"[REDACTED]"],[3,4]]}`,
+		},
+		{
+			name: "upi column",
+			redactionRules: []RedactionRule{
+				{UPIColumn: "email", Action: ActionRedact},
+			},
+			body: `{"predictionTable":{"name":"t","columns":[{"name":"id","type":"TYPE_INTEGER"},{"name":"email","type":"TYPE_STRING"}],"rows":[{"rowId":"1","values":[{"integerValue":"1"},{"stringValue":"a@b.c"}]}]}}`,
+			want: `{"predictionTable":{"name":"t","columns":[{"name":"id","type":"TYPE_INTEGER"},{"name":"email","type":"TYPE_STRING"}],"rows":[{"rowId":"1","values":[{"integerValue":"1"},{"stringValue":"[REDACTED]"}]}]}}`,
+		},
+		{
+			name: "non json body",
+			redactionRules: []RedactionRule{
+				{JsonPath: "$.customer.email", Action: ActionRedact},
+			},
+			body: `not json`,
+			want: `not json`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewFilter(&Rules{RedactionRules: tt.redactionRules}, testHashKey)
+			require.NoError(t, err)
+
+			body := []byte(tt.body)
+			got := filter.RedactBody(body)
+			if tt.want == tt.body {
+				assert.Equal(t, tt.want, string(got))
+			} else {
+				assert.JSONEq(t, tt.want, string(got))
+			}
+			// original body must not be modified
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+func TestNewFilter_HashWithoutKey(t *testing.T) {
+	_, err := NewFilter(&Rules{RedactionRules: []RedactionRule{{JsonPath: "$.phone", Action: ActionHash}}}, "")
+	assert.EqualError(t, err, "redaction rule 0 uses hash action which requires a hash key")
+
+	// the hash depends on the key, so that hashes of different deployments can't be joined
+	filter, err := NewFilter(&Rules{RedactionRules: []RedactionRule{{JsonPath: "$.phone", Action: ActionHash}}}, "other-hash-key")
+	require.NoError(t, err)
+	otherFilter, err := NewFilter(&Rules{RedactionRules: []RedactionRule{{JsonPath: "$.phone", Action: ActionHash}}}, testHashKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, string(filter.RedactBody([]byte(`{"phone":"123"}`))), string(otherFilter.RedactBody([]byte(`{"phone":"123"}`))))
+}
@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/jsonpath"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+)
+
+// JsonPath is a compiled json path used to select values from decoded JSON documents, it supports the same syntax as
+// the json paths of standard transformer, e.g. $.customer.email, $.instances[*].name or $.instances[0][1]
+type JsonPath struct {
+	compiled *jsonpath.Compiled
+	// multiple is true if the path can match multiple values, i.e. it contains a wildcard, range or filter
+	multiple bool
+}
+
+// CompileJsonPath compiles the path
+func CompileJsonPath(path string) (*JsonPath, error) {
+	compiled, err := jsonpath.CompileWithOption(jsonpath.JsonPathOption{JsonPath: path, SrcType: jsonpath.Map})
+	if err != nil {
+		return nil, fmt.Errorf("invalid json path %s: %w", path, err)
+	}
+	return &JsonPath{
+		compiled: compiled,
+		multiple: strings.ContainsAny(path, "*:?"),
+	}, nil
+}
+
+// Select returns every value matched by the path in the decoded JSON object, in document order
+func (p *JsonPath) Select(doc interface{}) []interface{} {
+	object, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	value, err := p.compiled.Lookup(types.JSONObject(object))
+	if err != nil || value == nil {
+		return nil
+	}
+	if p.multiple {
+		values, _ := value.([]interface{})
+		return values
+	}
+	return []interface{}{value}
+}
+
+// SelectList returns the elements of a list selected by the path.
+// If the path contains a wildcard every match is an element, e.g. $.instances[*].row_id,
+// otherwise the path must select a single list, e.g. $.predictions
+func (p *JsonPath) SelectList(doc interface{}) ([]interface{}, error) {
+	matches := p.Select(doc)
+	if p.multiple || len(matches) == 0 {
+		return matches, nil
+	}
+	list, ok := matches[0].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("json path does not select a list")
+	}
+	return list, nil
+}
+
+// jsonPathTarget selects the values replaced by a redaction rule, which are the values of the key, index or every
+// element of the containers selected by the parent path of the rule's json path
+type jsonPathTarget struct {
+	// parent is nil if the values are in the document root
+	parent   *JsonPath
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// compileJsonPathTarget splits the path into its parent path and the selector of its last segment, which is either a
+// key, an index or a wildcard
+func compileJsonPathTarget(path string) (*jsonPathTarget, error) {
+	if _, err := CompileJsonPath(path); err != nil {
+		return nil, err
+	}
+
+	target := &jsonPathTarget{}
+	var parentPath string
+	if strings.HasSuffix(path, "]") {
+		idx := strings.LastIndex(path, "[")
+		parentPath = path[:idx]
+		selector := path[idx+1 : len(path)-1]
+		if selector == "*" {
+			target.wildcard = true
+		} else {
+			index, err := strconv.Atoi(selector)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("json path %s must end with a key, an index or a wildcard", path)
+			}
+			target.index, target.isIndex = index, true
+		}
+	} else {
+		idx := strings.LastIndex(path, ".")
+		if idx < 0 || idx == len(path)-1 {
+			return nil, fmt.Errorf("json path %s must select a field", path)
+		}
+		parentPath, target.key = path[:idx], path[idx+1:]
+	}
+
+	if parentPath != "$" {
+		parent, err := CompileJsonPath(parentPath)
+		if err != nil {
+			return nil, err
+		}
+		target.parent = parent
+	}
+	return target, nil
+}
+
+// replace replaces every value selected by the target in the decoded JSON document with the result of replace
+func (t *jsonPathTarget) replace(doc interface{}, replace func(interface{}) interface{}) {
+	parents := []interface{}{doc}
+	if t.parent != nil {
+		parents = t.parent.Select(doc)
+	}
+
+	for _, parent := range parents {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if t.key == "" {
+				continue
+			}
+			if value, ok := node[t.key]; ok {
+				node[t.key] = replace(value)
+			}
+		case []interface{}:
+			switch {
+			case t.wildcard:
+				for i := range node {
+					node[i] = replace(node[i])
+				}
+			case t.isIndex && t.index < len(node):
+				node[t.index] = replace(node[t.index])
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJsonPath_SelectList(t *testing.T) {
+	doc := `{"instances": [{"id": "a", "features": [1, 2]}, {"id": "b", "features": [3, 4]}], "session_id": "s1"}`
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []interface{}
+		wantErr bool
+	}{
+		{
+			name: "list",
+			path: "$.instances[0].features",
+			want: []interface{}{float64(1), float64(2)},
+		},
+		{
+			name: "wildcard",
+			path: "$.instances[*].id",
+			want: []interface{}{"a", "b"},
+		},
+		{
+			name: "wildcard selecting lists",
+			path: "$.instances[*].features",
+			want: []interface{}{[]interface{}{float64(1), float64(2)}, []interface{}{float64(3), float64(4)}},
+		},
+		{
+			name: "missing field",
+			path: "$.predictions",
+		},
+		{
+			name:    "not a list",
+			path:    "$.session_id",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded interface{}
+			require.NoError(t, json.Unmarshal([]byte(doc), &decoded))
+
+			path, err := CompileJsonPath(tt.path)
+			require.NoError(t, err)
+
+			got, err := path.SelectList(decoded)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJsonPathTarget_Replace(t *testing.T) {
+	doc := `{"customer": {"email": "a@b.c"}, "instances": [{"id": "a", "features": [1, 2]}, {"id": "b", "features": [3, 4]}]}`
+	redact := func(interface{}) interface{} { return RedactedValue }
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "key",
+			path: "$.customer.email",
+			want: `{"customer": {"email": "[REDACTED]"}, "instances": [{"id": "a", "features": [1, 2]}, {"id": "b", "features": [3, 4]}]}`,
+		},
+		{
+			name: "root key",
+			path: "$.customer",
+			want: `{"customer": "[REDACTED]", "instances": [{"id": "a", "features": [1, 2]}, {"id": "b", "features": [3, 4]}]}`,
+		},
+		{
+			name: "key of every element",
+			path: "$.instances[*].id",
+			want: `{"customer": {"email": "a@b.c"}, "instances": [{"id": "[REDACTED]", "features": [1, 2]}, {"id": "[REDACTED]", "features": [3, 4]}]}`,
+		},
+		{
+			name: "index of every element",
+			path: "$.instances[*].features[1]",
+			want: `{"customer": {"email": "a@b.c"}, "instances": [{"id": "a", "features": [1, "[REDACTED]"]}, {"id": "b", "features": [3, "[REDACTED]"]}]}`,
+		},
+		{
+			name: "every element",
+			path: "$.instances[0].features[*]",
+			want: `{"customer": {"email": "a@b.c"}, "instances": [{"id": "a", "features": ["[REDACTED]", "[REDACTED]"]}, {"id": "b", "features": [3, 4]}]}`,
+		},
+		{
+			name:    "root",
+			path:    "$",
+			wantErr: true,
+		},
+		{
+			name:    "invalid index",
+			path:    "$.instances[a]",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := compileJsonPathTarget(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var decoded interface{}
+			require.NoError(t, json.Unmarshal([]byte(doc), &decoded))
+			target.replace(decoded, redact)
+
+			got, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
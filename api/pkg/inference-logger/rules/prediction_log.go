@@ -0,0 +1,57 @@
+package rules
+
+import "fmt"
+
+// PredictionLogMapping locates the prediction log fields in arbitrary HTTP_JSON request and response payloads
+type PredictionLogMapping struct {
+	// InstancesPath is the json path of the instances in the request, e.g. $.instances.
+	// An instance is either a list of feature values ordered by the model schema's feature orders or an object keyed by feature name
+	InstancesPath string `json:"instances_path"`
+	// RowIdsPath is the json path of the row ids in the request, e.g. $.instances[*].row_id.
+	// It can be omitted if the request only contains a single instance
+	RowIdsPath string `json:"row_ids_path,omitempty"`
+	// SessionIdPath is the json path of the session id in the request, e.g. $.session_id
+	SessionIdPath string `json:"session_id_path"`
+	// PredictionScoresPath is the json path of the prediction scores in the response, e.g. $.predictions
+	PredictionScoresPath string `json:"prediction_scores_path"`
+}
+
+// Validate returns error if any of the json paths is missing or invalid
+func (m *PredictionLogMapping) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	paths := []struct {
+		name     string
+		path     string
+		required bool
+	}{
+		{name: "instances_path", path: m.InstancesPath, required: true},
+		{name: "row_ids_path", path: m.RowIdsPath},
+		{name: "session_id_path", path: m.SessionIdPath, required: true},
+		{name: "prediction_scores_path", path: m.PredictionScoresPath, required: true},
+	}
+	for _, p := range paths {
+		if p.path == "" {
+			if p.required {
+				return fmt.Errorf("prediction log mapping must specify %s", p.name)
+			}
+			continue
+		}
+		if _, err := CompileJsonPath(p.path); err != nil {
+			return fmt.Errorf("prediction log mapping %s: %w", p.name, err)
+		}
+	}
+	return nil
+}
+
+// PredictionLogSchema combines the mapping with the columns of the version's model schema,
+// it is used by the model observability sink to build prediction logs of HTTP_JSON models
+type PredictionLogSchema struct {
+	PredictionLogMapping
+	// FeatureOrders are the feature names in the order of the features table columns
+	FeatureOrders []string `json:"feature_orders,omitempty"`
+	// PredictionScoreColumn is the column name of the prediction scores in the prediction results table
+	PredictionScoreColumn string `json:"prediction_score_column,omitempty"`
+}
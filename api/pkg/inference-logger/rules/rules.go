@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Action is the action applied to a field matched by a redaction rule
+type Action string
+
+const (
+	// ActionRedact replaces the field value with RedactedValue
+	ActionRedact Action = "redact"
+	// ActionHash replaces the field value with its HMAC-SHA256 hash keyed by the deployment's hash key, so that the value
+	// can still be joined or counted within the deployment's logs
+	ActionHash Action = "hash"
+)
+
+// RedactedValue is the value of redacted fields
+const RedactedValue = "[REDACTED]"
+
+// logUrlRulesKey is the key of the encoded rules in the fragment of the log url of deployments created before the
+// rules were moved to the logger config secret, see Config
+const logUrlRulesKey = "merlin-logger-rules"
+
+// RedactionRule redacts or hashes a field of the logged request and response payloads
+type RedactionRule struct {
+	// JsonPath of the field in HTTP_JSON payload, e.g. $.customer.email or $.instances[*].phone_number
+	JsonPath string `json:"json_path,omitempty"`
+	// UPIColumn is the column name of UPI tables whose values are redacted
+	UPIColumn string `json:"upi_column,omitempty"`
+	// Action applied to the matched field, either redact or hash
+	Action Action `json:"action"`
+}
+
+// Rules are sampling, header filtering and redaction rules applied by the inference logger before dispatching log entries
+type Rules struct {
+	// SamplingRate is the fraction of requests to be logged, between 0 and 1. All requests are logged if not set
+	SamplingRate *float64 `json:"sampling_rate,omitempty"`
+	// AlwaysLogErrors logs failed requests even if they are not sampled
+	AlwaysLogErrors bool `json:"always_log_errors,omitempty"`
+	// HeaderAllowlist is the list of headers to be logged, all headers are logged if empty
+	HeaderAllowlist []string `json:"header_allowlist,omitempty"`
+	// HeaderDenylist is the list of headers that are never logged
+	HeaderDenylist []string `json:"header_denylist,omitempty"`
+	// RedactionRules are applied to request and response body
+	RedactionRules []RedactionRule `json:"redaction_rules,omitempty"`
+	// Sinks are additional destinations the inference logs are fanned out to
+	Sinks []Sink `json:"sinks,omitempty"`
+	// PredictionLogSchema is used by the model observability sink to build prediction logs from arbitrary JSON payloads
+	PredictionLogSchema *PredictionLogSchema `json:"prediction_log_schema,omitempty"`
+}
+
+// IsEmpty returns true if no rule is configured
+func (r *Rules) IsEmpty() bool {
+	return r == nil || (r.SamplingRate == nil && !r.AlwaysLogErrors && len(r.HeaderAllowlist) == 0 &&
+		len(r.HeaderDenylist) == 0 && len(r.RedactionRules) == 0 && len(r.Sinks) == 0 && r.PredictionLogSchema == nil)
+}
+
+// UsesHashAction returns true if any redaction rule, including the ones of the sinks, uses the hash action
+func (r *Rules) UsesHashAction() bool {
+	if r == nil {
+		return false
+	}
+	for _, rule := range r.RedactionRules {
+		if rule.Action == ActionHash {
+			return true
+		}
+	}
+	for _, sink := range r.Sinks {
+		if sink.Rules.UsesHashAction() {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns error if any of the rules is invalid
+func (r *Rules) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	if r.SamplingRate != nil && (*r.SamplingRate < 0 || *r.SamplingRate > 1) {
+		return fmt.Errorf("sampling rate must be between 0 and 1, got %v", *r.SamplingRate)
+	}
+
+	for i, rule := range r.RedactionRules {
+		if (rule.JsonPath == "") == (rule.UPIColumn == "") {
+			return fmt.Errorf("redaction rule %d must specify either json_path or upi_column", i)
+		}
+		if rule.JsonPath != "" {
+			if _, err := compileJsonPathTarget(rule.JsonPath); err != nil {
+				return fmt.Errorf("redaction rule %d: %w", i, err)
+			}
+		}
+		switch rule.Action {
+		case ActionRedact, ActionHash:
+		default:
+			return fmt.Errorf("redaction rule %d has unsupported action %q, must be either %s or %s", i, rule.Action, ActionRedact, ActionHash)
+		}
+	}
+	if r.PredictionLogSchema != nil {
+		if err := r.PredictionLogSchema.Validate(); err != nil {
+			return err
+		}
+	}
+	return validateSinks(r.Sinks)
+}
+
+// ParseLogUrl returns the log url without the encoded rules and the decoded rules, rules is nil if the url doesn't contain any.
+// It is kept for the deployments whose rules are still encoded in the log url.
+func ParseLogUrl(logUrl string) (string, *Rules, error) {
+	idx := strings.LastIndex(logUrl, "#"+logUrlRulesKey+"=")
+	if idx < 0 {
+		return logUrl, nil, nil
+	}
+
+	encoded := logUrl[idx+len(logUrlRulesKey)+2:]
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode logger rules: %w", err)
+	}
+
+	r := &Rules{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return "", nil, fmt.Errorf("failed to decode logger rules: %w", err)
+	}
+	if err := r.Validate(); err != nil {
+		return "", nil, err
+	}
+	return logUrl[:idx], r, nil
+}
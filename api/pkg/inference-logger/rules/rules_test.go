@@ -0,0 +1,173 @@
+package rules
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestRules_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   *Rules
+		wantErr string
+	}{
+		{
+			name:  "nil rules",
+			rules: nil,
+		},
+		{
+			name: "valid rules",
+			rules: &Rules{
+				SamplingRate:    float64Ptr(0.5),
+				AlwaysLogErrors: true,
+				HeaderDenylist:  []string{"Authorization"},
+				RedactionRules: []RedactionRule{
+					{JsonPath: "$.instances[*].email", Action: ActionHash},
+					{UPIColumn: "phone_number", Action: ActionRedact},
+				},
+			},
+		},
+		{
+			name:    "invalid sampling rate",
+			rules:   &Rules{SamplingRate: float64Ptr(1.5)},
+			wantErr: "sampling rate must be between 0 and 1, got 1.5",
+		},
+		{
+			name: "both json path and upi column",
+			rules: &Rules{RedactionRules: []RedactionRule{
+				{JsonPath: "$.email", UPIColumn: "email", Action: ActionRedact},
+			}},
+			wantErr: "redaction rule 0 must specify either json_path or upi_column",
+		},
+		{
+			name: "invalid json path",
+			rules: &Rules{RedactionRules: []RedactionRule{
+				{JsonPath: "email", Action: ActionRedact},
+			}},
+			wantErr: "redaction rule 0: invalid json path email: should start with '$'",
+		},
+		{
+			name: "valid sinks",
+			rules: &Rules{Sinks: []Sink{
+				{Name: "audit", Url: "file:/var/log/audit", Mode: SinkModeRequest},
+				{Name: "otel", Url: "otlp:http://otel-collector:4318/v1/logs", Rules: Rules{SamplingRate: float64Ptr(0.1)}},
+			}},
+		},
+		{
+			name: "duplicate sink name",
+			rules: &Rules{Sinks: []Sink{
+				{Name: "audit", Url: "file:/var/log/audit"},
+				{Name: "audit", Url: "console:"},
+			}},
+			wantErr: "duplicate sink name audit",
+		},
+		{
+			name:    "sink without url",
+			rules:   &Rules{Sinks: []Sink{{Name: "audit"}}},
+			wantErr: "sink audit must specify url",
+		},
+		{
+			name:    "unsupported sink mode",
+			rules:   &Rules{Sinks: []Sink{{Name: "audit", Url: "console:", Mode: "headers"}}},
+			wantErr: `sink audit has unsupported mode "headers", must be either all, request or response`,
+		},
+		{
+			name:    "mlobs sink logging only the request",
+			rules:   &Rules{Sinks: []Sink{{Name: "mlobs", Url: "mlobs:broker:9092", Mode: SinkModeRequest}}},
+			wantErr: "sink mlobs of kind mlobs only supports mode all",
+		},
+		{
+			name:    "invalid sink rules",
+			rules:   &Rules{Sinks: []Sink{{Name: "audit", Url: "console:", Rules: Rules{SamplingRate: float64Ptr(-1)}}}},
+			wantErr: "sink audit: sampling rate must be between 0 and 1, got -1",
+		},
+		{
+			name: "nested sinks",
+			rules: &Rules{Sinks: []Sink{{Name: "audit", Url: "console:", Rules: Rules{
+				Sinks: []Sink{{Name: "nested", Url: "console:"}},
+			}}}},
+			wantErr: "sink audit must not contain nested sinks",
+		},
+		{
+			name: "valid prediction log schema",
+			rules: &Rules{PredictionLogSchema: &PredictionLogSchema{
+				PredictionLogMapping: PredictionLogMapping{
+					InstancesPath:        "$.inputs",
+					RowIdsPath:           "$.inputs[*].id",
+					SessionIdPath:        "$.session.id",
+					PredictionScoresPath: "$.outputs[*].score",
+				},
+				FeatureOrders: []string{"age", "income"},
+			}},
+		},
+		{
+			name: "prediction log schema without session id path",
+			rules: &Rules{PredictionLogSchema: &PredictionLogSchema{
+				PredictionLogMapping: PredictionLogMapping{InstancesPath: "$.inputs", PredictionScoresPath: "$.outputs"},
+			}},
+			wantErr: "prediction log mapping must specify session_id_path",
+		},
+		{
+			name: "prediction log schema with invalid path",
+			rules: &Rules{PredictionLogSchema: &PredictionLogSchema{
+				PredictionLogMapping: PredictionLogMapping{InstancesPath: "inputs", SessionIdPath: "$.id", PredictionScoresPath: "$.outputs"},
+			}},
+			wantErr: "prediction log mapping instances_path: invalid json path inputs: should start with '$'",
+		},
+		{
+			name: "unsupported action",
+			rules: &Rules{RedactionRules: []RedactionRule{
+				{UPIColumn: "email", Action: "drop"},
+			}},
+			wantErr: `redaction rule 0 has unsupported action "drop", must be either redact or hash`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseLogUrl(t *testing.T) {
+	r := &Rules{
+		SamplingRate:    float64Ptr(0.1),
+		HeaderAllowlist: []string{"Content-Type"},
+		RedactionRules: []RedactionRule{
+			{JsonPath: "$.customer.email", Action: ActionRedact},
+		},
+		Sinks: []Sink{
+			{Name: "audit", Url: "file:s3://audit/merlin?format=parquet", Mode: SinkModeRequest, Rules: Rules{HeaderDenylist: []string{"Authorization"}}},
+		},
+	}
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	logUrl := "kafka:broker-1:9092,broker-2:9092#merlin-logger-rules=" + base64.RawURLEncoding.EncodeToString(data)
+
+	sinkUrl, parsed, err := ParseLogUrl(logUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "kafka:broker-1:9092,broker-2:9092", sinkUrl)
+	assert.Equal(t, r, parsed)
+
+	// url without rules is returned as is
+	sinkUrl, parsed, err = ParseLogUrl("http://logger.default")
+	require.NoError(t, err)
+	assert.Equal(t, "http://logger.default", sinkUrl)
+	assert.Nil(t, parsed)
+
+	_, _, err = ParseLogUrl("http://logger.default#merlin-logger-rules=not-base64!")
+	assert.Error(t, err)
+}
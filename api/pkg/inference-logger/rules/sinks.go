@@ -0,0 +1,190 @@
+package rules
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Log modes of the additional sinks, they match the log modes of the inference logger
+const (
+	SinkModeAll      = "all"
+	SinkModeRequest  = "request"
+	SinkModeResponse = "response"
+)
+
+// Kinds of the log sinks, they match the prefixes of the log urls of the inference logger
+const (
+	SinkKindKafka    = "kafka"
+	SinkKindNewRelic = "newrelic"
+	SinkKindConsole  = "console"
+	SinkKindMLObs    = "mlobs"
+	SinkKindFile     = "file"
+	SinkKindWebhook  = "webhook"
+	SinkKindOTLP     = "otlp"
+)
+
+var sinkNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Sink is an additional destination of the inference logs with its own log mode, sampling and filtering rules
+//
+// Each sink is served by independent workers and queue, so that a slow sink doesn't block the others.
+type Sink struct {
+	// Name identifies the sink in the logs, metrics and spool directory
+	Name string `json:"name"`
+	// Url is the log url of the sink, e.g. kafka:broker:9092 or otlp:http://otel-collector:4318/v1/logs
+	Url string `json:"url"`
+	// Mode is the log mode of the sink, either all, request or response. Defaults to all
+	Mode string `json:"mode,omitempty"`
+	// Rules are the sampling, header filtering and redaction rules of the sink
+	Rules
+}
+
+// SinksConfig is the multi-sink configuration file of the inference logger
+type SinksConfig struct {
+	Sinks []Sink `json:"sinks"`
+}
+
+// LogMode returns the log mode of the sink
+func (s *Sink) LogMode() string {
+	if s.Mode == "" {
+		return SinkModeAll
+	}
+	return s.Mode
+}
+
+func validateSinks(sinks []Sink) error {
+	names := map[string]bool{}
+	for i, sink := range sinks {
+		if !sinkNameRegex.MatchString(sink.Name) {
+			return fmt.Errorf("sink %d has invalid name %q, must consist of lower case alphanumeric characters or '-'", i, sink.Name)
+		}
+		if names[sink.Name] {
+			return fmt.Errorf("duplicate sink name %s", sink.Name)
+		}
+		names[sink.Name] = true
+
+		if sink.Url == "" {
+			return fmt.Errorf("sink %s must specify url", sink.Name)
+		}
+		switch sink.LogMode() {
+		case SinkModeAll, SinkModeRequest, SinkModeResponse:
+		default:
+			return fmt.Errorf("sink %s has unsupported mode %q, must be either %s, %s or %s", sink.Name, sink.Mode, SinkModeAll, SinkModeRequest, SinkModeResponse)
+		}
+		// prediction logs are built from both the request and the response
+		if strings.HasPrefix(sink.Url, SinkKindMLObs+":") && sink.LogMode() != SinkModeAll {
+			return fmt.Errorf("sink %s of kind %s only supports mode %s", sink.Name, SinkKindMLObs, SinkModeAll)
+		}
+		if len(sink.Sinks) > 0 {
+			return fmt.Errorf("sink %s must not contain nested sinks", sink.Name)
+		}
+		if err := sink.Rules.Validate(); err != nil {
+			return fmt.Errorf("sink %s: %w", sink.Name, err)
+		}
+	}
+	return nil
+}
+
+// SinkAllowlist restricts the kinds and hosts of the additional sinks configured by the users, so that the inference
+// logs can only be sent to the destinations approved by the operator
+type SinkAllowlist struct {
+	// Kinds are the allowed sink kinds, e.g. kafka or webhook. No additional sink is allowed if empty
+	Kinds []string
+	// Hosts are the allowed hosts of the sinks, either a host name or a wildcard matching its sub domains,
+	// e.g. collector.example.com or *.example.com
+	Hosts []string
+}
+
+// Validate returns error if any of the sinks has a kind or host which isn't allowed
+func (a *SinkAllowlist) Validate(sinks []Sink) error {
+	for _, sink := range sinks {
+		kind, hosts, err := parseSinkUrl(sink.Url)
+		if err != nil {
+			return fmt.Errorf("sink %s: %w", sink.Name, err)
+		}
+		if !a.allowsKind(kind) {
+			return fmt.Errorf("sink %s has kind %s which is not allowed, allowed kinds: %v", sink.Name, kind, a.Kinds)
+		}
+		for _, host := range hosts {
+			if !a.allowsHost(host) {
+				return fmt.Errorf("sink %s has host %s which is not allowed, allowed hosts: %v", sink.Name, host, a.Hosts)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *SinkAllowlist) allowsKind(kind string) bool {
+	for _, allowed := range a.Kinds {
+		if allowed == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *SinkAllowlist) allowsHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range a.Hosts {
+		allowed = strings.ToLower(allowed)
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+			continue
+		}
+		if allowed == host {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSinkUrl returns the kind of the sink and the hosts the sink sends the logs to, e.g. the kafka brokers or the
+// host of the webhook url
+func parseSinkUrl(sinkUrl string) (string, []string, error) {
+	kind, target, _ := strings.Cut(sinkUrl, ":")
+	switch kind {
+	case SinkKindConsole:
+		return kind, nil, nil
+	case SinkKindKafka, SinkKindMLObs:
+		hosts := []string{}
+		for _, broker := range strings.Split(target, ",") {
+			host, _, err := net.SplitHostPort(strings.TrimSpace(broker))
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid broker %s: %w", broker, err)
+			}
+			hosts = append(hosts, host)
+		}
+		return kind, hosts, nil
+	case SinkKindNewRelic, SinkKindWebhook, SinkKindOTLP:
+		location, err := url.Parse(target)
+		if err != nil || location.Hostname() == "" {
+			return "", nil, fmt.Errorf("invalid %s url %s", kind, target)
+		}
+		return kind, []string{location.Hostname()}, nil
+	case SinkKindFile:
+		location, err := url.Parse(target)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid file url %s", target)
+		}
+		if location.Scheme != "s3" {
+			return kind, nil, nil
+		}
+		// S3 compatible storage is reached through the endpoint, otherwise the bucket is hosted by AWS
+		hosts := []string{location.Host}
+		if endpoint := location.Query().Get("endpoint"); endpoint != "" {
+			endpointUrl, err := url.Parse(endpoint)
+			if err != nil || endpointUrl.Hostname() == "" {
+				return "", nil, fmt.Errorf("invalid s3 endpoint %s", endpoint)
+			}
+			hosts = []string{endpointUrl.Hostname()}
+		}
+		return kind, hosts, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported sink url %s", sinkUrl)
+	}
+}
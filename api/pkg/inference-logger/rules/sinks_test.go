@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinkAllowlist_Validate(t *testing.T) {
+	allowlist := &SinkAllowlist{
+		Kinds: []string{SinkKindKafka, SinkKindWebhook, SinkKindFile},
+		Hosts: []string{"broker-1", "*.example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{
+			name: "allowed kafka brokers",
+			url:  "kafka:broker-1:9092,kafka.example.com:9092",
+		},
+		{
+			name: "allowed webhook host",
+			url:  "webhook:https://collector.example.com/ingest",
+		},
+		{
+			name: "local file",
+			url:  "file:/var/log/audit",
+		},
+		{
+			name: "allowed s3 endpoint",
+			url:  "file:s3://my-bucket/inference-log?endpoint=http://minio.example.com:9000",
+		},
+		{
+			name:    "kind not allowed",
+			url:     "otlp:http://otel.example.com:4318/v1/logs",
+			wantErr: "sink audit has kind otlp which is not allowed, allowed kinds: [kafka webhook file]",
+		},
+		{
+			name:    "kafka broker not allowed",
+			url:     "kafka:broker-1:9092,broker-2:9092",
+			wantErr: "sink audit has host broker-2 which is not allowed, allowed hosts: [broker-1 *.example.com]",
+		},
+		{
+			name:    "webhook host not allowed",
+			url:     "webhook:http://169.254.169.254/latest",
+			wantErr: "sink audit has host 169.254.169.254 which is not allowed, allowed hosts: [broker-1 *.example.com]",
+		},
+		{
+			name:    "wildcard doesn't match the parent domain",
+			url:     "webhook:https://example.com/ingest",
+			wantErr: "sink audit has host example.com which is not allowed, allowed hosts: [broker-1 *.example.com]",
+		},
+		{
+			name:    "s3 bucket not allowed",
+			url:     "file:s3://my-bucket/inference-log",
+			wantErr: "sink audit has host my-bucket which is not allowed, allowed hosts: [broker-1 *.example.com]",
+		},
+		{
+			name:    "unsupported sink",
+			url:     "http://collector.example.com",
+			wantErr: "sink audit: unsupported sink url http://collector.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := allowlist.Validate([]Sink{{Name: "audit", Url: tt.url}})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	// no additional sink is allowed by an empty allowlist
+	assert.Error(t, (&SinkAllowlist{}).Validate([]Sink{{Name: "audit", Url: "kafka:broker-1:9092"}}))
+	assert.NoError(t, (&SinkAllowlist{}).Validate(nil))
+}
@@ -3,6 +3,7 @@ package executor
 import (
 	prt "github.com/caraml-dev/merlin/pkg/protocol"
 	"github.com/caraml-dev/merlin/pkg/transformer/feast"
+	"github.com/caraml-dev/merlin/pkg/transformer/pipeline"
 	"go.uber.org/zap"
 )
 
@@ -42,3 +43,10 @@ func WithProtocol(protocol prt.Protocol) TransformerOptions {
 		cfg.protocol = protocol
 	}
 }
+
+// WithPredictionCache function to enable cache of the whole prediction response keyed by the given request fields
+func WithPredictionCache(opts pipeline.PredictionCacheOptions) TransformerOptions {
+	return func(cfg *transformerExecutorConfig) {
+		cfg.predictionCacheOpts = &opts
+	}
+}
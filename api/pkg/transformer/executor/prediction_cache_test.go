@@ -0,0 +1,112 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+
+	"github.com/caraml-dev/merlin/pkg/protocol"
+	"github.com/caraml-dev/merlin/pkg/transformer/pipeline"
+	"github.com/caraml-dev/merlin/pkg/transformer/spec"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+)
+
+type countingModelPredictor struct {
+	ModelPredictor
+	count int
+}
+
+func (c *countingModelPredictor) ModelPrediction(ctx context.Context, requestBody types.Payload, requestHeader map[string]string) (types.Payload, map[string]string, error) {
+	c.count++
+	return c.ModelPredictor.ModelPrediction(ctx, requestBody, requestHeader)
+}
+
+func TestStandardTransformer_Execute_PredictionCache(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	yamlBytes, err := os.ReadFile("../pipeline/testdata/valid_debug_trace.yaml")
+	require.NoError(t, err)
+	jsonBytes, err := yaml.YAMLToJSON(yamlBytes)
+	require.NoError(t, err)
+	var transformerConfig spec.StandardTransformerConfig
+	require.NoError(t, protojson.Unmarshal(jsonBytes, &transformerConfig))
+
+	type request struct {
+		body    types.JSONObject
+		headers map[string]string
+	}
+	tests := []struct {
+		name                string
+		requests            []request
+		wantPredictionCount int
+	}{
+		{
+			name: "identical requests are served from cache",
+			requests: []request{
+				{body: types.JSONObject{"customer": map[string]interface{}{"id": 1}, "entities": []interface{}{map[string]interface{}{"id": 1}}}},
+				{body: types.JSONObject{"entities": []interface{}{map[string]interface{}{"id": 1}}, "customer": map[string]interface{}{"id": 1}}},
+			},
+			wantPredictionCount: 1,
+		},
+		{
+			name: "requests with different key",
+			requests: []request{
+				{body: types.JSONObject{"customer": map[string]interface{}{"id": 1}, "entities": []interface{}{map[string]interface{}{"id": 1}}}},
+				{body: types.JSONObject{"customer": map[string]interface{}{"id": 2}, "entities": []interface{}{map[string]interface{}{"id": 1}}}},
+			},
+			wantPredictionCount: 2,
+		},
+		{
+			name: "requests without the key are not cached",
+			requests: []request{
+				{body: types.JSONObject{"customer": map[string]interface{}{"name": "foo"}, "entities": []interface{}{map[string]interface{}{"id": 1}}}},
+				{body: types.JSONObject{"customer": map[string]interface{}{"name": "bar"}, "entities": []interface{}{map[string]interface{}{"id": 2}}}},
+			},
+			wantPredictionCount: 2,
+		},
+		{
+			name: "bypass header",
+			requests: []request{
+				{body: types.JSONObject{"customer": map[string]interface{}{"id": 1}, "entities": []interface{}{map[string]interface{}{"id": 1}}}},
+				{
+					body:    types.JSONObject{"customer": map[string]interface{}{"id": 1}, "entities": []interface{}{map[string]interface{}{"id": 1}}},
+					headers: map[string]string{"x-merlin-cache-bypass": "true"},
+				},
+			},
+			wantPredictionCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predictor := &countingModelPredictor{ModelPredictor: newEchoMockPredictor()}
+			transformer, err := NewStandardTransformerWithConfig(context.Background(), &transformerConfig,
+				WithLogger(logger),
+				WithProtocol(protocol.HttpJson),
+				WithModelPredictor(predictor),
+				WithPredictionCache(pipeline.PredictionCacheOptions{
+					KeyJSONPaths: []string{"$.customer.id"},
+					TTL:          time.Minute,
+					SizeInMB:     1,
+				}),
+			)
+			require.NoError(t, err)
+
+			var responses []types.Payload
+			for _, req := range tt.requests {
+				resp := transformer.Execute(context.Background(), req.body, req.headers)
+				responses = append(responses, resp.Response)
+			}
+			assert.Equal(t, tt.wantPredictionCount, predictor.count)
+			if tt.wantPredictionCount == 1 {
+				assert.Equal(t, responses[0], responses[1])
+			}
+		})
+	}
+}
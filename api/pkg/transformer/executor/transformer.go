@@ -20,6 +20,7 @@ type standardTransformer struct {
 	compiledPipeline *pipeline.CompiledPipeline
 	modelPredictor   ModelPredictor
 	executorConfig   transformerExecutorConfig
+	predictionCache  *pipeline.PredictionCache
 	logger           *zap.Logger
 }
 
@@ -36,6 +37,7 @@ type transformerExecutorConfig struct {
 	logger               *zap.Logger
 	modelPredictor       ModelPredictor
 	protocol             prt.Protocol
+	predictionCacheOpts  *pipeline.PredictionCacheOptions
 }
 
 // NewStandardTransformerWithConfig initialize standard transformer executor object
@@ -70,10 +72,19 @@ func NewStandardTransformerWithConfig(ctx context.Context, transformerConfig *sp
 		return nil, err
 	}
 
+	var predCache *pipeline.PredictionCache
+	if executorConfig.predictionCacheOpts != nil {
+		predCache, err = pipeline.NewPredictionCache(*executorConfig.predictionCacheOpts, executorConfig.protocol)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &standardTransformer{
 		compiledPipeline: compiledPipeline,
 		modelPredictor:   executorConfig.modelPredictor,
 		executorConfig:   *executorConfig,
+		predictionCache:  predCache,
 		logger:           executorConfig.logger,
 	}, nil
 }
@@ -86,6 +97,19 @@ func (st *standardTransformer) Execute(ctx context.Context, requestBody types.JS
 		return generateErrorResponse(fmt.Errorf("request is not valid, user should specifies request with UPI PredictValuesRequest type"))
 	}
 
+	// cached response doesn't have operation tracing, thus the cache is not used when tracing is enabled
+	var cacheKey []byte
+	if st.predictionCache != nil && !st.executorConfig.traceEnabled {
+		cacheKey, err = st.predictionCache.Key(requestPayload)
+		if err != nil {
+			st.logger.Warn("unable to compute prediction cache key", zap.Error(err))
+		} else if cachedResponse, found := st.predictionCache.Fetch(cacheKey, requestHeaders); found {
+			return &types.PredictResponse{
+				Response: cachedResponse,
+			}
+		}
+	}
+
 	preprocessOut := requestPayload
 	st.logger.Debug("raw request_body", zap.Any("request_body", requestBody))
 
@@ -139,6 +163,12 @@ func (st *standardTransformer) Execute(ctx context.Context, requestBody types.JS
 		st.logger.Debug("executor tracing", zap.Any("tracing_details", resp.Tracing))
 	}
 
+	if cacheKey != nil {
+		if err := st.predictionCache.Insert(cacheKey, predictionOut); err != nil {
+			st.logger.Warn("unable to cache prediction", zap.Error(err))
+		}
+	}
+
 	return resp
 }
 
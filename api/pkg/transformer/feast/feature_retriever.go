@@ -13,6 +13,7 @@ import (
 	"github.com/caraml-dev/merlin/pkg/transformer/spec"
 	"github.com/caraml-dev/merlin/pkg/transformer/symbol"
 	transTypes "github.com/caraml-dev/merlin/pkg/transformer/types"
+	"github.com/caraml-dev/merlin/pkg/transformer/types/converter"
 	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -36,6 +37,7 @@ type (
 type FeatureRetriever interface {
 	RetrieveFeatureOfEntityInRequest(ctx context.Context, requestJson transTypes.JSONObject) ([]*transTypes.FeatureTable, error)
 	RetrieveFeatureOfEntityInSymbolRegistry(ctx context.Context, symbolRegistry symbol.Registry) ([]*transTypes.FeatureTable, error)
+	DefaultFeatureOfEntityInSymbolRegistry(ctx context.Context, symbolRegistry symbol.Registry) ([]*transTypes.FeatureTable, error)
 }
 
 // FeastRetriever is feature retriever implementation for retrieving features from Feast
@@ -159,6 +161,29 @@ func (fr *FeastRetriever) RetrieveFeatureOfEntityInSymbolRegistry(ctx context.Co
 	return feastFeatures, nil
 }
 
+// DefaultFeatureOfEntityInSymbolRegistry builds feature tables of the entities in symbol registry
+// where every feature is populated with its default value, without calling feast
+func (fr *FeastRetriever) DefaultFeatureOfEntityInSymbolRegistry(ctx context.Context, symbolRegistry symbol.Registry) ([]*transTypes.FeatureTable, error) {
+	_, span := tracer.Start(ctx, "feast.DefaultFromSymbolRegistry")
+	defer span.End()
+
+	feastFeatures := make([]*transTypes.FeatureTable, len(fr.featureTableSpecs))
+	for i, featureTableSpec := range fr.featureTableSpecs {
+		entities, err := fr.buildEntityRows(symbolRegistry, featureTableSpec.Entities)
+		if err != nil {
+			return nil, err
+		}
+
+		featureTable, err := fr.getDefaultFeatureTable(entities, featureTableSpec)
+		if err != nil {
+			return nil, err
+		}
+		feastFeatures[i] = featureTable.toFeatureTable(GetTableName(featureTableSpec))
+	}
+
+	return feastFeatures, nil
+}
+
 func (fr *FeastRetriever) getFeaturePerTable(ctx context.Context, symbolRegistry symbol.Registry, featureTableSpec *spec.FeatureTable) (*internalFeatureTable, error) {
 	ctx, span := tracer.Start(ctx, "feast.getFeatureTable")
 	span.SetAttributes(attribute.String("table.Name", GetTableName(featureTableSpec)))
@@ -317,6 +342,51 @@ func (fr *FeastRetriever) getFeatureTable(ctx context.Context, entities []feast.
 	return featureTable, nil
 }
 
+// getDefaultFeatureTable create feature table of the given entities, the features are populated with its default value or nil if not specified
+func (fr *FeastRetriever) getDefaultFeatureTable(entities []feast.Row, featureTableSpec *spec.FeatureTable) (*internalFeatureTable, error) {
+	columns := getColumnNames(featureTableSpec)
+	entitySet := getEntitySet(columns, featureTableSpec.Entities)
+	columnTypeMapping := getFeatureTypeMapping(featureTableSpec)
+
+	columnTypes := make([]types.ValueType_Enum, len(columns))
+	valueRows := make(transTypes.ValueRows, len(entities))
+	indexRows := make([]int, len(entities))
+	for rowIdx, entity := range entities {
+		valueRow := make(transTypes.ValueRow, len(columns))
+		for colIdx, column := range columns {
+			rawValue, ok := entity[column]
+			if !entitySet[column] {
+				columnTypes[colIdx] = columnTypeMapping[column]
+				rawValue, ok = fr.defaultValues.GetDefaultValue(featureTableSpec.Project, column)
+			}
+			if !ok {
+				// no default value is specified, we populate with nil
+				continue
+			}
+
+			val, valType, err := converter.ExtractFeastValue(rawValue)
+			if err != nil {
+				return nil, err
+			}
+			if valType != types.ValueType_INVALID {
+				columnTypes[colIdx] = valType
+			}
+			valueRow[colIdx] = val
+		}
+
+		valueRows[rowIdx] = valueRow
+		indexRows[rowIdx] = rowIdx
+	}
+
+	return &internalFeatureTable{
+		entities:    entities,
+		columnNames: columns,
+		columnTypes: columnTypes,
+		valueRows:   valueRows,
+		indexRows:   indexRows,
+	}, nil
+}
+
 func handleFeastError(err error) error {
 	if errors.Is(err, hystrix.ErrTimeout) {
 		return mErrors.NewDeadlineExceededError(err.Error())
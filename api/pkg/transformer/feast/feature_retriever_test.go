@@ -2019,6 +2019,79 @@ func TestFeatureRetriever_RetrieveFeatureOfEntityFromCache(t *testing.T) {
 	assert.Equal(t, expectedFeatureTable, *tablePartialCache[0])
 }
 
+func TestFeatureRetriever_DefaultFeatureOfEntityInSymbolRegistry(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockFeastClient := &mocks.Client{}
+	feastClients := Clients{
+		spec.ServingSource_REDIS: mockFeastClient,
+	}
+	featureTableSpecs := []*spec.FeatureTable{
+		{
+			TableName: "my-table",
+			Project:   "default",
+			Source:    spec.ServingSource_REDIS,
+			Entities: []*spec.Entity{
+				{
+					Name:      "merchant_id",
+					ValueType: "STRING",
+					Extractor: &spec.Entity_JsonPath{
+						JsonPath: "$.merchants[*]",
+					},
+				},
+			},
+			Features: []*spec.Feature{
+				{
+					Name:         "restaurant_features:sales_volume",
+					DefaultValue: "1",
+					ValueType:    "INT32",
+				},
+				{
+					Name:      "restaurant_features:rating",
+					ValueType: "DOUBLE",
+				},
+			},
+		},
+	}
+	compiledJSONPaths, err := CompileJSONPaths(featureTableSpecs, jsonpath.Map)
+	if err != nil {
+		panic(err)
+	}
+
+	compiledExpressions, err := CompileExpressions(featureTableSpecs, symbol.NewRegistry())
+	if err != nil {
+		panic(err)
+	}
+
+	jsonPathStorage := jsonpath.NewStorage()
+	jsonPathStorage.AddAll(compiledJSONPaths)
+	expressionStorage := expression.NewStorage()
+	expressionStorage.AddAll(compiledExpressions)
+	entityExtractor := NewEntityExtractor(jsonPathStorage, expressionStorage)
+	options := &Options{
+		FeastClientHystrixCommandName: "TestFeatureRetriever_DefaultFeatureOfEntityInSymbolRegistry",
+		FeastTimeout:                  1 * time.Second,
+		BatchSize:                     100,
+	}
+	fr := NewFeastRetriever(feastClients, entityExtractor, featureTableSpecs, options, logger)
+
+	var requestJson transTypes.JSONObject
+	_ = json.Unmarshal([]byte(`{"merchants": ["0", "1", "0"]}`), &requestJson)
+	sr := symbol.NewRegistryWithCompiledJSONPath(jsonPathStorage)
+	sr.SetRawRequest(requestJson)
+
+	got, err := fr.DefaultFeatureOfEntityInSymbolRegistry(context.Background(), sr)
+	assert.NoError(t, err)
+	assert.Equal(t, []*transTypes.FeatureTable{
+		{
+			Name:        "my-table",
+			Columns:     []string{"merchant_id", "restaurant_features:sales_volume", "restaurant_features:rating"},
+			ColumnTypes: []feastTypes.ValueType_Enum{feastTypes.ValueType_STRING, feastTypes.ValueType_INT32, feastTypes.ValueType_DOUBLE},
+			Data:        []transTypes.ValueRow{{"0", int32(1), nil}, {"1", int32(1), nil}},
+		},
+	}, got)
+	mockFeastClient.AssertNotCalled(t, "GetOnlineFeatures", mock.Anything, mock.Anything)
+}
+
 func TestFeatureRetriever_buildEntitiesRows(t *testing.T) {
 	type args struct {
 		request     []byte
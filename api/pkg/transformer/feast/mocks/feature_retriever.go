@@ -61,3 +61,26 @@ func (_m *FeatureRetriever) RetrieveFeatureOfEntityInSymbolRegistry(ctx context.
 
 	return r0, r1
 }
+
+// DefaultFeatureOfEntityInSymbolRegistry provides a mock function with given fields: ctx, symbolRegistry
+func (_m *FeatureRetriever) DefaultFeatureOfEntityInSymbolRegistry(ctx context.Context, symbolRegistry symbol.Registry) ([]*types.FeatureTable, error) {
+	ret := _m.Called(ctx, symbolRegistry)
+
+	var r0 []*types.FeatureTable
+	if rf, ok := ret.Get(0).(func(context.Context, symbol.Registry) []*types.FeatureTable); ok {
+		r0 = rf(ctx, symbolRegistry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.FeatureTable)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, symbol.Registry) error); ok {
+		r1 = rf(ctx, symbolRegistry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"time"
 
 	"github.com/caraml-dev/merlin/pkg/transformer/types/table"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/caraml-dev/merlin/pkg/transformer/jsonpath"
+	"github.com/caraml-dev/merlin/pkg/transformer/symbol"
 	"github.com/caraml-dev/merlin/pkg/transformer/types"
 	"github.com/caraml-dev/merlin/pkg/transformer/types/expression"
 )
@@ -56,12 +58,21 @@ func (p *CompiledPipeline) Postprocess(context context.Context, env *Environment
 func (p *CompiledPipeline) executePipelineOp(ctx context.Context, pType types.Pipeline, ops []Op, env *Environment) (types.Payload, error) {
 	tracingDetails := make([]types.TracingDetail, 0)
 	for _, op := range ops {
+		var snapshot symbol.Registry
+		if env.isDebugTraceEnabled() {
+			snapshot = env.snapshotSymbols()
+		}
+
+		startTime := time.Now()
 		err := op.Execute(ctx, env)
+		if env.isDebugTraceEnabled() {
+			env.recordDebugTrace(pType, op, snapshot, time.Since(startTime))
+		}
 		if err != nil {
 			return nil, errors.Wrapf(err, "error executing %s operation: %T", pType, op)
 		}
 
-		if p.tracingEnabled {
+		if p.tracingEnabled && !env.isSkipped(op) {
 			details, err := op.GetOperationTracingDetail()
 			if err != nil {
 				return nil, err
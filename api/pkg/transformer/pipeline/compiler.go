@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/ast"
+	"github.com/antonmedv/expr/parser"
 	"github.com/antonmedv/expr/vm"
 	"github.com/caraml-dev/merlin/pkg/transformer/feast"
 	"github.com/caraml-dev/merlin/pkg/transformer/jsonpath"
@@ -38,6 +40,12 @@ type Compiler struct {
 	jsonpathSourceType      jsonpath.SourceType
 
 	predictionLogProducer PredictionLogProducer
+
+	// name of preprocess table sent to the model, it is used as default feature table of explanation output
+	modelFeatureTableName string
+
+	// symbols produced by operations which are skipped on timeout, they can't be used by other operations
+	skippableSymbols map[string]bool
 }
 
 // NewCompiler create new compiler instance
@@ -149,12 +157,15 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 
 	// input
 	for _, input := range pipeline.Inputs {
+		inputStart := len(ops)
+		markSkippable := c.trackSkippableSymbols(input.ExecutionPolicy)
 		if input.Variables != nil {
 			varOp, err := c.parseVariablesSpec(input.Variables, compiledJsonPaths, compiledExpressions)
 			if err != nil {
 				return nil, nil, err
 			}
 			ops = append(ops, varOp)
+			markSkippable()
 		}
 
 		if input.Tables != nil {
@@ -164,6 +175,7 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 			}
 
 			ops = append(ops, tableOp)
+			markSkippable()
 			for k, v := range loadedTables {
 				preloadedTables[k] = v
 			}
@@ -192,6 +204,7 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 				return nil, nil, err
 			}
 			ops = append(ops, feastOp)
+			markSkippable()
 		}
 
 		if input.Encoders != nil {
@@ -200,6 +213,7 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 				return nil, nil, err
 			}
 			ops = append(ops, encoderOp)
+			markSkippable()
 		}
 		if input.Autoload != nil {
 			autoloadOp, err := c.parseUPIAutoloadSpec(input.Autoload, pipelineType, compiledExpressions)
@@ -207,17 +221,25 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 				return nil, nil, err
 			}
 			ops = append(ops, autoloadOp)
+			markSkippable()
+		}
+
+		if err := applyExecutionPolicy(ops, inputStart, input.ExecutionPolicy, pipelineType); err != nil {
+			return nil, nil, err
 		}
 	}
 
 	// transformation
 	for _, transformation := range pipeline.Transformations {
+		transformationStart := len(ops)
+		markSkippable := c.trackSkippableSymbols(transformation.ExecutionPolicy)
 		if transformation.TableTransformation != nil {
 			tableTransformOps, err := c.parseTableTransform(transformation.TableTransformation, compiledJsonPaths, compiledExpressions)
 			if err != nil {
 				return nil, nil, err
 			}
 			ops = append(ops, tableTransformOps)
+			markSkippable()
 		}
 
 		if transformation.TableJoin != nil {
@@ -226,6 +248,7 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 				return nil, nil, err
 			}
 			ops = append(ops, tableJoinOp)
+			markSkippable()
 		}
 		if len(transformation.Variables) > 0 {
 			varOp, err := c.parseVariablesSpec(transformation.Variables, compiledJsonPaths, compiledExpressions)
@@ -233,6 +256,11 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 				return nil, nil, err
 			}
 			ops = append(ops, varOp)
+			markSkippable()
+		}
+
+		if err := applyExecutionPolicy(ops, transformationStart, transformation.ExecutionPolicy, pipelineType); err != nil {
+			return nil, nil, err
 		}
 	}
 
@@ -259,11 +287,88 @@ func (c *Compiler) doCompilePipeline(pipeline *spec.Pipeline, pipelineType types
 			}
 			ops = append(ops, postprocesOutput)
 		}
+		if explanationOutput := output.ExplanationOutput; explanationOutput != nil {
+			explanationOutputOp, err := c.parseExplanationOutput(explanationOutput, compiledJsonPaths)
+			if err != nil {
+				return nil, nil, err
+			}
+			ops = append(ops, explanationOutputOp)
+		}
+
+		if pipelineType == types.Preprocess {
+			if tableName := modelFeatureTableName(output); tableName != "" {
+				c.modelFeatureTableName = tableName
+			}
+		}
 	}
 
 	return ops, preloadedTables, nil
 }
 
+// modelFeatureTableName returns name of the table sent to the model by preprocess output
+// for json output, the table can only be inferred if there is exactly one field populated from table
+func modelFeatureTableName(output *spec.Output) string {
+	if output.UpiPreprocessOutput != nil {
+		return output.UpiPreprocessOutput.PredictionTableName
+	}
+
+	if output.JsonOutput == nil || output.JsonOutput.JsonTemplate == nil {
+		return ""
+	}
+	tableName := ""
+	for _, field := range output.JsonOutput.JsonTemplate.Fields {
+		fromTable, ok := field.Value.(*spec.Field_FromTable)
+		if !ok {
+			continue
+		}
+		if tableName != "" {
+			return ""
+		}
+		tableName = fromTable.FromTable.TableName
+	}
+	return tableName
+}
+
+// applyExecutionPolicy wraps operations starting from index start with the execution policy, if any
+func applyExecutionPolicy(ops []Op, start int, policy *spec.ExecutionPolicy, pipelineType types.Pipeline) error {
+	if policy == nil {
+		return nil
+	}
+
+	for i := start; i < len(ops); i++ {
+		policyOp, err := NewExecutionPolicyOp(ops[i], policy, pipelineType, i)
+		if err != nil {
+			return err
+		}
+		ops[i] = policyOp
+	}
+	return nil
+}
+
+// trackSkippableSymbols returns a function which marks the symbols registered since trackSkippableSymbols is called
+// as skippable, if the operations are skipped on timeout
+func (c *Compiler) trackSkippableSymbols(policy *spec.ExecutionPolicy) func() {
+	if policy == nil || policy.OnTimeout != spec.OnTimeoutPolicy_SKIP {
+		return func() {}
+	}
+
+	registered := make(map[string]bool, len(c.sr))
+	for name := range c.sr {
+		registered[name] = true
+	}
+	return func() {
+		for name := range c.sr {
+			if registered[name] {
+				continue
+			}
+			if c.skippableSymbols == nil {
+				c.skippableSymbols = make(map[string]bool)
+			}
+			c.skippableSymbols[name] = true
+		}
+	}
+}
+
 func (c *Compiler) parseUpiPreprocessOutput(outputSpec *spec.UPIPreprocessOutput) (Op, error) {
 	if outputSpec.PredictionTableName == "" && len(outputSpec.TransformerInputTableNames) == 0 {
 		return nil, fmt.Errorf(`"predictionTableName" or "transformerInputTableNames" must be set for upi preprocess output spec`)
@@ -294,6 +399,43 @@ func (c *Compiler) parseUpiPostprocessOutput(outputSpec *spec.UPIPostprocessOutp
 	return output, nil
 }
 
+func (c *Compiler) parseExplanationOutput(outputSpec *spec.ExplanationOutput, compiledJsonPaths *jsonpath.Storage) (Op, error) {
+	if (outputSpec.AttributionJsonPath == "") == (outputSpec.AttributionTableName == "") {
+		return nil, fmt.Errorf(`either "attributionJsonPath" or "attributionTableName" must be set for explanation output spec`)
+	}
+	if outputSpec.TopK < 0 {
+		return nil, fmt.Errorf(`"topK" of explanation output spec must not be negative`)
+	}
+
+	featureTableName := outputSpec.FeatureTableName
+	if featureTableName == "" {
+		featureTableName = c.modelFeatureTableName
+	}
+	if featureTableName == "" {
+		return nil, fmt.Errorf(`"featureTableName" must be set for explanation output spec, the table sent to the model can't be inferred from preprocess output`)
+	}
+	if err := c.checkVariableRegistered(featureTableName); err != nil {
+		return nil, err
+	}
+
+	if outputSpec.AttributionTableName != "" {
+		if err := c.checkVariableRegistered(outputSpec.AttributionTableName); err != nil {
+			return nil, err
+		}
+	} else {
+		compiledJsonPath, err := jsonpath.CompileWithOption(jsonpath.JsonPathOption{
+			JsonPath: outputSpec.AttributionJsonPath,
+			SrcType:  c.jsonpathSourceType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		compiledJsonPaths.Set(outputSpec.AttributionJsonPath, compiledJsonPath)
+	}
+
+	return NewExplanationOutputOp(outputSpec, featureTableName, c.operationTracingEnabled), nil
+}
+
 func (c *Compiler) parseUPIAutoloadSpec(autoloadSpec *spec.UPIAutoload, pipelineType types.Pipeline, compiledExpressions *expression.Storage) (Op, error) {
 	for _, variableName := range autoloadSpec.VariableNames {
 		c.registerDummyVariable(variableName)
@@ -389,6 +531,10 @@ func (c *Compiler) parseTablesSpec(tableSpecs []*spec.Table, compiledJsonPaths *
 					return nil, nil, err
 				}
 				compiledJsonPaths.Set(bt.FromJson.JsonPath, compiledJsonPath)
+			case *spec.BaseTable_FromTable:
+				if err := c.checkNotSkippable(bt.FromTable.TableName); err != nil {
+					return nil, nil, err
+				}
 			case *spec.BaseTable_FromFile:
 				var records [][]string
 				var err error
@@ -635,6 +781,10 @@ func (c *Compiler) parseJsonFields(fields []*spec.Field, compiledJsonPaths *json
 }
 
 func (c *Compiler) compileExpression(expression string) (*vm.Program, error) {
+	if err := c.checkExpressionNotSkippable(expression); err != nil {
+		return nil, err
+	}
+
 	return expr.Compile(expression,
 		expr.Env(c.sr),
 		expr.Operator("&&", "AndOp"),
@@ -667,5 +817,43 @@ func (c *Compiler) checkVariableRegistered(varName string) error {
 		return fmt.Errorf("variable %s is not registered", varName)
 	}
 
+	return c.checkNotSkippable(varName)
+}
+
+func (c *Compiler) checkNotSkippable(varName string) error {
+	if c.skippableSymbols[varName] {
+		return fmt.Errorf("variable %s is produced by an operation which is skipped on timeout, it can't be used by other operations", varName)
+	}
+	return nil
+}
+
+// checkExpressionNotSkippable checks that the expression doesn't refer to any symbol produced by a skippable operation
+func (c *Compiler) checkExpressionNotSkippable(expression string) error {
+	if len(c.skippableSymbols) == 0 {
+		return nil
+	}
+
+	tree, err := parser.Parse(expression)
+	if err != nil {
+		return err
+	}
+	visitor := &identifierVisitor{}
+	ast.Walk(&tree.Node, visitor)
+	for _, identifier := range visitor.identifiers {
+		if err := c.checkNotSkippable(identifier); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// identifierVisitor collects the identifiers of an expression
+type identifierVisitor struct {
+	identifiers []string
+}
+
+func (v *identifierVisitor) Visit(node *ast.Node) {
+	if identifier, ok := (*node).(*ast.IdentifierNode); ok {
+		v.identifiers = append(v.identifiers, identifier.Value)
+	}
+}
@@ -564,6 +564,116 @@ func TestCompiler_Compile(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "feast with execution policy",
+			fields: fields{
+				sr:           symbol.NewRegistry(),
+				feastClients: feast.Clients{},
+				feastOptions: &feast.Options{
+					CacheEnabled:  true,
+					CacheSizeInMB: 100,
+				},
+				logger:   logger,
+				protocol: prt.HttpJson,
+			},
+			specYamlFilePath: "./testdata/valid_feast_execution_policy.yaml",
+			want: want{
+				expressions: []string{
+					"customer_id",
+				},
+				jsonPaths: []string{
+					"$.customer.id",
+					"$.drivers[*]",
+					"$.drivers[*].id",
+				},
+				preprocessOps: []Op{
+					&VariableDeclarationOp{},
+					&CreateTableOp{},
+					&ExecutionPolicyOp{},
+					&TableTransformOp{},
+					&ExecutionPolicyOp{},
+					&TableTransformOp{},
+					&JsonOutputOp{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid execution policy - use default is not supported by variables",
+			fields: fields{
+				sr:           symbol.NewRegistry(),
+				feastClients: feast.Clients{},
+				feastOptions: &feast.Options{
+					CacheEnabled:  true,
+					CacheSizeInMB: 100,
+				},
+				logger:   logger,
+				protocol: prt.HttpJson,
+			},
+			specYamlFilePath: "./testdata/invalid_execution_policy.yaml",
+			wantErr:          true,
+			expError:         errors.New("unable to compile preprocessing pipeline: variable_op doesn't support USE_DEFAULT on timeout policy"),
+		},
+		{
+			name: "invalid execution policy - output of skippable operation is used",
+			fields: fields{
+				sr:           symbol.NewRegistry(),
+				feastClients: feast.Clients{},
+				feastOptions: &feast.Options{
+					CacheEnabled:  true,
+					CacheSizeInMB: 100,
+				},
+				logger:   logger,
+				protocol: prt.HttpJson,
+			},
+			specYamlFilePath: "./testdata/invalid_skip_execution_policy.yaml",
+			wantErr:          true,
+			expError:         errors.New("unable to compile preprocessing pipeline: variable customer_id is produced by an operation which is skipped on timeout, it can't be used by other operations"),
+		},
+		{
+			name: "explanation output with feature table inferred from preprocess output",
+			fields: fields{
+				sr:           symbol.NewRegistry(),
+				feastClients: feast.Clients{},
+				feastOptions: &feast.Options{
+					CacheEnabled:  true,
+					CacheSizeInMB: 100,
+				},
+				logger:   logger,
+				protocol: prt.HttpJson,
+			},
+			specYamlFilePath: "./testdata/valid_explanation_output.yaml",
+			want: want{
+				jsonPaths: []string{
+					"$.drivers[*]",
+					"$.model_response.shap_values",
+				},
+				preprocessOps: []Op{
+					&CreateTableOp{},
+					&JsonOutputOp{},
+				},
+				postprocessOps: []Op{
+					&ExplanationOutputOp{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid explanation output - feature table can't be inferred",
+			fields: fields{
+				sr:           symbol.NewRegistry(),
+				feastClients: feast.Clients{},
+				feastOptions: &feast.Options{
+					CacheEnabled:  true,
+					CacheSizeInMB: 100,
+				},
+				logger:   logger,
+				protocol: prt.HttpJson,
+			},
+			specYamlFilePath: "./testdata/invalid_explanation_output.yaml",
+			wantErr:          true,
+			expError:         errors.New(`unable to compile postprocessing pipeline: "featureTableName" must be set for explanation output spec, the table sent to the model can't be inferred from preprocess output`),
+		},
 		{
 			name: "preprocess - postprocess input and output - invalid",
 			fields: fields{
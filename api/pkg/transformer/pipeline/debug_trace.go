@@ -0,0 +1,88 @@
+package pipeline
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/symbol"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+)
+
+type debugModeContextKey struct{}
+
+// WithDebugMode marks the request context so that the pipeline records the debug trace of every executed operation
+func WithDebugMode(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugModeContextKey{}, true)
+}
+
+// IsDebugMode returns true if debug mode is enabled for the request context
+func IsDebugMode(ctx context.Context) bool {
+	enabled, ok := ctx.Value(debugModeContextKey{}).(bool)
+	return ok && enabled
+}
+
+func (e *Environment) enableDebugTrace() {
+	e.debugTrace = &types.DebugTrace{
+		PreprocessTrace:  make([]types.OperationDebugDetail, 0),
+		PostprocessTrace: make([]types.OperationDebugDetail, 0),
+	}
+}
+
+// DebugTrace returns the operations executed in the environment, it returns nil if debug mode is not enabled
+func (e *Environment) DebugTrace() *types.DebugTrace {
+	return e.debugTrace
+}
+
+func (e *Environment) isDebugTraceEnabled() bool {
+	return e.debugTrace != nil
+}
+
+// snapshotSymbols copies current symbols so that the symbols set by an operation can be computed after it is executed
+func (e *Environment) snapshotSymbols() symbol.Registry {
+	snapshot := make(symbol.Registry, len(e.symbolRegistry))
+	for k, v := range e.symbolRegistry {
+		snapshot[k] = v
+	}
+	return snapshot
+}
+
+// recordDebugTrace appends the symbols set by the operation since the snapshot was taken to the debug trace
+func (e *Environment) recordDebugTrace(pType types.Pipeline, op Op, snapshot symbol.Registry, duration time.Duration) {
+	output := make(map[string]interface{})
+	for k, v := range e.symbolRegistry {
+		if symbol.IsReservedSymbol(k) {
+			continue
+		}
+		prev, exist := snapshot[k]
+		if exist && isSameSymbolValue(prev, v) {
+			continue
+		}
+		output[k] = v
+	}
+
+	if err := sanitizeIO(output); err != nil {
+		e.logger.Warn("error formatting debug trace output: " + err.Error())
+	}
+
+	detail := types.OperationDebugDetail{
+		OpType:     getOperationType(op),
+		Output:     output,
+		DurationMs: float64(duration) / float64(time.Millisecond),
+		Skipped:    e.isSkipped(op),
+	}
+
+	if pType == types.Preprocess {
+		e.debugTrace.PreprocessTrace = append(e.debugTrace.PreprocessTrace, detail)
+	} else {
+		e.debugTrace.PostprocessTrace = append(e.debugTrace.PostprocessTrace, detail)
+	}
+}
+
+func isSameSymbolValue(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Ptr && vb.Kind() == reflect.Ptr {
+		return va.Pointer() == vb.Pointer()
+	}
+	return reflect.DeepEqual(a, b)
+}
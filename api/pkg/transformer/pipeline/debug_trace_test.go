@@ -0,0 +1,100 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+
+	"github.com/caraml-dev/merlin/pkg/protocol"
+	"github.com/caraml-dev/merlin/pkg/transformer/feast"
+	"github.com/caraml-dev/merlin/pkg/transformer/spec"
+	"github.com/caraml-dev/merlin/pkg/transformer/symbol"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+)
+
+func TestHandler_DebugTrace(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	yamlBytes, err := os.ReadFile("testdata/valid_debug_trace.yaml")
+	require.NoError(t, err)
+	jsonBytes, err := yaml.YAMLToJSON(yamlBytes)
+	require.NoError(t, err)
+	var stdSpec spec.StandardTransformerConfig
+	require.NoError(t, protojson.Unmarshal(jsonBytes, &stdSpec))
+
+	compiler := NewCompiler(symbol.NewRegistry(), feast.Clients{}, &feast.Options{}, WithLogger(logger), WithProtocol(protocol.HttpJson))
+	compiledPipeline, err := compiler.Compile(&stdSpec)
+	require.NoError(t, err)
+	handler := NewHandler(compiledPipeline, logger)
+
+	request := types.BytePayload(`{"customer": {"id": 1111}, "entities": [{"id": 1, "name": "entity-1"}]}`)
+
+	tests := []struct {
+		name          string
+		debugMode     bool
+		expDebugTrace *types.DebugTrace
+	}{
+		{
+			name:          "debug mode is not enabled",
+			debugMode:     false,
+			expDebugTrace: nil,
+		},
+		{
+			name:      "debug mode is enabled",
+			debugMode: true,
+			expDebugTrace: &types.DebugTrace{
+				PreprocessTrace: []types.OperationDebugDetail{
+					{
+						OpType: types.VariableOpType,
+						Output: map[string]interface{}{"customer_id": float64(1111)},
+					},
+					{
+						OpType: types.CreateTableOpType,
+						Output: map[string]interface{}{
+							"entity_table": []interface{}{
+								map[string]interface{}{"id": float64(1), "name": "entity-1"},
+							},
+						},
+					},
+					{
+						OpType: types.JsonOutputOpType,
+						Output: map[string]interface{}{},
+					},
+				},
+				PostprocessTrace: []types.OperationDebugDetail{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.debugMode {
+				ctx = WithDebugMode(ctx)
+			}
+			ctx = handler.EmbedEnvironment(ctx)
+
+			_, err := handler.Preprocess(ctx, request, map[string]string{})
+			require.NoError(t, err)
+
+			got := handler.DebugTrace(ctx)
+			if tt.expDebugTrace == nil {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.Len(t, got.PreprocessTrace, len(tt.expDebugTrace.PreprocessTrace))
+			for i, exp := range tt.expDebugTrace.PreprocessTrace {
+				assert.Equal(t, exp.OpType, got.PreprocessTrace[i].OpType)
+				assert.Equal(t, exp.Output, got.PreprocessTrace[i].Output)
+				assert.GreaterOrEqual(t, got.PreprocessTrace[i].DurationMs, float64(0))
+			}
+			assert.Equal(t, tt.expDebugTrace.PostprocessTrace, got.PostprocessTrace)
+		})
+	}
+}
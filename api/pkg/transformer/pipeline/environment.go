@@ -3,6 +3,7 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/antonmedv/expr/vm"
 	"go.uber.org/zap"
@@ -17,6 +18,13 @@ type Environment struct {
 	compiledPipeline *CompiledPipeline
 	output           types.Payload
 	logger           *zap.Logger
+
+	// operations skipped due to their execution policy
+	skippedOps map[Op]bool
+	// set once a forked environment is abandoned after its deadline, the writes of the operation still running are discarded
+	detached atomic.Bool
+	// trace of executed operations, only recorded if debug mode is enabled for the request
+	debugTrace *types.DebugTrace
 }
 
 func NewEnvironment(compiledPipeline *CompiledPipeline, logger *zap.Logger) *Environment {
@@ -69,6 +77,45 @@ func (e *Environment) PublishPredictionLog(ctx context.Context, result *types.Pr
 	go predictionLogOp.ProducePredictionLog(ctx, result, e) //nolint:errcheck
 }
 
+// fork creates a copy of the environment, symbols set in the copy are not visible in the original environment until it is merged back
+func (e *Environment) fork() *Environment {
+	sr := make(symbol.Registry, len(e.symbolRegistry))
+	for k, v := range e.symbolRegistry {
+		sr[k] = v
+	}
+
+	return &Environment{
+		symbolRegistry:   sr,
+		compiledPipeline: e.compiledPipeline,
+		output:           e.output,
+		logger:           e.logger,
+	}
+}
+
+// merge applies symbols and output of a forked environment to the environment
+func (e *Environment) merge(forked *Environment) {
+	for k, v := range forked.symbolRegistry {
+		e.symbolRegistry[k] = v
+	}
+	e.output = forked.output
+}
+
+// detach discards any subsequent write to the forked environment
+func (e *Environment) detach() {
+	e.detached.Store(true)
+}
+
+func (e *Environment) markSkipped(op Op) {
+	if e.skippedOps == nil {
+		e.skippedOps = make(map[Op]bool)
+	}
+	e.skippedOps[op] = true
+}
+
+func (e *Environment) isSkipped(op Op) bool {
+	return e.skippedOps[op]
+}
+
 func (e *Environment) IsPostProcessOpExist() bool {
 	return len(e.compiledPipeline.postprocessOps) > 0
 }
@@ -86,6 +133,9 @@ func (e *Environment) PreprocessResponse() types.Payload {
 }
 
 func (e *Environment) SetOutput(payload types.Payload) {
+	if e.detached.Load() {
+		return
+	}
 	e.output = payload
 }
 
@@ -98,6 +148,9 @@ func (e *Environment) PayloadContainer() types.PayloadObjectContainer {
 }
 
 func (e *Environment) SetSymbol(name string, value interface{}) {
+	if e.detached.Load() {
+		return
+	}
 	e.symbolRegistry[name] = value
 }
 
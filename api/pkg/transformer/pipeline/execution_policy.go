@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	mErrors "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/transformer/spec"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+)
+
+// DefaultOutputSetter is implemented by operation which is able to populate its output with default values.
+// Only those operations can be configured with USE_DEFAULT on timeout policy
+type DefaultOutputSetter interface {
+	SetDefaultOutput(ctx context.Context, env *Environment) error
+}
+
+// ExecutionPolicyOp wraps an operation with a deadline and decides how the pipeline continues once the deadline is exceeded
+type ExecutionPolicyOp struct {
+	Op
+	timeout   time.Duration
+	onTimeout spec.OnTimeoutPolicy
+
+	pipelineType types.Pipeline
+	step         int
+	opType       types.OperationType
+}
+
+// NewExecutionPolicyOp create operation that executes op within the deadline specified by policy
+func NewExecutionPolicyOp(op Op, policy *spec.ExecutionPolicy, pipelineType types.Pipeline, step int) (*ExecutionPolicyOp, error) {
+	if policy.Timeout == nil {
+		return nil, errors.New("timeout must be specified in execution policy")
+	}
+	if err := policy.Timeout.CheckValid(); err != nil {
+		return nil, fmt.Errorf("invalid execution policy timeout: %w", err)
+	}
+
+	timeout := policy.Timeout.AsDuration()
+	if timeout <= 0 {
+		return nil, fmt.Errorf("execution policy timeout must be positive, got %s", timeout)
+	}
+
+	opType := getOperationType(op)
+	if _, ok := op.(DefaultOutputSetter); policy.OnTimeout == spec.OnTimeoutPolicy_USE_DEFAULT && !ok {
+		return nil, fmt.Errorf("%s doesn't support %s on timeout policy", opType, spec.OnTimeoutPolicy_USE_DEFAULT)
+	}
+
+	return &ExecutionPolicyOp{
+		Op:           op,
+		timeout:      timeout,
+		onTimeout:    policy.OnTimeout,
+		pipelineType: pipelineType,
+		step:         step,
+		opType:       opType,
+	}, nil
+}
+
+// Execute runs the wrapped operation in a forked environment, its result is only applied if it completes within the deadline.
+// The context of the operation is cancelled once the deadline is exceeded.
+func (op *ExecutionPolicyOp) Execute(ctx context.Context, env *Environment) error {
+	opCtx, cancel := context.WithTimeout(ctx, op.timeout)
+	defer cancel()
+
+	forked := env.fork()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- op.Op.Execute(opCtx, forked)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			env.merge(forked)
+			return nil
+		}
+		if !errors.Is(opCtx.Err(), context.DeadlineExceeded) {
+			return err
+		}
+	case <-opCtx.Done():
+	}
+
+	// the operation may still be running, cancel it and discard whatever it writes afterward
+	cancel()
+	forked.detach()
+	return op.handleTimeout(ctx, env)
+}
+
+func (op *ExecutionPolicyOp) handleTimeout(ctx context.Context, env *Environment) error {
+	operationTimeout.WithLabelValues(string(op.pipelineType), strconv.Itoa(op.step), string(op.opType), op.onTimeout.String()).Inc()
+
+	switch op.onTimeout {
+	case spec.OnTimeoutPolicy_USE_DEFAULT:
+		env.LogOperation(fmt.Sprintf("%s timeout, using default output", op.opType))
+		return op.Op.(DefaultOutputSetter).SetDefaultOutput(ctx, env)
+	case spec.OnTimeoutPolicy_SKIP:
+		env.LogOperation(fmt.Sprintf("%s timeout, skipped", op.opType))
+		env.markSkipped(op)
+		return nil
+	default:
+		return mErrors.NewDeadlineExceededErrorf("%s exceeded its deadline of %s", op.opType, op.timeout)
+	}
+}
+
+func getOperationType(op Op) types.OperationType {
+	switch o := op.(type) {
+	case *ExecutionPolicyOp:
+		return o.opType
+	case *VariableDeclarationOp:
+		return types.VariableOpType
+	case *CreateTableOp:
+		return types.CreateTableOpType
+	case *FeastOp:
+		return types.FeastOpType
+	case *JsonOutputOp:
+		return types.JsonOutputOpType
+	case *EncoderOp:
+		return types.EncoderOpType
+	case *TableJoinOp:
+		return types.TableJoinOpType
+	case *TableTransformOp:
+		return types.TableTransformOp
+	case *UPIAutoloadingOp:
+		return types.UPIAutoloadingOp
+	case *UPIPreprocessOutputOp:
+		return types.UPIPreprocessOutputOp
+	case *UPIPostprocessOutputOp:
+		return types.UPIPostprocessOutputOp
+	case *ExplanationOutputOp:
+		return types.ExplanationOutputOp
+	default:
+		return types.OperationType(fmt.Sprintf("%T", op))
+	}
+}
@@ -0,0 +1,224 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/feast-dev/feast/sdk/go/protos/feast/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	mErrors "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/transformer/feast/mocks"
+	"github.com/caraml-dev/merlin/pkg/transformer/spec"
+	"github.com/caraml-dev/merlin/pkg/transformer/symbol"
+	transTypes "github.com/caraml-dev/merlin/pkg/transformer/types"
+	"github.com/caraml-dev/merlin/pkg/transformer/types/series"
+	"github.com/caraml-dev/merlin/pkg/transformer/types/table"
+)
+
+func TestNewExecutionPolicyOp(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      Op
+		policy  *spec.ExecutionPolicy
+		wantErr string
+	}{
+		{
+			name: "valid policy",
+			op:   &FeastOp{},
+			policy: &spec.ExecutionPolicy{
+				Timeout:   durationpb.New(10 * time.Millisecond),
+				OnTimeout: spec.OnTimeoutPolicy_USE_DEFAULT,
+			},
+		},
+		{
+			name: "timeout is not set",
+			op:   &FeastOp{},
+			policy: &spec.ExecutionPolicy{
+				OnTimeout: spec.OnTimeoutPolicy_SKIP,
+			},
+			wantErr: "timeout must be specified in execution policy",
+		},
+		{
+			name: "negative timeout",
+			op:   &FeastOp{},
+			policy: &spec.ExecutionPolicy{
+				Timeout: durationpb.New(-10 * time.Millisecond),
+			},
+			wantErr: "execution policy timeout must be positive, got -10ms",
+		},
+		{
+			name: "use default is not supported by the operation",
+			op:   &VariableDeclarationOp{},
+			policy: &spec.ExecutionPolicy{
+				Timeout:   durationpb.New(10 * time.Millisecond),
+				OnTimeout: spec.OnTimeoutPolicy_USE_DEFAULT,
+			},
+			wantErr: "variable_op doesn't support USE_DEFAULT on timeout policy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExecutionPolicyOp(tt.op, tt.policy, transTypes.Preprocess, 0)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.op, got.Op)
+		})
+	}
+}
+
+func TestExecutionPolicyOp_Execute(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	featureTable := &transTypes.FeatureTable{
+		Name:        "driver_table",
+		Columns:     []string{"driver_id", "feature_a"},
+		Data:        transTypes.ValueRows{{"1111", 11.11}},
+		ColumnTypes: []types.ValueType_Enum{types.ValueType_STRING, types.ValueType_DOUBLE},
+	}
+	defaultFeatureTable := &transTypes.FeatureTable{
+		Name:        "driver_table",
+		Columns:     []string{"driver_id", "feature_a"},
+		Data:        transTypes.ValueRows{{"1111", 0.0}},
+		ColumnTypes: []types.ValueType_Enum{types.ValueType_STRING, types.ValueType_DOUBLE},
+	}
+
+	tests := []struct {
+		name           string
+		onTimeout      spec.OnTimeoutPolicy
+		retrievalDelay time.Duration
+		retrievalErr   error
+		expTable       *table.Table
+		expSkipped     bool
+		wantErr        error
+	}{
+		{
+			name:           "operation completes within deadline",
+			onTimeout:      spec.OnTimeoutPolicy_FAIL,
+			retrievalDelay: 0,
+			expTable: table.New(
+				series.New([]string{"1111"}, series.String, "driver_id"),
+				series.New([]float64{11.11}, series.Float, "feature_a"),
+			),
+		},
+		{
+			name:           "operation returns error within deadline",
+			onTimeout:      spec.OnTimeoutPolicy_SKIP,
+			retrievalDelay: 0,
+			retrievalErr:   errors.New("feast error"),
+			wantErr:        errors.New("feast error"),
+		},
+		{
+			name:           "timeout with fail policy",
+			onTimeout:      spec.OnTimeoutPolicy_FAIL,
+			retrievalDelay: 200 * time.Millisecond,
+			wantErr:        mErrors.ErrDeadlineExceeded,
+		},
+		{
+			name:           "timeout with use default policy",
+			onTimeout:      spec.OnTimeoutPolicy_USE_DEFAULT,
+			retrievalDelay: 200 * time.Millisecond,
+			expTable: table.New(
+				series.New([]string{"1111"}, series.String, "driver_id"),
+				series.New([]float64{0}, series.Float, "feature_a"),
+			),
+		},
+		{
+			name:           "timeout with skip policy",
+			onTimeout:      spec.OnTimeoutPolicy_SKIP,
+			retrievalDelay: 200 * time.Millisecond,
+			expSkipped:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFeastRetriever := &mocks.FeatureRetriever{}
+			mockFeastRetriever.On("RetrieveFeatureOfEntityInSymbolRegistry", mock.Anything, mock.Anything).
+				Return([]*transTypes.FeatureTable{featureTable}, tt.retrievalErr).
+				After(tt.retrievalDelay)
+			mockFeastRetriever.On("DefaultFeatureOfEntityInSymbolRegistry", mock.Anything, mock.Anything).
+				Return([]*transTypes.FeatureTable{defaultFeatureTable}, nil)
+
+			env := &Environment{
+				symbolRegistry: symbol.NewRegistry(),
+				logger:         logger,
+			}
+			op, err := NewExecutionPolicyOp(&FeastOp{
+				feastRetriever: mockFeastRetriever,
+				logger:         logger,
+			}, &spec.ExecutionPolicy{
+				Timeout:   durationpb.New(50 * time.Millisecond),
+				OnTimeout: tt.onTimeout,
+			}, transTypes.Preprocess, 0)
+			assert.NoError(t, err)
+
+			err = op.Execute(context.Background(), env)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+				_, exist := env.symbolRegistry["driver_table"]
+				assert.False(t, exist)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expSkipped, env.isSkipped(op))
+			if tt.expTable == nil {
+				_, exist := env.symbolRegistry["driver_table"]
+				assert.False(t, exist)
+				return
+			}
+			assert.Equal(t, tt.expTable, env.symbolRegistry["driver_table"])
+		})
+	}
+}
+
+// lateWriterOp writes its output once its context is cancelled, like an operation ignoring its deadline
+type lateWriterOp struct {
+	*OperationTracing
+	written chan struct{}
+}
+
+func (op *lateWriterOp) Execute(ctx context.Context, env *Environment) error {
+	<-ctx.Done()
+	env.SetSymbol("late_output", "value")
+	env.SetOutput(transTypes.JSONObject{"late": true})
+	close(op.written)
+	return nil
+}
+
+func TestExecutionPolicyOp_ExecuteDiscardsLateWrites(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	env := &Environment{
+		symbolRegistry: symbol.NewRegistry(),
+		logger:         logger,
+	}
+
+	lateOp := &lateWriterOp{written: make(chan struct{})}
+	op, err := NewExecutionPolicyOp(lateOp, &spec.ExecutionPolicy{
+		Timeout:   durationpb.New(10 * time.Millisecond),
+		OnTimeout: spec.OnTimeoutPolicy_SKIP,
+	}, transTypes.Preprocess, 0)
+	assert.NoError(t, err)
+
+	err = op.Execute(context.Background(), env)
+	assert.NoError(t, err)
+
+	select {
+	case <-lateOp.written:
+	case <-time.After(time.Second):
+		t.Fatal("the context of the operation is not cancelled after its deadline")
+	}
+
+	_, exist := env.symbolRegistry["late_output"]
+	assert.False(t, exist)
+	assert.Nil(t, env.Output())
+	assert.True(t, env.isSkipped(op))
+}
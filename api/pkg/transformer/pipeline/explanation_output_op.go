@@ -0,0 +1,209 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
+	"google.golang.org/protobuf/proto"
+
+	mErrors "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/transformer/spec"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+	"github.com/caraml-dev/merlin/pkg/transformer/types/converter"
+	"github.com/caraml-dev/merlin/pkg/transformer/types/table"
+)
+
+const defaultExplanationOutputName = "explanations"
+
+// FeatureAttribution is attribution of a single feature to a prediction
+type FeatureAttribution struct {
+	Feature     string  `json:"feature"`
+	Attribution float64 `json:"attribution"`
+}
+
+// ExplanationOutputOp adds named explanations of every prediction into the current output
+// attributions returned by the model are zipped with the column order of the feature table sent to the model
+type ExplanationOutputOp struct {
+	outputSpec       *spec.ExplanationOutput
+	featureTableName string
+	outputName       string
+	*OperationTracing
+}
+
+// NewExplanationOutputOp creates explanation output operation
+func NewExplanationOutputOp(outputSpec *spec.ExplanationOutput, featureTableName string, tracingEnabled bool) *ExplanationOutputOp {
+	outputName := outputSpec.OutputName
+	if outputName == "" {
+		outputName = defaultExplanationOutputName
+	}
+
+	output := &ExplanationOutputOp{
+		outputSpec:       outputSpec,
+		featureTableName: featureTableName,
+		outputName:       outputName,
+	}
+	if tracingEnabled {
+		output.OperationTracing = NewOperationTracing(outputSpec, types.ExplanationOutputOp)
+	}
+	return output
+}
+
+// Execute explanation output operation
+func (e *ExplanationOutputOp) Execute(ctx context.Context, env *Environment) error {
+	_, span := tracer.Start(ctx, "pipeline.ExplanationOutputOp")
+	defer span.End()
+
+	featureTable, err := getTable(env, e.featureTableName)
+	if err != nil {
+		return err
+	}
+	featureNames := make([]string, 0, len(featureTable.ColumnNames()))
+	for _, column := range featureTable.ColumnNames() {
+		if column == table.RowIDColumn {
+			continue
+		}
+		featureNames = append(featureNames, column)
+	}
+
+	attributions, err := e.getAttributions(env)
+	if err != nil {
+		return err
+	}
+
+	explanations := make([][]FeatureAttribution, len(attributions))
+	for i, rowAttributions := range attributions {
+		if len(rowAttributions) != len(featureNames) {
+			return mErrors.NewInvalidInputErrorf("number of attributions (%d) of prediction %d does not match with number of features (%d) in table %s", len(rowAttributions), i, len(featureNames), e.featureTableName)
+		}
+		explanations[i] = topKAttributions(featureNames, rowAttributions, int(e.outputSpec.TopK))
+	}
+
+	output, err := e.setExplanations(env.Output(), explanations)
+	if err != nil {
+		return err
+	}
+	env.SetOutput(output)
+
+	if e.OperationTracing != nil {
+		return e.AddInputOutput(nil, map[string]interface{}{e.outputName: explanations})
+	}
+	return nil
+}
+
+// getAttributions returns attributions of every prediction either from the model response or from a table
+func (e *ExplanationOutputOp) getAttributions(env *Environment) ([][]float64, error) {
+	if e.outputSpec.AttributionTableName != "" {
+		tbl, err := getTable(env, e.outputSpec.AttributionTableName)
+		if err != nil {
+			return nil, err
+		}
+
+		columns := tbl.ColumnsExcluding([]string{table.RowIDColumn})
+		attributions := make([][]float64, tbl.NRow())
+		for row := 0; row < tbl.NRow(); row++ {
+			attributions[row] = make([]float64, len(columns))
+			for col, column := range columns {
+				val, err := converter.ToFloat64(column.Get(row))
+				if err != nil {
+					return nil, mErrors.NewInvalidInputErrorf("invalid attribution in column %s of table %s: %v", column.Series().Name, e.outputSpec.AttributionTableName, err)
+				}
+				attributions[row][col] = val
+			}
+		}
+		return attributions, nil
+	}
+
+	val, err := evalJSONPath(env, e.outputSpec.AttributionJsonPath)
+	if err != nil {
+		return nil, err
+	}
+	rows, ok := val.([]interface{})
+	if !ok {
+		return nil, mErrors.NewInvalidInputErrorf("attributions in %s must be an array, got %T", e.outputSpec.AttributionJsonPath, val)
+	}
+
+	// single array of numbers is attributions of a single prediction
+	if len(rows) > 0 {
+		if _, nested := rows[0].([]interface{}); !nested {
+			rows = []interface{}{rows}
+		}
+	}
+
+	attributions := make([][]float64, len(rows))
+	for i, row := range rows {
+		values, ok := row.([]interface{})
+		if !ok {
+			return nil, mErrors.NewInvalidInputErrorf("attributions of prediction %d must be an array, got %T", i, row)
+		}
+		attributions[i] = make([]float64, len(values))
+		for j, v := range values {
+			attribution, err := converter.ToFloat64(v)
+			if err != nil {
+				return nil, mErrors.NewInvalidInputErrorf("invalid attribution of prediction %d: %v", i, err)
+			}
+			attributions[i][j] = attribution
+		}
+	}
+	return attributions, nil
+}
+
+// setExplanations adds the explanations into HTTP_JSON response field or UPI_V1 prediction result table column
+func (e *ExplanationOutputOp) setExplanations(output types.Payload, explanations [][]FeatureAttribution) (types.Payload, error) {
+	switch out := output.(type) {
+	case types.BytePayload:
+		obj, err := out.AsInput()
+		if err != nil {
+			return nil, mErrors.NewInvalidInputErrorf("unable to add explanations into non JSON object response: %v", err)
+		}
+		return e.setExplanations(obj, explanations)
+	case types.JSONObject:
+		result := make(types.JSONObject, len(out)+1)
+		for k, v := range out {
+			result[k] = v
+		}
+		result[e.outputName] = explanations
+		return result, nil
+	case *types.UPIPredictionResponse:
+		response := proto.Clone((*upiv1.PredictValuesResponse)(out)).(*upiv1.PredictValuesResponse)
+		resultTable := response.PredictionResultTable
+		if resultTable == nil {
+			return nil, fmt.Errorf("prediction result table is not set in the response")
+		}
+		if len(resultTable.Rows) != len(explanations) {
+			return nil, mErrors.NewInvalidInputErrorf("number of explanations (%d) does not match with number of rows (%d) in prediction result table", len(explanations), len(resultTable.Rows))
+		}
+
+		resultTable.Columns = append(resultTable.Columns, &upiv1.Column{Name: e.outputName, Type: upiv1.Type_TYPE_STRING})
+		for i, row := range resultTable.Rows {
+			explanation, err := json.Marshal(explanations[i])
+			if err != nil {
+				return nil, err
+			}
+			row.Values = append(row.Values, &upiv1.Value{StringValue: string(explanation)})
+		}
+		return (*types.UPIPredictionResponse)(response), nil
+	default:
+		return nil, fmt.Errorf("unsupported output type for explanation output: %T", output)
+	}
+}
+
+// topKAttributions returns k features with the highest absolute attribution, all features are returned if k is not positive
+func topKAttributions(featureNames []string, attributions []float64, k int) []FeatureAttribution {
+	result := make([]FeatureAttribution, len(featureNames))
+	for i, name := range featureNames {
+		result[i] = FeatureAttribution{Feature: name, Attribution: attributions[i]}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return math.Abs(result[i].Attribution) > math.Abs(result[j].Attribution)
+	})
+
+	if k > 0 && k < len(result) {
+		result = result[:k]
+	}
+	return result
+}
@@ -0,0 +1,223 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/jsonpath"
+	"github.com/caraml-dev/merlin/pkg/transformer/spec"
+	"github.com/caraml-dev/merlin/pkg/transformer/symbol"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+	"github.com/caraml-dev/merlin/pkg/transformer/types/series"
+	"github.com/caraml-dev/merlin/pkg/transformer/types/table"
+)
+
+func TestExplanationOutputOp_Execute_HTTP(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	featureTable := table.New(
+		series.New([]any{1.1, 2.2}, series.Float, "distance"),
+		series.New([]any{1, 2}, series.Int, "order_count"),
+		series.New([]any{"a", "b"}, series.String, "area"),
+	)
+
+	tests := []struct {
+		name          string
+		modelResponse types.JSONObject
+		output        types.Payload
+		outputSpec    *spec.ExplanationOutput
+		want          types.JSONObject
+		wantErr       string
+	}{
+		{
+			name: "attributions of multiple predictions",
+			modelResponse: types.JSONObject{
+				"predictions": []interface{}{0.1, 0.9},
+				"shap_values": []interface{}{
+					[]interface{}{0.1, -0.5, 0.2},
+					[]interface{}{0.3, 0.05, -0.1},
+				},
+			},
+			outputSpec: &spec.ExplanationOutput{
+				FeatureTableName:    "feature_table",
+				AttributionJsonPath: "$.model_response.shap_values",
+			},
+			want: types.JSONObject{
+				"predictions": []interface{}{0.1, 0.9},
+				"shap_values": []interface{}{
+					[]interface{}{0.1, -0.5, 0.2},
+					[]interface{}{0.3, 0.05, -0.1},
+				},
+				"explanations": [][]FeatureAttribution{
+					{
+						{Feature: "order_count", Attribution: -0.5},
+						{Feature: "area", Attribution: 0.2},
+						{Feature: "distance", Attribution: 0.1},
+					},
+					{
+						{Feature: "distance", Attribution: 0.3},
+						{Feature: "area", Attribution: -0.1},
+						{Feature: "order_count", Attribution: 0.05},
+					},
+				},
+			},
+		},
+		{
+			name: "attributions of single prediction with top k",
+			modelResponse: types.JSONObject{
+				"shap_values": []interface{}{0.1, -0.5, 0.2},
+			},
+			outputSpec: &spec.ExplanationOutput{
+				FeatureTableName:    "feature_table",
+				AttributionJsonPath: "$.model_response.shap_values",
+				TopK:                2,
+				OutputName:          "top_features",
+			},
+			want: types.JSONObject{
+				"shap_values": []interface{}{0.1, -0.5, 0.2},
+				"top_features": [][]FeatureAttribution{
+					{
+						{Feature: "order_count", Attribution: -0.5},
+						{Feature: "area", Attribution: 0.2},
+					},
+				},
+			},
+		},
+		{
+			name: "output is raw JSON response",
+			modelResponse: types.JSONObject{
+				"shap_values": []interface{}{0.1, -0.5, 0.2},
+			},
+			output: types.BytePayload(`{"predictions": [0.1]}`),
+			outputSpec: &spec.ExplanationOutput{
+				FeatureTableName:    "feature_table",
+				AttributionJsonPath: "$.model_response.shap_values",
+				TopK:                1,
+			},
+			want: types.JSONObject{
+				"predictions": []interface{}{0.1},
+				"explanations": [][]FeatureAttribution{
+					{
+						{Feature: "order_count", Attribution: -0.5},
+					},
+				},
+			},
+		},
+		{
+			name: "number of attributions doesn't match number of features",
+			modelResponse: types.JSONObject{
+				"shap_values": []interface{}{0.1, -0.5},
+			},
+			outputSpec: &spec.ExplanationOutput{
+				FeatureTableName:    "feature_table",
+				AttributionJsonPath: "$.model_response.shap_values",
+			},
+			wantErr: "invalid input: number of attributions (2) of prediction 0 does not match with number of features (3) in table feature_table",
+		},
+		{
+			name: "attributions is not an array",
+			modelResponse: types.JSONObject{
+				"shap_values": "0.1",
+			},
+			outputSpec: &spec.ExplanationOutput{
+				FeatureTableName:    "feature_table",
+				AttributionJsonPath: "$.model_response.shap_values",
+			},
+			wantErr: "invalid input: attributions in $.model_response.shap_values must be an array, got string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiledJsonPath := jsonpath.NewStorage()
+			compiledJsonPath.Set(tt.outputSpec.AttributionJsonPath, jsonpath.MustCompileJsonPath(tt.outputSpec.AttributionJsonPath))
+			env := &Environment{
+				symbolRegistry:   symbol.NewRegistryWithCompiledJSONPath(compiledJsonPath),
+				compiledPipeline: &CompiledPipeline{compiledJsonpath: compiledJsonPath},
+				logger:           logger,
+			}
+			env.SetSymbol("feature_table", featureTable)
+			env.symbolRegistry.SetModelResponse(tt.modelResponse)
+			env.SetOutput(tt.modelResponse)
+			if tt.output != nil {
+				env.SetOutput(tt.output)
+			}
+
+			op := NewExplanationOutputOp(tt.outputSpec, tt.outputSpec.FeatureTableName, false)
+			err := op.Execute(context.Background(), env)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, env.Output())
+		})
+	}
+}
+
+func TestExplanationOutputOp_Execute_UPI(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	featureTable := table.New(
+		series.New([]any{1.1, 2.2}, series.Float, "distance"),
+		series.New([]any{1, 2}, series.Int, "order_count"),
+		series.New([]any{"row1", "row2"}, series.String, table.RowIDColumn),
+	)
+	attributionTable := table.New(
+		series.New([]any{0.4, -0.1}, series.Float, "shap_0"),
+		series.New([]any{-0.6, 0.3}, series.Float, "shap_1"),
+		series.New([]any{"row1", "row2"}, series.String, table.RowIDColumn),
+	)
+	response := &upiv1.PredictValuesResponse{
+		PredictionResultTable: &upiv1.Table{
+			Name:    "prediction_result",
+			Columns: []*upiv1.Column{{Name: "probability", Type: upiv1.Type_TYPE_DOUBLE}},
+			Rows: []*upiv1.Row{
+				{RowId: "row1", Values: []*upiv1.Value{{DoubleValue: 0.2}}},
+				{RowId: "row2", Values: []*upiv1.Value{{DoubleValue: 0.8}}},
+			},
+		},
+	}
+	originalResponse := proto.Clone(response)
+
+	env := &Environment{
+		symbolRegistry:   symbol.NewRegistry(),
+		compiledPipeline: &CompiledPipeline{compiledJsonpath: jsonpath.NewStorage()},
+		logger:           logger,
+	}
+	env.SetSymbol("feature_table", featureTable)
+	env.SetSymbol("attribution_table", attributionTable)
+	env.symbolRegistry.SetModelResponse((*types.UPIPredictionResponse)(response))
+	env.SetOutput((*types.UPIPredictionResponse)(response))
+
+	op := NewExplanationOutputOp(&spec.ExplanationOutput{
+		AttributionTableName: "attribution_table",
+		TopK:                 1,
+	}, "feature_table", false)
+	err := op.Execute(context.Background(), env)
+	require.NoError(t, err)
+
+	want := &upiv1.PredictValuesResponse{
+		PredictionResultTable: &upiv1.Table{
+			Name: "prediction_result",
+			Columns: []*upiv1.Column{
+				{Name: "probability", Type: upiv1.Type_TYPE_DOUBLE},
+				{Name: "explanations", Type: upiv1.Type_TYPE_STRING},
+			},
+			Rows: []*upiv1.Row{
+				{RowId: "row1", Values: []*upiv1.Value{{DoubleValue: 0.2}, {StringValue: `[{"feature":"order_count","attribution":-0.6}]`}}},
+				{RowId: "row2", Values: []*upiv1.Value{{DoubleValue: 0.8}, {StringValue: `[{"feature":"order_count","attribution":0.3}]`}}},
+			},
+		},
+	}
+	output, ok := env.Output().(*types.UPIPredictionResponse)
+	require.True(t, ok)
+	assert.True(t, proto.Equal(want, (*upiv1.PredictValuesResponse)(output)))
+	// model response must not be modified
+	assert.True(t, proto.Equal(originalResponse, response))
+}
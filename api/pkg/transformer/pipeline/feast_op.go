@@ -46,6 +46,23 @@ func (op *FeastOp) Execute(ctx context.Context, env *Environment) error {
 		return err
 	}
 
+	return op.setFeatureTables(env, featureTables)
+}
+
+// SetDefaultOutput populates the feature tables with the default value of each feature without calling feast
+func (op *FeastOp) SetDefaultOutput(ctx context.Context, env *Environment) error {
+	ctx, span := tracer.Start(ctx, "pipeline.FeastOp.SetDefaultOutput")
+	defer span.End()
+
+	featureTables, err := op.feastRetriever.DefaultFeatureOfEntityInSymbolRegistry(ctx, env.SymbolRegistry())
+	if err != nil {
+		return err
+	}
+
+	return op.setFeatureTables(env, featureTables)
+}
+
+func (op *FeastOp) setFeatureTables(env *Environment, featureTables []*types.FeatureTable) error {
 	for _, featureTable := range featureTables {
 		tbl, err := featureTable.AsTable()
 		if err != nil {
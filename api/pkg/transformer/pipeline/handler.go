@@ -69,9 +69,17 @@ func (h *Handler) PredictionLogHandler(ctx context.Context, result *types.Predic
 
 func (h *Handler) EmbedEnvironment(ctx context.Context) context.Context {
 	env := NewEnvironment(h.compiledPipeline, h.logger)
+	if IsDebugMode(ctx) {
+		env.enableDebugTrace()
+	}
 	return context.WithValue(ctx, PipelineEnvironmentContext, env) //nolint: staticcheck
 }
 
+// DebugTrace returns the operations executed while serving the request, it returns nil if debug mode is not enabled for the request
+func (h *Handler) DebugTrace(ctx context.Context) *types.DebugTrace {
+	return getEnvironment(ctx).DebugTrace()
+}
+
 func getEnvironment(ctx context.Context) *Environment {
 	return ctx.Value(PipelineEnvironmentContext).(*Environment)
 }
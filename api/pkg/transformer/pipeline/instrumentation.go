@@ -0,0 +1,27 @@
+package pipeline
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/caraml-dev/merlin/pkg/transformer"
+)
+
+const (
+	cacheHit    = "hit"
+	cacheMiss   = "miss"
+	cacheBypass = "bypass"
+)
+
+var (
+	operationTimeout = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: transformer.PromNamespace,
+		Name:      "operation_timeout_count",
+		Help:      "The total number of operation exceeding its deadline, labeled by the applied on timeout policy",
+	}, []string{"pipeline", "step", "operation", "policy"})
+	predictionCacheCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: transformer.PromNamespace,
+		Name:      "prediction_cache_count",
+		Help:      "The total number of prediction cache lookup, labeled by the result (hit, miss or bypass)",
+	}, []string{"result"})
+)
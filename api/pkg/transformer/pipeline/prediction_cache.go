@@ -0,0 +1,235 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	prt "github.com/caraml-dev/merlin/pkg/protocol"
+	"github.com/caraml-dev/merlin/pkg/transformer/cache"
+	"github.com/caraml-dev/merlin/pkg/transformer/jsonpath"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+)
+
+// PredictionCacheBypassHeader is request header to skip lookup of cached prediction, the fresh response is still cached
+const PredictionCacheBypassHeader = "X-Merlin-Cache-Bypass"
+
+const (
+	defaultPredictionCacheSizeInMB = 100
+	predictionCacheKeyPrefix       = "prediction:"
+)
+
+// PredictionCacheOptions configures cache of the final postprocessed response
+// requests with the same value of all key fields are served from the cache, skipping Feast and the model
+type PredictionCacheOptions struct {
+	// KeyJSONPaths is list of jsonpath of request fields used as cache key, e.g. $.customer.id
+	KeyJSONPaths []string
+	// KeyVariables is list of prediction_context variable names used as cache key, only applicable for UPI_V1 protocol
+	KeyVariables []string
+	// TTL is duration of cached response, minimum is 1 second
+	TTL time.Duration
+	// SizeInMB is maximum size of the cache, default to 100MB
+	SizeInMB int
+}
+
+// PredictionCache stores the final postprocessed response of a request keyed by the value of the configured request fields
+type PredictionCache struct {
+	cache        cache.Cache
+	ttl          time.Duration
+	protocol     prt.Protocol
+	keyJSONPaths []string
+	compiledKeys map[string]*jsonpath.Compiled
+	keyVariables []string
+}
+
+// NewPredictionCache creates prediction cache of the given protocol, returns error if the options are invalid
+func NewPredictionCache(opts PredictionCacheOptions, protocol prt.Protocol) (*PredictionCache, error) {
+	if len(opts.KeyJSONPaths) == 0 && len(opts.KeyVariables) == 0 {
+		return nil, fmt.Errorf("prediction cache requires at least one key jsonpath or variable")
+	}
+	if len(opts.KeyVariables) > 0 && protocol != prt.UpiV1 {
+		return nil, fmt.Errorf("prediction cache key variables are only supported for %s protocol", prt.UpiV1)
+	}
+	if opts.TTL < time.Second {
+		return nil, fmt.Errorf("prediction cache TTL must be at least 1s, got %s", opts.TTL)
+	}
+
+	srcType := jsonpath.Map
+	if protocol == prt.UpiV1 {
+		srcType = jsonpath.Proto
+	}
+	compiledKeys := make(map[string]*jsonpath.Compiled, len(opts.KeyJSONPaths))
+	for _, keyJSONPath := range opts.KeyJSONPaths {
+		compiled, err := jsonpath.CompileWithOption(jsonpath.JsonPathOption{
+			JsonPath: keyJSONPath,
+			SrcType:  srcType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid prediction cache key %s: %w", keyJSONPath, err)
+		}
+		compiledKeys[keyJSONPath] = compiled
+	}
+
+	sizeInMB := opts.SizeInMB
+	if sizeInMB <= 0 {
+		sizeInMB = defaultPredictionCacheSizeInMB
+	}
+
+	return &PredictionCache{
+		cache:        cache.NewInMemoryCache(sizeInMB),
+		ttl:          opts.TTL,
+		protocol:     protocol,
+		keyJSONPaths: opts.KeyJSONPaths,
+		compiledKeys: compiledKeys,
+		keyVariables: opts.KeyVariables,
+	}, nil
+}
+
+// ErrPredictionCacheKeyNotFound is returned when the request doesn't have the value of a key field, such request is not
+// cached since it would otherwise share the cached response of all the other requests missing the same field
+var ErrPredictionCacheKeyNotFound = errors.New("prediction cache key is not found in the request")
+
+// Key computes cache key of the request from the value of the key fields
+// values are serialized as JSON thus the key doesn't depend on the field order of the request
+func (pc *PredictionCache) Key(request types.Payload) ([]byte, error) {
+	if rawRequest, ok := request.(types.BytePayload); ok {
+		input, err := rawRequest.AsInput()
+		if err != nil {
+			return nil, err
+		}
+		request = input
+	}
+
+	keyValues := make(map[string]interface{}, len(pc.keyJSONPaths)+len(pc.keyVariables))
+	for _, keyJSONPath := range pc.keyJSONPaths {
+		val, err := pc.compiledKeys[keyJSONPath].Lookup(request)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, fmt.Errorf("%w: %s", ErrPredictionCacheKeyNotFound, keyJSONPath)
+		}
+		keyValues[keyJSONPath] = val
+	}
+
+	if len(pc.keyVariables) > 0 {
+		upiRequest, ok := request.(*types.UPIPredictionRequest)
+		if !ok {
+			return nil, fmt.Errorf("unexpected request type %T", request)
+		}
+		for _, name := range pc.keyVariables {
+			val := findVariableValue(upiRequest.PredictionContext, name)
+			if val == nil {
+				return nil, fmt.Errorf("%w: variable %s", ErrPredictionCacheKeyNotFound, name)
+			}
+			keyValues["variable:"+name] = val
+		}
+	}
+
+	keyBytes, err := json.Marshal(keyValues)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(keyBytes)
+	return append([]byte(predictionCacheKeyPrefix), hash[:]...), nil
+}
+
+func findVariableValue(variables []*upiv1.Variable, name string) interface{} {
+	for _, variable := range variables {
+		if variable.Name != name {
+			continue
+		}
+		switch variable.Type {
+		case upiv1.Type_TYPE_INTEGER:
+			return variable.IntegerValue
+		case upiv1.Type_TYPE_DOUBLE:
+			return variable.DoubleValue
+		default:
+			return variable.StringValue
+		}
+	}
+	return nil
+}
+
+// Fetch returns the cached response of the key, if any
+// lookup is skipped when the request headers ask to bypass the cache
+func (pc *PredictionCache) Fetch(key []byte, requestHeaders map[string]string) (types.Payload, bool) {
+	if isPredictionCacheBypassed(requestHeaders) {
+		predictionCacheCount.WithLabelValues(cacheBypass).Inc()
+		return nil, false
+	}
+
+	val, err := pc.cache.Fetch(key)
+	if err != nil {
+		predictionCacheCount.WithLabelValues(cacheMiss).Inc()
+		return nil, false
+	}
+
+	response, err := pc.decode(val)
+	if err != nil {
+		predictionCacheCount.WithLabelValues(cacheMiss).Inc()
+		return nil, false
+	}
+	predictionCacheCount.WithLabelValues(cacheHit).Inc()
+	return response, true
+}
+
+// Insert stores the response with the configured TTL
+func (pc *PredictionCache) Insert(key []byte, response types.Payload) error {
+	val, err := pc.encode(response)
+	if err != nil {
+		return err
+	}
+	return pc.cache.Insert(key, val, pc.ttl)
+}
+
+func (pc *PredictionCache) encode(response types.Payload) ([]byte, error) {
+	switch resp := response.(type) {
+	case types.JSONObject:
+		return json.Marshal(resp)
+	case types.BytePayload:
+		if !json.Valid(resp) {
+			return nil, fmt.Errorf("response is not a valid JSON")
+		}
+		return resp, nil
+	case *types.UPIPredictionResponse:
+		return protojson.Marshal((*upiv1.PredictValuesResponse)(resp))
+	default:
+		return nil, fmt.Errorf("unsupported response type for prediction cache %T", response)
+	}
+}
+
+func (pc *PredictionCache) decode(val []byte) (types.Payload, error) {
+	if pc.protocol == prt.UpiV1 {
+		var resp upiv1.PredictValuesResponse
+		if err := protojson.Unmarshal(val, &resp); err != nil {
+			return nil, err
+		}
+		return (*types.UPIPredictionResponse)(&resp), nil
+	}
+
+	var resp types.JSONObject
+	if err := json.Unmarshal(val, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// isPredictionCacheBypassed checks whether the request asks to skip the cached prediction
+func isPredictionCacheBypassed(requestHeaders map[string]string) bool {
+	for k, v := range requestHeaders {
+		if !strings.EqualFold(k, PredictionCacheBypassHeader) {
+			continue
+		}
+		bypass, err := strconv.ParseBool(v)
+		return err == nil && bypass
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	upiv1 "github.com/caraml-dev/universal-prediction-interface/gen/go/grpc/caraml/upi/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/merlin/pkg/protocol"
+	"github.com/caraml-dev/merlin/pkg/transformer/types"
+)
+
+func TestNewPredictionCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     PredictionCacheOptions
+		protocol protocol.Protocol
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			opts:     PredictionCacheOptions{KeyJSONPaths: []string{"$.customer.id"}, TTL: time.Minute},
+			protocol: protocol.HttpJson,
+		},
+		{
+			name:     "no key",
+			opts:     PredictionCacheOptions{TTL: time.Minute},
+			protocol: protocol.HttpJson,
+			wantErr:  "prediction cache requires at least one key jsonpath or variable",
+		},
+		{
+			name:     "key variables for http json",
+			opts:     PredictionCacheOptions{KeyVariables: []string{"customer_id"}, TTL: time.Minute},
+			protocol: protocol.HttpJson,
+			wantErr:  "prediction cache key variables are only supported for UPI_V1 protocol",
+		},
+		{
+			name:     "ttl less than a second",
+			opts:     PredictionCacheOptions{KeyJSONPaths: []string{"$.customer.id"}, TTL: 100 * time.Millisecond},
+			protocol: protocol.HttpJson,
+			wantErr:  "prediction cache TTL must be at least 1s, got 100ms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPredictionCache(tt.opts, tt.protocol)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPredictionCache_UPI(t *testing.T) {
+	pc, err := NewPredictionCache(PredictionCacheOptions{
+		KeyVariables: []string{"customer_id"},
+		TTL:          time.Minute,
+		SizeInMB:     1,
+	}, protocol.UpiV1)
+	require.NoError(t, err)
+
+	newRequest := func(customerID int64, country string) *types.UPIPredictionRequest {
+		return &types.UPIPredictionRequest{
+			PredictionContext: []*upiv1.Variable{
+				{Name: "customer_id", Type: upiv1.Type_TYPE_INTEGER, IntegerValue: customerID},
+				{Name: "country", Type: upiv1.Type_TYPE_STRING, StringValue: country},
+			},
+		}
+	}
+
+	key1, err := pc.Key(newRequest(1, "ID"))
+	require.NoError(t, err)
+	key2, err := pc.Key(newRequest(1, "SG"))
+	require.NoError(t, err)
+	key3, err := pc.Key(newRequest(2, "ID"))
+	require.NoError(t, err)
+	assert.Equal(t, key1, key2)
+	assert.NotEqual(t, key1, key3)
+
+	_, found := pc.Fetch(key1, nil)
+	assert.False(t, found)
+
+	response := &types.UPIPredictionResponse{
+		PredictionResultTable: &upiv1.Table{
+			Name:    "result",
+			Columns: []*upiv1.Column{{Name: "score", Type: upiv1.Type_TYPE_DOUBLE}},
+			Rows:    []*upiv1.Row{{RowId: "1", Values: []*upiv1.Value{{DoubleValue: 0.5}}}},
+		},
+	}
+	require.NoError(t, pc.Insert(key1, response))
+
+	_, found = pc.Fetch(key1, map[string]string{"X-Merlin-Cache-Bypass": "true"})
+	assert.False(t, found)
+
+	cached, found := pc.Fetch(key1, nil)
+	require.True(t, found)
+	cachedResponse, ok := cached.(*types.UPIPredictionResponse)
+	require.True(t, ok)
+	assert.Equal(t, "score", cachedResponse.PredictionResultTable.Columns[0].Name)
+	assert.Equal(t, 0.5, cachedResponse.PredictionResultTable.Rows[0].Values[0].DoubleValue)
+}
+
+func TestPredictionCache_MissingKey(t *testing.T) {
+	pc, err := NewPredictionCache(PredictionCacheOptions{
+		KeyJSONPaths: []string{"$.customer.id"},
+		TTL:          time.Minute,
+		SizeInMB:     1,
+	}, protocol.HttpJson)
+	require.NoError(t, err)
+
+	key, err := pc.Key(types.BytePayload(`{"customer":{"id":1}}`))
+	require.NoError(t, err)
+	require.NoError(t, pc.Insert(key, types.JSONObject{"score": 0.5}))
+
+	// requests without the key don't share any cached response
+	for _, request := range []string{`{"customer":{"name":"foo"}}`, `{"customer":{"id":null}}`, `{}`} {
+		_, err := pc.Key(types.BytePayload(request))
+		assert.Error(t, err, request)
+	}
+
+	upiCache, err := NewPredictionCache(PredictionCacheOptions{
+		KeyVariables: []string{"customer_id"},
+		TTL:          time.Minute,
+		SizeInMB:     1,
+	}, protocol.UpiV1)
+	require.NoError(t, err)
+
+	_, err = upiCache.Key(&types.UPIPredictionRequest{
+		PredictionContext: []*upiv1.Variable{{Name: "country", Type: upiv1.Type_TYPE_STRING, StringValue: "ID"}},
+	})
+	assert.ErrorIs(t, err, ErrPredictionCacheKeyNotFound)
+}
@@ -87,6 +87,11 @@ func httpTransformerValidation(config *spec.StandardTransformerConfig) error {
 		if err := validationFn(preprocess); err != nil {
 			return err
 		}
+		for _, output := range preprocess.Outputs {
+			if output.ExplanationOutput != nil {
+				return fmt.Errorf("explanationOutput is not supported in preprocess step")
+			}
+		}
 	}
 	if postprocess := config.TransformerConfig.Postprocess; postprocess != nil {
 		if err := validationFn(postprocess); err != nil {
@@ -109,6 +114,9 @@ func upiTransformerValidation(spec *spec.StandardTransformerConfig) error {
 			if output.UpiPostprocessOutput != nil {
 				return fmt.Errorf("UPIPostprocessOutput is not supported in preprocess step")
 			}
+			if output.ExplanationOutput != nil {
+				return fmt.Errorf("explanationOutput is not supported in preprocess step")
+			}
 		}
 	}
 	if postprocess := spec.TransformerConfig.Postprocess; postprocess != nil {
@@ -119,6 +127,9 @@ func upiTransformerValidation(spec *spec.StandardTransformerConfig) error {
 			if output.UpiPreprocessOutput != nil {
 				return fmt.Errorf("UPIPreprocessOutput is not supported in postprocess step")
 			}
+			if output.ExplanationOutput != nil && output.ExplanationOutput.AttributionJsonPath != "" {
+				return fmt.Errorf("attributionJsonPath of explanationOutput is not supported for upi_v1 protocol, use attributionTableName instead")
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,70 @@
+package admission
+
+import (
+	"errors"
+	"time"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/server/config"
+)
+
+var (
+	// ErrRateLimited is returned when the client exceeds its request rate
+	ErrRateLimited = errors.New("too many requests from client")
+	// ErrOverloaded is returned when the server has reached its concurrency limit
+	ErrOverloaded = errors.New("server is overloaded")
+)
+
+const (
+	rateLimitedReason = "rate_limited"
+	overloadedReason  = "overloaded"
+)
+
+// Controller decides whether incoming request is admitted
+// request is rejected early if its client exceeds the rate limit or the server reaches its adaptive concurrency limit
+type Controller struct {
+	rateLimiter        *clientRateLimiter
+	concurrencyLimiter *concurrencyLimiter
+}
+
+// NewController creates admission controller from the server options, it returns nil if both rate limiting and load shedding are disabled
+func NewController(opts *config.Options) *Controller {
+	if !opts.RateLimitEnabled && !opts.LoadSheddingEnabled {
+		return nil
+	}
+
+	controller := &Controller{}
+	if opts.RateLimitEnabled {
+		controller.rateLimiter = newClientRateLimiter(opts.RateLimitRequestsPerSecond, opts.RateLimitBurst)
+	}
+	if opts.LoadSheddingEnabled {
+		controller.concurrencyLimiter = newConcurrencyLimiter(
+			opts.LoadSheddingInitialConcurrency,
+			opts.LoadSheddingMinConcurrency,
+			opts.LoadSheddingMaxConcurrency,
+			opts.LoadSheddingTargetLatency,
+		)
+	}
+	return controller
+}
+
+// Admit checks whether request of the client is allowed to be processed
+// the returned release function must be called once the request is completed
+func (c *Controller) Admit(clientID string) (release func(), err error) {
+	if c.rateLimiter != nil && !c.rateLimiter.allow(clientID) {
+		rejectedRequestCount.WithLabelValues(rateLimitedReason).Inc()
+		return nil, ErrRateLimited
+	}
+
+	if c.concurrencyLimiter == nil {
+		return func() {}, nil
+	}
+
+	if !c.concurrencyLimiter.acquire() {
+		rejectedRequestCount.WithLabelValues(overloadedReason).Inc()
+		return nil, ErrOverloaded
+	}
+	startTime := time.Now()
+	return func() {
+		c.concurrencyLimiter.release(time.Since(startTime))
+	}, nil
+}
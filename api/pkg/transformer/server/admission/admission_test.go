@@ -0,0 +1,131 @@
+package admission
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/server/config"
+)
+
+func TestNewController(t *testing.T) {
+	assert.Nil(t, NewController(&config.Options{}))
+	assert.NotNil(t, NewController(&config.Options{RateLimitEnabled: true}))
+	assert.NotNil(t, NewController(&config.Options{LoadSheddingEnabled: true}))
+}
+
+func TestController_Admit_RateLimit(t *testing.T) {
+	controller := NewController(&config.Options{
+		RateLimitEnabled:           true,
+		RateLimitRequestsPerSecond: 0.001,
+		RateLimitBurst:             2,
+	})
+
+	for i := 0; i < 2; i++ {
+		release, err := controller.Admit("client-a")
+		require.NoError(t, err)
+		release()
+	}
+
+	_, err := controller.Admit("client-a")
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	// other client has its own bucket
+	release, err := controller.Admit("client-b")
+	require.NoError(t, err)
+	release()
+}
+
+func TestClientRateLimiter_EvictLeastRecentlySeenClient(t *testing.T) {
+	limiter := newClientRateLimiter(0.001, 1)
+	limiter.maxClients = 2
+
+	assert.True(t, limiter.allow("client-a"))
+	assert.True(t, limiter.allow("client-b"))
+	// client-a becomes the most recently seen client
+	assert.False(t, limiter.allow("client-a"))
+
+	// client-b is evicted to track client-c, hence it starts again with a full bucket
+	assert.True(t, limiter.allow("client-c"))
+	assert.Len(t, limiter.clients, 2)
+	assert.Equal(t, 2, limiter.recency.Len())
+	assert.False(t, limiter.allow("client-a"))
+	assert.True(t, limiter.allow("client-b"))
+	assert.Len(t, limiter.clients, 2)
+}
+
+func TestController_Admit_LoadShedding(t *testing.T) {
+	controller := NewController(&config.Options{
+		LoadSheddingEnabled:            true,
+		LoadSheddingInitialConcurrency: 1,
+		LoadSheddingMinConcurrency:     1,
+		LoadSheddingMaxConcurrency:     10,
+		LoadSheddingTargetLatency:      time.Second,
+	})
+
+	release, err := controller.Admit("client-a")
+	require.NoError(t, err)
+
+	_, err = controller.Admit("client-b")
+	assert.ErrorIs(t, err, ErrOverloaded)
+
+	release()
+	release, err = controller.Admit("client-b")
+	require.NoError(t, err)
+	release()
+}
+
+func TestConcurrencyLimiter_Release(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		latency   time.Duration
+		wantLimit float64
+	}{
+		{
+			name:      "latency within target increases limit",
+			limit:     10,
+			latency:   10 * time.Millisecond,
+			wantLimit: 10.1,
+		},
+		{
+			name:      "latency exceeding target decreases limit",
+			limit:     10,
+			latency:   200 * time.Millisecond,
+			wantLimit: 9,
+		},
+		{
+			name:      "limit doesn't go below minimum",
+			limit:     5,
+			latency:   200 * time.Millisecond,
+			wantLimit: 5,
+		},
+		{
+			name:      "limit doesn't go above maximum",
+			limit:     20,
+			latency:   10 * time.Millisecond,
+			wantLimit: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newConcurrencyLimiter(tt.limit, 5, 20, 100*time.Millisecond)
+			require.True(t, l.acquire())
+			l.release(tt.latency)
+			assert.InDelta(t, tt.wantLimit, l.limit, 0.0001)
+			assert.Equal(t, 0, l.inflight)
+		})
+	}
+}
+
+func TestConcurrencyLimiter_MinimumLimit(t *testing.T) {
+	l := newConcurrencyLimiter(1, 0, 10, 100*time.Millisecond)
+	for i := 0; i < 50; i++ {
+		require.True(t, l.acquire())
+		l.release(200 * time.Millisecond)
+	}
+	assert.InDelta(t, 1, l.limit, 0.0001)
+	assert.True(t, l.acquire())
+}
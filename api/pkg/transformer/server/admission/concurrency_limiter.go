@@ -0,0 +1,69 @@
+package admission
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// multiplicative decrease factor of concurrency limit when the latency exceeds the target
+	backoffRatio = 0.9
+	// lowest concurrency limit, so that the server never rejects every request
+	minConcurrencyLimit = 1
+)
+
+// concurrencyLimiter limits the number of in-flight requests
+// the limit is adjusted using AIMD: it grows by one for every limit number of requests completed within the target latency
+// and it is decreased multiplicatively when a request exceeds the target latency
+type concurrencyLimiter struct {
+	mu            sync.Mutex
+	limit         float64
+	minLimit      float64
+	maxLimit      float64
+	inflight      int
+	targetLatency time.Duration
+}
+
+func newConcurrencyLimiter(initialLimit, minLimit, maxLimit int, targetLatency time.Duration) *concurrencyLimiter {
+	if minLimit < minConcurrencyLimit {
+		minLimit = minConcurrencyLimit
+	}
+	if maxLimit < minLimit {
+		maxLimit = minLimit
+	}
+	l := &concurrencyLimiter{
+		limit:         math.Min(math.Max(float64(initialLimit), float64(minLimit)), float64(maxLimit)),
+		minLimit:      float64(minLimit),
+		maxLimit:      float64(maxLimit),
+		targetLatency: targetLatency,
+	}
+	concurrencyLimit.Set(l.limit)
+	return l
+}
+
+func (l *concurrencyLimiter) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inflight >= int(l.limit) {
+		return false
+	}
+	l.inflight++
+	inflightRequests.Set(float64(l.inflight))
+	return true
+}
+
+func (l *concurrencyLimiter) release(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight--
+	if latency > l.targetLatency {
+		l.limit = math.Max(l.minLimit, l.limit*backoffRatio)
+	} else {
+		l.limit = math.Min(l.maxLimit, l.limit+1/l.limit)
+	}
+	inflightRequests.Set(float64(l.inflight))
+	concurrencyLimit.Set(l.limit)
+}
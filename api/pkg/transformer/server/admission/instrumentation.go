@@ -0,0 +1,28 @@
+package admission
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/caraml-dev/merlin/pkg/transformer"
+)
+
+var (
+	rejectedRequestCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: transformer.PromNamespace,
+		Name:      "rejected_request_count",
+		Help:      "The total number of requests rejected by admission control, labeled by the reason (rate_limited or overloaded)",
+	}, []string{"reason"})
+
+	concurrencyLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: transformer.PromNamespace,
+		Name:      "concurrency_limit",
+		Help:      "Current adaptive concurrency limit of the transformer server",
+	})
+
+	inflightRequests = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: transformer.PromNamespace,
+		Name:      "inflight_requests",
+		Help:      "Number of requests being processed by the transformer server",
+	})
+)
@@ -0,0 +1,64 @@
+package admission
+
+import (
+	"container/list"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// maximum number of clients tracked, the least recently seen client is evicted once it's reached
+const maxTrackedClients = 10000
+
+type clientBucket struct {
+	clientID string
+	limiter  *rate.Limiter
+}
+
+// clientRateLimiter is token bucket rate limiter per client identifier
+// buckets are kept in a fixed-size LRU, thus an evicted client starts again with a full bucket
+type clientRateLimiter struct {
+	mu         sync.Mutex
+	limit      rate.Limit
+	burst      int
+	maxClients int
+	clients    map[string]*list.Element
+	// clients ordered from the most to the least recently seen
+	recency *list.List
+}
+
+func newClientRateLimiter(requestsPerSecond float64, burst int) *clientRateLimiter {
+	return &clientRateLimiter{
+		limit:      rate.Limit(requestsPerSecond),
+		burst:      burst,
+		maxClients: maxTrackedClients,
+		clients:    make(map[string]*list.Element),
+		recency:    list.New(),
+	}
+}
+
+func (l *clientRateLimiter) allow(clientID string) bool {
+	l.mu.Lock()
+	bucket := l.bucket(clientID)
+	l.mu.Unlock()
+
+	return bucket.limiter.Allow()
+}
+
+// bucket returns the bucket of the client, marking it as the most recently seen client
+func (l *clientRateLimiter) bucket(clientID string) *clientBucket {
+	if elem, ok := l.clients[clientID]; ok {
+		l.recency.MoveToFront(elem)
+		return elem.Value.(*clientBucket)
+	}
+
+	if l.recency.Len() >= l.maxClients {
+		oldest := l.recency.Back()
+		l.recency.Remove(oldest)
+		delete(l.clients, oldest.Value.(*clientBucket).clientID)
+	}
+
+	bucket := &clientBucket{clientID: clientID, limiter: rate.NewLimiter(l.limit, l.burst)}
+	l.clients[clientID] = l.recency.PushFront(bucket)
+	return bucket
+}
@@ -1,11 +1,23 @@
 package config
 
 import (
+	"crypto/subtle"
+	"fmt"
 	"time"
 
 	"github.com/caraml-dev/merlin/pkg/protocol"
 )
 
+const (
+	// DebugModeHeader is request header (or gRPC metadata) containing the shared secret to enable debug mode of a request
+	DebugModeHeader = "X-Merlin-Debug"
+	// DebugTraceMetadataKey is gRPC trailer metadata containing the pipeline trace of a request in debug mode
+	DebugTraceMetadataKey = "x-merlin-debug-trace"
+	// DebugTraceTruncatedMetadataKey is gRPC trailer metadata containing the original size in bytes of the pipeline
+	// trace, it's only sent when the trace exceeds DebugTraceMaxBytes and is truncated
+	DebugTraceTruncatedMetadataKey = "x-merlin-debug-trace-truncated"
+)
+
 // Option show all configuration for transformer server
 type Options struct {
 	// Assigned port number for HTTP endpoint
@@ -53,4 +65,76 @@ type Options struct {
 
 	// PyFunc UPI over HTTP flag
 	PredictorUPIHTTPEnabled bool `envconfig:"PREDICTOR_UPI_HTTP_ENABLED" default:"false"`
+
+	// Flag to allow request to enable debug mode, which returns the pipeline trace alongside the response
+	DebugModeEnabled bool `envconfig:"DEBUG_MODE_ENABLED" default:"false"`
+	// Shared secret that must be sent in debug mode header to enable debug mode of a request
+	DebugModeSecret string `envconfig:"DEBUG_MODE_SECRET"`
+	// Maximum size in bytes of the pipeline trace returned in gRPC trailer metadata, larger trace is truncated so that
+	// it doesn't exceed the metadata size limit of the client
+	DebugTraceMaxBytes int `envconfig:"DEBUG_TRACE_MAX_BYTES" default:"4096"`
+
+	// Flag to enable token bucket rate limiting of incoming requests per client
+	RateLimitEnabled bool `envconfig:"RATE_LIMIT_ENABLED" default:"false"`
+	// Request header (or gRPC metadata) identifying the client, requests without it share the same bucket
+	RateLimitClientIDHeader string `envconfig:"RATE_LIMIT_CLIENT_ID_HEADER" default:"X-Client-Id"`
+	// Number of requests per second allowed for each client
+	RateLimitRequestsPerSecond float64 `envconfig:"RATE_LIMIT_REQUESTS_PER_SECOND" default:"100"`
+	// Maximum number of requests allowed at once for each client
+	RateLimitBurst int `envconfig:"RATE_LIMIT_BURST" default:"100"`
+
+	// Flag to enable load shedding once the number of in-flight requests reaches the adaptive concurrency limit
+	LoadSheddingEnabled bool `envconfig:"LOAD_SHEDDING_ENABLED" default:"false"`
+	// Request latency above which the concurrency limit is decreased
+	LoadSheddingTargetLatency time.Duration `envconfig:"LOAD_SHEDDING_TARGET_LATENCY" default:"500ms"`
+	// Initial number of in-flight requests allowed
+	LoadSheddingInitialConcurrency int `envconfig:"LOAD_SHEDDING_INITIAL_CONCURRENCY" default:"100"`
+	// Lower bound of the concurrency limit
+	LoadSheddingMinConcurrency int `envconfig:"LOAD_SHEDDING_MIN_CONCURRENCY" default:"10"`
+	// Upper bound of the concurrency limit
+	LoadSheddingMaxConcurrency int `envconfig:"LOAD_SHEDDING_MAX_CONCURRENCY" default:"1000"`
+
+	// Flag to enable cache of the final response, requests with the same cache key skip the pipeline and the model
+	PredictionCacheEnabled bool `envconfig:"PREDICTION_CACHE_ENABLED" default:"false"`
+	// Comma separated jsonpath of the request fields used as cache key, e.g. $.customer.id
+	PredictionCacheKeyJSONPaths []string `envconfig:"PREDICTION_CACHE_KEY_JSONPATHS"`
+	// Comma separated prediction_context variable names used as cache key, only applicable for UPI_V1 protocol
+	PredictionCacheKeyVariables []string `envconfig:"PREDICTION_CACHE_KEY_VARIABLES"`
+	// Duration of cached response, minimum is 1 second
+	PredictionCacheTTL time.Duration `envconfig:"PREDICTION_CACHE_TTL" default:"60s"`
+	// Maximum size of the cache
+	PredictionCacheSizeInMB int `envconfig:"PREDICTION_CACHE_SIZE_IN_MB" default:"100"`
+}
+
+// IsDebugModeAuthorized checks whether the secret sent by a request is allowed to enable debug mode
+func (o *Options) IsDebugModeAuthorized(secret string) bool {
+	if !o.DebugModeEnabled || o.DebugModeSecret == "" || secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(o.DebugModeSecret)) == 1
+}
+
+// Validate checks whether the options are consistent, it must be called before the server is started
+func (o *Options) Validate() error {
+	if o.DebugModeEnabled && o.DebugTraceMaxBytes < 1 {
+		return fmt.Errorf("DEBUG_TRACE_MAX_BYTES must be at least 1, got %d", o.DebugTraceMaxBytes)
+	}
+	if o.RateLimitEnabled {
+		if o.RateLimitRequestsPerSecond <= 0 {
+			return fmt.Errorf("RATE_LIMIT_REQUESTS_PER_SECOND must be greater than 0, got %v", o.RateLimitRequestsPerSecond)
+		}
+		if o.RateLimitBurst < 1 {
+			return fmt.Errorf("RATE_LIMIT_BURST must be at least 1, got %d", o.RateLimitBurst)
+		}
+	}
+	if o.LoadSheddingEnabled {
+		if o.LoadSheddingMinConcurrency < 1 {
+			return fmt.Errorf("LOAD_SHEDDING_MIN_CONCURRENCY must be at least 1, got %d", o.LoadSheddingMinConcurrency)
+		}
+		if o.LoadSheddingMinConcurrency > o.LoadSheddingInitialConcurrency || o.LoadSheddingInitialConcurrency > o.LoadSheddingMaxConcurrency {
+			return fmt.Errorf("load shedding concurrency must satisfy min <= initial <= max, got min=%d initial=%d max=%d",
+				o.LoadSheddingMinConcurrency, o.LoadSheddingInitialConcurrency, o.LoadSheddingMaxConcurrency)
+		}
+	}
+	return nil
 }
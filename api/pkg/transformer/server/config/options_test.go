@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{
+			name: "load shedding disabled",
+			opts: Options{LoadSheddingMinConcurrency: 0},
+		},
+		{
+			name: "valid debug mode",
+			opts: Options{DebugModeEnabled: true, DebugTraceMaxBytes: 4096},
+		},
+		{
+			name:    "zero debug trace max bytes",
+			opts:    Options{DebugModeEnabled: true, DebugTraceMaxBytes: 0},
+			wantErr: "DEBUG_TRACE_MAX_BYTES must be at least 1, got 0",
+		},
+		{
+			name: "rate limit disabled",
+			opts: Options{RateLimitRequestsPerSecond: 0, RateLimitBurst: 0},
+		},
+		{
+			name: "valid rate limit",
+			opts: Options{RateLimitEnabled: true, RateLimitRequestsPerSecond: 0.5, RateLimitBurst: 1},
+		},
+		{
+			name:    "zero rate limit requests per second",
+			opts:    Options{RateLimitEnabled: true, RateLimitRequestsPerSecond: 0, RateLimitBurst: 100},
+			wantErr: "RATE_LIMIT_REQUESTS_PER_SECOND must be greater than 0, got 0",
+		},
+		{
+			name:    "negative rate limit requests per second",
+			opts:    Options{RateLimitEnabled: true, RateLimitRequestsPerSecond: -1, RateLimitBurst: 100},
+			wantErr: "RATE_LIMIT_REQUESTS_PER_SECOND must be greater than 0, got -1",
+		},
+		{
+			name:    "zero rate limit burst",
+			opts:    Options{RateLimitEnabled: true, RateLimitRequestsPerSecond: 100, RateLimitBurst: 0},
+			wantErr: "RATE_LIMIT_BURST must be at least 1, got 0",
+		},
+		{
+			name: "valid load shedding",
+			opts: Options{
+				LoadSheddingEnabled:            true,
+				LoadSheddingMinConcurrency:     10,
+				LoadSheddingInitialConcurrency: 100,
+				LoadSheddingMaxConcurrency:     1000,
+			},
+		},
+		{
+			name: "zero minimum concurrency",
+			opts: Options{
+				LoadSheddingEnabled:            true,
+				LoadSheddingMinConcurrency:     0,
+				LoadSheddingInitialConcurrency: 100,
+				LoadSheddingMaxConcurrency:     1000,
+			},
+			wantErr: "LOAD_SHEDDING_MIN_CONCURRENCY must be at least 1, got 0",
+		},
+		{
+			name: "initial concurrency below minimum",
+			opts: Options{
+				LoadSheddingEnabled:            true,
+				LoadSheddingMinConcurrency:     10,
+				LoadSheddingInitialConcurrency: 5,
+				LoadSheddingMaxConcurrency:     1000,
+			},
+			wantErr: "load shedding concurrency must satisfy min <= initial <= max, got min=10 initial=5 max=1000",
+		},
+		{
+			name: "initial concurrency above maximum",
+			opts: Options{
+				LoadSheddingEnabled:            true,
+				LoadSheddingMinConcurrency:     10,
+				LoadSheddingInitialConcurrency: 100,
+				LoadSheddingMaxConcurrency:     50,
+			},
+			wantErr: "load shedding concurrency must satisfy min <= initial <= max, got min=10 initial=100 max=50",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/server/admission"
+)
+
+const healthCheckMethodPrefix = "/grpc.health.v1.Health/"
+
+// AdmissionControlInterceptor interceptor to reject request with RESOURCE_EXHAUSTED code if it is not admitted by the controller
+// client of the request is identified by the value of clientIDKey metadata, health check requests are always admitted
+func AdmissionControlInterceptor(controller *admission.Controller, clientIDKey string) grpc.UnaryServerInterceptor {
+	clientIDKey = strings.ToLower(clientIDKey)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if controller == nil || strings.HasPrefix(info.FullMethod, healthCheckMethodPrefix) {
+			return handler(ctx, req)
+		}
+
+		var clientID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(clientIDKey); len(values) > 0 {
+				clientID = values[0]
+			}
+		}
+
+		release, err := controller.Admit(clientID)
+		if err != nil {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
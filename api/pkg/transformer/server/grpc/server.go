@@ -2,18 +2,22 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/afex/hystrix-go/hystrix"
 	mErrors "github.com/caraml-dev/merlin/pkg/errors"
 	"github.com/caraml-dev/merlin/pkg/transformer/pipeline"
+	"github.com/caraml-dev/merlin/pkg/transformer/server/admission"
 	"github.com/caraml-dev/merlin/pkg/transformer/server/config"
 	"github.com/caraml-dev/merlin/pkg/transformer/server/grpc/interceptors"
 	"github.com/caraml-dev/merlin/pkg/transformer/server/instrumentation"
@@ -63,6 +67,10 @@ type UPIServer struct {
 	PostprocessHandler func(ctx context.Context, response types.Payload, responseHeaders map[string]string) (types.Payload, error)
 	// PredictionLogHandler function to publish prediction log
 	PredictionLogHandler func(ctx context.Context, predictionResult *types.PredictionResult)
+	// DebugTraceHandler function to retrieve the pipeline trace of a request in debug mode
+	DebugTraceHandler func(ctx context.Context) *types.DebugTrace
+	// PredictionCache stores successful response keyed by the request, nil if prediction cache is not enabled
+	PredictionCache *pipeline.PredictionCache
 }
 
 // NewUPIServer creates GRPC server that implement UPI Service
@@ -85,6 +93,7 @@ func NewUPIServer(opts *config.Options, handler *pipeline.Handler, instrumentati
 		svr.PreprocessHandler = handler.Preprocess
 		svr.PostprocessHandler = handler.Postprocess
 		svr.PredictionLogHandler = handler.PredictionLogHandler
+		svr.DebugTraceHandler = handler.DebugTrace
 	}
 
 	return svr, nil
@@ -107,6 +116,7 @@ func (us *UPIServer) Run() {
 	opts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			interceptors.PanicRecoveryInterceptor(),
+			interceptors.AdmissionControlInterceptor(admission.NewController(us.opts), us.opts.RateLimitClientIDHeader),
 		)),
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	}
@@ -180,6 +190,11 @@ func setupSignalHandler() (stopCh <-chan struct{}) {
 // PredictValues method to performing model prediction
 // it is including preprocessing - model infer - postprocessing
 func (us *UPIServer) PredictValues(ctx context.Context, request *upiv1.PredictValuesRequest) (response *upiv1.PredictValuesResponse, grpcErr error) {
+	ctx, debugMode := us.isDebugModeRequest(ctx)
+	if debugMode {
+		ctx = pipeline.WithDebugMode(ctx)
+	}
+
 	meta := getMetadata(ctx)
 	ctx, span := us.tracer.Start(ctx, "PredictHandler")
 	defer span.End()
@@ -209,6 +224,15 @@ func (us *UPIServer) PredictValues(ctx context.Context, request *upiv1.PredictVa
 		}()
 	}
 
+	cacheKey := us.predictionCacheKey(request, debugMode)
+	if cacheKey != nil {
+		if cachedResponse, found := us.PredictionCache.Fetch(cacheKey, meta); found {
+			if cached, ok := cachedResponse.(*types.UPIPredictionResponse); ok {
+				return (*upiv1.PredictValuesResponse)(cached), nil
+			}
+		}
+	}
+
 	preprocessOutput, err := us.preprocess(ctx, request, meta)
 	if err != nil {
 		us.logger.Error("preprocess error", zap.Error(err))
@@ -227,9 +251,95 @@ func (us *UPIServer) PredictValues(ctx context.Context, request *upiv1.PredictVa
 		return nil, status.Errorf(getGRPCCode(err), "postprocess err: %v", err)
 	}
 
+	if cacheKey != nil {
+		if err := us.PredictionCache.Insert(cacheKey, (*types.UPIPredictionResponse)(postprocessOutput)); err != nil {
+			us.logger.Warn("unable to cache prediction", zap.Error(err))
+		}
+	}
+
+	if debugMode {
+		us.sendDebugTrace(ctx)
+	}
+
 	return postprocessOutput, nil
 }
 
+// predictionCacheKey returns nil if the response of the request can't be cached
+// cached response doesn't have the pipeline trace, thus the cache is not used in debug mode
+func (us *UPIServer) predictionCacheKey(request *upiv1.PredictValuesRequest, debugMode bool) []byte {
+	if us.PredictionCache == nil || debugMode {
+		return nil
+	}
+	cacheKey, err := us.PredictionCache.Key((*types.UPIPredictionRequest)(request))
+	if err != nil {
+		us.logger.Warn("unable to compute prediction cache key", zap.Error(err))
+		return nil
+	}
+	return cacheKey
+}
+
+// isDebugModeRequest checks whether the request is authorized to enable debug mode
+// the debug mode metadata is removed from the returned context so that the shared secret is not propagated to the model or the pipeline
+func (us *UPIServer) isDebugModeRequest(ctx context.Context) (context.Context, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, false
+	}
+
+	key := strings.ToLower(config.DebugModeHeader)
+	secrets := md.Get(key)
+	if len(secrets) == 0 {
+		return ctx, false
+	}
+
+	md = md.Copy()
+	md.Delete(key)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	if !us.opts.IsDebugModeAuthorized(secrets[0]) {
+		us.logger.Warn("unauthorized debug mode request")
+		return ctx, false
+	}
+	return ctx, true
+}
+
+// sendDebugTrace sends the pipeline trace of the request as trailer metadata, so that a large trace doesn't fail the
+// response headers. The trace is truncated to DebugTraceMaxBytes, in which case its original size is sent alongside it.
+func (us *UPIServer) sendDebugTrace(ctx context.Context) {
+	if us.DebugTraceHandler == nil {
+		return
+	}
+
+	trace, err := json.Marshal(us.DebugTraceHandler(ctx))
+	if err != nil {
+		us.logger.Warn("failed marshalling debug trace", zap.Error(err))
+		return
+	}
+
+	md := metadata.Pairs(config.DebugTraceMetadataKey, string(trace))
+	if maxBytes := us.opts.DebugTraceMaxBytes; len(trace) > maxBytes {
+		md = metadata.Pairs(
+			config.DebugTraceMetadataKey, truncateUTF8(string(trace), maxBytes),
+			config.DebugTraceTruncatedMetadataKey, strconv.Itoa(len(trace)),
+		)
+	}
+
+	if err := grpc.SetTrailer(ctx, md); err != nil {
+		us.logger.Warn("failed sending debug trace", zap.Error(err))
+	}
+}
+
+// truncateUTF8 returns the longest prefix of s of at most maxBytes that doesn't split a UTF-8 encoded rune
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
+
 func (us *UPIServer) preprocess(ctx context.Context, request *upiv1.PredictValuesRequest, meta map[string]string) (*upiv1.PredictValuesRequest, error) {
 	ctx, span := us.tracer.Start(ctx, string(types.Preprocess))
 	defer span.End()
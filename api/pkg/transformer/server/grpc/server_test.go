@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -34,8 +35,12 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"sigs.k8s.io/yaml"
@@ -629,6 +634,243 @@ func TestUPIServer_PredictValues_WithMockPreprocessAndPostprocessHandler(t *test
 	}
 }
 
+func TestUPIServer_PredictValues_DebugMode(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	request := &upiv1.PredictValuesRequest{
+		PredictionTable: &upiv1.Table{
+			Name:    "instances",
+			Columns: []*upiv1.Column{{Name: "rating", Type: upiv1.Type_TYPE_DOUBLE}},
+			Rows:    []*upiv1.Row{{RowId: "1", Values: []*upiv1.Value{{DoubleValue: 3.2}}}},
+		},
+	}
+	modelResponse := &upiv1.PredictValuesResponse{
+		PredictionResultTable: &upiv1.Table{
+			Name:    "result",
+			Columns: []*upiv1.Column{{Name: "probability", Type: upiv1.Type_TYPE_DOUBLE}},
+			Rows:    []*upiv1.Row{{RowId: "1", Values: []*upiv1.Value{{DoubleValue: 0.2}}}},
+		},
+	}
+	debugTrace := &types.DebugTrace{
+		PreprocessTrace: []types.OperationDebugDetail{
+			{OpType: types.VariableOpType, Output: map[string]interface{}{"rating": 3.2}, DurationMs: 0.01},
+		},
+		PostprocessTrace: []types.OperationDebugDetail{},
+	}
+
+	// trace of a large table exceeds the metadata size limit of the client
+	rows := make([]interface{}, 1000)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"customer_id": fmt.Sprintf("customer-%d", i), "rating": 3.2}
+	}
+	largeDebugTrace := &types.DebugTrace{
+		PreprocessTrace: []types.OperationDebugDetail{
+			{OpType: types.TableJoinOpType, Output: map[string]interface{}{"customer_table": rows}, DurationMs: 0.01},
+		},
+		PostprocessTrace: []types.OperationDebugDetail{},
+	}
+
+	tests := []struct {
+		name          string
+		debugHeader   string
+		debugTrace    *types.DebugTrace
+		modelErr      error
+		expDebugTrace bool
+		expTruncated  bool
+		expErr        error
+	}{
+		{
+			name:          "debug mode is authorized",
+			debugHeader:   "secret",
+			debugTrace:    debugTrace,
+			expDebugTrace: true,
+		},
+		{
+			name:          "large trace is truncated",
+			debugHeader:   "secret",
+			debugTrace:    largeDebugTrace,
+			expDebugTrace: true,
+			expTruncated:  true,
+		},
+		{
+			name:          "invalid secret",
+			debugHeader:   "invalid-secret",
+			expDebugTrace: false,
+		},
+		{
+			name:          "model returns error",
+			debugHeader:   "secret",
+			modelErr:      status.Error(codes.ResourceExhausted, "model is overloaded"),
+			expDebugTrace: false,
+			expErr:        status.Error(codes.ResourceExhausted, "predict err: rpc error: code = ResourceExhausted desc = model is overloaded"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := &mocks.UniversalPredictionServiceClient{}
+			clientMock.On("PredictValues", mock.Anything, mock.Anything, mock.Anything).Return(modelResponse, tt.modelErr)
+
+			us := &UPIServer{
+				predictorClient: &grpcClient{upiClient: clientMock, opts: &config.Options{ModelGRPCHystrixCommandName: "gRPCCommand"}},
+				opts: &config.Options{
+					ModelGRPCHystrixCommandName: "grpcHandler",
+					DebugModeEnabled:            true,
+					DebugModeSecret:             "secret",
+					DebugTraceMaxBytes:          4096,
+				},
+				logger: logger,
+				tracer: noop.NewTracerProvider().Tracer(""),
+				PreprocessHandler: func(ctx context.Context, request types.Payload, requestHeaders map[string]string) (types.Payload, error) {
+					// shared secret must not be propagated to the pipeline or the model
+					md, _ := metadata.FromIncomingContext(ctx)
+					assert.Empty(t, md.Get(config.DebugModeHeader))
+					assert.Equal(t, tt.debugHeader == "secret", pipeline.IsDebugMode(ctx))
+					return request, nil
+				},
+				DebugTraceHandler: func(ctx context.Context) *types.DebugTrace {
+					return tt.debugTrace
+				},
+			}
+
+			lis := bufconn.Listen(1024 * 1024)
+			grpcServer := grpc.NewServer()
+			upiv1.RegisterUniversalPredictionServiceServer(grpcServer, us)
+			go func() {
+				_ = grpcServer.Serve(lis)
+			}()
+			defer grpcServer.Stop()
+
+			conn, err := grpc.Dial("bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return lis.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithMaxHeaderListSize(8*1024),
+			)
+			require.NoError(t, err)
+			defer conn.Close() //nolint: errcheck
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), config.DebugModeHeader, tt.debugHeader)
+			var trailer metadata.MD
+			got, err := upiv1.NewUniversalPredictionServiceClient(conn).PredictValues(ctx, request, grpc.Trailer(&trailer))
+
+			assert.Equal(t, tt.expDebugTrace, len(trailer.Get(config.DebugTraceMetadataKey)) > 0)
+			if tt.expErr != nil {
+				assert.Equal(t, status.Code(tt.expErr), status.Code(err))
+				assert.Equal(t, tt.expErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(modelResponse, got))
+
+			if tt.expTruncated {
+				trace, err := json.Marshal(tt.debugTrace)
+				require.NoError(t, err)
+				assert.Equal(t, string(trace[:4096]), trailer.Get(config.DebugTraceMetadataKey)[0])
+				assert.Equal(t, []string{fmt.Sprint(len(trace))}, trailer.Get(config.DebugTraceTruncatedMetadataKey))
+				return
+			}
+			if tt.expDebugTrace {
+				var gotTrace types.DebugTrace
+				require.NoError(t, json.Unmarshal([]byte(trailer.Get(config.DebugTraceMetadataKey)[0]), &gotTrace))
+				assert.Equal(t, *tt.debugTrace, gotTrace)
+				assert.Empty(t, trailer.Get(config.DebugTraceTruncatedMetadataKey))
+			}
+		})
+	}
+}
+
+func TestUPIServer_PredictValues_PredictionCache(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	newRequest := func(customerID int64) *upiv1.PredictValuesRequest {
+		return &upiv1.PredictValuesRequest{
+			PredictionTable: &upiv1.Table{
+				Name:    "instances",
+				Columns: []*upiv1.Column{{Name: "rating", Type: upiv1.Type_TYPE_DOUBLE}},
+				Rows:    []*upiv1.Row{{RowId: "1", Values: []*upiv1.Value{{DoubleValue: 3.2}}}},
+			},
+			PredictionContext: []*upiv1.Variable{
+				{Name: "customer_id", Type: upiv1.Type_TYPE_INTEGER, IntegerValue: customerID},
+			},
+		}
+	}
+	modelResponse := &upiv1.PredictValuesResponse{
+		PredictionResultTable: &upiv1.Table{
+			Name:    "result",
+			Columns: []*upiv1.Column{{Name: "probability", Type: upiv1.Type_TYPE_DOUBLE}},
+			Rows:    []*upiv1.Row{{RowId: "1", Values: []*upiv1.Value{{DoubleValue: 0.2}}}},
+		},
+	}
+
+	type request struct {
+		request  *upiv1.PredictValuesRequest
+		metadata map[string]string
+	}
+	tests := []struct {
+		name          string
+		requests      []request
+		modelErr      error
+		expModelCalls int
+	}{
+		{
+			name:          "identical requests are served from cache",
+			requests:      []request{{request: newRequest(1)}, {request: newRequest(1)}},
+			expModelCalls: 1,
+		},
+		{
+			name:          "requests with different key",
+			requests:      []request{{request: newRequest(1)}, {request: newRequest(2)}},
+			expModelCalls: 2,
+		},
+		{
+			name: "bypass metadata",
+			requests: []request{
+				{request: newRequest(1)},
+				{request: newRequest(1), metadata: map[string]string{pipeline.PredictionCacheBypassHeader: "true"}},
+			},
+			expModelCalls: 2,
+		},
+		{
+			name:          "error is not cached",
+			requests:      []request{{request: newRequest(1)}, {request: newRequest(1)}},
+			modelErr:      status.Error(codes.InvalidArgument, "invalid request"),
+			expModelCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := &mocks.UniversalPredictionServiceClient{}
+			clientMock.On("PredictValues", mock.Anything, mock.Anything, mock.Anything).Return(modelResponse, tt.modelErr)
+
+			predictionCache, err := pipeline.NewPredictionCache(pipeline.PredictionCacheOptions{
+				KeyVariables: []string{"customer_id"},
+				TTL:          time.Minute,
+				SizeInMB:     1,
+			}, protocol.UpiV1)
+			require.NoError(t, err)
+
+			us := &UPIServer{
+				predictorClient: &grpcClient{upiClient: clientMock, opts: &config.Options{ModelGRPCHystrixCommandName: "gRPCCommand"}},
+				opts:            &config.Options{ModelGRPCHystrixCommandName: "grpcHandler"},
+				logger:          logger,
+				tracer:          noop.NewTracerProvider().Tracer(""),
+				PredictionCache: predictionCache,
+			}
+
+			for _, r := range tt.requests {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.New(r.metadata))
+				got, err := us.PredictValues(ctx, r.request)
+				if tt.modelErr != nil {
+					assert.Equal(t, status.Code(tt.modelErr), status.Code(err))
+					continue
+				}
+				require.NoError(t, err)
+				assert.True(t, proto.Equal(modelResponse, got))
+			}
+			clientMock.AssertNumberOfCalls(t, "PredictValues", tt.expModelCalls)
+		})
+	}
+}
+
 func TestUPIServer_PredictValues(t *testing.T) {
 	type mockFeast struct {
 		request         *feastSdk.OnlineFeaturesRequest
@@ -5897,3 +6139,11 @@ func compareProto(expected, actual proto.Message, ignoreFields ...string) bool {
 	}
 	return true
 }
+
+func TestTruncateUTF8(t *testing.T) {
+	assert.Equal(t, "abc", truncateUTF8("abc", 5))
+	assert.Equal(t, "ab", truncateUTF8("abc", 2))
+	// multi-byte rune is not split
+	assert.Equal(t, "a", truncateUTF8("aé", 2))
+	assert.Equal(t, "aé", truncateUTF8("aé", 3))
+}
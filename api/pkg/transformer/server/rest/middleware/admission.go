@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/server/admission"
+	"github.com/caraml-dev/merlin/pkg/transformer/server/response"
+)
+
+// AdmissionControlHandler rejects request with 429 status code if it is not admitted by the controller
+// client of the request is identified by the value of clientIDHeader
+func AdmissionControlHandler(controller *admission.Controller, clientIDHeader string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if controller == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			release, err := controller.Admit(r.Header.Get(clientIDHeader))
+			if err != nil {
+				w.Header().Set("Retry-After", "1")
+				response.NewError(http.StatusTooManyRequests, err).Write(w)
+				return
+			}
+			defer release()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caraml-dev/merlin/pkg/transformer/server/admission"
+	"github.com/caraml-dev/merlin/pkg/transformer/server/config"
+)
+
+func TestAdmissionControlHandler(t *testing.T) {
+	controller := admission.NewController(&config.Options{
+		RateLimitEnabled:           true,
+		RateLimitRequestsPerSecond: 0.001,
+		RateLimitBurst:             1,
+	})
+	handler := AdmissionControlHandler(controller, "X-Client-Id")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name     string
+		clientID string
+		wantCode int
+	}{
+		{name: "first request of client a", clientID: "a", wantCode: http.StatusOK},
+		{name: "second request of client a", clientID: "a", wantCode: http.StatusTooManyRequests},
+		{name: "first request of client b", clientID: "b", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("X-Client-Id", tt.clientID)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantCode, rr.Code)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	mErrors "github.com/caraml-dev/merlin/pkg/errors"
 	hystrixpkg "github.com/caraml-dev/merlin/pkg/hystrix"
 	"github.com/caraml-dev/merlin/pkg/transformer/pipeline"
+	"github.com/caraml-dev/merlin/pkg/transformer/server/admission"
 	"github.com/caraml-dev/merlin/pkg/transformer/server/config"
 	"github.com/caraml-dev/merlin/pkg/transformer/server/instrumentation"
 	"github.com/caraml-dev/merlin/pkg/transformer/server/response"
@@ -60,6 +62,16 @@ type HTTPServer struct {
 	// request parameter for this function must be in types.BytePayload type
 	// output payload  of this function must be in types.BytePayload type
 	PostprocessHandler pipelineHandler
+	// DebugTraceHandler function to retrieve the pipeline trace of a request in debug mode
+	DebugTraceHandler func(ctx context.Context) *types.DebugTrace
+	// PredictionCache stores successful response keyed by the request, nil if prediction cache is not enabled
+	PredictionCache *pipeline.PredictionCache
+}
+
+// debugResponse is the response body returned to request in debug mode
+type debugResponse struct {
+	Response   json.RawMessage   `json:"response"`
+	DebugTrace *types.DebugTrace `json:"debug_trace"`
 }
 
 type pipelineHandler func(ctx context.Context, request types.Payload, requestHeaders map[string]string) (types.Payload, error)
@@ -93,6 +105,7 @@ func NewWithHandler(o *config.Options, handler *pipeline.Handler, logger *zap.Lo
 		srv.PreprocessHandler = handler.Preprocess
 		srv.PostprocessHandler = handler.Postprocess
 		srv.ContextModifier = handler.EmbedEnvironment
+		srv.DebugTraceHandler = handler.DebugTrace
 	}
 	return srv
 }
@@ -114,6 +127,10 @@ func newHTTPHystrixClient(commandName string, o *config.Options) *hystrixpkg.Cli
 // PredictHandler handles prediction request to the transformer and model.
 func (s *HTTPServer) PredictHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	debugMode := s.isDebugModeRequest(r)
+	if debugMode {
+		ctx = pipeline.WithDebugMode(ctx)
+	}
 	if s.ContextModifier != nil {
 		ctx = s.ContextModifier(ctx)
 	}
@@ -135,6 +152,14 @@ func (s *HTTPServer) PredictHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	s.logger.Debug("raw request_body", zap.ByteString("request_body", requestBody))
 
+	cacheKey := s.predictionCacheKey(requestBody, debugMode)
+	if cacheKey != nil {
+		if cachedResponse, found := s.PredictionCache.Fetch(cacheKey, getHeaders(r.Header)); found {
+			s.writeCachedResponse(w, cachedResponse)
+			return
+		}
+	}
+
 	preprocessOutput, err := s.preprocess(ctx, requestBody, r.Header)
 	if err != nil {
 		s.logger.Error("preprocess error", zap.Error(err))
@@ -167,7 +192,17 @@ func (s *HTTPServer) PredictHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	s.logger.Debug("postprocess response", zap.ByteString("postprocess_response", postprocessOutput))
 
+	if cacheKey != nil && resp.StatusCode == http.StatusOK {
+		if err := s.PredictionCache.Insert(cacheKey, types.BytePayload(postprocessOutput)); err != nil {
+			s.logger.Warn("unable to cache prediction", zap.Error(err))
+		}
+	}
+
 	copyHeader(w.Header(), resp.Header)
+	// non-200 response of the model is passed through unchanged so that the upstream error isn't hidden by the trace
+	if debugMode && resp.StatusCode == http.StatusOK {
+		postprocessOutput = s.withDebugTrace(ctx, w, postprocessOutput)
+	}
 	w.Header().Set("Content-Length", fmt.Sprint(len(postprocessOutput)))
 	w.WriteHeader(resp.StatusCode)
 	_, err = w.Write(postprocessOutput)
@@ -176,6 +211,71 @@ func (s *HTTPServer) PredictHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// predictionCacheKey returns nil if the response of the request can't be cached
+// cached response doesn't have the pipeline trace, thus the cache is not used in debug mode
+func (s *HTTPServer) predictionCacheKey(requestBody []byte, debugMode bool) []byte {
+	if s.PredictionCache == nil || debugMode {
+		return nil
+	}
+	cacheKey, err := s.PredictionCache.Key(types.BytePayload(requestBody))
+	if err != nil {
+		s.logger.Warn("unable to compute prediction cache key", zap.Error(err))
+		return nil
+	}
+	return cacheKey
+}
+
+func (s *HTTPServer) writeCachedResponse(w http.ResponseWriter, cachedResponse types.Payload) {
+	output, err := cachedResponse.AsOutput()
+	if err != nil {
+		response.NewError(http.StatusInternalServerError, err).Write(w)
+		return
+	}
+	body, _ := output.(types.BytePayload)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		s.logger.Error("failed writing cached response", zap.Error(err))
+	}
+}
+
+// isDebugModeRequest checks whether the request is authorized to enable debug mode
+// the debug mode header is removed so that the shared secret is not propagated to the model or the pipeline
+func (s *HTTPServer) isDebugModeRequest(r *http.Request) bool {
+	secret := r.Header.Get(config.DebugModeHeader)
+	if secret == "" {
+		return false
+	}
+	r.Header.Del(config.DebugModeHeader)
+
+	if !s.options.IsDebugModeAuthorized(secret) {
+		s.logger.Warn("unauthorized debug mode request")
+		return false
+	}
+	return true
+}
+
+// withDebugTrace wraps the response together with the pipeline trace of the request
+// original response is returned if the trace can't be attached
+func (s *HTTPServer) withDebugTrace(ctx context.Context, w http.ResponseWriter, output []byte) []byte {
+	if s.DebugTraceHandler == nil {
+		return output
+	}
+
+	body, err := json.Marshal(debugResponse{
+		Response:   output,
+		DebugTrace: s.DebugTraceHandler(ctx),
+	})
+	if err != nil {
+		s.logger.Warn("failed attaching debug trace to response", zap.Error(err))
+		return output
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return body
+}
+
 func responseCodeFromError(err error) int {
 	if errors.Is(err, mErrors.ErrInvalidInput) {
 		return http.StatusBadRequest
@@ -269,7 +369,8 @@ func (s *HTTPServer) Run() {
 	router := s.router
 	attachInstrumentationRoutes(router)
 
-	router.HandleFunc(fmt.Sprintf("/v1/models/%s:predict", s.options.ModelFullName), s.PredictHandler).Methods("POST")
+	admissionControl := middleware.AdmissionControlHandler(admission.NewController(s.options), s.options.RateLimitClientIDHeader)
+	router.Handle(fmt.Sprintf("/v1/models/%s:predict", s.options.ModelFullName), admissionControl(http.HandlerFunc(s.PredictHandler))).Methods("POST")
 	run("standard transformer", router, s.options, s.logger)
 }
 
@@ -1368,6 +1368,236 @@ func TestServer_PredictHandler_StandardTransformer(t *testing.T) {
 	}
 }
 
+func TestServer_PredictHandler_ExplanationOutput(t *testing.T) {
+	modelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"instances": {"columns": ["distance", "id", "rating"], "data": [[1.5, 1, 4.8], [3.2, 2, 4.1]]}}`, string(body))
+
+		w.Header().Add("Content-Type", "application/json")
+		_, err = w.Write([]byte(`{"predictions": [0.2, 0.7], "shap_values": [[0.1, -0.4, 0.2], [0.3, 0.05, -0.6]]}`))
+		assert.NoError(t, err)
+	}))
+	defer modelServer.Close()
+
+	transformerServer, err := createTransformerServer("../../pipeline/testdata/valid_explanation_output.yaml", feast.Clients{}, &config.Options{
+		ModelPredictURL: modelServer.URL,
+	})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	reqBody := bytes.NewBufferString(`{"drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}, {"id": 2, "distance": 3.2, "rating": 4.1}]}`)
+	req, err := http.NewRequest("POST", modelServer.URL, reqBody)
+	require.NoError(t, err)
+
+	transformerServer.PredictHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"predictions": [0.2, 0.7],
+		"shap_values": [[0.1, -0.4, 0.2], [0.3, 0.05, -0.6]],
+		"explanations": [
+			[
+				{"feature": "id", "attribution": -0.4},
+				{"feature": "rating", "attribution": 0.2},
+				{"feature": "distance", "attribution": 0.1}
+			],
+			[
+				{"feature": "rating", "attribution": -0.6},
+				{"feature": "distance", "attribution": 0.3},
+				{"feature": "id", "attribution": 0.05}
+			]
+		]
+	}`, rr.Body.String())
+}
+
+func TestServer_PredictHandler_PredictionCache(t *testing.T) {
+	type request struct {
+		body    string
+		headers map[string]string
+	}
+	tests := []struct {
+		name          string
+		requests      []request
+		modelStatus   int
+		expModelCalls int
+	}{
+		{
+			name: "identical requests are served from cache",
+			requests: []request{
+				{body: `{"customer": {"id": 1}, "drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}]}`},
+				{body: `{"drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}], "customer": {"id": 1}}`},
+			},
+			expModelCalls: 1,
+		},
+		{
+			name: "requests with different key",
+			requests: []request{
+				{body: `{"customer": {"id": 1}, "drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}]}`},
+				{body: `{"customer": {"id": 2}, "drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}]}`},
+			},
+			expModelCalls: 2,
+		},
+		{
+			name: "bypass header",
+			requests: []request{
+				{body: `{"customer": {"id": 1}, "drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}]}`},
+				{
+					body:    `{"customer": {"id": 1}, "drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}]}`,
+					headers: map[string]string{pipeline.PredictionCacheBypassHeader: "true"},
+				},
+			},
+			expModelCalls: 2,
+		},
+		{
+			name: "non-200 response is not cached",
+			requests: []request{
+				{body: `{"customer": {"id": 1}, "drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}]}`},
+				{body: `{"customer": {"id": 1}, "drivers": [{"id": 1, "distance": 1.5, "rating": 4.8}]}`},
+			},
+			modelStatus:   http.StatusBadRequest,
+			expModelCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelCalls := 0
+			modelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				modelCalls++
+				w.Header().Add("Content-Type", "application/json")
+				if tt.modelStatus != 0 {
+					w.WriteHeader(tt.modelStatus)
+				}
+				_, err := w.Write([]byte(`{"predictions": [0.2], "shap_values": [[0.1, -0.4, 0.2]]}`))
+				assert.NoError(t, err)
+			}))
+			defer modelServer.Close()
+
+			transformerServer, err := createTransformerServer("../../pipeline/testdata/valid_explanation_output.yaml", feast.Clients{}, &config.Options{
+				ModelPredictURL: modelServer.URL,
+			})
+			require.NoError(t, err)
+			transformerServer.PredictionCache, err = pipeline.NewPredictionCache(pipeline.PredictionCacheOptions{
+				KeyJSONPaths: []string{"$.customer.id", "$.drivers"},
+				TTL:          time.Minute,
+				SizeInMB:     1,
+			}, protocol.HttpJson)
+			require.NoError(t, err)
+
+			var responses []string
+			for _, r := range tt.requests {
+				rr := httptest.NewRecorder()
+				req, err := http.NewRequest("POST", modelServer.URL, bytes.NewBufferString(r.body))
+				require.NoError(t, err)
+				for k, v := range r.headers {
+					req.Header.Set(k, v)
+				}
+
+				transformerServer.PredictHandler(rr, req)
+				responses = append(responses, rr.Body.String())
+			}
+
+			assert.Equal(t, tt.expModelCalls, modelCalls)
+			assert.JSONEq(t, responses[0], responses[1])
+		})
+	}
+}
+
+func TestServer_PredictHandler_DebugMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       *config.Options
+		debugHeader   string
+		modelStatus   int
+		expDebugTrace bool
+	}{
+		{
+			name:          "debug mode is authorized",
+			options:       &config.Options{DebugModeEnabled: true, DebugModeSecret: "secret"},
+			debugHeader:   "secret",
+			expDebugTrace: true,
+		},
+		{
+			name:          "model returns non-200 response",
+			options:       &config.Options{DebugModeEnabled: true, DebugModeSecret: "secret"},
+			debugHeader:   "secret",
+			modelStatus:   http.StatusBadRequest,
+			expDebugTrace: false,
+		},
+		{
+			name:          "invalid secret",
+			options:       &config.Options{DebugModeEnabled: true, DebugModeSecret: "secret"},
+			debugHeader:   "invalid-secret",
+			expDebugTrace: false,
+		},
+		{
+			name:          "debug mode is disabled",
+			options:       &config.Options{DebugModeEnabled: false, DebugModeSecret: "secret"},
+			debugHeader:   "secret",
+			expDebugTrace: false,
+		},
+		{
+			name:          "debug mode is enabled without secret",
+			options:       &config.Options{DebugModeEnabled: true},
+			debugHeader:   "",
+			expDebugTrace: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelResponse := []byte(`{"predictions": [1]}`)
+			modelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// shared secret must not be propagated to model
+				assert.Empty(t, r.Header.Get(config.DebugModeHeader))
+
+				w.Header().Add("Content-Type", "application/json")
+				if tt.modelStatus != 0 {
+					w.WriteHeader(tt.modelStatus)
+				}
+				_, err := w.Write(modelResponse)
+				assert.NoError(t, err)
+			}))
+			defer modelServer.Close()
+
+			expStatus := http.StatusOK
+			if tt.modelStatus != 0 {
+				expStatus = tt.modelStatus
+			}
+
+			tt.options.ModelPredictURL = modelServer.URL
+			transformerServer, err := createTransformerServer("../../pipeline/testdata/valid_debug_trace.yaml", feast.Clients{}, tt.options)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			reqBody := bytes.NewBufferString(`{"customer": {"id": 1111}, "entities": [{"id": 1, "name": "entity-1"}]}`)
+			req, err := http.NewRequest("POST", modelServer.URL, reqBody)
+			require.NoError(t, err)
+			req.Header.Set(config.DebugModeHeader, tt.debugHeader)
+
+			transformerServer.PredictHandler(rr, req)
+			assert.Equal(t, expStatus, rr.Code)
+
+			if !tt.expDebugTrace {
+				assert.JSONEq(t, string(modelResponse), rr.Body.String())
+				return
+			}
+
+			var resp struct {
+				Response   json.RawMessage   `json:"response"`
+				DebugTrace *types.DebugTrace `json:"debug_trace"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.JSONEq(t, string(modelResponse), string(resp.Response))
+			require.NotNil(t, resp.DebugTrace)
+			require.Len(t, resp.DebugTrace.PreprocessTrace, 3)
+			assert.Equal(t, types.VariableOpType, resp.DebugTrace.PreprocessTrace[0].OpType)
+			assert.Equal(t, map[string]interface{}{"customer_id": float64(1111)}, resp.DebugTrace.PreprocessTrace[0].Output)
+			assert.Equal(t, types.CreateTableOpType, resp.DebugTrace.PreprocessTrace[1].OpType)
+			assert.Equal(t, types.JsonOutputOpType, resp.DebugTrace.PreprocessTrace[2].OpType)
+			assert.Equal(t, fmt.Sprint(rr.Body.Len()), rr.Header().Get("Content-Length"))
+		})
+	}
+}
+
 func Test_newHTTPHystrixClient(t *testing.T) {
 	defaultRequestBodyString := `{ "name": "merlin" }`
 	defaultResponseBodyString := `{ "response": "ok" }`
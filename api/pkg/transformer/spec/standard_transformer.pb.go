@@ -9,6 +9,7 @@ package spec
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +21,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// OnTimeoutPolicy determines what happens when an operation exceeds its deadline
+type OnTimeoutPolicy int32
+
+const (
+	OnTimeoutPolicy_FAIL        OnTimeoutPolicy = 0 // Fail the whole request
+	OnTimeoutPolicy_USE_DEFAULT OnTimeoutPolicy = 1 // Populate the operation output with default values and continue
+	OnTimeoutPolicy_SKIP        OnTimeoutPolicy = 2 // Skip the operation and continue without its output
+)
+
+// Enum value maps for OnTimeoutPolicy.
+var (
+	OnTimeoutPolicy_name = map[int32]string{
+		0: "FAIL",
+		1: "USE_DEFAULT",
+		2: "SKIP",
+	}
+	OnTimeoutPolicy_value = map[string]int32{
+		"FAIL":        0,
+		"USE_DEFAULT": 1,
+		"SKIP":        2,
+	}
+)
+
+func (x OnTimeoutPolicy) Enum() *OnTimeoutPolicy {
+	p := new(OnTimeoutPolicy)
+	*p = x
+	return p
+}
+
+func (x OnTimeoutPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OnTimeoutPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_transformer_spec_standard_transformer_proto_enumTypes[0].Descriptor()
+}
+
+func (OnTimeoutPolicy) Type() protoreflect.EnumType {
+	return &file_transformer_spec_standard_transformer_proto_enumTypes[0]
+}
+
+func (x OnTimeoutPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OnTimeoutPolicy.Descriptor instead.
+func (OnTimeoutPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_transformer_spec_standard_transformer_proto_rawDescGZIP(), []int{0}
+}
+
 type StandardTransformerConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -219,11 +270,12 @@ type Input struct {
 	// however it's not possible to have repeated field in oneof
 	// https://github.com/protocolbuffers/protobuf/issues/2592
 	// Thus we will handle the oneof behavior in the code side
-	Variables []*Variable     `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
-	Feast     []*FeatureTable `protobuf:"bytes,2,rep,name=feast,proto3" json:"feast,omitempty"`
-	Tables    []*Table        `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty"`
-	Encoders  []*Encoder      `protobuf:"bytes,4,rep,name=encoders,proto3" json:"encoders,omitempty"`
-	Autoload  *UPIAutoload    `protobuf:"bytes,5,opt,name=autoload,proto3" json:"autoload,omitempty"`
+	Variables       []*Variable      `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
+	Feast           []*FeatureTable  `protobuf:"bytes,2,rep,name=feast,proto3" json:"feast,omitempty"`
+	Tables          []*Table         `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty"`
+	Encoders        []*Encoder       `protobuf:"bytes,4,rep,name=encoders,proto3" json:"encoders,omitempty"`
+	Autoload        *UPIAutoload     `protobuf:"bytes,5,opt,name=autoload,proto3" json:"autoload,omitempty"`
+	ExecutionPolicy *ExecutionPolicy `protobuf:"bytes,6,opt,name=executionPolicy,proto3" json:"executionPolicy,omitempty"` // Deadline and degradation policy of the operations in this input
 }
 
 func (x *Input) Reset() {
@@ -293,6 +345,13 @@ func (x *Input) GetAutoload() *UPIAutoload {
 	return nil
 }
 
+func (x *Input) GetExecutionPolicy() *ExecutionPolicy {
+	if x != nil {
+		return x.ExecutionPolicy
+	}
+	return nil
+}
+
 type Transformation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -313,6 +372,7 @@ type Transformation struct {
 	TableJoin           *TableJoin           `protobuf:"bytes,1,opt,name=tableJoin,proto3" json:"tableJoin,omitempty"`
 	TableTransformation *TableTransformation `protobuf:"bytes,2,opt,name=tableTransformation,proto3" json:"tableTransformation,omitempty"`
 	Variables           []*Variable          `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
+	ExecutionPolicy     *ExecutionPolicy     `protobuf:"bytes,4,opt,name=executionPolicy,proto3" json:"executionPolicy,omitempty"` // Deadline and degradation policy of the operations in this transformation
 }
 
 func (x *Transformation) Reset() {
@@ -368,6 +428,13 @@ func (x *Transformation) GetVariables() []*Variable {
 	return nil
 }
 
+func (x *Transformation) GetExecutionPolicy() *ExecutionPolicy {
+	if x != nil {
+		return x.ExecutionPolicy
+	}
+	return nil
+}
+
 type Output struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -376,6 +443,7 @@ type Output struct {
 	JsonOutput           *JsonOutput           `protobuf:"bytes,1,opt,name=jsonOutput,proto3" json:"jsonOutput,omitempty"`
 	UpiPreprocessOutput  *UPIPreprocessOutput  `protobuf:"bytes,2,opt,name=upiPreprocessOutput,proto3" json:"upiPreprocessOutput,omitempty"`
 	UpiPostprocessOutput *UPIPostprocessOutput `protobuf:"bytes,3,opt,name=upiPostprocessOutput,proto3" json:"upiPostprocessOutput,omitempty"`
+	ExplanationOutput    *ExplanationOutput    `protobuf:"bytes,4,opt,name=explanationOutput,proto3" json:"explanationOutput,omitempty"`
 }
 
 func (x *Output) Reset() {
@@ -431,6 +499,148 @@ func (x *Output) GetUpiPostprocessOutput() *UPIPostprocessOutput {
 	return nil
 }
 
+func (x *Output) GetExplanationOutput() *ExplanationOutput {
+	if x != nil {
+		return x.ExplanationOutput
+	}
+	return nil
+}
+
+type ExecutionPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timeout   *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`                                              // Maximum duration of the operation, required
+	OnTimeout OnTimeoutPolicy      `protobuf:"varint,2,opt,name=onTimeout,proto3,enum=merlin.transformer.OnTimeoutPolicy" json:"onTimeout,omitempty"` // Behaviour once the deadline is exceeded
+}
+
+func (x *ExecutionPolicy) Reset() {
+	*x = ExecutionPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transformer_spec_standard_transformer_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecutionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionPolicy) ProtoMessage() {}
+
+func (x *ExecutionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_transformer_spec_standard_transformer_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionPolicy.ProtoReflect.Descriptor instead.
+func (*ExecutionPolicy) Descriptor() ([]byte, []int) {
+	return file_transformer_spec_standard_transformer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExecutionPolicy) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ExecutionPolicy) GetOnTimeout() OnTimeoutPolicy {
+	if x != nil {
+		return x.OnTimeout
+	}
+	return OnTimeoutPolicy_FAIL
+}
+
+// ExplanationOutput maps feature attributions returned by the model back to the feature names
+type ExplanationOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeatureTableName     string `protobuf:"bytes,1,opt,name=featureTableName,proto3" json:"featureTableName,omitempty"`         // Preprocess table whose column order is the feature order of the attributions, default to the table sent to the model by preprocess output
+	AttributionJsonPath  string `protobuf:"bytes,2,opt,name=attributionJsonPath,proto3" json:"attributionJsonPath,omitempty"`   // JSONPath of attributions in model response, one attribution array per prediction
+	AttributionTableName string `protobuf:"bytes,3,opt,name=attributionTableName,proto3" json:"attributionTableName,omitempty"` // Table containing attributions, one row per prediction and one column per feature
+	TopK                 int32  `protobuf:"varint,4,opt,name=topK,proto3" json:"topK,omitempty"`                                // Number of features with the highest absolute attribution returned per prediction, all features are returned if not set
+	OutputName           string `protobuf:"bytes,5,opt,name=outputName,proto3" json:"outputName,omitempty"`                     // Name of response field (HTTP_JSON) or prediction result table column (UPI_V1) containing the explanations, default to "explanations"
+}
+
+func (x *ExplanationOutput) Reset() {
+	*x = ExplanationOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transformer_spec_standard_transformer_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplanationOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplanationOutput) ProtoMessage() {}
+
+func (x *ExplanationOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_transformer_spec_standard_transformer_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplanationOutput.ProtoReflect.Descriptor instead.
+func (*ExplanationOutput) Descriptor() ([]byte, []int) {
+	return file_transformer_spec_standard_transformer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExplanationOutput) GetFeatureTableName() string {
+	if x != nil {
+		return x.FeatureTableName
+	}
+	return ""
+}
+
+func (x *ExplanationOutput) GetAttributionJsonPath() string {
+	if x != nil {
+		return x.AttributionJsonPath
+	}
+	return ""
+}
+
+func (x *ExplanationOutput) GetAttributionTableName() string {
+	if x != nil {
+		return x.AttributionTableName
+	}
+	return ""
+}
+
+func (x *ExplanationOutput) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *ExplanationOutput) GetOutputName() string {
+	if x != nil {
+		return x.OutputName
+	}
+	return ""
+}
+
 var File_transformer_spec_standard_transformer_proto protoreflect.FileDescriptor
 
 var file_transformer_spec_standard_transformer_proto_rawDesc = []byte{
@@ -454,7 +664,9 @@ var file_transformer_spec_standard_transformer_proto_rawDesc = []byte{
 	0x2f, 0x75, 0x70, 0x69, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x25, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72,
 	0x2f, 0x73, 0x70, 0x65, 0x63, 0x2f, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcb, 0x01, 0x0a, 0x19, 0x53,
+	0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcb, 0x01, 0x0a, 0x19, 0x53,
 	0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
 	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x53, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e,
 	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20,
@@ -492,7 +704,7 @@ var file_transformer_spec_standard_transformer_proto_rawDesc = []byte{
 	0x6e, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20,
 	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61,
 	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52,
-	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0xa4, 0x02, 0x0a, 0x05, 0x49, 0x6e, 0x70,
+	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0xf3, 0x02, 0x0a, 0x05, 0x49, 0x6e, 0x70,
 	0x75, 0x74, 0x12, 0x3a, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18,
 	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74,
 	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61,
@@ -510,42 +722,83 @@ var file_transformer_spec_standard_transformer_proto_rawDesc = []byte{
 	0x72, 0x73, 0x12, 0x3b, 0x0a, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72,
 	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x55, 0x50, 0x49, 0x41, 0x75, 0x74,
-	0x6f, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x61, 0x64, 0x22,
-	0xe4, 0x01, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x12,
-	0x59, 0x0a, 0x13, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6d,
+	0x6f, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x4d, 0x0a, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69,
+	0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0xb3,
+	0x02, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x3b, 0x0a, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4a,
+	0x6f, 0x69, 0x6e, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x59,
+	0x0a, 0x13, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6d, 0x65,
+	0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72,
+	0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x09, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d,
 	0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65,
-	0x72, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x09, 0x76, 0x61,
-	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
-	0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
-	0x65, 0x72, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x09, 0x76, 0x61, 0x72,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0x81, 0x02, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x12, 0x3e, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x4a, 0x73, 0x6f, 0x6e, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x12, 0x59, 0x0a, 0x13, 0x75, 0x70, 0x69, 0x50, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27,
+	0x72, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x4d, 0x0a, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
 	0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
-	0x6d, 0x65, 0x72, 0x2e, 0x55, 0x50, 0x49, 0x50, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
-	0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x13, 0x75, 0x70, 0x69, 0x50, 0x72, 0x65, 0x70,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x5c, 0x0a, 0x14,
-	0x75, 0x70, 0x69, 0x50, 0x6f, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6d, 0x65, 0x72,
-	0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e,
-	0x55, 0x50, 0x49, 0x50, 0x6f, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x52, 0x14, 0x75, 0x70, 0x69, 0x50, 0x6f, 0x73, 0x74, 0x70, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x61, 0x72, 0x61, 0x6d, 0x6c, 0x2d,
-	0x64, 0x65, 0x76, 0x2f, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2f, 0x73, 0x70, 0x65, 0x63, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x22, 0xd6, 0x02, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12,
+	0x3e, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x4a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12,
+	0x59, 0x0a, 0x13, 0x75, 0x70, 0x69, 0x50, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6d,
+	0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65,
+	0x72, 0x2e, 0x55, 0x50, 0x49, 0x50, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x13, 0x75, 0x70, 0x69, 0x50, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x5c, 0x0a, 0x14, 0x75, 0x70,
+	0x69, 0x50, 0x6f, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69,
+	0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x55, 0x50,
+	0x49, 0x50, 0x6f, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x52, 0x14, 0x75, 0x70, 0x69, 0x50, 0x6f, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x53, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x6c,
+	0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x11, 0x65, 0x78, 0x70, 0x6c,
+	0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x89, 0x01,
+	0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x41, 0x0a, 0x09, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6d, 0x65, 0x72, 0x6c,
+	0x69, 0x6e, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2e, 0x4f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x09,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xd9, 0x01, 0x0a, 0x11, 0x45, 0x78,
+	0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12,
+	0x2a, 0x0a, 0x10, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x66, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x61,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x73, 0x6f, 0x6e, 0x50, 0x61,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x12, 0x32, 0x0a,
+	0x14, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x74, 0x6f, 0x70, 0x4b, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x2a, 0x36, 0x0a, 0x0f, 0x4f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x41, 0x49, 0x4c,
+	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x53, 0x45, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c,
+	0x54, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x4b, 0x49, 0x50, 0x10, 0x02, 0x42, 0x33, 0x5a,
+	0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x61, 0x72, 0x61,
+	0x6d, 0x6c, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x6d, 0x65, 0x72, 0x6c, 0x69, 0x6e, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x72, 0x2f, 0x73, 0x70,
+	0x65, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -560,51 +813,61 @@ func file_transformer_spec_standard_transformer_proto_rawDescGZIP() []byte {
 	return file_transformer_spec_standard_transformer_proto_rawDescData
 }
 
-var file_transformer_spec_standard_transformer_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_transformer_spec_standard_transformer_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_transformer_spec_standard_transformer_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_transformer_spec_standard_transformer_proto_goTypes = []interface{}{
-	(*StandardTransformerConfig)(nil), // 0: merlin.transformer.StandardTransformerConfig
-	(*TransformerConfig)(nil),         // 1: merlin.transformer.TransformerConfig
-	(*Pipeline)(nil),                  // 2: merlin.transformer.Pipeline
-	(*Input)(nil),                     // 3: merlin.transformer.Input
-	(*Transformation)(nil),            // 4: merlin.transformer.Transformation
-	(*Output)(nil),                    // 5: merlin.transformer.Output
-	(*PredictionLogConfig)(nil),       // 6: merlin.transformer.PredictionLogConfig
-	(*FeatureTable)(nil),              // 7: merlin.transformer.FeatureTable
-	(*Variable)(nil),                  // 8: merlin.transformer.Variable
-	(*Table)(nil),                     // 9: merlin.transformer.Table
-	(*Encoder)(nil),                   // 10: merlin.transformer.Encoder
-	(*UPIAutoload)(nil),               // 11: merlin.transformer.UPIAutoload
-	(*TableJoin)(nil),                 // 12: merlin.transformer.TableJoin
-	(*TableTransformation)(nil),       // 13: merlin.transformer.TableTransformation
-	(*JsonOutput)(nil),                // 14: merlin.transformer.JsonOutput
-	(*UPIPreprocessOutput)(nil),       // 15: merlin.transformer.UPIPreprocessOutput
-	(*UPIPostprocessOutput)(nil),      // 16: merlin.transformer.UPIPostprocessOutput
+	(OnTimeoutPolicy)(0),              // 0: merlin.transformer.OnTimeoutPolicy
+	(*StandardTransformerConfig)(nil), // 1: merlin.transformer.StandardTransformerConfig
+	(*TransformerConfig)(nil),         // 2: merlin.transformer.TransformerConfig
+	(*Pipeline)(nil),                  // 3: merlin.transformer.Pipeline
+	(*Input)(nil),                     // 4: merlin.transformer.Input
+	(*Transformation)(nil),            // 5: merlin.transformer.Transformation
+	(*Output)(nil),                    // 6: merlin.transformer.Output
+	(*ExecutionPolicy)(nil),           // 7: merlin.transformer.ExecutionPolicy
+	(*ExplanationOutput)(nil),         // 8: merlin.transformer.ExplanationOutput
+	(*PredictionLogConfig)(nil),       // 9: merlin.transformer.PredictionLogConfig
+	(*FeatureTable)(nil),              // 10: merlin.transformer.FeatureTable
+	(*Variable)(nil),                  // 11: merlin.transformer.Variable
+	(*Table)(nil),                     // 12: merlin.transformer.Table
+	(*Encoder)(nil),                   // 13: merlin.transformer.Encoder
+	(*UPIAutoload)(nil),               // 14: merlin.transformer.UPIAutoload
+	(*TableJoin)(nil),                 // 15: merlin.transformer.TableJoin
+	(*TableTransformation)(nil),       // 16: merlin.transformer.TableTransformation
+	(*JsonOutput)(nil),                // 17: merlin.transformer.JsonOutput
+	(*UPIPreprocessOutput)(nil),       // 18: merlin.transformer.UPIPreprocessOutput
+	(*UPIPostprocessOutput)(nil),      // 19: merlin.transformer.UPIPostprocessOutput
+	(*durationpb.Duration)(nil),       // 20: google.protobuf.Duration
 }
 var file_transformer_spec_standard_transformer_proto_depIdxs = []int32{
-	1,  // 0: merlin.transformer.StandardTransformerConfig.transformerConfig:type_name -> merlin.transformer.TransformerConfig
-	6,  // 1: merlin.transformer.StandardTransformerConfig.predictionLogConfig:type_name -> merlin.transformer.PredictionLogConfig
-	7,  // 2: merlin.transformer.TransformerConfig.feast:type_name -> merlin.transformer.FeatureTable
-	2,  // 3: merlin.transformer.TransformerConfig.preprocess:type_name -> merlin.transformer.Pipeline
-	2,  // 4: merlin.transformer.TransformerConfig.postprocess:type_name -> merlin.transformer.Pipeline
-	3,  // 5: merlin.transformer.Pipeline.inputs:type_name -> merlin.transformer.Input
-	4,  // 6: merlin.transformer.Pipeline.transformations:type_name -> merlin.transformer.Transformation
-	5,  // 7: merlin.transformer.Pipeline.outputs:type_name -> merlin.transformer.Output
-	8,  // 8: merlin.transformer.Input.variables:type_name -> merlin.transformer.Variable
-	7,  // 9: merlin.transformer.Input.feast:type_name -> merlin.transformer.FeatureTable
-	9,  // 10: merlin.transformer.Input.tables:type_name -> merlin.transformer.Table
-	10, // 11: merlin.transformer.Input.encoders:type_name -> merlin.transformer.Encoder
-	11, // 12: merlin.transformer.Input.autoload:type_name -> merlin.transformer.UPIAutoload
-	12, // 13: merlin.transformer.Transformation.tableJoin:type_name -> merlin.transformer.TableJoin
-	13, // 14: merlin.transformer.Transformation.tableTransformation:type_name -> merlin.transformer.TableTransformation
-	8,  // 15: merlin.transformer.Transformation.variables:type_name -> merlin.transformer.Variable
-	14, // 16: merlin.transformer.Output.jsonOutput:type_name -> merlin.transformer.JsonOutput
-	15, // 17: merlin.transformer.Output.upiPreprocessOutput:type_name -> merlin.transformer.UPIPreprocessOutput
-	16, // 18: merlin.transformer.Output.upiPostprocessOutput:type_name -> merlin.transformer.UPIPostprocessOutput
-	19, // [19:19] is the sub-list for method output_type
-	19, // [19:19] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	2,  // 0: merlin.transformer.StandardTransformerConfig.transformerConfig:type_name -> merlin.transformer.TransformerConfig
+	9,  // 1: merlin.transformer.StandardTransformerConfig.predictionLogConfig:type_name -> merlin.transformer.PredictionLogConfig
+	10, // 2: merlin.transformer.TransformerConfig.feast:type_name -> merlin.transformer.FeatureTable
+	3,  // 3: merlin.transformer.TransformerConfig.preprocess:type_name -> merlin.transformer.Pipeline
+	3,  // 4: merlin.transformer.TransformerConfig.postprocess:type_name -> merlin.transformer.Pipeline
+	4,  // 5: merlin.transformer.Pipeline.inputs:type_name -> merlin.transformer.Input
+	5,  // 6: merlin.transformer.Pipeline.transformations:type_name -> merlin.transformer.Transformation
+	6,  // 7: merlin.transformer.Pipeline.outputs:type_name -> merlin.transformer.Output
+	11, // 8: merlin.transformer.Input.variables:type_name -> merlin.transformer.Variable
+	10, // 9: merlin.transformer.Input.feast:type_name -> merlin.transformer.FeatureTable
+	12, // 10: merlin.transformer.Input.tables:type_name -> merlin.transformer.Table
+	13, // 11: merlin.transformer.Input.encoders:type_name -> merlin.transformer.Encoder
+	14, // 12: merlin.transformer.Input.autoload:type_name -> merlin.transformer.UPIAutoload
+	7,  // 13: merlin.transformer.Input.executionPolicy:type_name -> merlin.transformer.ExecutionPolicy
+	15, // 14: merlin.transformer.Transformation.tableJoin:type_name -> merlin.transformer.TableJoin
+	16, // 15: merlin.transformer.Transformation.tableTransformation:type_name -> merlin.transformer.TableTransformation
+	11, // 16: merlin.transformer.Transformation.variables:type_name -> merlin.transformer.Variable
+	7,  // 17: merlin.transformer.Transformation.executionPolicy:type_name -> merlin.transformer.ExecutionPolicy
+	17, // 18: merlin.transformer.Output.jsonOutput:type_name -> merlin.transformer.JsonOutput
+	18, // 19: merlin.transformer.Output.upiPreprocessOutput:type_name -> merlin.transformer.UPIPreprocessOutput
+	19, // 20: merlin.transformer.Output.upiPostprocessOutput:type_name -> merlin.transformer.UPIPostprocessOutput
+	8,  // 21: merlin.transformer.Output.explanationOutput:type_name -> merlin.transformer.ExplanationOutput
+	20, // 22: merlin.transformer.ExecutionPolicy.timeout:type_name -> google.protobuf.Duration
+	0,  // 23: merlin.transformer.ExecutionPolicy.onTimeout:type_name -> merlin.transformer.OnTimeoutPolicy
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_transformer_spec_standard_transformer_proto_init() }
@@ -693,19 +956,44 @@ func file_transformer_spec_standard_transformer_proto_init() {
 				return nil
 			}
 		}
+		file_transformer_spec_standard_transformer_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecutionPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_transformer_spec_standard_transformer_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplanationOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_transformer_spec_standard_transformer_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_transformer_spec_standard_transformer_proto_goTypes,
 		DependencyIndexes: file_transformer_spec_standard_transformer_proto_depIdxs,
+		EnumInfos:         file_transformer_spec_standard_transformer_proto_enumTypes,
 		MessageInfos:      file_transformer_spec_standard_transformer_proto_msgTypes,
 	}.Build()
 	File_transformer_spec_standard_transformer_proto = out.File
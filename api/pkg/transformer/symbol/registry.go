@@ -28,6 +28,16 @@ const (
 // All exported method of Registry is accessible as built-in function
 type Registry map[string]interface{}
 
+// IsReservedSymbol returns true if the symbol is set internally by the registry instead of by the pipeline operations
+func IsReservedSymbol(name string) bool {
+	switch name {
+	case sourceKey, compiledJSONPathKey, rawRequestHeadersKey, modelResponseHeadersKey, preprocessResponseKey, preprocessTracingKey, postprocessTracingKey:
+		return true
+	default:
+		return false
+	}
+}
+
 func NewRegistryWithCompiledJSONPath(compiledJSONPaths *jsonpath.Storage) Registry {
 	r := Registry{}
 	r[compiledJSONPathKey] = compiledJSONPaths
@@ -20,6 +20,7 @@ const (
 	UPIAutoloadingOp       OperationType = "upi_autoloading_op"
 	UPIPreprocessOutputOp  OperationType = "upi_preprocess_output_op"
 	UPIPostprocessOutputOp OperationType = "upi_postprocess_output_op"
+	ExplanationOutputOp    OperationType = "explanation_output_op"
 )
 
 type PredictResponse struct {
@@ -31,3 +32,17 @@ type OperationTracing struct {
 	PreprocessTracing  []TracingDetail `json:"preprocess"`
 	PostprocessTracing []TracingDetail `json:"postprocess"`
 }
+
+// DebugTrace contains the operations executed by the pipeline when serving a request in debug mode
+type DebugTrace struct {
+	PreprocessTrace  []OperationDebugDetail `json:"preprocess"`
+	PostprocessTrace []OperationDebugDetail `json:"postprocess"`
+}
+
+// OperationDebugDetail contains the variables and tables set by an operation and its execution duration
+type OperationDebugDetail struct {
+	OpType     OperationType          `json:"operation_type"`
+	Output     map[string]interface{} `json:"output"`
+	DurationMs float64                `json:"duration_ms"`
+	Skipped    bool                   `json:"skipped,omitempty"`
+}
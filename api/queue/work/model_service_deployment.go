@@ -117,6 +117,8 @@ func (depl *ModelServiceDeployment) Deploy(job *queue.Job) error {
 			endpoint.RevisionID,
 			endpoint.ID,
 		)
+		// the aborted deployment is retried with the configuration of this job
+		deployment.Spec = models.NewDeploymentSpec(endpoint)
 	} else {
 		log.Infof("creating deployment for model %s version %s revision %s with endpoint id: %s", model.Name, endpoint.VersionID, endpoint.RevisionID, endpoint.ID)
 		deployment, err = depl.DeploymentStorage.Save(&models.Deployment{
@@ -125,6 +127,7 @@ func (depl *ModelServiceDeployment) Deploy(job *queue.Job) error {
 			VersionID:         endpoint.VersionID,
 			VersionEndpointID: endpoint.ID,
 			Status:            models.EndpointPending,
+			Spec:              models.NewDeploymentSpec(endpoint),
 		})
 		// record the deployment process
 		if err != nil {
@@ -88,7 +88,12 @@ func TestExecuteDeployment(t *testing.T) {
 				Namespace:       project.Name,
 			},
 			deploymentStorage: func() *mocks.DeploymentStorage {
-				mockStorage := createDefaultMockDeploymentStorage()
+				mockStorage := &mocks.DeploymentStorage{}
+				mockStorage.On("GetLatestDeployment", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+				mockStorage.On("Save", mock.MatchedBy(func(deployment *models.Deployment) bool {
+					return deployment.Spec != nil && deployment.Spec.ResourceRequest.MaxReplica == env.DefaultResourceRequest.MaxReplica &&
+						deployment.Spec.ResourceRequest.CPURequest.Equal(env.DefaultResourceRequest.CPURequest)
+				})).Return(&models.Deployment{}, nil)
 				mockStorage.On("OnDeploymentSuccess", mock.Anything).Return(nil)
 				return mockStorage
 			},
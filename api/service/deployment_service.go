@@ -7,6 +7,7 @@ import (
 
 type DeploymentService interface {
 	ListDeployments(modelID, versionID, endpointUUID string) ([]*models.Deployment, error)
+	GetDeployment(deploymentID models.ID) (*models.Deployment, error)
 }
 
 func NewDeploymentService(storage storage.DeploymentStorage) DeploymentService {
@@ -23,3 +24,7 @@ func (service *deploymentService) ListDeployments(modelID, versionID, endpointUU
 	// TODO: Add pagination
 	return service.storage.ListInModelVersion(modelID, versionID, endpointUUID)
 }
+
+func (service *deploymentService) GetDeployment(deploymentID models.ID) (*models.Deployment, error) {
+	return service.storage.Get(deploymentID)
+}
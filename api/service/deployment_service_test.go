@@ -86,3 +86,56 @@ func Test_deploymentService_ListDeployments(t *testing.T) {
 		})
 	}
 }
+
+func Test_deploymentService_GetDeployment(t *testing.T) {
+	deployment := &models.Deployment{
+		ID:                models.ID(2),
+		VersionEndpointID: uuid.New(),
+		Status:            models.EndpointRunning,
+		Spec:              &models.DeploymentSpec{EnvVars: models.EnvVars{{Name: "WORKERS", Value: "2"}}},
+	}
+
+	tests := []struct {
+		name                  string
+		deploymentID          models.ID
+		mockDeploymentStorage func() *mocks.DeploymentStorage
+		want                  *models.Deployment
+		wantErr               bool
+	}{
+		{
+			name:         "success",
+			deploymentID: models.ID(2),
+			mockDeploymentStorage: func() *mocks.DeploymentStorage {
+				mockStorage := &mocks.DeploymentStorage{}
+				mockStorage.On("Get", models.ID(2)).Return(deployment, nil)
+				return mockStorage
+			},
+			want: deployment,
+		},
+		{
+			name:         "not found",
+			deploymentID: models.ID(3),
+			mockDeploymentStorage: func() *mocks.DeploymentStorage {
+				mockStorage := &mocks.DeploymentStorage{}
+				mockStorage.On("Get", models.ID(3)).Return(nil, fmt.Errorf("record not found"))
+				return mockStorage
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &deploymentService{
+				storage: tt.mockDeploymentStorage(),
+			}
+			got, err := service.GetDeployment(tt.deploymentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deploymentService.GetDeployment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deploymentService.GetDeployment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,32 @@ type DeploymentService struct {
 	mock.Mock
 }
 
+// GetDeployment provides a mock function with given fields: deploymentID
+func (_m *DeploymentService) GetDeployment(deploymentID models.ID) (*models.Deployment, error) {
+	ret := _m.Called(deploymentID)
+
+	var r0 *models.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.ID) (*models.Deployment, error)); ok {
+		return rf(deploymentID)
+	}
+	if rf, ok := ret.Get(0).(func(models.ID) *models.Deployment); ok {
+		r0 = rf(deploymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.ID) error); ok {
+		r1 = rf(deploymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDeployments provides a mock function with given fields: modelID, versionID, endpointUUID
 func (_m *DeploymentService) ListDeployments(modelID string, versionID string, endpointUUID string) ([]*models.Deployment, error) {
 	ret := _m.Called(modelID, versionID, endpointUUID)
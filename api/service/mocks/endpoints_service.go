@@ -80,6 +80,32 @@ func (_m *EndpointsService) DeployEndpoint(ctx context.Context, environment *mod
 	return r0, r1
 }
 
+// DryRunEndpoint provides a mock function with given fields: ctx, environment, model, version, endpoint
+func (_m *EndpointsService) DryRunEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.DeploymentPlan, error) {
+	ret := _m.Called(ctx, environment, model, version, endpoint)
+
+	var r0 *models.DeploymentPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Environment, *models.Model, *models.Version, *models.VersionEndpoint) (*models.DeploymentPlan, error)); ok {
+		return rf(ctx, environment, model, version, endpoint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Environment, *models.Model, *models.Version, *models.VersionEndpoint) *models.DeploymentPlan); ok {
+		r0 = rf(ctx, environment, model, version, endpoint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeploymentPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Environment, *models.Model, *models.Version, *models.VersionEndpoint) error); ok {
+		r1 = rf(ctx, environment, model, version, endpoint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, endpointUuid
 func (_m *EndpointsService) FindByID(ctx context.Context, endpointUuid uuid.UUID) (*models.VersionEndpoint, error) {
 	ret := _m.Called(ctx, endpointUuid)
@@ -158,6 +184,32 @@ func (_m *EndpointsService) ListEndpoints(ctx context.Context, model *models.Mod
 	return r0, r1
 }
 
+// RollbackEndpoint provides a mock function with given fields: ctx, environment, model, version, endpoint, deployment
+func (_m *EndpointsService) RollbackEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint, deployment *models.Deployment) (*models.VersionEndpoint, error) {
+	ret := _m.Called(ctx, environment, model, version, endpoint, deployment)
+
+	var r0 *models.VersionEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Environment, *models.Model, *models.Version, *models.VersionEndpoint, *models.Deployment) (*models.VersionEndpoint, error)); ok {
+		return rf(ctx, environment, model, version, endpoint, deployment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Environment, *models.Model, *models.Version, *models.VersionEndpoint, *models.Deployment) *models.VersionEndpoint); ok {
+		r0 = rf(ctx, environment, model, version, endpoint, deployment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VersionEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Environment, *models.Model, *models.Version, *models.VersionEndpoint, *models.Deployment) error); ok {
+		r1 = rf(ctx, environment, model, version, endpoint, deployment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UndeployEndpoint provides a mock function with given fields: ctx, environment, model, version, endpoint
 func (_m *EndpointsService) UndeployEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.VersionEndpoint, error) {
 	ret := _m.Called(ctx, environment, model, version, endpoint)
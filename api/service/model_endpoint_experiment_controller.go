@@ -0,0 +1,153 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/rand"
+
+	"github.com/caraml-dev/merlin/log"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/storage"
+)
+
+// ModelEndpointExperimentController adjusts the weights of the variants of the model endpoints' bandit experiments.
+//
+// On every sync, the weights of each bandit experiment are recomputed by Thompson sampling the rewards of its
+// variants. The model endpoint's VirtualService is patched when a weight changes by at least minWeightChange, which
+// reassigns the units of the buckets moving between variants. The syncs of a controller are serialized, and each
+// update is claimed in the database so that the API server replicas don't update the same experiment concurrently.
+type ModelEndpointExperimentController struct {
+	modelEndpointsService ModelEndpointsService
+	modelsService         ModelsService
+	modelEndpointStorage  storage.ModelEndpointStorage
+	rewardStorage         storage.ExperimentRewardStorage
+	minWeightChange       int32
+
+	mu sync.Mutex
+}
+
+// NewModelEndpointExperimentController returns an initialized ModelEndpointExperimentController.
+func NewModelEndpointExperimentController(
+	modelEndpointsService ModelEndpointsService,
+	modelsService ModelsService,
+	modelEndpointStorage storage.ModelEndpointStorage,
+	rewardStorage storage.ExperimentRewardStorage,
+	minWeightChange int32,
+) *ModelEndpointExperimentController {
+	return &ModelEndpointExperimentController{
+		modelEndpointsService: modelEndpointsService,
+		modelsService:         modelsService,
+		modelEndpointStorage:  modelEndpointStorage,
+		rewardStorage:         rewardStorage,
+		minWeightChange:       minWeightChange,
+	}
+}
+
+// Sync adjusts the weights of all bandit experiments
+func (c *ModelEndpointExperimentController) Sync() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx := context.Background()
+
+	endpoints, err := c.modelEndpointStorage.ListBanditExperiments(ctx)
+	if err != nil {
+		log.Errorf("failed to list bandit experiments: %v", err)
+		return
+	}
+
+	src := rand.NewSource(uint64(time.Now().UnixNano()))
+	for _, endpoint := range endpoints {
+		if err := c.syncBandit(ctx, endpoint, src); err != nil {
+			log.Errorf("failed to sync bandit experiment of model endpoint %d: %v", endpoint.ID, err)
+		}
+	}
+}
+
+func (c *ModelEndpointExperimentController) syncBandit(ctx context.Context, endpoint *models.ModelEndpoint, src rand.Source) error {
+	experiment := endpoint.Experiment
+
+	rewards, err := c.rewardStorage.List(ctx, endpoint.ID, experiment.Name)
+	if err != nil {
+		return errors.Wrap(err, "failed to list rewards")
+	}
+	// the weights are kept until the first reward is reported
+	if len(rewards) == 0 {
+		return nil
+	}
+
+	weights := experiment.ThompsonWeights(rewards, src)
+	if !c.changed(experiment.Weights(), weights) {
+		return nil
+	}
+
+	model, err := c.modelsService.FindByID(ctx, endpoint.ModelID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find model %d", endpoint.ModelID)
+	}
+
+	now := time.Now()
+	newExperiment := *experiment
+	newExperiment.Bandit = &models.ExperimentBandit{MinWeight: experiment.Bandit.MinWeight, UpdatedAt: &now}
+	newExperiment.Variants = make([]*models.ExperimentVariant, 0, len(experiment.Variants))
+	for i, variant := range experiment.Variants {
+		newVariant := *variant
+		newVariant.Weight = weights[i]
+		newExperiment.Variants = append(newExperiment.Variants, &newVariant)
+	}
+
+	// Claim the update so that it isn't applied concurrently by another API server replica syncing the same experiment
+	claimed, err := c.modelEndpointStorage.UpdateExperimentBandit(ctx, endpoint.ID, experiment.Bandit, newExperiment.Bandit)
+	if err != nil {
+		return errors.Wrap(err, "failed to claim experiment update")
+	}
+	if !claimed {
+		log.Infof("bandit experiment of model endpoint %d has been changed since it was read, skipping", endpoint.ID)
+		return nil
+	}
+
+	newEndpoint := *endpoint
+	newEndpoint.Experiment = &newExperiment
+	if _, err := c.modelEndpointsService.UpdateEndpoint(ctx, model, endpoint, &newEndpoint); err != nil {
+		// Release the update so that it is retried on the next sync
+		if _, releaseErr := c.modelEndpointStorage.UpdateExperimentBandit(ctx, endpoint.ID, newExperiment.Bandit, experiment.Bandit); releaseErr != nil {
+			log.Errorf("failed to release experiment update of model endpoint %d: %v", endpoint.ID, releaseErr)
+		}
+		return errors.Wrap(err, "failed to update model endpoint")
+	}
+
+	log.Infof("bandit experiment %s of model endpoint %d: weights updated to %v", experiment.Name, endpoint.ID, weights)
+	return nil
+}
+
+// changed returns true if the weight of a variant changes by at least minWeightChange, the sampled weights jitter
+// between syncs even when the rewards don't change much
+func (c *ModelEndpointExperimentController) changed(weights []int32, newWeights []int32) bool {
+	for i, weight := range weights {
+		delta := newWeights[i] - weight
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta > 0 && delta >= c.minWeightChange {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+	networking "istio.io/api/networking/v1beta1"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/istio"
+	istioCliMock "github.com/caraml-dev/merlin/istio/mocks"
+	"github.com/caraml-dev/merlin/models"
+	eventMock "github.com/caraml-dev/merlin/pkg/observability/event/mocks"
+	"github.com/caraml-dev/merlin/pkg/protocol"
+	storageMock "github.com/caraml-dev/merlin/storage/mocks"
+)
+
+func TestModelEndpointExperimentController_syncBandit(t *testing.T) {
+	control := &models.VersionEndpoint{
+		ID:                   uuid.New(),
+		VersionID:            1,
+		VersionModelID:       1,
+		Status:               models.EndpointServing,
+		URL:                  "http://version-1.project-1.mlp.io/v1/models/version-1:predict",
+		InferenceServiceName: "version-1",
+		Namespace:            "project-1",
+		Protocol:             protocol.HttpJson,
+	}
+	treatment := &models.VersionEndpoint{
+		ID:                   uuid.New(),
+		VersionID:            2,
+		VersionModelID:       1,
+		Status:               models.EndpointServing,
+		URL:                  "http://version-2.project-1.mlp.io/v1/models/version-2:predict",
+		InferenceServiceName: "version-2",
+		Namespace:            "project-1",
+		Protocol:             protocol.HttpJson,
+	}
+
+	newEndpoint := func() *models.ModelEndpoint {
+		experiment := &models.ModelEndpointExperiment{
+			Name:         "exp",
+			UnitIDHeader: "X-User-Id",
+			Variants: []*models.ExperimentVariant{
+				{Name: "control", VersionEndpointID: control.ID, Weight: 50},
+				{Name: "treatment", VersionEndpointID: treatment.ID, Weight: 50},
+			},
+			Bandit: &models.ExperimentBandit{MinWeight: 5},
+		}
+		return &models.ModelEndpoint{
+			ID:              1,
+			ModelID:         model1.ID,
+			Status:          models.EndpointServing,
+			EnvironmentName: env.Name,
+			Rule:            experimentRule(experiment, nil),
+			Experiment:      experiment,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		rewards []*models.ExperimentReward
+		// converged sets the current weights to the ones sampled from the rewards, shifted by jitter
+		converged bool
+		jitter    int32
+		// alreadyClaimed is set when the experiment has been updated by another replica since it was read
+		alreadyClaimed bool
+		wantWeights    []int32
+	}{
+		{
+			name: "no rewards",
+		},
+		{
+			name: "treatment is better",
+			rewards: []*models.ExperimentReward{
+				{Variant: "control", Successes: 100, Failures: 900},
+				{Variant: "treatment", Successes: 500, Failures: 500},
+			},
+			wantWeights: []int32{5, 95},
+		},
+		{
+			name: "weights unchanged",
+			rewards: []*models.ExperimentReward{
+				{Variant: "control", Successes: 100000, Failures: 100000},
+				{Variant: "treatment", Successes: 100000, Failures: 100000},
+			},
+			converged: true,
+		},
+		{
+			name: "weights changed less than the min weight change",
+			rewards: []*models.ExperimentReward{
+				{Variant: "control", Successes: 100000, Failures: 100000},
+				{Variant: "treatment", Successes: 100000, Failures: 100000},
+			},
+			converged: true,
+			jitter:    4,
+		},
+		{
+			name: "update already claimed",
+			rewards: []*models.ExperimentReward{
+				{Variant: "control", Successes: 100, Failures: 900},
+				{Variant: "treatment", Successes: 500, Failures: 500},
+			},
+			alreadyClaimed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := newEndpoint()
+			if tt.converged {
+				weights := endpoint.Experiment.ThompsonWeights(tt.rewards, rand.NewSource(1))
+				for i, weight := range weights {
+					endpoint.Experiment.Variants[i].Weight = weight
+				}
+				endpoint.Experiment.Variants[0].Weight += tt.jitter
+				endpoint.Experiment.Variants[1].Weight -= tt.jitter
+			}
+
+			istioClient := &istioCliMock.Client{}
+			istioClient.On("ApplyEnvoyFilter", mock.Anything, defaultExperimentFilterNamespace, mock.AnythingOfType("*v1alpha3.EnvoyFilter")).
+				Return(&v1alpha3.EnvoyFilter{}, nil)
+			var vs *v1beta1.VirtualService
+			istioClient.On("PatchVirtualService", mock.Anything, "project-1", mock.Anything).
+				Run(func(args mock.Arguments) {
+					vs = args.Get(2).(*v1beta1.VirtualService)
+				}).
+				Return(&v1beta1.VirtualService{Spec: networking.VirtualService{Hosts: []string{"model-1.project-1.mlp.io"}}}, nil)
+
+			veStorage := &storageMock.VersionEndpointStorage{}
+			veStorage.On("Get", control.ID).Return(control, nil)
+			veStorage.On("Get", treatment.ID).Return(treatment, nil)
+
+			var saved *models.ModelEndpoint
+			meStorage := &storageMock.ModelEndpointStorage{}
+			meStorage.On("UpdateExperimentBandit", mock.Anything, endpoint.ID, endpoint.Experiment.Bandit, mock.AnythingOfType("*models.ExperimentBandit")).
+				Return(!tt.alreadyClaimed, nil)
+			meStorage.On("Save", mock.Anything, endpoint, mock.AnythingOfType("*models.ModelEndpoint")).
+				Run(func(args mock.Arguments) {
+					saved = args.Get(2).(*models.ModelEndpoint)
+				}).Return(nil)
+
+			rewardStorage := &storageMock.ExperimentRewardStorage{}
+			rewardStorage.On("List", mock.Anything, endpoint.ID, "exp").Return(tt.rewards, nil)
+
+			eventProducer := &eventMock.EventProducer{}
+			eventProducer.On("ModelEndpointChangeEvent", mock.Anything, mock.Anything).Return(nil)
+
+			modelEndpointsService := newModelEndpointsService(map[string]istio.Client{env.Name: istioClient}, meStorage, veStorage, testEnvironmentName, eventProducer, config.ExperimentConfig{})
+			controller := NewModelEndpointExperimentController(modelEndpointsService, &stubModelsService{model: model1}, meStorage, rewardStorage, 5)
+
+			err := controller.syncBandit(context.Background(), endpoint, rand.NewSource(1))
+			require.NoError(t, err)
+
+			if tt.wantWeights == nil {
+				assert.Nil(t, saved)
+				if !tt.alreadyClaimed {
+					meStorage.AssertNotCalled(t, "UpdateExperimentBandit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
+				istioClient.AssertNotCalled(t, "PatchVirtualService", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NotNil(t, saved)
+			assert.Equal(t, tt.wantWeights, saved.Experiment.Weights())
+			assert.NotNil(t, saved.Experiment.Bandit.UpdatedAt)
+			// the experiment in the database is only changed through the saved copy
+			assert.Equal(t, []int32{50, 50}, endpoint.Experiment.Weights())
+
+			var weights []int32
+			for _, destination := range saved.Rule.Destination {
+				weights = append(weights, destination.Weight)
+			}
+			assert.Equal(t, tt.wantWeights, weights)
+
+			// one route per variant matching its buckets, followed by the weighted route
+			require.NotNil(t, vs)
+			require.Len(t, vs.Spec.Http, 3)
+			assert.Equal(t, "experiment-control", vs.Spec.Http[0].Name)
+			assert.Equal(t, "^(0|1|2|3|4)$", vs.Spec.Http[0].Match[0].Headers[models.ExperimentBucketHeader].GetRegex())
+			assert.Equal(t, "experiment-treatment", vs.Spec.Http[1].Name)
+			assert.Equal(t, "version-2.project-1.mlp.io", vs.Spec.Http[1].Route[0].Headers.Request.Set["Host"])
+			assert.Len(t, vs.Spec.Http[2].Route, 2)
+		})
+	}
+}
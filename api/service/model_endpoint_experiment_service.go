@@ -0,0 +1,288 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	istiov1alpha3 "istio.io/api/networking/v1alpha3"
+	istiov1beta1 "istio.io/api/networking/v1beta1"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/pkg/protocol"
+	"github.com/caraml-dev/merlin/storage"
+)
+
+const (
+	// defaultExperimentFilterNamespace is the namespace of the experiments' EnvoyFilters if it isn't configured
+	defaultExperimentFilterNamespace = "istio-system"
+
+	// defaultExperimentGatewayPort is the port of the ingress gateway server if none is configured
+	defaultExperimentGatewayPort = 80
+
+	// experimentFilterCode hashes the unit id of the requests into a bucket, using the same 32-bit FNV-1a hash as
+	// models.ExperimentBucket. The multiplication by the FNV prime (2^24 + 403) is split so that the intermediate
+	// results stay within the integer precision of Lua numbers. The code runs only on the virtual hosts of the model
+	// endpoint, and the route is recomputed once the bucket header is set.
+	experimentFilterCode = `function envoy_on_request(request_handle)
+  local headers = request_handle:headers()
+  headers:remove(%q)
+  local unit = headers:get(%q)
+  if unit == nil or unit == "" then
+    return
+  end
+  local h = 2166136261
+  for i = 1, #unit do
+    h = bit.bxor(h, string.byte(unit, i)) %% 4294967296
+    h = (h * 403 + (h %% 256) * 16777216) %% 4294967296
+  end
+  headers:add(%q, tostring(h %% %d))
+end
+`
+
+	// experimentFilterDefaultCode is run by the experiment's Lua filter on the other virtual hosts of the gateway
+	experimentFilterDefaultCode = `function envoy_on_request(request_handle)
+end
+`
+)
+
+var (
+	// ErrInvalidExperiment is returned when the experiment of a model endpoint is invalid
+	ErrInvalidExperiment = errors.New("invalid experiment")
+
+	// defaultExperimentGatewaySelector selects the ingress gateway pods if no selector is configured
+	defaultExperimentGatewaySelector = map[string]string{"istio": "ingressgateway"}
+)
+
+// ModelEndpointExperimentService stores the rewards of the variants of the model endpoints' experiments
+type ModelEndpointExperimentService interface {
+	// AddRewards adds the successes and failures to the rewards of the variants of the model endpoint's experiment
+	AddRewards(ctx context.Context, endpoint *models.ModelEndpoint, rewards []*models.ExperimentReward) error
+	// ListRewards lists the total rewards of the variants of the model endpoint's experiment
+	ListRewards(ctx context.Context, endpoint *models.ModelEndpoint) ([]*models.ExperimentReward, error)
+}
+
+type modelEndpointExperimentService struct {
+	storage storage.ExperimentRewardStorage
+}
+
+// NewModelEndpointExperimentService creates new instance of ModelEndpointExperimentService
+func NewModelEndpointExperimentService(storage storage.ExperimentRewardStorage) ModelEndpointExperimentService {
+	return &modelEndpointExperimentService{storage: storage}
+}
+
+func (s *modelEndpointExperimentService) AddRewards(ctx context.Context, endpoint *models.ModelEndpoint, rewards []*models.ExperimentReward) error {
+	experiment := endpoint.Experiment
+	if experiment == nil {
+		return fmt.Errorf("%w: model endpoint %d has no experiment", ErrInvalidExperiment, endpoint.ID)
+	}
+
+	for i, reward := range rewards {
+		if experiment.Variant(reward.Variant) == nil {
+			return fmt.Errorf("%w: reward %d: unknown variant %q", ErrInvalidExperiment, i, reward.Variant)
+		}
+		if reward.Successes < 0 || reward.Failures < 0 {
+			return fmt.Errorf("%w: reward %d: successes and failures must not be negative", ErrInvalidExperiment, i)
+		}
+		reward.ModelEndpointID = endpoint.ID
+		reward.ExperimentName = experiment.Name
+	}
+
+	if err := s.storage.Add(ctx, rewards); err != nil {
+		return errors.Wrap(err, "failed to add experiment rewards")
+	}
+	return nil
+}
+
+func (s *modelEndpointExperimentService) ListRewards(ctx context.Context, endpoint *models.ModelEndpoint) ([]*models.ExperimentReward, error) {
+	if endpoint.Experiment == nil {
+		return []*models.ExperimentReward{}, nil
+	}
+	return s.storage.List(ctx, endpoint.ID, endpoint.Experiment.Name)
+}
+
+// validateExperiment validates the model endpoint's experiment, experiments and canary rollouts both own the weights of the rule
+func validateExperiment(endpoint *models.ModelEndpoint) error {
+	if err := endpoint.Experiment.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidExperiment, err)
+	}
+	if endpoint.Rollout.InProgress() || (endpoint.Rollout != nil && endpoint.Rollout.Status == nil) {
+		return fmt.Errorf("%w: experiment can't run during a canary rollout", ErrInvalidExperiment)
+	}
+	return nil
+}
+
+// experimentRule routes the traffic to the variants of the experiment by weight, keeping the routes and mirror of the base rule
+func experimentRule(experiment *models.ModelEndpointExperiment, base *models.ModelEndpointRule) *models.ModelEndpointRule {
+	rule := &models.ModelEndpointRule{}
+	if base != nil {
+		rule.Routes = base.Routes
+		rule.Mirror = base.Mirror
+		rule.MirrorPercentage = base.MirrorPercentage
+	}
+	for _, variant := range experiment.Variants {
+		rule.Destination = append(rule.Destination, &models.ModelEndpointRuleDestination{
+			VersionEndpointID: variant.VersionEndpointID,
+			Weight:            variant.Weight,
+		})
+	}
+	return rule
+}
+
+// createExperimentHttpRoutes returns one HTTP route per variant matching the range of buckets owned by the variant
+func createExperimentHttpRoutes(experiment *models.ModelEndpointExperiment, destinations []*models.ModelEndpointRuleDestination, value protocol.Protocol) []*istiov1beta1.HTTPRoute {
+	versionEndpoints := map[string]*models.VersionEndpoint{}
+	for _, destination := range destinations {
+		versionEndpoints[destination.VersionEndpointID.String()] = destination.VersionEndpoint
+	}
+
+	httpRoutes := make([]*istiov1beta1.HTTPRoute, 0, len(experiment.Variants))
+	for i, bucketRange := range models.ExperimentBucketRanges(experiment.Weights()) {
+		variant := experiment.Variants[i]
+		versionEndpoint, ok := versionEndpoints[variant.VersionEndpointID.String()]
+		if !ok || bucketRange[0] == bucketRange[1] {
+			continue
+		}
+
+		match := &istiov1beta1.HTTPMatchRequest{
+			Headers: map[string]*istiov1beta1.StringMatch{
+				models.ExperimentBucketHeader: createStringMatch(&models.StringMatch{Regex: models.ExperimentBucketRegex(bucketRange[0], bucketRange[1])}),
+			},
+		}
+		httpRoute := createHttpRoute(predictPath(versionEndpoint), match, []*istiov1beta1.HTTPRouteDestination{createHttpRouteDestination(versionEndpoint, 100)}, value)
+		httpRoute.Name = fmt.Sprintf("experiment-%s", variant.Name)
+		httpRoutes = append(httpRoutes, httpRoute)
+	}
+	return httpRoutes
+}
+
+// experimentFilterName returns the name of the EnvoyFilter of the model's endpoint experiment
+func experimentFilterName(model *models.Model) string {
+	return fmt.Sprintf("%s-%s-experiment", model.Project.Name, model.Name)
+}
+
+// experimentHttpFilterName returns the name of the Lua HTTP filter of the model's endpoint experiment
+func experimentHttpFilterName(model *models.Model) string {
+	return fmt.Sprintf("merlin.experiment.%s", experimentFilterName(model))
+}
+
+// withExperimentDefaults returns the experiment config with the defaults of the EnvoyFilter's namespace, gateway
+// selector and gateway ports
+func withExperimentDefaults(cfg config.ExperimentConfig) config.ExperimentConfig {
+	if cfg.EnvoyFilterNamespace == "" {
+		cfg.EnvoyFilterNamespace = defaultExperimentFilterNamespace
+	}
+	if len(cfg.GatewaySelector) == 0 {
+		cfg.GatewaySelector = defaultExperimentGatewaySelector
+	}
+	if len(cfg.GatewayPorts) == 0 {
+		cfg.GatewayPorts = []int{defaultExperimentGatewayPort}
+	}
+	return cfg
+}
+
+// createExperimentEnvoyFilter returns the EnvoyFilter setting the experiment bucket header of the requests to the model
+// endpoint's host. The Lua filter is inserted in the gateway's filter chain with a no-op code, which is overridden by
+// the experiment code on the virtual hosts of the model endpoint's host only.
+func createExperimentEnvoyFilter(model *models.Model, host string, experiment *models.ModelEndpointExperiment, labels map[string]string, cfg config.ExperimentConfig) (*v1alpha3.EnvoyFilter, error) {
+	filterName := experimentHttpFilterName(model)
+	filter, err := structpb.NewStruct(map[string]interface{}{
+		"name": filterName,
+		"typed_config": map[string]interface{}{
+			"@type":      "type.googleapis.com/envoy.extensions.filters.http.lua.v3.Lua",
+			"inlineCode": experimentFilterDefaultCode,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	code := fmt.Sprintf(experimentFilterCode, models.ExperimentBucketHeader, strings.ToLower(experiment.UnitIDHeader), models.ExperimentBucketHeader, models.ExperimentBuckets)
+	perRouteConfig, err := structpb.NewStruct(map[string]interface{}{
+		"typed_per_filter_config": map[string]interface{}{
+			filterName: map[string]interface{}{
+				"@type": "type.googleapis.com/envoy.extensions.filters.http.lua.v3.LuaPerRoute",
+				"source_code": map[string]interface{}{
+					"inline_string": code,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	configPatches := []*istiov1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		{
+			ApplyTo: istiov1alpha3.EnvoyFilter_HTTP_FILTER,
+			Match: &istiov1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+				Context: istiov1alpha3.EnvoyFilter_GATEWAY,
+				ObjectTypes: &istiov1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+					Listener: &istiov1alpha3.EnvoyFilter_ListenerMatch{
+						FilterChain: &istiov1alpha3.EnvoyFilter_ListenerMatch_FilterChainMatch{
+							Filter: &istiov1alpha3.EnvoyFilter_ListenerMatch_FilterMatch{
+								Name:      "envoy.filters.network.http_connection_manager",
+								SubFilter: &istiov1alpha3.EnvoyFilter_ListenerMatch_SubFilterMatch{Name: "envoy.filters.http.router"},
+							},
+						},
+					},
+				},
+			},
+			Patch: &istiov1alpha3.EnvoyFilter_Patch{
+				Operation: istiov1alpha3.EnvoyFilter_Patch_INSERT_BEFORE,
+				Value:     filter,
+			},
+		},
+	}
+	// the virtual hosts of a gateway are named after the host and the port of the gateway server
+	for _, port := range cfg.GatewayPorts {
+		configPatches = append(configPatches, &istiov1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+			ApplyTo: istiov1alpha3.EnvoyFilter_VIRTUAL_HOST,
+			Match: &istiov1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+				Context: istiov1alpha3.EnvoyFilter_GATEWAY,
+				ObjectTypes: &istiov1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_RouteConfiguration{
+					RouteConfiguration: &istiov1alpha3.EnvoyFilter_RouteConfigurationMatch{
+						Vhost: &istiov1alpha3.EnvoyFilter_RouteConfigurationMatch_VirtualHostMatch{
+							Name: fmt.Sprintf("%s:%d", host, port),
+						},
+					},
+				},
+			},
+			Patch: &istiov1alpha3.EnvoyFilter_Patch{
+				Operation: istiov1alpha3.EnvoyFilter_Patch_MERGE,
+				Value:     perRouteConfig,
+			},
+		})
+	}
+
+	return &v1alpha3.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      experimentFilterName(model),
+			Namespace: cfg.EnvoyFilterNamespace,
+			Labels:    labels,
+		},
+		Spec: istiov1alpha3.EnvoyFilter{
+			WorkloadSelector: &istiov1alpha3.WorkloadSelector{Labels: cfg.GatewaySelector},
+			ConfigPatches:    configPatches,
+		},
+	}, nil
+}
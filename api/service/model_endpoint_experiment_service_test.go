@@ -0,0 +1,234 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	istiov1alpha3 "istio.io/api/networking/v1alpha3"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/models"
+	storageMock "github.com/caraml-dev/merlin/storage/mocks"
+)
+
+func newTestExperiment() *models.ModelEndpointExperiment {
+	return &models.ModelEndpointExperiment{
+		Name:         "exp",
+		UnitIDHeader: "X-User-Id",
+		Variants: []*models.ExperimentVariant{
+			{Name: "control", VersionEndpointID: uuid.New(), Weight: 70},
+			{Name: "treatment", VersionEndpointID: uuid.New(), Weight: 30},
+		},
+	}
+}
+
+func TestModelEndpointExperimentService_AddRewards(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoint   *models.ModelEndpoint
+		rewards    []*models.ExperimentReward
+		storageErr error
+		wantErr    error
+	}{
+		{
+			name:     "success",
+			endpoint: &models.ModelEndpoint{ID: 1, Experiment: newTestExperiment()},
+			rewards:  []*models.ExperimentReward{{Variant: "control", Successes: 1}, {Variant: "treatment", Failures: 1}},
+		},
+		{
+			name:     "no experiment",
+			endpoint: &models.ModelEndpoint{ID: 1},
+			rewards:  []*models.ExperimentReward{{Variant: "control", Successes: 1}},
+			wantErr:  ErrInvalidExperiment,
+		},
+		{
+			name:     "unknown variant",
+			endpoint: &models.ModelEndpoint{ID: 1, Experiment: newTestExperiment()},
+			rewards:  []*models.ExperimentReward{{Variant: "other", Successes: 1}},
+			wantErr:  ErrInvalidExperiment,
+		},
+		{
+			name:     "negative reward",
+			endpoint: &models.ModelEndpoint{ID: 1, Experiment: newTestExperiment()},
+			rewards:  []*models.ExperimentReward{{Variant: "control", Successes: -1}},
+			wantErr:  ErrInvalidExperiment,
+		},
+		{
+			name:       "storage error",
+			endpoint:   &models.ModelEndpoint{ID: 1, Experiment: newTestExperiment()},
+			rewards:    []*models.ExperimentReward{{Variant: "control", Successes: 1}},
+			storageErr: errors.New("db is down"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rewardStorage := &storageMock.ExperimentRewardStorage{}
+			rewardStorage.On("Add", mock.Anything, tt.rewards).Return(tt.storageErr)
+
+			err := NewModelEndpointExperimentService(rewardStorage).AddRewards(context.Background(), tt.endpoint, tt.rewards)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				rewardStorage.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			case tt.storageErr != nil:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				for _, reward := range tt.rewards {
+					assert.Equal(t, tt.endpoint.ID, reward.ModelEndpointID)
+					assert.Equal(t, "exp", reward.ExperimentName)
+				}
+			}
+		})
+	}
+}
+
+func Test_validateExperiment(t *testing.T) {
+	invalid := newTestExperiment()
+	invalid.Variants[0].Weight = 10
+
+	tests := []struct {
+		name     string
+		endpoint *models.ModelEndpoint
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			endpoint: &models.ModelEndpoint{Experiment: newTestExperiment()},
+		},
+		{
+			name:     "invalid weights",
+			endpoint: &models.ModelEndpoint{Experiment: invalid},
+			wantErr:  true,
+		},
+		{
+			name: "finished rollout",
+			endpoint: &models.ModelEndpoint{
+				Experiment: newTestExperiment(),
+				Rollout:    &models.ModelEndpointRollout{Status: &models.RolloutStatus{Phase: models.RolloutPhasePromoted}},
+			},
+		},
+		{
+			name: "rollout in progress",
+			endpoint: &models.ModelEndpoint{
+				Experiment: newTestExperiment(),
+				Rollout:    &models.ModelEndpointRollout{Status: &models.RolloutStatus{Phase: models.RolloutPhaseProgressing}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rollout requested",
+			endpoint: &models.ModelEndpoint{
+				Experiment: newTestExperiment(),
+				Rollout:    &models.ModelEndpointRollout{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExperiment(tt.endpoint)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidExperiment)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_createExperimentEnvoyFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.ExperimentConfig
+		wantNamespace string
+		wantSelector  map[string]string
+		wantVhosts    []string
+	}{
+		{
+			name:          "default config",
+			wantNamespace: "istio-system",
+			wantSelector:  map[string]string{"istio": "ingressgateway"},
+			wantVhosts:    []string{"model-1.project-1.mlp.io:80"},
+		},
+		{
+			name: "configured gateway",
+			cfg: config.ExperimentConfig{
+				EnvoyFilterNamespace: "gateway",
+				GatewaySelector:      map[string]string{"app": "model-gateway"},
+				GatewayPorts:         []int{80, 443},
+			},
+			wantNamespace: "gateway",
+			wantSelector:  map[string]string{"app": "model-gateway"},
+			wantVhosts:    []string{"model-1.project-1.mlp.io:80", "model-1.project-1.mlp.io:443"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ef, err := createExperimentEnvoyFilter(model1, "model-1.project-1.mlp.io", newTestExperiment(), map[string]string{"app": "model-1"}, withExperimentDefaults(tt.cfg))
+			require.NoError(t, err)
+
+			assert.Equal(t, "project-1-model-1-experiment", ef.Name)
+			assert.Equal(t, tt.wantNamespace, ef.Namespace)
+			assert.Equal(t, tt.wantSelector, ef.Spec.WorkloadSelector.Labels)
+			require.Len(t, ef.Spec.ConfigPatches, 1+len(tt.wantVhosts))
+
+			// the Lua filter does nothing outside of the model endpoint's virtual hosts
+			filter := ef.Spec.ConfigPatches[0]
+			assert.Equal(t, istiov1alpha3.EnvoyFilter_HTTP_FILTER, filter.ApplyTo)
+			assert.Equal(t, "merlin.experiment.project-1-model-1-experiment", filter.Patch.Value.Fields["name"].GetStringValue())
+			assert.Equal(t, experimentFilterDefaultCode, filter.Patch.Value.Fields["typed_config"].GetStructValue().Fields["inlineCode"].GetStringValue())
+
+			for i, vhost := range tt.wantVhosts {
+				patch := ef.Spec.ConfigPatches[i+1]
+				assert.Equal(t, istiov1alpha3.EnvoyFilter_VIRTUAL_HOST, patch.ApplyTo)
+				assert.Equal(t, vhost, patch.Match.GetRouteConfiguration().GetVhost().GetName())
+
+				perRouteConfig := patch.Patch.Value.Fields["typed_per_filter_config"].GetStructValue().Fields["merlin.experiment.project-1-model-1-experiment"].GetStructValue()
+				code := perRouteConfig.Fields["source_code"].GetStructValue().Fields["inline_string"].GetStringValue()
+				assert.Contains(t, code, `headers:get("x-user-id")`)
+				assert.Contains(t, code, `headers:add("x-merlin-experiment-bucket", tostring(h % 100))`)
+				assert.NotContains(t, code, "%!")
+			}
+		})
+	}
+}
+
+// luaExperimentBucket mirrors the arithmetic of experimentFilterCode on float64, the number type of Lua
+func luaExperimentBucket(unitID string) int {
+	h := 2166136261.0
+	for i := 0; i < len(unitID); i++ {
+		h = float64(uint32(h) ^ uint32(unitID[i]))
+		h = math.Mod(h*403+math.Mod(h, 256)*16777216, 4294967296)
+	}
+	return int(math.Mod(h, models.ExperimentBuckets))
+}
+
+func TestExperimentFilterBucket(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		unitID := fmt.Sprintf("user-%d", i)
+		assert.Equal(t, models.ExperimentBucket(unitID), luaExperimentBucket(unitID), unitID)
+	}
+	assert.Equal(t, models.ExperimentBucket("\xff\xfe"), luaExperimentBucket("\xff\xfe"))
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	mlogs "github.com/caraml-dev/merlin/pkg/log"
+
+	"github.com/caraml-dev/merlin/models"
+)
+
+// ModelEndpointMirrorService compares the responses of the model endpoints' mirrors with their primary responses
+type ModelEndpointMirrorService interface {
+	// Report compares the mirrored responses of the given inference logs with the primary responses of the same requests
+	Report(ctx context.Context, model *models.Model, endpoint *models.ModelEndpoint, logs []*mlogs.InferenceLogMessage, tolerance float64) (*models.MirrorReport, error)
+}
+
+type modelEndpointMirrorService struct{}
+
+// NewModelEndpointMirrorService creates new instance of ModelEndpointMirrorService
+func NewModelEndpointMirrorService() ModelEndpointMirrorService {
+	return &modelEndpointMirrorService{}
+}
+
+// Report compares the logs of the model's versions, the logs of mirrored requests are tagged by the inference logger.
+// The logs aren't stored, the time range of the report is the range of the event timestamps of the given logs.
+func (s *modelEndpointMirrorService) Report(_ context.Context, model *models.Model, endpoint *models.ModelEndpoint, logs []*mlogs.InferenceLogMessage, tolerance float64) (*models.MirrorReport, error) {
+	report := &models.MirrorReport{
+		ModelEndpointID: endpoint.ID,
+		Tolerance:       tolerance,
+	}
+
+	var mirroredLogs, primaryLogs []*models.ModelEndpointMirrorLog
+	for _, inferenceLog := range logs {
+		if inferenceLog.ModelName != model.Name || inferenceLog.RequestId == "" {
+			continue
+		}
+
+		var eventTimestamp time.Time
+		if inferenceLog.EventTimestamp != nil {
+			eventTimestamp = inferenceLog.EventTimestamp.AsTime()
+			if report.StartTime.IsZero() || eventTimestamp.Before(report.StartTime) {
+				report.StartTime = eventTimestamp
+			}
+			if eventTimestamp.After(report.EndTime) {
+				report.EndTime = eventTimestamp
+			}
+		}
+
+		mirrorLog := &models.ModelEndpointMirrorLog{
+			RequestID:      inferenceLog.RequestId,
+			ModelVersion:   inferenceLog.ModelVersion,
+			Mirrored:       isMirroredLog(inferenceLog),
+			StatusCode:     int(inferenceLog.GetResponse().GetStatusCode()),
+			ResponseBody:   inferenceLog.GetResponse().GetBody(),
+			EventTimestamp: eventTimestamp,
+		}
+		if mirrorLog.Mirrored {
+			mirroredLogs = append(mirroredLogs, mirrorLog)
+		} else {
+			primaryLogs = append(primaryLogs, mirrorLog)
+		}
+	}
+
+	report.Mirrors = models.NewMirrorComparisons(mirroredLogs, primaryLogs, tolerance)
+	return report, nil
+}
+
+// isMirroredLog returns true if the request headers contain the mirrored tag, gRPC metadata keys are lowercase
+func isMirroredLog(inferenceLog *mlogs.InferenceLogMessage) bool {
+	for name, value := range inferenceLog.GetRequest().GetHeader() {
+		if strings.EqualFold(name, models.MirroredLogHeader) {
+			return value == "true"
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/caraml-dev/merlin/models"
+	mlogs "github.com/caraml-dev/merlin/pkg/log"
+)
+
+func TestModelEndpointMirrorService_Report(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Minute)
+	model := &models.Model{ID: 1, Name: "my-model"}
+	endpoint := &models.ModelEndpoint{ID: 2, ModelID: 1}
+	mirroredHeader := map[string]string{"x-merlin-mirrored": "true"}
+
+	logs := []*mlogs.InferenceLogMessage{
+		{
+			RequestId:      "1",
+			EventTimestamp: timestamppb.New(endTime),
+			ModelName:      "my-model",
+			ModelVersion:   "1",
+			Request:        &mlogs.Request{Header: map[string]string{"Content-Type": "application/json"}},
+			Response:       &mlogs.Response{StatusCode: 200, Body: `{"predictions":[0.5]}`},
+		},
+		{
+			RequestId:      "1",
+			EventTimestamp: timestamppb.New(startTime),
+			ModelName:      "my-model",
+			ModelVersion:   "2",
+			Request:        &mlogs.Request{Header: mirroredHeader},
+			Response:       &mlogs.Response{StatusCode: 200, Body: `{"predictions":[0.5]}`},
+		},
+		{
+			RequestId:    "2",
+			ModelName:    "my-model",
+			ModelVersion: "1",
+			Response:     &mlogs.Response{StatusCode: 200, Body: `{"predictions":[0.5]}`},
+		},
+		{
+			RequestId:    "2",
+			ModelName:    "my-model",
+			ModelVersion: "2",
+			Request:      &mlogs.Request{Header: mirroredHeader},
+			Response:     &mlogs.Response{StatusCode: 200, Body: `{"predictions":[0.75]}`},
+		},
+		{
+			RequestId:    "3",
+			ModelName:    "other-model",
+			ModelVersion: "2",
+			Request:      &mlogs.Request{Header: mirroredHeader},
+		},
+		{
+			ModelName:    "my-model",
+			ModelVersion: "2",
+			Request:      &mlogs.Request{Header: mirroredHeader},
+		},
+	}
+
+	report, err := NewModelEndpointMirrorService().Report(context.Background(), model, endpoint, logs, models.DefaultMirrorAgreementTolerance)
+	require.NoError(t, err)
+	assert.Equal(t, models.ID(2), report.ModelEndpointID)
+	assert.Equal(t, startTime, report.StartTime)
+	assert.Equal(t, endTime, report.EndTime)
+	require.Len(t, report.Mirrors, 1)
+	assert.Equal(t, "2", report.Mirrors[0].ModelVersion)
+	assert.Equal(t, 2, report.Mirrors[0].MirroredRequests)
+	assert.Equal(t, 2, report.Mirrors[0].MatchedRequests)
+	assert.Equal(t, 0.5, *report.Mirrors[0].AgreementRate)
+	assert.Equal(t, 0.25, report.Mirrors[0].ScoreDelta.Max)
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/caraml-dev/merlin/log"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/storage"
+)
+
+// ModelEndpointRolloutController progresses the canary rollouts of model endpoints.
+//
+// On every sync, the canary of each rollout in progress is analyzed against the success criteria. The canary is
+// rolled back to the stable version endpoint as soon as the criteria are breached, otherwise the traffic is shifted
+// to the next step once the current step's duration has elapsed and the canary is promoted after the last step.
+// Each transition patches the model endpoint's VirtualService and is recorded in the canary's deployment history.
+//
+// Syncs don't overlap within an API server, and a transition is only applied by the API server replica that first
+// moves the rollout status away from the status it has read.
+type ModelEndpointRolloutController struct {
+	modelEndpointsService  ModelEndpointsService
+	modelsService          ModelsService
+	modelEndpointStorage   storage.ModelEndpointStorage
+	versionEndpointStorage storage.VersionEndpointStorage
+	deploymentStorage      storage.DeploymentStorage
+	analyzer               RolloutAnalyzer
+
+	mu sync.Mutex
+}
+
+// NewModelEndpointRolloutController returns an initialized ModelEndpointRolloutController.
+func NewModelEndpointRolloutController(
+	modelEndpointsService ModelEndpointsService,
+	modelsService ModelsService,
+	modelEndpointStorage storage.ModelEndpointStorage,
+	versionEndpointStorage storage.VersionEndpointStorage,
+	deploymentStorage storage.DeploymentStorage,
+	analyzer RolloutAnalyzer,
+) *ModelEndpointRolloutController {
+	return &ModelEndpointRolloutController{
+		modelEndpointsService:  modelEndpointsService,
+		modelsService:          modelsService,
+		modelEndpointStorage:   modelEndpointStorage,
+		versionEndpointStorage: versionEndpointStorage,
+		deploymentStorage:      deploymentStorage,
+		analyzer:               analyzer,
+	}
+}
+
+// Sync evaluates and progresses all rollouts in progress
+func (c *ModelEndpointRolloutController) Sync() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx := context.Background()
+
+	endpoints, err := c.modelEndpointStorage.ListRolloutsInProgress(ctx)
+	if err != nil {
+		log.Errorf("failed to list rollouts in progress: %v", err)
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		if err := c.syncRollout(ctx, endpoint); err != nil {
+			log.Errorf("failed to sync rollout of model endpoint %d: %v", endpoint.ID, err)
+		}
+	}
+}
+
+func (c *ModelEndpointRolloutController) syncRollout(ctx context.Context, endpoint *models.ModelEndpoint) error {
+	rollout := endpoint.Rollout
+	currentStep := rollout.Status.CurrentStep
+	if currentStep < 0 || currentStep >= len(rollout.Steps) {
+		return fmt.Errorf("invalid current step %d", currentStep)
+	}
+
+	model, err := c.modelsService.FindByID(ctx, endpoint.ModelID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find model %d", endpoint.ModelID)
+	}
+
+	canary, err := c.versionEndpointStorage.Get(rollout.CanaryVersionEndpointID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find canary version endpoint %s", rollout.CanaryVersionEndpointID)
+	}
+	if !canary.IsRunning() && !canary.IsServing() {
+		return c.rollback(ctx, model, endpoint, canary, fmt.Sprintf("canary version endpoint is %s", canary.Status))
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(rollout.Status.StepStartedAt)
+
+	if rollout.SuccessCriteria != nil {
+		analysis, err := c.analyzer.Analyze(ctx, canary, rollout.SuccessCriteria, elapsed)
+		if err != nil {
+			// the rollout stays at the current step until the canary can be analyzed
+			return errors.Wrap(err, "failed to analyze canary")
+		}
+		if reason := rollout.SuccessCriteria.Breach(analysis); reason != "" {
+			return c.rollback(ctx, model, endpoint, canary, reason)
+		}
+	}
+
+	duration, err := rollout.Steps[currentStep].ParseDuration()
+	if err != nil {
+		return err
+	}
+	if elapsed < duration {
+		return nil
+	}
+
+	nextStep := currentStep + 1
+	if nextStep == len(rollout.Steps) {
+		return c.transition(ctx, model, endpoint, canary, &models.RolloutStatus{
+			Phase:         models.RolloutPhasePromoted,
+			CurrentStep:   currentStep,
+			StepStartedAt: now,
+			Message:       "canary promoted to 100% of traffic",
+		}, 100)
+	}
+
+	return c.transition(ctx, model, endpoint, canary, &models.RolloutStatus{
+		Phase:         models.RolloutPhaseProgressing,
+		CurrentStep:   nextStep,
+		StepStartedAt: now,
+		Message:       rolloutStepMessage(rollout, nextStep),
+	}, rollout.Steps[nextStep].Weight)
+}
+
+func (c *ModelEndpointRolloutController) rollback(ctx context.Context, model *models.Model, endpoint *models.ModelEndpoint, canary *models.VersionEndpoint, reason string) error {
+	log.Warnf("rolling back canary %s of model endpoint %d: %s", canary.ID, endpoint.ID, reason)
+
+	return c.transition(ctx, model, endpoint, canary, &models.RolloutStatus{
+		Phase:         models.RolloutPhaseRolledBack,
+		CurrentStep:   endpoint.Rollout.Status.CurrentStep,
+		StepStartedAt: time.Now(),
+		Message:       fmt.Sprintf("rolled back: %s", reason),
+	}, 0)
+}
+
+// transition routes canaryWeight of the traffic to the canary, saves the rollout status and records it in the canary's deployment history
+func (c *ModelEndpointRolloutController) transition(ctx context.Context, model *models.Model, endpoint *models.ModelEndpoint, canary *models.VersionEndpoint, status *models.RolloutStatus, canaryWeight int32) error {
+	rollout := *endpoint.Rollout
+	rollout.Status = status
+
+	newEndpoint := *endpoint
+	newEndpoint.Rollout = &rollout
+	newEndpoint.Rule = rolloutRule(&rollout, canaryWeight, endpoint.Rule)
+
+	// Claim the transition so that it isn't applied again by another API server replica syncing the same rollout
+	claimed, err := c.modelEndpointStorage.UpdateRolloutStatus(ctx, endpoint.ID, endpoint.Rollout.Status, status)
+	if err != nil {
+		return errors.Wrap(err, "failed to claim rollout transition")
+	}
+	if !claimed {
+		log.Infof("rollout of model endpoint %d has been changed since it was read, skipping", endpoint.ID)
+		return nil
+	}
+
+	if _, err := c.modelEndpointsService.UpdateEndpoint(ctx, model, endpoint, &newEndpoint); err != nil {
+		// Release the transition so that it is retried on the next sync
+		if _, releaseErr := c.modelEndpointStorage.UpdateRolloutStatus(ctx, endpoint.ID, status, endpoint.Rollout.Status); releaseErr != nil {
+			log.Errorf("failed to release rollout transition of model endpoint %d: %v", endpoint.ID, releaseErr)
+		}
+		return errors.Wrap(err, "failed to update model endpoint")
+	}
+
+	deployment := &models.Deployment{
+		ProjectID:         model.ProjectID,
+		VersionModelID:    canary.VersionModelID,
+		VersionID:         canary.VersionID,
+		VersionEndpointID: canary.ID,
+		Status:            models.EndpointServing,
+		Spec:              models.NewDeploymentSpec(canary),
+	}
+	if status.Phase == models.RolloutPhaseRolledBack {
+		deployment.Status = models.EndpointFailed
+		deployment.Error = status.Message
+	}
+	if _, err := c.deploymentStorage.Save(deployment); err != nil {
+		return errors.Wrap(err, "failed to record rollout deployment")
+	}
+
+	log.Infof("rollout of model endpoint %d: %s", endpoint.ID, status.Message)
+	return nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	networking "istio.io/api/networking/v1beta1"
+	"istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/istio"
+	istioCliMock "github.com/caraml-dev/merlin/istio/mocks"
+	"github.com/caraml-dev/merlin/models"
+	eventMock "github.com/caraml-dev/merlin/pkg/observability/event/mocks"
+	"github.com/caraml-dev/merlin/pkg/protocol"
+	storageMock "github.com/caraml-dev/merlin/storage/mocks"
+)
+
+// stubModelsService returns the same model for every FindByID
+type stubModelsService struct {
+	ModelsService
+	model *models.Model
+}
+
+func (s *stubModelsService) FindByID(ctx context.Context, modelID models.ID) (*models.Model, error) {
+	return s.model, nil
+}
+
+type rolloutAnalyzerFunc func(ctx context.Context, canary *models.VersionEndpoint, criteria *models.RolloutSuccessCriteria, window time.Duration) (*models.RolloutAnalysis, error)
+
+func (f rolloutAnalyzerFunc) Analyze(ctx context.Context, canary *models.VersionEndpoint, criteria *models.RolloutSuccessCriteria, window time.Duration) (*models.RolloutAnalysis, error) {
+	return f(ctx, canary, criteria, window)
+}
+
+func TestModelEndpointRolloutController_syncRollout(t *testing.T) {
+	stable := &models.VersionEndpoint{
+		ID:                   uuid.New(),
+		VersionID:            1,
+		VersionModelID:       1,
+		Status:               models.EndpointServing,
+		URL:                  "http://version-1.project-1.mlp.io/v1/models/version-1:predict",
+		InferenceServiceName: "version-1",
+		Namespace:            "project-1",
+		Protocol:             protocol.HttpJson,
+	}
+	canary := &models.VersionEndpoint{
+		ID:                   uuid.New(),
+		VersionID:            2,
+		VersionModelID:       1,
+		Status:               models.EndpointServing,
+		URL:                  "http://version-2.project-1.mlp.io/v1/models/version-2:predict",
+		InferenceServiceName: "version-2",
+		Namespace:            "project-1",
+		Protocol:             protocol.HttpJson,
+	}
+	maxErrorRate := 0.05
+	errorRate := 0.2
+	lowErrorRate := 0.01
+
+	newEndpoint := func(currentStep int, stepStartedAt time.Time) *models.ModelEndpoint {
+		rollout := &models.ModelEndpointRollout{
+			StableVersionEndpointID: stable.ID,
+			CanaryVersionEndpointID: canary.ID,
+			Steps: []*models.RolloutStep{
+				{Weight: 10, Duration: "10m"},
+				{Weight: 50, Duration: "10m"},
+			},
+			SuccessCriteria: &models.RolloutSuccessCriteria{MaxErrorRate: &maxErrorRate},
+			Status: &models.RolloutStatus{
+				Phase:         models.RolloutPhaseProgressing,
+				CurrentStep:   currentStep,
+				StepStartedAt: stepStartedAt,
+			},
+		}
+		return &models.ModelEndpoint{
+			ID:              1,
+			ModelID:         model1.ID,
+			Status:          models.EndpointServing,
+			EnvironmentName: env.Name,
+			Rule:            rolloutRule(rollout, rollout.Steps[currentStep].Weight, nil),
+			Rollout:         rollout,
+		}
+	}
+
+	tests := []struct {
+		name              string
+		endpoint          *models.ModelEndpoint
+		analysis          *models.RolloutAnalysis
+		analysisErr       error
+		alreadyClaimed    bool
+		wantErr           bool
+		wantPhase         models.RolloutPhase
+		wantStep          int
+		wantWeights       map[uuid.UUID]int32
+		wantDeployment    models.EndpointStatus
+		wantDeploymentErr string
+	}{
+		{
+			name:     "step not elapsed",
+			endpoint: newEndpoint(0, time.Now().Add(-time.Minute)),
+			analysis: &models.RolloutAnalysis{ErrorRate: &lowErrorRate},
+		},
+		{
+			name:           "step elapsed",
+			endpoint:       newEndpoint(0, time.Now().Add(-11*time.Minute)),
+			analysis:       &models.RolloutAnalysis{ErrorRate: &lowErrorRate},
+			wantPhase:      models.RolloutPhaseProgressing,
+			wantStep:       1,
+			wantWeights:    map[uuid.UUID]int32{stable.ID: 50, canary.ID: 50},
+			wantDeployment: models.EndpointServing,
+		},
+		{
+			name:           "no traffic to analyze",
+			endpoint:       newEndpoint(0, time.Now().Add(-11*time.Minute)),
+			analysis:       &models.RolloutAnalysis{},
+			wantPhase:      models.RolloutPhaseProgressing,
+			wantStep:       1,
+			wantWeights:    map[uuid.UUID]int32{stable.ID: 50, canary.ID: 50},
+			wantDeployment: models.EndpointServing,
+		},
+		{
+			name:           "last step elapsed",
+			endpoint:       newEndpoint(1, time.Now().Add(-11*time.Minute)),
+			analysis:       &models.RolloutAnalysis{ErrorRate: &lowErrorRate},
+			wantPhase:      models.RolloutPhasePromoted,
+			wantStep:       1,
+			wantWeights:    map[uuid.UUID]int32{canary.ID: 100},
+			wantDeployment: models.EndpointServing,
+		},
+		{
+			name:              "success criteria breached",
+			endpoint:          newEndpoint(0, time.Now().Add(-time.Minute)),
+			analysis:          &models.RolloutAnalysis{ErrorRate: &errorRate},
+			wantPhase:         models.RolloutPhaseRolledBack,
+			wantStep:          0,
+			wantWeights:       map[uuid.UUID]int32{stable.ID: 100},
+			wantDeployment:    models.EndpointFailed,
+			wantDeploymentErr: "rolled back: error rate 0.2000 exceeds 0.0500",
+		},
+		{
+			name:           "step elapsed and transition claimed by another replica",
+			endpoint:       newEndpoint(0, time.Now().Add(-11*time.Minute)),
+			analysis:       &models.RolloutAnalysis{ErrorRate: &lowErrorRate},
+			alreadyClaimed: true,
+		},
+		{
+			name:        "analysis failed",
+			endpoint:    newEndpoint(0, time.Now().Add(-11*time.Minute)),
+			analysisErr: errors.New("prometheus unavailable"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			istioClient := &istioCliMock.Client{}
+			istioClient.On("PatchVirtualService", mock.Anything, "project-1", mock.Anything).
+				Return(&v1beta1.VirtualService{Spec: networking.VirtualService{Hosts: []string{"model-1.project-1.mlp.io"}}}, nil)
+
+			veStorage := &storageMock.VersionEndpointStorage{}
+			veStorage.On("Get", stable.ID).Return(stable, nil)
+			veStorage.On("Get", canary.ID).Return(canary, nil)
+
+			var saved *models.ModelEndpoint
+			meStorage := &storageMock.ModelEndpointStorage{}
+			meStorage.On("Save", mock.Anything, tt.endpoint, mock.AnythingOfType("*models.ModelEndpoint")).
+				Run(func(args mock.Arguments) {
+					saved = args.Get(2).(*models.ModelEndpoint)
+				}).Return(nil)
+			meStorage.On("UpdateRolloutStatus", mock.Anything, tt.endpoint.ID, tt.endpoint.Rollout.Status, mock.AnythingOfType("*models.RolloutStatus")).
+				Return(!tt.alreadyClaimed, nil)
+
+			eventProducer := &eventMock.EventProducer{}
+			eventProducer.On("ModelEndpointChangeEvent", mock.Anything, mock.Anything).Return(nil)
+
+			var deployment *models.Deployment
+			deploymentStorage := &storageMock.DeploymentStorage{}
+			deploymentStorage.On("Save", mock.AnythingOfType("*models.Deployment")).
+				Run(func(args mock.Arguments) {
+					deployment = args.Get(0).(*models.Deployment)
+				}).Return(nil, nil)
+
+			modelEndpointsService := newModelEndpointsService(map[string]istio.Client{env.Name: istioClient}, meStorage, veStorage, testEnvironmentName, eventProducer, config.ExperimentConfig{})
+			analyzer := rolloutAnalyzerFunc(func(ctx context.Context, ve *models.VersionEndpoint, criteria *models.RolloutSuccessCriteria, window time.Duration) (*models.RolloutAnalysis, error) {
+				assert.Equal(t, canary, ve)
+				return tt.analysis, tt.analysisErr
+			})
+			controller := NewModelEndpointRolloutController(modelEndpointsService, &stubModelsService{model: model1},
+				meStorage, veStorage, deploymentStorage, analyzer)
+
+			err := controller.syncRollout(context.Background(), tt.endpoint)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.wantPhase == "" {
+				assert.Nil(t, saved)
+				istioClient.AssertNotCalled(t, "PatchVirtualService", mock.Anything, mock.Anything, mock.Anything)
+				deploymentStorage.AssertNotCalled(t, "Save", mock.Anything)
+				return
+			}
+
+			require.NotNil(t, saved)
+			assert.Equal(t, tt.wantPhase, saved.Rollout.Status.Phase)
+			assert.Equal(t, tt.wantStep, saved.Rollout.Status.CurrentStep)
+			// the rollout in the database is only changed through the saved copy
+			assert.Equal(t, models.RolloutPhaseProgressing, tt.endpoint.Rollout.Status.Phase)
+
+			weights := map[uuid.UUID]int32{}
+			for _, destination := range saved.Rule.Destination {
+				weights[destination.VersionEndpointID] = destination.Weight
+			}
+			assert.Equal(t, tt.wantWeights, weights)
+
+			require.NotNil(t, deployment)
+			assert.Equal(t, canary.ID, deployment.VersionEndpointID)
+			assert.Equal(t, canary.VersionID, deployment.VersionID)
+			assert.Equal(t, tt.wantDeployment, deployment.Status)
+			assert.Equal(t, tt.wantDeploymentErr, deployment.Error)
+		})
+	}
+}
+
+func Test_startRollout(t *testing.T) {
+	canaryID := uuid.New()
+	steps := []*models.RolloutStep{{Weight: 20, Duration: "5m"}}
+
+	tests := []struct {
+		name        string
+		oldEndpoint *models.ModelEndpoint
+		rollout     *models.ModelEndpointRollout
+		wantErr     bool
+	}{
+		{
+			name:        "success",
+			oldEndpoint: modelEndpointRequest1,
+			rollout:     &models.ModelEndpointRollout{CanaryVersionEndpointID: canaryID, Steps: steps},
+		},
+		{
+			name:        "invalid plan",
+			oldEndpoint: modelEndpointRequest1,
+			rollout:     &models.ModelEndpointRollout{CanaryVersionEndpointID: canaryID},
+			wantErr:     true,
+		},
+		{
+			name:        "canary already serving",
+			oldEndpoint: modelEndpointRequest1,
+			rollout:     &models.ModelEndpointRollout{CanaryVersionEndpointID: uuid1, Steps: steps},
+			wantErr:     true,
+		},
+		{
+			name: "rollout already in progress",
+			oldEndpoint: &models.ModelEndpoint{
+				Rule: modelEndpointRequest1.Rule,
+				Rollout: &models.ModelEndpointRollout{
+					Status: &models.RolloutStatus{Phase: models.RolloutPhaseProgressing},
+				},
+			},
+			rollout: &models.ModelEndpointRollout{CanaryVersionEndpointID: canaryID, Steps: steps},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newEndpoint := &models.ModelEndpoint{Rollout: tt.rollout}
+
+			err := startRollout(tt.oldEndpoint, newEndpoint)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRollout)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, uuid1, newEndpoint.Rollout.StableVersionEndpointID)
+			assert.Equal(t, models.RolloutPhaseProgressing, newEndpoint.Rollout.Status.Phase)
+			assert.Equal(t, []*models.ModelEndpointRuleDestination{
+				{VersionEndpointID: uuid1, Weight: 80},
+				{VersionEndpointID: canaryID, Weight: 20},
+			}, newEndpoint.Rule.Destination)
+		})
+	}
+}
@@ -19,7 +19,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/caraml-dev/merlin/config"
 	"github.com/caraml-dev/merlin/pkg/observability/event"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	"github.com/caraml-dev/merlin/storage"
@@ -44,9 +46,21 @@ const (
 	defaultMatchURIPrefix = "/v1/predict"
 	predictPathSuffix     = ":predict"
 
+	// mirroredHostSuffix is appended by Istio to the host of mirrored requests
+	mirroredHostSuffix = "-shadow"
+
 	dataArgKey = "data"
 )
 
+var (
+	// ErrInvalidRollout is returned when the canary rollout requested on a model endpoint can't be started
+	ErrInvalidRollout = errors.New("invalid rollout")
+	// ErrInvalidRoute is returned when a route of the model endpoint's rule is invalid for the endpoint's protocol
+	ErrInvalidRoute = errors.New("invalid route")
+	// ErrInvalidMirror is returned when the mirror of the model endpoint's rule is invalid
+	ErrInvalidMirror = errors.New("invalid mirror")
+)
+
 // ModelEndpointsService interface.
 type ModelEndpointsService interface {
 	// ListModelEndpoints list all model endpoints owned by a model given the model ID
@@ -66,8 +80,8 @@ type ModelEndpointsService interface {
 }
 
 // NewModelEndpointsService returns an initialized ModelEndpointsService.
-func NewModelEndpointsService(istioClients map[string]istio.Client, modelEndpointStorage storage.ModelEndpointStorage, versionEndpointStorage storage.VersionEndpointStorage, environment string, observabilityEventProducer event.EventProducer) ModelEndpointsService {
-	return newModelEndpointsService(istioClients, modelEndpointStorage, versionEndpointStorage, environment, observabilityEventProducer)
+func NewModelEndpointsService(istioClients map[string]istio.Client, modelEndpointStorage storage.ModelEndpointStorage, versionEndpointStorage storage.VersionEndpointStorage, environment string, observabilityEventProducer event.EventProducer, experimentConfig config.ExperimentConfig) ModelEndpointsService {
+	return newModelEndpointsService(istioClients, modelEndpointStorage, versionEndpointStorage, environment, observabilityEventProducer, experimentConfig)
 }
 
 type modelEndpointsService struct {
@@ -76,15 +90,17 @@ type modelEndpointsService struct {
 	versionEndpointStorage     storage.VersionEndpointStorage
 	environment                string
 	observabilityEventProducer event.EventProducer
+	experimentConfig           config.ExperimentConfig
 }
 
-func newModelEndpointsService(istioClients map[string]istio.Client, modelEndpointStorage storage.ModelEndpointStorage, versionEndpointStorage storage.VersionEndpointStorage, environment string, observabilityEventProducer event.EventProducer) *modelEndpointsService {
+func newModelEndpointsService(istioClients map[string]istio.Client, modelEndpointStorage storage.ModelEndpointStorage, versionEndpointStorage storage.VersionEndpointStorage, environment string, observabilityEventProducer event.EventProducer, experimentConfig config.ExperimentConfig) *modelEndpointsService {
 	return &modelEndpointsService{
 		istioClients:               istioClients,
 		modelEndpointStorage:       modelEndpointStorage,
 		versionEndpointStorage:     versionEndpointStorage,
 		environment:                environment,
 		observabilityEventProducer: observabilityEventProducer,
+		experimentConfig:           withExperimentDefaults(experimentConfig),
 	}
 }
 
@@ -105,6 +121,16 @@ func (s *modelEndpointsService) FindByID(ctx context.Context, id models.ID) (*mo
 
 // DeployEndpoint creates new model endpoint of a model
 func (s *modelEndpointsService) DeployEndpoint(ctx context.Context, model *models.Model, endpoint *models.ModelEndpoint) (*models.ModelEndpoint, error) {
+	if endpoint.Rollout != nil && endpoint.Rollout.Status == nil {
+		return nil, fmt.Errorf("%w: rollout can only be started on a serving model endpoint", ErrInvalidRollout)
+	}
+	if endpoint.Experiment != nil {
+		if err := validateExperiment(endpoint); err != nil {
+			return nil, err
+		}
+		endpoint.Rule = experimentRule(endpoint.Experiment, endpoint.Rule)
+	}
+
 	endpoint, err := s.assignVersionEndpoint(ctx, endpoint)
 	if err != nil {
 		log.Errorf("failed to assign version endpoint: %v", err)
@@ -124,6 +150,10 @@ func (s *modelEndpointsService) DeployEndpoint(ctx context.Context, model *model
 		return nil, fmt.Errorf("unable to find istio client for environment: %s", endpoint.EnvironmentName)
 	}
 
+	if err := s.applyExperimentFilter(ctx, istioClient, model, nil, endpoint, vs); err != nil {
+		return nil, err
+	}
+
 	// Deploy Istio's VirtualService
 	vs, err = istioClient.CreateVirtualService(ctx, model.Project.Name, vs)
 	if err != nil {
@@ -153,6 +183,20 @@ func (s *modelEndpointsService) DeployEndpoint(ctx context.Context, model *model
 
 // UpdateEndpoint update existing model endpoint owned by model
 func (s *modelEndpointsService) UpdateEndpoint(ctx context.Context, model *models.Model, oldEndpoint *models.ModelEndpoint, newEndpoint *models.ModelEndpoint) (*models.ModelEndpoint, error) {
+	if newEndpoint.Experiment != nil {
+		if err := validateExperiment(newEndpoint); err != nil {
+			return nil, err
+		}
+		newEndpoint.Rule = experimentRule(newEndpoint.Experiment, newEndpoint.Rule)
+	}
+
+	// A rollout without status is requested by the user, the following steps are progressed by the rollout controller
+	if newEndpoint.Rollout != nil && newEndpoint.Rollout.Status == nil {
+		if err := startRollout(oldEndpoint, newEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
 	newEndpoint, err := s.assignVersionEndpoint(ctx, newEndpoint)
 	if err != nil {
 		log.Errorf("failed to assign version endpoint: %v", err)
@@ -172,6 +216,10 @@ func (s *modelEndpointsService) UpdateEndpoint(ctx context.Context, model *model
 		return nil, fmt.Errorf("unable to find istio client for environment: %s", newEndpoint.EnvironmentName)
 	}
 
+	if err := s.applyExperimentFilter(ctx, istioClient, model, oldEndpoint, newEndpoint, vs); err != nil {
+		return nil, err
+	}
+
 	// Update Istio's VirtualService
 	vs, err = istioClient.PatchVirtualService(ctx, model.Project.Name, vs)
 	if err != nil {
@@ -215,6 +263,14 @@ func (s *modelEndpointsService) UndeployEndpoint(ctx context.Context, model *mod
 		return nil, errors.Wrapf(err, "failed to delete VirtualService resource on cluster")
 	}
 
+	if endpoint.Experiment != nil {
+		err = istioClient.DeleteEnvoyFilter(ctx, s.experimentConfig.EnvoyFilterNamespace, experimentFilterName(model))
+		if client.IgnoreNotFound(err) != nil {
+			log.Errorf("failed to delete EnvoyFilter: %v", err)
+			return nil, errors.Wrapf(err, "failed to delete experiment EnvoyFilter resource on cluster")
+		}
+	}
+
 	endpoint.Status = models.EndpointTerminated
 	err = s.modelEndpointStorage.Save(ctx, nil, endpoint)
 	if err != nil {
@@ -261,7 +317,8 @@ func (s *modelEndpointsService) createVirtualService(model *models.Model, endpoi
 		versionEndpoint := destination.VersionEndpoint
 		protocolValue = destination.VersionEndpoint.Protocol
 
-		if versionEndpoint.Status != models.EndpointRunning {
+		// version endpoints already serving the model endpoint are kept during canary rollout
+		if versionEndpoint.Status != models.EndpointRunning && versionEndpoint.Status != models.EndpointServing {
 			return nil, fmt.Errorf("version endpoint (%s) is not running, but %s", versionEndpoint.ID, versionEndpoint.Status)
 		}
 
@@ -276,17 +333,7 @@ func (s *modelEndpointsService) createVirtualService(model *models.Model, endpoi
 			versionEndpointPath = vePath
 		}
 
-		httpRouteDest := &istiov1beta1.HTTPRouteDestination{
-			Destination: &istiov1beta1.Destination{
-				Host: defaultIstioGateway,
-			},
-			Headers: &istiov1beta1.Headers{
-				Request: &istiov1beta1.Headers_HeaderOperations{
-					Set: map[string]string{"Host": versionEndpoint.Hostname()},
-				},
-			},
-			Weight: destination.Weight,
-		}
+		httpRouteDest := createHttpRouteDestination(versionEndpoint, destination.Weight)
 
 		httpRouteDestinations = append(httpRouteDestinations, httpRouteDest)
 	}
@@ -295,9 +342,43 @@ func (s *modelEndpointsService) createVirtualService(model *models.Model, endpoi
 		versionEndpointPath += predictPathSuffix
 	}
 
+	for _, route := range endpoint.Rule.Routes {
+		versionEndpoint := route.VersionEndpoint
+		if versionEndpoint.Status != models.EndpointRunning && versionEndpoint.Status != models.EndpointServing {
+			return nil, fmt.Errorf("version endpoint (%s) is not running, but %s", versionEndpoint.ID, versionEndpoint.Status)
+		}
+	}
+
 	vs.Spec.Hosts = []string{modelEndpointHost}
 	vs.Spec.Gateways = []string{defaultGateway}
-	vs.Spec.Http = createHttpRoutes(versionEndpointPath, httpRouteDestinations, protocolValue)
+	vs.Spec.Http = createHttpRoutes(versionEndpointPath, httpRouteDestinations, endpoint.Rule.Routes, protocolValue)
+
+	// the variants' routes match the bucket of the unit id, the weighted destinations remain for requests without unit id
+	if endpoint.Experiment != nil {
+		fallbackRoute := vs.Spec.Http[len(vs.Spec.Http)-1]
+		vs.Spec.Http = append(vs.Spec.Http[:len(vs.Spec.Http)-1], createExperimentHttpRoutes(endpoint.Experiment, endpoint.Rule.Destination, protocolValue)...)
+		vs.Spec.Http = append(vs.Spec.Http, fallbackRoute)
+	}
+
+	// the copies of the requests are sent back to the gateway, where the mirror routes match their shadow host
+	if mirror := endpoint.Rule.Mirror; mirror != nil {
+		if mirror.Status != models.EndpointRunning && mirror.Status != models.EndpointServing {
+			return nil, fmt.Errorf("version endpoint (%s) is not running, but %s", mirror.ID, mirror.Status)
+		}
+
+		mirrorPercentage := 100.0
+		if endpoint.Rule.MirrorPercentage != nil {
+			mirrorPercentage = *endpoint.Rule.MirrorPercentage
+		}
+		for _, httpRoute := range vs.Spec.Http {
+			httpRoute.Mirror = &istiov1beta1.Destination{Host: defaultIstioGateway}
+			httpRoute.MirrorPercentage = &istiov1beta1.Percent{Value: mirrorPercentage}
+		}
+
+		shadowHosts, mirrorRoutes := createMirrorHttpRoutes(endpoint.Rule, protocolValue)
+		vs.Spec.Hosts = append(vs.Spec.Hosts, shadowHosts...)
+		vs.Spec.Http = append(mirrorRoutes, vs.Spec.Http...)
+	}
 
 	return vs, nil
 }
@@ -313,6 +394,33 @@ func (s *modelEndpointsService) createModelEndpointHost(model *models.Model, ver
 	return fmt.Sprintf("%s.%s.%s", model.Name, model.Project.Name, domain), nil
 }
 
+// applyExperimentFilter creates or updates the EnvoyFilter assigning the requests to the experiment's buckets,
+// the EnvoyFilter of the old endpoint is deleted if the experiment has ended
+func (s *modelEndpointsService) applyExperimentFilter(ctx context.Context, istioClient istio.Client, model *models.Model, oldEndpoint *models.ModelEndpoint, newEndpoint *models.ModelEndpoint, vs *v1beta1.VirtualService) error {
+	if newEndpoint.Experiment == nil {
+		if oldEndpoint == nil || oldEndpoint.Experiment == nil {
+			return nil
+		}
+		err := istioClient.DeleteEnvoyFilter(ctx, s.experimentConfig.EnvoyFilterNamespace, experimentFilterName(model))
+		if client.IgnoreNotFound(err) != nil {
+			log.Errorf("failed to delete EnvoyFilter: %v", err)
+			return errors.Wrapf(err, "failed to delete experiment EnvoyFilter resource on cluster")
+		}
+		return nil
+	}
+
+	ef, err := createExperimentEnvoyFilter(model, vs.Spec.Hosts[0], newEndpoint.Experiment, vs.Labels, s.experimentConfig)
+	if err != nil {
+		log.Errorf("failed to create EnvoyFilter specification: %v", err)
+		return errors.Wrapf(err, "failed to create experiment EnvoyFilter specification")
+	}
+	if _, err := istioClient.ApplyEnvoyFilter(ctx, s.experimentConfig.EnvoyFilterNamespace, ef); err != nil {
+		log.Errorf("failed to apply EnvoyFilter: %v", err)
+		return errors.Wrapf(err, "failed to apply experiment EnvoyFilter resource on cluster")
+	}
+	return nil
+}
+
 // assignVersionEndpoint fetches destination version endpoints from database and assign to model endpoint.
 // assignVersionEndpoint validates version endpoint status and returns error if find no running version endpoint.
 func (c *modelEndpointsService) assignVersionEndpoint(ctx context.Context, endpoint *models.ModelEndpoint) (*models.ModelEndpoint, error) {
@@ -325,7 +433,7 @@ func (c *modelEndpointsService) assignVersionEndpoint(ctx context.Context, endpo
 			return nil, fmt.Errorf("version Endpoint with given `version_endpoint_id: %s` not found", versionEndpointID)
 		}
 
-		if !versionEndpoint.IsRunning() {
+		if !versionEndpoint.IsRunning() && !versionEndpoint.IsServing() {
 			return nil, fmt.Errorf("version Endpoint %s is not running, but %s", versionEndpoint.ID, versionEndpoint.Status)
 		}
 
@@ -339,36 +447,265 @@ func (c *modelEndpointsService) assignVersionEndpoint(ctx context.Context, endpo
 		endpoint.Rule.Destination[k].VersionEndpoint = versionEndpoint
 	}
 
+	for k, route := range endpoint.Rule.Routes {
+		versionEndpoint, err := c.versionEndpointStorage.Get(route.VersionEndpointID)
+		if err != nil {
+			return nil, fmt.Errorf("version Endpoint with given `version_endpoint_id: %s` not found", route.VersionEndpointID)
+		}
+
+		if !versionEndpoint.IsRunning() && !versionEndpoint.IsServing() {
+			return nil, fmt.Errorf("version Endpoint %s is not running, but %s", versionEndpoint.ID, versionEndpoint.Status)
+		}
+
+		// routes are matched on the same host, hence must have the same protocol as the weighted destinations
+		if protocolValue != "" && protocolValue != versionEndpoint.Protocol {
+			return nil, fmt.Errorf("all version endpoint protocol must be same")
+		}
+
+		if err := route.Validate(versionEndpoint.Protocol); err != nil {
+			return nil, fmt.Errorf("%w: route %d: %s", ErrInvalidRoute, k, err)
+		}
+		endpoint.Rule.Routes[k].VersionEndpoint = versionEndpoint
+	}
+
+	if mirror := endpoint.Rule.Mirror; mirror != nil {
+		versionEndpoint, err := c.versionEndpointStorage.Get(mirror.ID)
+		if err != nil {
+			return nil, fmt.Errorf("version Endpoint with given `version_endpoint_id: %s` not found", mirror.ID)
+		}
+
+		if !versionEndpoint.IsRunning() && !versionEndpoint.IsServing() {
+			return nil, fmt.Errorf("version Endpoint %s is not running, but %s", versionEndpoint.ID, versionEndpoint.Status)
+		}
+
+		// the mirror receives copies of the requests sent to the other version endpoints, hence must have the same protocol
+		if protocolValue != "" && protocolValue != versionEndpoint.Protocol {
+			return nil, fmt.Errorf("all version endpoint protocol must be same")
+		}
+
+		for _, versionEndpointID := range endpoint.Rule.VersionEndpointIDs() {
+			if versionEndpointID == versionEndpoint.ID {
+				return nil, fmt.Errorf("%w: version endpoint %s already receives the traffic of the model endpoint", ErrInvalidMirror, versionEndpoint.ID)
+			}
+		}
+		if percentage := endpoint.Rule.MirrorPercentage; percentage != nil && (*percentage <= 0 || *percentage > 100) {
+			return nil, fmt.Errorf("%w: mirror percentage must be greater than 0 and at most 100", ErrInvalidMirror)
+		}
+		endpoint.Rule.Mirror = versionEndpoint
+	}
+
 	return endpoint, nil
 }
 
-func createHttpRoutes(versionEndpointPath string, httpRouteDestinations []*istiov1beta1.HTTPRouteDestination, value protocol.Protocol) []*istiov1beta1.HTTPRoute {
+// startRollout routes the first step's weight to the canary and the rest to the version endpoint currently serving the model endpoint
+func startRollout(oldEndpoint *models.ModelEndpoint, newEndpoint *models.ModelEndpoint) error {
+	rollout := newEndpoint.Rollout
+	if err := rollout.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRollout, err)
+	}
+	if oldEndpoint.Rollout.InProgress() {
+		return fmt.Errorf("%w: a rollout is already in progress", ErrInvalidRollout)
+	}
+	if oldEndpoint.Rule == nil || len(oldEndpoint.Rule.Destination) != 1 {
+		return fmt.Errorf("%w: model endpoint must route to a single version endpoint", ErrInvalidRollout)
+	}
+
+	stableVersionEndpointID := oldEndpoint.Rule.Destination[0].VersionEndpointID
+	if stableVersionEndpointID == rollout.CanaryVersionEndpointID {
+		return fmt.Errorf("%w: canary version endpoint is already serving the model endpoint", ErrInvalidRollout)
+	}
+
+	rollout.StableVersionEndpointID = stableVersionEndpointID
+	rollout.Status = &models.RolloutStatus{
+		Phase:         models.RolloutPhaseProgressing,
+		CurrentStep:   0,
+		StepStartedAt: time.Now(),
+		Message:       rolloutStepMessage(rollout, 0),
+	}
+
+	newEndpoint.Rule = rolloutRule(rollout, rollout.Steps[0].Weight, newEndpoint.Rule)
+	return nil
+}
+
+// rolloutRule splits the traffic between the stable and canary version endpoints of the rollout, keeping the routes and mirror of the base rule
+func rolloutRule(rollout *models.ModelEndpointRollout, canaryWeight int32, base *models.ModelEndpointRule) *models.ModelEndpointRule {
+	rule := &models.ModelEndpointRule{}
+	if base != nil {
+		rule.Routes = base.Routes
+		rule.Mirror = base.Mirror
+		rule.MirrorPercentage = base.MirrorPercentage
+	}
+	if canaryWeight < 100 {
+		rule.Destination = append(rule.Destination, &models.ModelEndpointRuleDestination{
+			VersionEndpointID: rollout.StableVersionEndpointID,
+			Weight:            100 - canaryWeight,
+		})
+	}
+	if canaryWeight > 0 {
+		rule.Destination = append(rule.Destination, &models.ModelEndpointRuleDestination{
+			VersionEndpointID: rollout.CanaryVersionEndpointID,
+			Weight:            canaryWeight,
+		})
+	}
+	return rule
+}
+
+func rolloutStepMessage(rollout *models.ModelEndpointRollout, step int) string {
+	return fmt.Sprintf("step %d of %d: %d%% of traffic to canary", step+1, len(rollout.Steps), rollout.Steps[step].Weight)
+}
+
+// createHttpRoutes returns the HTTP routes matching the model endpoint's routes in order, followed by the weighted destinations
+func createHttpRoutes(versionEndpointPath string, httpRouteDestinations []*istiov1beta1.HTTPRouteDestination, routes []*models.ModelEndpointRuleRoute, value protocol.Protocol) []*istiov1beta1.HTTPRoute {
+	httpRoutes := make([]*istiov1beta1.HTTPRoute, 0, len(routes)+1)
+	for _, route := range routes {
+		httpRoute := createHttpRoute(
+			predictPath(route.VersionEndpoint),
+			createHttpMatchRequest(route),
+			[]*istiov1beta1.HTTPRouteDestination{createHttpRouteDestination(route.VersionEndpoint, 100)},
+			value,
+		)
+		httpRoute.Name = route.Name
+		httpRoutes = append(httpRoutes, httpRoute)
+	}
+
+	return append(httpRoutes, createHttpRoute(versionEndpointPath, &istiov1beta1.HTTPMatchRequest{}, httpRouteDestinations, value))
+}
+
+func createHttpRoute(versionEndpointPath string, match *istiov1beta1.HTTPMatchRequest, httpRouteDestinations []*istiov1beta1.HTTPRouteDestination, value protocol.Protocol) *istiov1beta1.HTTPRoute {
 	switch value {
 	case protocol.UpiV1:
-		return []*istiov1beta1.HTTPRoute{
-			{
-				Route: httpRouteDestinations,
-			},
+		httpRoute := &istiov1beta1.HTTPRoute{
+			Route: httpRouteDestinations,
 		}
+		if match.Headers != nil || match.SourceLabels != nil {
+			httpRoute.Match = []*istiov1beta1.HTTPMatchRequest{match}
+		}
+		return httpRoute
 
 	default:
-		return []*istiov1beta1.HTTPRoute{
-			{
-				Match: []*istiov1beta1.HTTPMatchRequest{
-					{
-						Uri: &istiov1beta1.StringMatch{
-							MatchType: &istiov1beta1.StringMatch_Prefix{
-								Prefix: defaultMatchURIPrefix,
-							},
-						},
-					},
-				},
-				Rewrite: &istiov1beta1.HTTPRewrite{
-					Uri: versionEndpointPath,
-				},
-
-				Route: httpRouteDestinations,
+		match.Uri = &istiov1beta1.StringMatch{
+			MatchType: &istiov1beta1.StringMatch_Prefix{
+				Prefix: defaultMatchURIPrefix,
+			},
+		}
+		return &istiov1beta1.HTTPRoute{
+			Match: []*istiov1beta1.HTTPMatchRequest{match},
+			Rewrite: &istiov1beta1.HTTPRewrite{
+				Uri: versionEndpointPath,
+			},
+
+			Route: httpRouteDestinations,
+		}
+	}
+}
+
+// createMirrorHttpRoutes returns the shadow hosts of the version endpoints receiving the traffic of the rule and the
+// HTTP routes sending their mirrored requests to the mirror.
+//
+// Istio appends mirroredHostSuffix to the host of a mirrored request, which is the host of its destination version
+// endpoint, after its path is rewritten, hence the whole path is rewritten to the mirror's predict path.
+func createMirrorHttpRoutes(rule *models.ModelEndpointRule, value protocol.Protocol) ([]string, []*istiov1beta1.HTTPRoute) {
+	versionEndpoints := make([]*models.VersionEndpoint, 0, len(rule.Destination)+len(rule.Routes))
+	for _, destination := range rule.Destination {
+		versionEndpoints = append(versionEndpoints, destination.VersionEndpoint)
+	}
+	for _, route := range rule.Routes {
+		versionEndpoints = append(versionEndpoints, route.VersionEndpoint)
+	}
+
+	var shadowHosts []string
+	var httpRoutes []*istiov1beta1.HTTPRoute
+	seen := map[string]bool{}
+	for _, versionEndpoint := range versionEndpoints {
+		shadowHost := versionEndpoint.Hostname() + mirroredHostSuffix
+		if seen[shadowHost] {
+			continue
+		}
+		seen[shadowHost] = true
+		shadowHosts = append(shadowHosts, shadowHost)
+
+		match := &istiov1beta1.HTTPMatchRequest{
+			Authority: &istiov1beta1.StringMatch{
+				MatchType: &istiov1beta1.StringMatch_Exact{Exact: shadowHost},
 			},
 		}
+		// the mirrored request is flagged so that the inference logger of the mirror can tell it apart
+		mirrorDestination := createHttpRouteDestination(rule.Mirror, 100)
+		mirrorDestination.Headers.Request.Set[models.MirroredLogHeader] = "true"
+		httpRoute := &istiov1beta1.HTTPRoute{
+			Match: []*istiov1beta1.HTTPMatchRequest{match},
+			Route: []*istiov1beta1.HTTPRouteDestination{mirrorDestination},
+		}
+		if value != protocol.UpiV1 {
+			httpRoute.Rewrite = &istiov1beta1.HTTPRewrite{
+				UriRegexRewrite: &istiov1beta1.RegexRewrite{Match: "^.*$", Rewrite: predictPath(rule.Mirror)},
+			}
+		}
+		httpRoutes = append(httpRoutes, httpRoute)
+	}
+	return shadowHosts, httpRoutes
+}
+
+func createHttpRouteDestination(versionEndpoint *models.VersionEndpoint, weight int32) *istiov1beta1.HTTPRouteDestination {
+	return &istiov1beta1.HTTPRouteDestination{
+		Destination: &istiov1beta1.Destination{
+			Host: defaultIstioGateway,
+		},
+		Headers: &istiov1beta1.Headers{
+			Request: &istiov1beta1.Headers_HeaderOperations{
+				Set: map[string]string{"Host": versionEndpoint.Hostname()},
+			},
+		},
+		Weight: weight,
+	}
+}
+
+// createHttpMatchRequest returns the match of the route's conditions, header names are lower cased as required by Istio
+func createHttpMatchRequest(route *models.ModelEndpointRuleRoute) *istiov1beta1.HTTPMatchRequest {
+	match := &istiov1beta1.HTTPMatchRequest{}
+
+	if len(route.Headers) > 0 || len(route.Cookies) > 0 {
+		match.Headers = map[string]*istiov1beta1.StringMatch{}
+	}
+	for name, stringMatch := range route.Headers {
+		match.Headers[strings.ToLower(name)] = createStringMatch(stringMatch)
+	}
+	for name, value := range route.Cookies {
+		match.Headers[models.CookieHeader] = createStringMatch(&models.StringMatch{Regex: models.CookieRegex(name, value)})
+	}
+
+	if len(route.QueryParams) > 0 {
+		match.QueryParams = map[string]*istiov1beta1.StringMatch{}
+	}
+	for name, stringMatch := range route.QueryParams {
+		match.QueryParams[name] = createStringMatch(stringMatch)
+	}
+
+	if len(route.SourceLabels) > 0 {
+		match.SourceLabels = route.SourceLabels
+	}
+	return match
+}
+
+func createStringMatch(stringMatch *models.StringMatch) *istiov1beta1.StringMatch {
+	switch {
+	case stringMatch.Regex != "":
+		return &istiov1beta1.StringMatch{MatchType: &istiov1beta1.StringMatch_Regex{Regex: stringMatch.Regex}}
+	case stringMatch.Prefix != "":
+		return &istiov1beta1.StringMatch{MatchType: &istiov1beta1.StringMatch_Prefix{Prefix: stringMatch.Prefix}}
+	default:
+		return &istiov1beta1.StringMatch{MatchType: &istiov1beta1.StringMatch_Exact{Exact: stringMatch.Exact}}
+	}
+}
+
+// predictPath returns the path of the version endpoint's predict API
+func predictPath(versionEndpoint *models.VersionEndpoint) string {
+	var versionEndpointPath string
+	if versionEndpoint.Protocol != protocol.UpiV1 {
+		versionEndpointPath = versionEndpoint.Path()
+	}
+	if !strings.HasSuffix(versionEndpointPath, predictPathSuffix) {
+		versionEndpointPath += predictPathSuffix
 	}
+	return versionEndpointPath
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/caraml-dev/merlin/cluster/labeller"
+	"github.com/caraml-dev/merlin/config"
 	"github.com/caraml-dev/merlin/istio"
 	istioCliMock "github.com/caraml-dev/merlin/istio/mocks"
 	"github.com/caraml-dev/merlin/models"
@@ -33,7 +34,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	networking "istio.io/api/networking/v1beta1"
 	"istio.io/client-go/pkg/apis/networking/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
@@ -161,7 +164,7 @@ func Test_modelEndpointsService_DeployEndpoint(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newModelEndpointsService(tt.fields.istioClients, tt.fields.modelEndpointStorage, tt.fields.versionEndpointStorage, tt.fields.environment, tt.fields.eventProducer)
+			s := newModelEndpointsService(tt.fields.istioClients, tt.fields.modelEndpointStorage, tt.fields.versionEndpointStorage, tt.fields.environment, tt.fields.eventProducer, config.ExperimentConfig{})
 
 			tt.mockFunc(s)
 
@@ -380,7 +383,7 @@ func Test_modelEndpointsService_UpdateEndpoint(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newModelEndpointsService(tt.fields.istioClients, tt.fields.modelEndpointStorage, tt.fields.versionEndpointStorage, tt.fields.environment, tt.fields.observabilityEventProducer)
+			s := newModelEndpointsService(tt.fields.istioClients, tt.fields.modelEndpointStorage, tt.fields.versionEndpointStorage, tt.fields.environment, tt.fields.observabilityEventProducer, config.ExperimentConfig{})
 
 			tt.mockFunc(s)
 
@@ -446,6 +449,36 @@ func Test_modelEndpointsService_UndeployEndpoint(t *testing.T) {
 			want:    modelEndpointResponseTerminated,
 			wantErr: false,
 		},
+		{
+			name: "success: experiment filter deleted",
+			fields: fields{
+				istioClients:           map[string]istio.Client{env.Name: &istioCliMock.Client{}},
+				modelEndpointStorage:   &storageMock.ModelEndpointStorage{},
+				versionEndpointStorage: &storageMock.VersionEndpointStorage{},
+				environment:            testEnvironmentName,
+				observabilityEventProducer: func() event.EventProducer {
+					eProducer := &eventMock.EventProducer{}
+					eProducer.On("ModelEndpointChangeEvent", mock.Anything, mock.Anything).Return(nil)
+					return eProducer
+				}(),
+			},
+			mockFunc: func(s *modelEndpointsService) {
+				mockIstio := s.istioClients[env.Name].(*istioCliMock.Client)
+				mockIstio.On("DeleteVirtualService", context.Background(), "project-1", "model-1").Return(nil)
+				mockIstio.On("DeleteEnvoyFilter", context.Background(), defaultExperimentFilterNamespace, "project-1-model-1-experiment").
+					Return(kerrors.NewNotFound(schema.GroupResource{Resource: "envoyfilters"}, "project-1-model-1-experiment"))
+
+				mockMeStorage := s.modelEndpointStorage.(*storageMock.ModelEndpointStorage)
+				mockMeStorage.On("Save", context.Background(), mock.AnythingOfType("*models.ModelEndpoint"), mock.AnythingOfType("*models.ModelEndpoint")).Return(nil)
+			},
+			args: args{
+				context.Background(),
+				model1,
+				&models.ModelEndpoint{ID: 1, EnvironmentName: env.Name, Experiment: &models.ModelEndpointExperiment{Name: "exp"}},
+			},
+			want:    &models.ModelEndpoint{ID: 1, EnvironmentName: env.Name, Experiment: &models.ModelEndpointExperiment{Name: "exp"}, Status: models.EndpointTerminated},
+			wantErr: false,
+		},
 		{
 			"error: environment not found",
 			fields{
@@ -469,7 +502,7 @@ func Test_modelEndpointsService_UndeployEndpoint(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newModelEndpointsService(tt.fields.istioClients, tt.fields.modelEndpointStorage, tt.fields.versionEndpointStorage, tt.fields.environment, tt.fields.observabilityEventProducer)
+			s := newModelEndpointsService(tt.fields.istioClients, tt.fields.modelEndpointStorage, tt.fields.versionEndpointStorage, tt.fields.environment, tt.fields.observabilityEventProducer, config.ExperimentConfig{})
 
 			tt.mockFunc(s)
 
@@ -491,6 +524,8 @@ func TestModelEndpointService_createVirtualService(t *testing.T) {
 		_ = labeller.InitKubernetesLabeller("", "", "")
 	}()
 
+	mirrorPercentage := 25.0
+
 	type fields struct {
 		environment string
 	}
@@ -634,6 +669,355 @@ func TestModelEndpointService_createVirtualService(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success: http_json with routes",
+			fields: fields{
+				environment: testEnvironmentName,
+			},
+			args: args{
+				model: model1,
+				modelEndpoint: &models.ModelEndpoint{
+					ModelID: 1,
+					Rule: &models.ModelEndpointRule{
+						Destination: modelEndpointRequest1.Rule.Destination,
+						Routes: []*models.ModelEndpointRuleRoute{
+							{
+								Name: "testers",
+								Headers: map[string]*models.StringMatch{
+									"X-Tester": {Exact: "true"},
+								},
+								QueryParams: map[string]*models.StringMatch{
+									"variant": {Regex: "^b.*"},
+								},
+								VersionEndpointID: versionEndpoint2ID,
+								VersionEndpoint:   versionEndpoint2,
+							},
+							{
+								Cookies:           map[string]string{"beta": "1"},
+								VersionEndpointID: versionEndpoint2ID,
+								VersionEndpoint:   versionEndpoint2,
+							},
+						},
+					},
+					EnvironmentName: env.Name,
+				},
+			},
+			want: &v1beta1.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      model1.Name,
+					Namespace: model1.Project.Name,
+					Labels: map[string]string{
+						"gojek.com/app":          model1.Name,
+						"gojek.com/component":    models.ComponentModelEndpoint,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       model1.Project.Stream,
+						"gojek.com/team":         model1.Project.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: networking.VirtualService{
+					Hosts:    []string{"model-1.project-1.mlp.io"},
+					Gateways: []string{"knative-ingress-gateway.knative-serving"},
+					Http: []*networking.HTTPRoute{
+						{
+							Name: "testers",
+							Match: []*networking.HTTPMatchRequest{
+								{
+									Uri: &networking.StringMatch{
+										MatchType: &networking.StringMatch_Prefix{Prefix: defaultMatchURIPrefix},
+									},
+									Headers: map[string]*networking.StringMatch{
+										"x-tester": {MatchType: &networking.StringMatch_Exact{Exact: "true"}},
+									},
+									QueryParams: map[string]*networking.StringMatch{
+										"variant": {MatchType: &networking.StringMatch_Regex{Regex: "^b.*"}},
+									},
+								},
+							},
+							Route: []*networking.HTTPRouteDestination{
+								{
+									Destination: &networking.Destination{Host: defaultIstioGateway},
+									Headers: &networking.Headers{
+										Request: &networking.Headers_HeaderOperations{
+											Set: map[string]string{"Host": versionEndpoint2.Hostname()},
+										},
+									},
+									Weight: 100,
+								},
+							},
+							Rewrite: &networking.HTTPRewrite{Uri: "/v1/models/version-2:predict"},
+						},
+						{
+							Match: []*networking.HTTPMatchRequest{
+								{
+									Uri: &networking.StringMatch{
+										MatchType: &networking.StringMatch_Prefix{Prefix: defaultMatchURIPrefix},
+									},
+									Headers: map[string]*networking.StringMatch{
+										"cookie": {MatchType: &networking.StringMatch_Regex{Regex: `^(.*?;\s*)?beta=1(;.*)?$`}},
+									},
+								},
+							},
+							Route: []*networking.HTTPRouteDestination{
+								{
+									Destination: &networking.Destination{Host: defaultIstioGateway},
+									Headers: &networking.Headers{
+										Request: &networking.Headers_HeaderOperations{
+											Set: map[string]string{"Host": versionEndpoint2.Hostname()},
+										},
+									},
+									Weight: 100,
+								},
+							},
+							Rewrite: &networking.HTTPRewrite{Uri: "/v1/models/version-2:predict"},
+						},
+						{
+							Match: []*networking.HTTPMatchRequest{
+								{
+									Uri: &networking.StringMatch{
+										MatchType: &networking.StringMatch_Prefix{Prefix: defaultMatchURIPrefix},
+									},
+								},
+							},
+							Route: []*networking.HTTPRouteDestination{
+								{
+									Destination: &networking.Destination{Host: defaultIstioGateway},
+									Headers: &networking.Headers{
+										Request: &networking.Headers_HeaderOperations{
+											Set: map[string]string{"Host": versionEndpoint1.Hostname()},
+										},
+									},
+									Weight: 100,
+								},
+							},
+							Rewrite: &networking.HTTPRewrite{Uri: "/v1/models/version-1:predict"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "success: upiv1 with header route",
+			fields: fields{
+				environment: testEnvironmentName,
+			},
+			args: args{
+				model: model1,
+				modelEndpoint: &models.ModelEndpoint{
+					ModelID: 1,
+					Rule: &models.ModelEndpointRule{
+						Destination: []*models.ModelEndpointRuleDestination{
+							{
+								VersionEndpointID: upiV1VersionEndpoint1UUID,
+								VersionEndpoint:   upiV1VersionEndpoint1,
+								Weight:            int32(100),
+							},
+						},
+						Routes: []*models.ModelEndpointRuleRoute{
+							{
+								Headers: map[string]*models.StringMatch{
+									"x-client": {Prefix: "internal-"},
+								},
+								VersionEndpointID: upiV1VersionEndpoint1UUID,
+								VersionEndpoint:   upiV1VersionEndpoint1,
+							},
+						},
+					},
+					EnvironmentName: env.Name,
+				},
+			},
+			want: &v1beta1.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      model1.Name,
+					Namespace: model1.Project.Name,
+					Labels: map[string]string{
+						"gojek.com/app":          model1.Name,
+						"gojek.com/component":    models.ComponentModelEndpoint,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       model1.Project.Stream,
+						"gojek.com/team":         model1.Project.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: networking.VirtualService{
+					Hosts:    []string{"model-1.project-1.mlp.io"},
+					Gateways: []string{"knative-ingress-gateway.knative-serving"},
+					Http: []*networking.HTTPRoute{
+						{
+							Match: []*networking.HTTPMatchRequest{
+								{
+									Headers: map[string]*networking.StringMatch{
+										"x-client": {MatchType: &networking.StringMatch_Prefix{Prefix: "internal-"}},
+									},
+								},
+							},
+							Route: []*networking.HTTPRouteDestination{
+								{
+									Destination: &networking.Destination{Host: defaultIstioGateway},
+									Headers: &networking.Headers{
+										Request: &networking.Headers_HeaderOperations{
+											Set: map[string]string{"Host": "model-upi-1.project-1.mlp.io"},
+										},
+									},
+									Weight: 100,
+								},
+							},
+						},
+						{
+							Route: []*networking.HTTPRouteDestination{
+								{
+									Destination: &networking.Destination{Host: defaultIstioGateway},
+									Headers: &networking.Headers{
+										Request: &networking.Headers_HeaderOperations{
+											Set: map[string]string{"Host": "model-upi-1.project-1.mlp.io"},
+										},
+									},
+									Weight: 100,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "success: http_json with mirror",
+			fields: fields{
+				environment: testEnvironmentName,
+			},
+			args: args{
+				model: model1,
+				modelEndpoint: &models.ModelEndpoint{
+					ModelID: 1,
+					Rule: &models.ModelEndpointRule{
+						Destination:      modelEndpointRequest1.Rule.Destination,
+						Mirror:           versionEndpoint2,
+						MirrorPercentage: &mirrorPercentage,
+					},
+					EnvironmentName: env.Name,
+				},
+			},
+			want: &v1beta1.VirtualService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      model1.Name,
+					Namespace: model1.Project.Name,
+					Labels: map[string]string{
+						"gojek.com/app":          model1.Name,
+						"gojek.com/component":    models.ComponentModelEndpoint,
+						"gojek.com/environment":  testEnvironmentName,
+						"gojek.com/orchestrator": testOrchestratorName,
+						"gojek.com/stream":       model1.Project.Stream,
+						"gojek.com/team":         model1.Project.Team,
+						"sample":                 "true",
+					},
+				},
+				Spec: networking.VirtualService{
+					Hosts:    []string{"model-1.project-1.mlp.io", "version-1.project-1.mlp.io-shadow"},
+					Gateways: []string{"knative-ingress-gateway.knative-serving"},
+					Http: []*networking.HTTPRoute{
+						{
+							Match: []*networking.HTTPMatchRequest{
+								{
+									Authority: &networking.StringMatch{
+										MatchType: &networking.StringMatch_Exact{Exact: "version-1.project-1.mlp.io-shadow"},
+									},
+								},
+							},
+							Route: []*networking.HTTPRouteDestination{
+								{
+									Destination: &networking.Destination{Host: defaultIstioGateway},
+									Headers: &networking.Headers{
+										Request: &networking.Headers_HeaderOperations{
+											Set: map[string]string{
+												"Host":                   versionEndpoint2.Hostname(),
+												models.MirroredLogHeader: "true",
+											},
+										},
+									},
+									Weight: 100,
+								},
+							},
+							Rewrite: &networking.HTTPRewrite{
+								UriRegexRewrite: &networking.RegexRewrite{Match: "^.*$", Rewrite: "/v1/models/version-2:predict"},
+							},
+						},
+						{
+							Match: []*networking.HTTPMatchRequest{
+								{
+									Uri: &networking.StringMatch{
+										MatchType: &networking.StringMatch_Prefix{Prefix: defaultMatchURIPrefix},
+									},
+								},
+							},
+							Route: []*networking.HTTPRouteDestination{
+								{
+									Destination: &networking.Destination{Host: defaultIstioGateway},
+									Headers: &networking.Headers{
+										Request: &networking.Headers_HeaderOperations{
+											Set: map[string]string{"Host": versionEndpoint1.Hostname()},
+										},
+									},
+									Weight: 100,
+								},
+							},
+							Rewrite:          &networking.HTTPRewrite{Uri: "/v1/models/version-1:predict"},
+							Mirror:           &networking.Destination{Host: defaultIstioGateway},
+							MirrorPercentage: &networking.Percent{Value: 25},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "fail: mirror version endpoint not running",
+			fields: fields{
+				environment: testEnvironmentName,
+			},
+			args: args{
+				model: model1,
+				modelEndpoint: &models.ModelEndpoint{
+					ModelID: 1,
+					Rule: &models.ModelEndpointRule{
+						Destination: modelEndpointRequest1.Rule.Destination,
+						Mirror: &models.VersionEndpoint{
+							ID:     versionEndpoint2ID,
+							Status: models.EndpointFailed,
+						},
+					},
+					EnvironmentName: env.Name,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail: route version endpoint not running",
+			fields: fields{
+				environment: testEnvironmentName,
+			},
+			args: args{
+				model: model1,
+				modelEndpoint: &models.ModelEndpoint{
+					ModelID: 1,
+					Rule: &models.ModelEndpointRule{
+						Destination: modelEndpointRequest1.Rule.Destination,
+						Routes: []*models.ModelEndpointRuleRoute{
+							{
+								Headers:           map[string]*models.StringMatch{"x-tester": {Exact: "true"}},
+								VersionEndpointID: versionEndpoint2ID,
+								VersionEndpoint: &models.VersionEndpoint{
+									ID:     versionEndpoint2ID,
+									Status: models.EndpointFailed,
+								},
+							},
+						},
+					},
+					EnvironmentName: env.Name,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -648,3 +1032,67 @@ func TestModelEndpointService_createVirtualService(t *testing.T) {
 		})
 	}
 }
+
+func Test_modelEndpointsService_assignVersionEndpoint_mirror(t *testing.T) {
+	validPercentage := 10.0
+	invalidPercentage := 150.0
+
+	tests := []struct {
+		name             string
+		mirror           *models.VersionEndpoint
+		mirrorPercentage *float64
+		wantErr          error
+		wantErrMsg       string
+	}{
+		{
+			name:             "success",
+			mirror:           &models.VersionEndpoint{ID: versionEndpoint2ID},
+			mirrorPercentage: &validPercentage,
+		},
+		{
+			name:    "mirror receives traffic",
+			mirror:  &models.VersionEndpoint{ID: uuid1},
+			wantErr: ErrInvalidMirror,
+		},
+		{
+			name:             "invalid mirror percentage",
+			mirror:           &models.VersionEndpoint{ID: versionEndpoint2ID},
+			mirrorPercentage: &invalidPercentage,
+			wantErr:          ErrInvalidMirror,
+		},
+		{
+			name:       "protocol mismatch",
+			mirror:     &models.VersionEndpoint{ID: upiV1VersionEndpoint1UUID},
+			wantErrMsg: "all version endpoint protocol must be same",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			veStorage := &storageMock.VersionEndpointStorage{}
+			veStorage.On("Get", uuid1).Return(versionEndpoint1, nil)
+			veStorage.On("Get", versionEndpoint2ID).Return(versionEndpoint2, nil)
+			veStorage.On("Get", upiV1VersionEndpoint1UUID).Return(upiV1VersionEndpoint1, nil)
+
+			s := newModelEndpointsService(nil, &storageMock.ModelEndpointStorage{}, veStorage, testEnvironmentName, nil, config.ExperimentConfig{})
+			endpoint := &models.ModelEndpoint{
+				Rule: &models.ModelEndpointRule{
+					Destination:      []*models.ModelEndpointRuleDestination{{VersionEndpointID: uuid1, Weight: 100}},
+					Mirror:           tt.mirror,
+					MirrorPercentage: tt.mirrorPercentage,
+				},
+			}
+
+			got, err := s.assignVersionEndpoint(context.Background(), endpoint)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, versionEndpoint2, got.Rule.Mirror)
+		})
+	}
+}
@@ -0,0 +1,798 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/gitlab"
+	"github.com/caraml-dev/merlin/pkg/protocol"
+)
+
+const manifestVersionsPageSize = 50
+
+// errManifestChangeSkipped is returned by a step which finds out that its change can't be applied yet
+var errManifestChangeSkipped = errors.New("manifest change skipped")
+
+// ModelManifestService converges the schemas, version endpoints, model endpoints and alerts of a model to its manifest
+type ModelManifestService interface {
+	// Apply computes the changes converging the model to the manifest and applies them, unless it is a dry-run
+	Apply(ctx context.Context, model *models.Model, manifest *models.ModelManifest, options ApplyOptions) (*models.ApplyPlan, error)
+}
+
+// ApplyOptions are the options of the apply of a model manifest
+type ApplyOptions struct {
+	// User is the author of the alerts and of the manifest commit
+	User   string
+	DryRun bool
+	// Prune undeploys the version endpoints and model endpoints which are not declared by the manifest,
+	// in the environments declared by the manifest
+	Prune bool
+	// Commit commits the applied manifest to the manifest repository
+	Commit bool
+	// RawManifest is the manifest as submitted, it is committed as is to keep its comments and formatting
+	RawManifest []byte
+	// ValidateVersionEndpoint validates the deployments and undeployments of version endpoints, nothing is validated
+	// if it is nil
+	ValidateVersionEndpoint VersionEndpointValidator
+}
+
+// VersionEndpointValidator validates the change of a version endpoint from prev to new the same way as the version
+// endpoint API, prev is nil if the version endpoint doesn't exist yet
+type VersionEndpointValidator func(ctx context.Context, model *models.Model, version *models.Version, env *models.Environment, prev *models.VersionEndpoint, new *models.VersionEndpoint) error
+
+type ModelManifestServiceParams struct {
+	EnvironmentService    EnvironmentService
+	VersionsService       VersionsService
+	EndpointsService      EndpointsService
+	ModelEndpointsService ModelEndpointsService
+	// ModelEndpointAlertService is nil if alerts are disabled
+	ModelEndpointAlertService ModelEndpointAlertService
+	ModelSchemaService        ModelSchemaService
+	MonitoringConfig          config.MonitoringConfig
+	GitlabClient              gitlab.Client
+	// ManifestRepository is the repository the applied manifests are committed to, the commit-back is disabled if empty
+	ManifestRepository string
+	ManifestBranch     string
+}
+
+type modelManifestService struct {
+	environmentService        EnvironmentService
+	versionsService           VersionsService
+	endpointsService          EndpointsService
+	modelEndpointsService     ModelEndpointsService
+	modelEndpointAlertService ModelEndpointAlertService
+	modelSchemaService        ModelSchemaService
+	monitoringConfig          config.MonitoringConfig
+	gitlabClient              gitlab.Client
+	manifestRepository        string
+	manifestBranch            string
+}
+
+// NewModelManifestService returns an initialized ModelManifestService
+func NewModelManifestService(params ModelManifestServiceParams) ModelManifestService {
+	return &modelManifestService{
+		environmentService:        params.EnvironmentService,
+		versionsService:           params.VersionsService,
+		endpointsService:          params.EndpointsService,
+		modelEndpointsService:     params.ModelEndpointsService,
+		modelEndpointAlertService: params.ModelEndpointAlertService,
+		modelSchemaService:        params.ModelSchemaService,
+		monitoringConfig:          params.MonitoringConfig,
+		gitlabClient:              params.GitlabClient,
+		manifestRepository:        params.ManifestRepository,
+		manifestBranch:            params.ManifestBranch,
+	}
+}
+
+// manifestStep applies a change of the apply plan
+type manifestStep struct {
+	change *models.ManifestChange
+	apply  func(ctx context.Context) error
+}
+
+// manifestApply holds the state of the apply of a manifest to a model
+type manifestApply struct {
+	*modelManifestService
+	model    *models.Model
+	manifest *models.ModelManifest
+	options  ApplyOptions
+	plan     *models.ApplyPlan
+	steps    []*manifestStep
+
+	environments map[string]*models.Environment
+	versions     map[models.ID]*models.Version
+}
+
+// Apply plans the changes of the schemas, model endpoints, alerts and version endpoints, then the deletions of the
+// pruned resources, and applies them in that order. The model endpoints are updated before the version endpoints
+// are redeployed since a model endpoint can only route the traffic to running version endpoints: a model endpoint
+// routing to a version endpoint which is not running yet is converged by a later apply.
+//
+// The plan is returned along with the error of a failed change, the changes applied before it are flagged as applied.
+// A failure to commit the manifest is only a warning since the model has been converged.
+func (s *modelManifestService) Apply(ctx context.Context, model *models.Model, manifest *models.ModelManifest, options ApplyOptions) (*models.ApplyPlan, error) {
+	if err := manifest.Validate(); err != nil {
+		return nil, merror.NewInvalidInputError(err.Error())
+	}
+	if manifest.Model != model.Name {
+		return nil, merror.NewInvalidInputErrorf("manifest of model %s can't be applied to model %s", manifest.Model, model.Name)
+	}
+	if options.Commit && s.manifestRepository == "" {
+		return nil, merror.NewInvalidInputError("committing the manifest is not enabled")
+	}
+
+	a := &manifestApply{
+		modelManifestService: s,
+		model:                model,
+		manifest:             manifest,
+		options:              options,
+		plan:                 models.NewApplyPlan(options.DryRun),
+		environments:         map[string]*models.Environment{},
+		versions:             map[models.ID]*models.Version{},
+	}
+
+	if err := a.planSchemas(ctx); err != nil {
+		return nil, err
+	}
+	if err := a.planModelEndpoints(ctx, options.User); err != nil {
+		return nil, err
+	}
+	if err := a.planVersionEndpoints(ctx); err != nil {
+		return nil, err
+	}
+	if options.Prune {
+		if err := a.planPrune(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.DryRun {
+		return a.plan, nil
+	}
+
+	for _, step := range a.steps {
+		if err := step.apply(ctx); err != nil {
+			err = fmt.Errorf("failed to %s %s: %w", step.change.Action, describeManifestChange(step.change), err)
+			a.plan.Error = err.Error()
+			return a.plan, err
+		}
+	}
+
+	if options.Commit {
+		committed, err := s.commitManifest(model, options.User, options.RawManifest)
+		if err != nil {
+			a.plan.AddWarning("manifest is not committed: %v", err)
+		} else {
+			a.plan.Committed = committed
+		}
+	}
+	return a.plan, nil
+}
+
+func describeManifestChange(change *models.ManifestChange) string {
+	switch change.Kind {
+	case models.ManifestSchema:
+		return fmt.Sprintf("schema of version %d", change.VersionID)
+	case models.ManifestVersionEndpoint:
+		return fmt.Sprintf("version endpoint of version %d in %s", change.VersionID, change.EnvironmentName)
+	case models.ManifestModelEndpoint:
+		return fmt.Sprintf("model endpoint in %s", change.EnvironmentName)
+	default:
+		return fmt.Sprintf("alert of model endpoint in %s", change.EnvironmentName)
+	}
+}
+
+// addChange adds the change to the plan and its step, if any, to the steps to apply
+func (a *manifestApply) addChange(change *models.ManifestChange, apply func(ctx context.Context) error) {
+	a.plan.AddChange(change)
+	if apply == nil || change.Action == models.ManifestActionNone {
+		return
+	}
+	a.steps = append(a.steps, &manifestStep{
+		change: change,
+		apply: func(ctx context.Context) error {
+			if err := apply(ctx); err != nil {
+				if errors.Is(err, errManifestChangeSkipped) {
+					return nil
+				}
+				return err
+			}
+			change.Applied = true
+			return nil
+		},
+	})
+}
+
+func (a *manifestApply) environment(name string) (*models.Environment, error) {
+	if env, ok := a.environments[name]; ok {
+		return env, nil
+	}
+	env, err := a.environmentService.GetEnvironment(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, merror.NewInvalidInputErrorf("environment %s not found", name)
+		}
+		return nil, err
+	}
+	a.environments[name] = env
+	return env, nil
+}
+
+func (a *manifestApply) version(ctx context.Context, versionID models.ID) (*models.Version, error) {
+	if version, ok := a.versions[versionID]; ok {
+		return version, nil
+	}
+	version, err := a.versionsService.FindByID(ctx, a.model.ID, versionID, a.monitoringConfig)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, merror.NewInvalidInputErrorf("version %d of model %s not found", versionID, a.model.Name)
+		}
+		return nil, err
+	}
+	a.versions[versionID] = version
+	return version, nil
+}
+
+// planSchemas creates the schemas of the versions without schema and updates the others in place,
+// a schema shared by several versions is hence updated for all of them
+func (a *manifestApply) planSchemas(ctx context.Context) error {
+	for _, schemaManifest := range a.manifest.Schemas {
+		version, err := a.version(ctx, schemaManifest.VersionID)
+		if err != nil {
+			return err
+		}
+
+		change := &models.ManifestChange{
+			Kind:      models.ManifestSchema,
+			Action:    models.ManifestActionCreate,
+			VersionID: version.ID,
+		}
+		schema := &models.ModelSchema{ModelID: a.model.ID, Spec: schemaManifest.Spec}
+		if version.ModelSchema != nil {
+			schema.ID = version.ModelSchema.ID
+			change.Diff, err = models.DiffValues(version.ModelSchema.Spec, schema.Spec)
+			if err != nil {
+				return err
+			}
+			change.Action = updateAction(change.Diff)
+		}
+
+		a.addChange(change, func(ctx context.Context) error {
+			savedSchema, err := a.modelSchemaService.Save(ctx, schema)
+			if err != nil {
+				return err
+			}
+			if version.ModelSchemaID != nil && *version.ModelSchemaID == savedSchema.ID {
+				return nil
+			}
+			version.ModelSchemaID = &savedSchema.ID
+			version.ModelSchema = savedSchema
+			_, err = a.versionsService.Save(ctx, version, a.monitoringConfig)
+			return err
+		})
+	}
+	return nil
+}
+
+func updateAction(diff []*models.DeploymentSpecChange) models.ManifestAction {
+	if len(diff) == 0 {
+		return models.ManifestActionNone
+	}
+	return models.ManifestActionUpdate
+}
+
+// currentModelEndpoints returns the model endpoints of the model by environment, a terminated model endpoint is only
+// returned if there is no other model endpoint in its environment
+func (a *manifestApply) currentModelEndpoints(ctx context.Context) (map[string]*models.ModelEndpoint, error) {
+	modelEndpoints, err := a.modelEndpointsService.ListModelEndpoints(ctx, a.model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]*models.ModelEndpoint{}
+	for _, modelEndpoint := range modelEndpoints {
+		if current, ok := result[modelEndpoint.EnvironmentName]; ok && current.Status != models.EndpointTerminated {
+			continue
+		}
+		result[modelEndpoint.EnvironmentName] = modelEndpoint
+	}
+	return result, nil
+}
+
+// planModelEndpoints creates or updates the weighted destinations of the model endpoints, their routes and mirror
+// are kept as is. A model endpoint with an experiment or a rollout in progress is left to its controller.
+func (a *manifestApply) planModelEndpoints(ctx context.Context, user string) error {
+	currentEndpoints, err := a.currentModelEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, endpointManifest := range a.manifest.ModelEndpoints {
+		env, err := a.environment(endpointManifest.EnvironmentName)
+		if err != nil {
+			return err
+		}
+
+		destinations, ready, err := a.modelEndpointDestinations(ctx, endpointManifest)
+		if err != nil {
+			return err
+		}
+
+		change := &models.ManifestChange{
+			Kind:            models.ManifestModelEndpoint,
+			Action:          models.ManifestActionCreate,
+			EnvironmentName: env.Name,
+		}
+
+		current := currentEndpoints[env.Name]
+		endpoint := &models.ModelEndpoint{
+			ModelID:         a.model.ID,
+			EnvironmentName: env.Name,
+			Environment:     env,
+			Protocol:        protocol.HttpJson,
+			Rule:            &models.ModelEndpointRule{Destination: destinations},
+		}
+		if destinations[0].VersionEndpoint != nil {
+			endpoint.Protocol = destinations[0].VersionEndpoint.Protocol
+		}
+
+		if current != nil && current.Status != models.EndpointTerminated {
+			currentManifest, err := a.modelEndpointManifest(ctx, current)
+			if err != nil {
+				return err
+			}
+			change.Diff, err = models.DiffValues(currentManifest.Destinations, endpointManifest.Destinations)
+			if err != nil {
+				return err
+			}
+			change.Action = updateAction(change.Diff)
+
+			newEndpoint := *current
+			newEndpoint.Environment = env
+			rule := models.ModelEndpointRule{}
+			if current.Rule != nil {
+				rule = *current.Rule
+			}
+			rule.Destination = destinations
+			newEndpoint.Rule = &rule
+			endpoint = &newEndpoint
+		} else if current != nil {
+			endpoint.ID = current.ID
+		}
+
+		var apply func(ctx context.Context) error
+		switch {
+		case change.Action == models.ManifestActionNone:
+		case current != nil && current.Experiment != nil:
+			a.plan.AddWarning("model endpoint in %s is not updated since its traffic is assigned by its experiment", env.Name)
+		case current != nil && current.Rollout != nil && current.Rollout.InProgress():
+			a.plan.AddWarning("model endpoint in %s is not updated since its rollout is in progress", env.Name)
+		case !ready:
+			a.plan.AddWarning("model endpoint in %s is not updated since its version endpoints are not all running, apply the manifest again once they are", env.Name)
+		case change.Action == models.ManifestActionCreate:
+			apply = func(ctx context.Context) error {
+				deployed, err := a.modelEndpointsService.DeployEndpoint(ctx, a.model, endpoint)
+				if err != nil {
+					return err
+				}
+				*endpoint = *deployed
+				return nil
+			}
+		default:
+			apply = func(ctx context.Context) error {
+				_, err := a.modelEndpointsService.UpdateEndpoint(ctx, a.model, current, endpoint)
+				return err
+			}
+		}
+		a.addChange(change, apply)
+
+		if endpointManifest.Alert != nil {
+			// the alert of a new model endpoint can only be created once the model endpoint is
+			created := change.Action != models.ManifestActionCreate || apply != nil
+			if err := a.planModelEndpointAlert(endpointManifest.Alert, endpoint, created, user); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// modelEndpointDestinations resolves the version endpoints of the destinations, ready is false if a version
+// endpoint doesn't exist or is not running
+func (a *manifestApply) modelEndpointDestinations(ctx context.Context, endpointManifest *models.ModelEndpointManifest) ([]*models.ModelEndpointRuleDestination, bool, error) {
+	ready := true
+	destinations := []*models.ModelEndpointRuleDestination{}
+	for _, destinationManifest := range endpointManifest.Destinations {
+		version, err := a.version(ctx, destinationManifest.VersionID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		destination := &models.ModelEndpointRuleDestination{Weight: destinationManifest.Weight}
+		versionEndpoint, ok := version.GetEndpointByEnvironmentName(endpointManifest.EnvironmentName)
+		if ok {
+			destination.VersionEndpointID = versionEndpoint.ID
+			destination.VersionEndpoint = versionEndpoint
+		}
+		if !ok || (versionEndpoint.Status != models.EndpointRunning && versionEndpoint.Status != models.EndpointServing) {
+			ready = false
+		}
+		destinations = append(destinations, destination)
+	}
+	return destinations, ready, nil
+}
+
+// modelEndpointManifest returns the manifest of the weighted destinations of a model endpoint
+func (a *manifestApply) modelEndpointManifest(ctx context.Context, endpoint *models.ModelEndpoint) (*models.ModelEndpointManifest, error) {
+	endpointManifest := &models.ModelEndpointManifest{
+		EnvironmentName: endpoint.EnvironmentName,
+		Destinations:    []*models.ModelEndpointDestinationManifest{},
+	}
+	if endpoint.Rule == nil {
+		return endpointManifest, nil
+	}
+
+	for _, destination := range endpoint.Rule.Destination {
+		versionEndpoint := destination.VersionEndpoint
+		if versionEndpoint == nil {
+			var err error
+			versionEndpoint, err = a.endpointsService.FindByID(ctx, destination.VersionEndpointID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		endpointManifest.Destinations = append(endpointManifest.Destinations, &models.ModelEndpointDestinationManifest{
+			VersionID: versionEndpoint.VersionID,
+			Weight:    destination.Weight,
+		})
+	}
+	return endpointManifest, nil
+}
+
+// planModelEndpointAlert creates or updates the alert of a model endpoint, created is false if the model endpoint
+// doesn't exist and is not created by this apply
+func (a *manifestApply) planModelEndpointAlert(alertManifest *models.ModelEndpointAlertManifest, endpoint *models.ModelEndpoint, created bool, user string) error {
+	if a.modelEndpointAlertService == nil {
+		return merror.NewInvalidInputErrorf("alert of model endpoint in %s: alerts are not enabled", endpoint.EnvironmentName)
+	}
+
+	change := &models.ManifestChange{
+		Kind:            models.ManifestModelEndpointAlert,
+		Action:          models.ManifestActionCreate,
+		EnvironmentName: endpoint.EnvironmentName,
+	}
+	alert := &models.ModelEndpointAlert{
+		ModelID:         a.model.ID,
+		Model:           a.model,
+		EnvironmentName: endpoint.EnvironmentName,
+		TeamName:        alertManifest.TeamName,
+		AlertConditions: alertManifest.AlertConditions,
+	}
+
+	if endpoint.ID != 0 {
+		current, err := a.modelEndpointAlertService.GetModelEndpointAlert(a.model.ID, endpoint.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if current != nil {
+			alert.ID = current.ID
+			currentManifest := &models.ModelEndpointAlertManifest{TeamName: current.TeamName, AlertConditions: current.AlertConditions}
+			change.Diff, err = models.DiffValues(currentManifest, alertManifest)
+			if err != nil {
+				return err
+			}
+			change.Action = updateAction(change.Diff)
+		}
+	}
+
+	var apply func(ctx context.Context) error
+	if !created {
+		a.plan.AddWarning("alert of model endpoint in %s is not created until the model endpoint is", endpoint.EnvironmentName)
+	} else {
+		apply = func(ctx context.Context) error {
+			alert.ModelEndpointID = endpoint.ID
+			alert.ModelEndpoint = endpoint
+			var err error
+			if change.Action == models.ManifestActionCreate {
+				_, err = a.modelEndpointAlertService.CreateModelEndpointAlert(user, alert)
+			} else {
+				_, err = a.modelEndpointAlertService.UpdateModelEndpointAlert(user, alert)
+			}
+			return err
+		}
+	}
+	a.addChange(change, apply)
+	return nil
+}
+
+// planVersionEndpoints deploys the version endpoints which don't exist, are terminated or failed, and redeploys the
+// running version endpoints whose configuration differs from their manifest. The configuration is compared with the
+// one rendered by a dry-run, which applies the defaults and the standard transformer configuration of a deployment.
+func (a *manifestApply) planVersionEndpoints(ctx context.Context) error {
+	for _, endpointManifest := range a.manifest.VersionEndpoints {
+		env, err := a.environment(endpointManifest.EnvironmentName)
+		if err != nil {
+			return err
+		}
+		version, err := a.version(ctx, endpointManifest.VersionID)
+		if err != nil {
+			return err
+		}
+
+		spec := endpointManifest.Spec
+		if spec == nil {
+			spec = &models.DeploymentSpec{}
+		}
+
+		change := &models.ManifestChange{
+			Kind:            models.ManifestVersionEndpoint,
+			Action:          models.ManifestActionCreate,
+			VersionID:       version.ID,
+			EnvironmentName: env.Name,
+		}
+
+		current, _ := version.GetEndpointByEnvironmentName(env.Name)
+		deploymentPlan, err := a.endpointsService.DryRunEndpoint(ctx, env, a.model, version, newManifestVersionEndpoint(spec, env, current))
+		if err != nil {
+			return fmt.Errorf("version endpoint of version %d in %s: %w", version.ID, env.Name, err)
+		}
+
+		if current != nil && current.Status != models.EndpointTerminated {
+			change.Diff, err = models.DiffDeploymentSpecs(models.NewDeploymentSpec(current), models.NewDeploymentSpec(deploymentPlan.Endpoint))
+			if err != nil {
+				return err
+			}
+			change.Action = updateAction(change.Diff)
+			if current.Status == models.EndpointFailed {
+				change.Action = models.ManifestActionUpdate
+			}
+		}
+
+		if change.Action == models.ManifestActionNone {
+			a.addChange(change, nil)
+			continue
+		}
+		if current != nil && current.Status == models.EndpointPending {
+			a.plan.AddWarning("version endpoint of version %d in %s is not updated since it is being deployed, apply the manifest again once it is deployed", version.ID, env.Name)
+			a.addChange(change, nil)
+			continue
+		}
+
+		if err := a.validateVersionEndpoint(ctx, version, env, current, newManifestVersionEndpoint(spec, env, current)); err != nil {
+			return err
+		}
+		for _, warning := range deploymentPlan.Warnings {
+			a.plan.AddWarning("version endpoint of version %d in %s: %s", version.ID, env.Name, warning)
+		}
+
+		a.addChange(change, func(ctx context.Context) error {
+			_, err := a.endpointsService.DeployEndpoint(ctx, env, a.model, version, newManifestVersionEndpoint(spec, env, current))
+			return err
+		})
+	}
+	return nil
+}
+
+// newManifestVersionEndpoint returns the version endpoint deploying the spec of a manifest, the same way as the
+// request of the version endpoint API. The deployment overrides the current configuration with the fields set by spec.
+func newManifestVersionEndpoint(spec *models.DeploymentSpec, env *models.Environment, current *models.VersionEndpoint) *models.VersionEndpoint {
+	endpoint := spec.VersionEndpoint()
+	endpoint.EnvironmentName = env.Name
+	endpoint.Status = models.EndpointRunning
+	if current != nil && current.IsServing() {
+		endpoint.Status = models.EndpointServing
+	}
+	return endpoint
+}
+
+// validateVersionEndpoint validates the change of a version endpoint with the validator of the apply options
+func (a *manifestApply) validateVersionEndpoint(ctx context.Context, version *models.Version, env *models.Environment, prev *models.VersionEndpoint, new *models.VersionEndpoint) error {
+	if a.options.ValidateVersionEndpoint == nil {
+		return nil
+	}
+	if err := a.options.ValidateVersionEndpoint(ctx, a.model, version, env, prev, new); err != nil {
+		return merror.NewInvalidInputErrorf("version endpoint of version %d in %s: %v", version.ID, env.Name, err)
+	}
+	return nil
+}
+
+// planPrune undeploys the model endpoints and version endpoints which are not declared by the manifest in its
+// environments. A version endpoint still serving a model endpoint once the model endpoints are converged is kept.
+func (a *manifestApply) planPrune(ctx context.Context) error {
+	environments := []string{}
+	declaredModelEndpoints := map[string]bool{}
+	declaredVersionEndpoints := map[string]bool{}
+	for _, endpointManifest := range a.manifest.VersionEndpoints {
+		if !contains(environments, endpointManifest.EnvironmentName) {
+			environments = append(environments, endpointManifest.EnvironmentName)
+		}
+		declaredVersionEndpoints[manifestVersionEndpointKey(endpointManifest.VersionID, endpointManifest.EnvironmentName)] = true
+	}
+	for _, endpointManifest := range a.manifest.ModelEndpoints {
+		if !contains(environments, endpointManifest.EnvironmentName) {
+			environments = append(environments, endpointManifest.EnvironmentName)
+		}
+		declaredModelEndpoints[endpointManifest.EnvironmentName] = true
+	}
+
+	currentEndpoints, err := a.currentModelEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+	for _, envName := range environments {
+		current, ok := currentEndpoints[envName]
+		if !ok || declaredModelEndpoints[envName] || current.Status == models.EndpointTerminated {
+			continue
+		}
+		a.addChange(&models.ManifestChange{
+			Kind:            models.ManifestModelEndpoint,
+			Action:          models.ManifestActionDelete,
+			EnvironmentName: envName,
+		}, func(ctx context.Context) error {
+			_, err := a.modelEndpointsService.UndeployEndpoint(ctx, a.model, current)
+			return err
+		})
+	}
+
+	for _, envName := range environments {
+		env, err := a.environment(envName)
+		if err != nil {
+			return err
+		}
+		versions, err := a.deployedVersions(ctx, envName)
+		if err != nil {
+			return err
+		}
+
+		for _, version := range versions {
+			endpoint, ok := version.GetEndpointByEnvironmentName(envName)
+			if !ok || endpoint.Status == models.EndpointTerminated || declaredVersionEndpoints[manifestVersionEndpointKey(version.ID, envName)] {
+				continue
+			}
+			version := version
+			change := &models.ManifestChange{
+				Kind:            models.ManifestVersionEndpoint,
+				Action:          models.ManifestActionDelete,
+				VersionID:       version.ID,
+				EnvironmentName: envName,
+			}
+			switch endpoint.Status {
+			case models.EndpointPending:
+				a.plan.AddWarning("version endpoint of version %d in %s is not undeployed since it is being deployed, apply the manifest again once it is deployed", version.ID, envName)
+				a.addChange(change, nil)
+				continue
+			case models.EndpointServing:
+				// the version endpoint is validated once it doesn't serve a model endpoint anymore
+				a.plan.AddWarning("version endpoint of version %d in %s is only undeployed if it doesn't serve a model endpoint anymore", version.ID, envName)
+			default:
+				if err := a.validateVersionEndpoint(ctx, version, env, endpoint, newTerminatedVersionEndpoint(endpoint)); err != nil {
+					return err
+				}
+			}
+
+			a.addChange(change, func(ctx context.Context) error {
+				// the version endpoint may have stopped serving when the model endpoints were converged
+				current, err := a.endpointsService.FindByID(ctx, endpoint.ID)
+				if err != nil {
+					return err
+				}
+				if current.Status == models.EndpointServing {
+					a.plan.AddWarning("version endpoint of version %d in %s is not undeployed since it is serving a model endpoint", version.ID, envName)
+					return errManifestChangeSkipped
+				}
+				if err := a.validateVersionEndpoint(ctx, version, env, current, newTerminatedVersionEndpoint(current)); err != nil {
+					return err
+				}
+				_, err = a.endpointsService.UndeployEndpoint(ctx, env, a.model, version, current)
+				return err
+			})
+		}
+	}
+	return nil
+}
+
+// newTerminatedVersionEndpoint returns the version endpoint undeploying endpoint, the same way as the request of the
+// version endpoint API
+func newTerminatedVersionEndpoint(endpoint *models.VersionEndpoint) *models.VersionEndpoint {
+	terminated := *endpoint
+	terminated.Status = models.EndpointTerminated
+	return &terminated
+}
+
+// deployedVersions lists the versions of the model with a version endpoint which is not terminated in the environment
+func (a *manifestApply) deployedVersions(ctx context.Context, envName string) ([]*models.Version, error) {
+	query := VersionQuery{
+		PaginationQuery: PaginationQuery{Limit: manifestVersionsPageSize},
+		Search:          fmt.Sprintf("environment_name:%s", envName),
+	}
+
+	result := []*models.Version{}
+	for {
+		versions, nextCursor, err := a.versionsService.ListVersions(ctx, a.model.ID, a.monitoringConfig, query)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, versions...)
+		if nextCursor == "" {
+			return result, nil
+		}
+		query.Cursor = nextCursor
+	}
+}
+
+func manifestVersionEndpointKey(versionID models.ID, envName string) string {
+	return fmt.Sprintf("%d/%s", versionID, envName)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// commitManifest commits the manifest to <project>/<model>.yaml of the manifest repository,
+// it returns false if the committed manifest is already up to date
+func (s *modelManifestService) commitManifest(model *models.Model, user string, manifest []byte) (bool, error) {
+	fileName := fmt.Sprintf("%s/%s.yaml", model.Project.Name, model.Name)
+	commitMessage := fmt.Sprintf("Autogenerated by Merlin: Apply manifest of %s/%s", model.Project.Name, model.Name)
+
+	currentContent, err := s.gitlabClient.GetFileContent(gitlab.GetFileContentOptions{
+		Repository: s.manifestRepository,
+		Branch:     s.manifestBranch,
+		FileName:   fileName,
+	})
+	if errors.Is(err, gitlab.ErrFileNotFound) {
+		// the manifest has never been committed
+		return true, s.gitlabClient.CreateFile(gitlab.CreateFileOptions{
+			Repository:    s.manifestRepository,
+			Branch:        s.manifestBranch,
+			FileName:      fileName,
+			Content:       string(manifest),
+			CommitMessage: commitMessage,
+			AuthorEmail:   user,
+			AuthorName:    user,
+		})
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// GitLab returns the content of the files base64 encoded
+	if decoded, err := base64.StdEncoding.DecodeString(currentContent); err == nil && string(decoded) == string(manifest) {
+		return false, nil
+	}
+
+	return true, s.gitlabClient.UpdateFile(gitlab.UpdateFileOptions{
+		Repository:    s.manifestRepository,
+		Branch:        s.manifestBranch,
+		FileName:      fileName,
+		Content:       string(manifest),
+		CommitMessage: commitMessage,
+		AuthorEmail:   user,
+		AuthorName:    user,
+	})
+}
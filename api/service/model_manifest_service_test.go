@@ -0,0 +1,632 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/mlp"
+	"github.com/caraml-dev/merlin/models"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
+	"github.com/caraml-dev/merlin/pkg/gitlab"
+	gitlabmocks "github.com/caraml-dev/merlin/pkg/gitlab/mocks"
+	"github.com/caraml-dev/merlin/pkg/protocol"
+	"github.com/caraml-dev/merlin/pkg/transformer"
+	"github.com/caraml-dev/merlin/service"
+	"github.com/caraml-dev/merlin/service/mocks"
+)
+
+// manifestCalls records the changes made by the mocked services of the apply of a manifest
+type manifestCalls []string
+
+func (c *manifestCalls) add(format string, a ...interface{}) {
+	*c = append(*c, fmt.Sprintf(format, a...))
+}
+
+// manifestMocks are the mocked services of the apply of a manifest, they keep the state of the model up to date
+// with the changes they make so that a manifest can be applied again
+type manifestMocks struct {
+	calls                     *manifestCalls
+	environmentService        *mocks.EnvironmentService
+	versionsService           *mocks.VersionsService
+	endpointsService          *mocks.EndpointsService
+	modelEndpointsService     *mocks.ModelEndpointsService
+	modelEndpointAlertService *mocks.ModelEndpointAlertService
+	modelSchemaService        *mocks.ModelSchemaService
+}
+
+// renderManifestVersionEndpoint renders the version endpoint deployed by a request the way the endpoints service does,
+// the current configuration is overridden and the feature table specs are added to the standard transformer
+func renderManifestVersionEndpoint(env *models.Environment, version *models.Version, endpoint *models.VersionEndpoint) *models.VersionEndpoint {
+	rendered := models.VersionEndpoint{VersionID: version.ID, EnvironmentName: env.Name, Protocol: protocol.HttpJson}
+	if current, ok := version.GetEndpointByEnvironmentName(env.Name); ok {
+		rendered = *current
+	}
+	if endpoint.ResourceRequest != nil {
+		rendered.ResourceRequest = endpoint.ResourceRequest
+	}
+	if endpoint.Transformer != nil {
+		standardTransformer := *endpoint.Transformer
+		standardTransformer.EnvVars = append(models.EnvVars{{Name: transformer.FeastFeatureTableSpecsJSON, Value: "[]"}}, standardTransformer.EnvVars...)
+		rendered.Transformer = &standardTransformer
+	}
+	return &rendered
+}
+
+func newManifestMocks(t *testing.T, env *models.Environment, versions []*models.Version, modelEndpoint *models.ModelEndpoint, deployErr error) *manifestMocks {
+	m := &manifestMocks{
+		calls:                     &manifestCalls{},
+		environmentService:        mocks.NewEnvironmentService(t),
+		versionsService:           mocks.NewVersionsService(t),
+		endpointsService:          mocks.NewEndpointsService(t),
+		modelEndpointsService:     mocks.NewModelEndpointsService(t),
+		modelEndpointAlertService: mocks.NewModelEndpointAlertService(t),
+		modelSchemaService:        mocks.NewModelSchemaService(t),
+	}
+
+	m.environmentService.On("GetEnvironment", env.Name).Return(env, nil).Maybe()
+
+	m.versionsService.On("FindByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, modelID, versionID models.ID, monitoringConfig config.MonitoringConfig) (*models.Version, error) {
+			for _, version := range versions {
+				if version.ID == versionID {
+					return version, nil
+				}
+			}
+			return nil, gorm.ErrRecordNotFound
+		}).Maybe()
+	m.versionsService.On("Save", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, version *models.Version, monitoringConfig config.MonitoringConfig) (*models.Version, error) {
+			m.calls.add("save version %d with schema %d", version.ID, *version.ModelSchemaID)
+			return version, nil
+		}).Maybe()
+
+	m.endpointsService.On("FindByID", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, endpointUuid uuid.UUID) (*models.VersionEndpoint, error) {
+			for _, version := range versions {
+				for _, endpoint := range version.Endpoints {
+					if endpoint.ID == endpointUuid {
+						return endpoint, nil
+					}
+				}
+			}
+			return nil, gorm.ErrRecordNotFound
+		}).Maybe()
+	m.endpointsService.On("DryRunEndpoint", mock.Anything, env, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.DeploymentPlan, error) {
+			m.calls.add("dry-run version endpoint %d", version.ID)
+			plan := models.NewDeploymentPlan()
+			plan.Endpoint = renderManifestVersionEndpoint(environment, version, endpoint)
+			return plan, nil
+		}).Maybe()
+	m.endpointsService.On("DeployEndpoint", mock.Anything, env, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.VersionEndpoint, error) {
+			m.calls.add("deploy version endpoint %d with max replica %d", version.ID, endpoint.ResourceRequest.MaxReplica)
+			if deployErr != nil {
+				return nil, deployErr
+			}
+			deployed := renderManifestVersionEndpoint(environment, version, endpoint)
+			if current, ok := version.GetEndpointByEnvironmentName(environment.Name); ok {
+				*current = *deployed
+			} else {
+				version.Endpoints = append(version.Endpoints, deployed)
+			}
+			return deployed, nil
+		}).Maybe()
+	m.endpointsService.On("UndeployEndpoint", mock.Anything, env, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.VersionEndpoint, error) {
+			m.calls.add("undeploy version endpoint %d", version.ID)
+			endpoint.Status = models.EndpointTerminated
+			return endpoint, nil
+		}).Maybe()
+
+	m.modelEndpointsService.On("ListModelEndpoints", mock.Anything, mock.Anything).
+		Return([]*models.ModelEndpoint{modelEndpoint}, nil).Maybe()
+	m.modelEndpointsService.On("UpdateEndpoint", mock.Anything, mock.Anything, modelEndpoint, mock.Anything).
+		Return(func(ctx context.Context, model *models.Model, oldEndpoint *models.ModelEndpoint, newEndpoint *models.ModelEndpoint) (*models.ModelEndpoint, error) {
+			weights := []int32{}
+			for _, destination := range newEndpoint.Rule.Destination {
+				weights = append(weights, destination.Weight)
+			}
+			m.calls.add("update model endpoint in %s with weights %v", newEndpoint.EnvironmentName, weights)
+			*oldEndpoint = *newEndpoint
+			return oldEndpoint, nil
+		}).Maybe()
+
+	var alert *models.ModelEndpointAlert
+	m.modelEndpointAlertService.On("GetModelEndpointAlert", mock.Anything, modelEndpoint.ID).
+		Return(func(modelID models.ID, modelEndpointID models.ID) (*models.ModelEndpointAlert, error) {
+			if alert == nil {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return alert, nil
+		}).Maybe()
+	m.modelEndpointAlertService.On("CreateModelEndpointAlert", mock.Anything, mock.Anything).
+		Return(func(user string, newAlert *models.ModelEndpointAlert) (*models.ModelEndpointAlert, error) {
+			m.calls.add("create alert of model endpoint %d for %s", newAlert.ModelEndpointID, newAlert.TeamName)
+			alert = newAlert
+			return newAlert, nil
+		}).Maybe()
+
+	m.modelSchemaService.On("Save", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, modelSchema *models.ModelSchema) (*models.ModelSchema, error) {
+			m.calls.add("save schema %d", modelSchema.ID)
+			saved := *modelSchema
+			if saved.ID == 0 {
+				saved.ID = 5
+			}
+			return &saved, nil
+		}).Maybe()
+	return m
+}
+
+func (m *manifestMocks) params(gitlabClient gitlab.Client) service.ModelManifestServiceParams {
+	return service.ModelManifestServiceParams{
+		EnvironmentService:        m.environmentService,
+		VersionsService:           m.versionsService,
+		EndpointsService:          m.endpointsService,
+		ModelEndpointsService:     m.modelEndpointsService,
+		ModelEndpointAlertService: m.modelEndpointAlertService,
+		ModelSchemaService:        m.modelSchemaService,
+		GitlabClient:              gitlabClient,
+		ManifestRepository:        "merlin/manifests",
+		ManifestBranch:            "master",
+	}
+}
+
+// validateManifestVersionEndpoint records the validations of the version endpoints
+func (m *manifestMocks) validateManifestVersionEndpoint(ctx context.Context, model *models.Model, version *models.Version, env *models.Environment, prev *models.VersionEndpoint, new *models.VersionEndpoint) error {
+	m.calls.add("validate version endpoint %d to %s", version.ID, new.Status)
+	return nil
+}
+
+func TestModelManifestService_Apply(t *testing.T) {
+	model := &models.Model{ID: 1, Name: "my-model", Project: mlp.Project{Name: "my-project"}}
+	env := &models.Environment{Name: "production"}
+
+	newVersionEndpoint := func(versionID models.ID, status models.EndpointStatus) *models.VersionEndpoint {
+		return &models.VersionEndpoint{
+			ID:              uuid.New(),
+			VersionID:       versionID,
+			VersionModelID:  model.ID,
+			Status:          status,
+			EnvironmentName: env.Name,
+			Protocol:        protocol.HttpJson,
+			ResourceRequest: &models.ResourceRequest{
+				MinReplica:    1,
+				MaxReplica:    2,
+				CPURequest:    resource.MustParse("500m"),
+				MemoryRequest: resource.MustParse("1Gi"),
+			},
+		}
+	}
+
+	newVersions := func(secondVersionStatus models.EndpointStatus) []*models.Version {
+		return []*models.Version{
+			{ID: 1, ModelID: model.ID, Endpoints: []*models.VersionEndpoint{newVersionEndpoint(1, models.EndpointServing)}},
+			{ID: 2, ModelID: model.ID, Endpoints: []*models.VersionEndpoint{newVersionEndpoint(2, secondVersionStatus)}},
+			{ID: 3, ModelID: model.ID, Endpoints: []*models.VersionEndpoint{newVersionEndpoint(3, models.EndpointRunning)}},
+		}
+	}
+
+	manifest := &models.ModelManifest{
+		Model:   "my-model",
+		Schemas: []*models.ModelSchemaManifest{{VersionID: 2, Spec: &models.SchemaSpec{SessionIDColumn: "session_id"}}},
+		VersionEndpoints: []*models.VersionEndpointManifest{
+			{
+				VersionID:       1,
+				EnvironmentName: "production",
+				Spec: &models.DeploymentSpec{
+					ResourceRequest: &models.ResourceRequest{
+						MinReplica:    1,
+						MaxReplica:    4,
+						CPURequest:    resource.MustParse("0.5"),
+						MemoryRequest: resource.MustParse("1Gi"),
+					},
+				},
+			},
+			{VersionID: 2, EnvironmentName: "production"},
+		},
+		ModelEndpoints: []*models.ModelEndpointManifest{
+			{
+				EnvironmentName: "production",
+				Destinations:    []*models.ModelEndpointDestinationManifest{{VersionID: 1, Weight: 80}, {VersionID: 2, Weight: 20}},
+				Alert:           &models.ModelEndpointAlertManifest{TeamName: "my-team"},
+			},
+		},
+	}
+
+	type change struct {
+		kind      models.ManifestResourceKind
+		action    models.ManifestAction
+		versionID models.ID
+		applied   bool
+	}
+
+	notFoundErr := fmt.Errorf("my-project/my-model.yaml: %w", gitlab.ErrFileNotFound)
+
+	tests := []struct {
+		name                string
+		manifest            *models.ModelManifest
+		secondVersionStatus models.EndpointStatus
+		options             func(m *manifestMocks) service.ApplyOptions
+		getFileErr          error
+		createFileErr       error
+		deployErr           error
+		wantChanges         []change
+		wantCalls           manifestCalls
+		wantWarnings        int
+		wantCommitted       bool
+		wantErr             error
+		wantPlanError       bool
+	}{
+		{
+			name:                "dry-run plans the changes without applying them",
+			manifest:            manifest,
+			secondVersionStatus: models.EndpointRunning,
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{DryRun: true, ValidateVersionEndpoint: m.validateManifestVersionEndpoint}
+			},
+			wantChanges: []change{
+				{kind: models.ManifestSchema, action: models.ManifestActionCreate, versionID: 2},
+				{kind: models.ManifestModelEndpoint, action: models.ManifestActionUpdate},
+				{kind: models.ManifestModelEndpointAlert, action: models.ManifestActionCreate},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionUpdate, versionID: 1},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionNone, versionID: 2},
+			},
+			wantCalls: manifestCalls{
+				"dry-run version endpoint 1",
+				"validate version endpoint 1 to serving",
+				"dry-run version endpoint 2",
+			},
+		},
+		{
+			name:                "apply converges the model, prunes and commits the manifest",
+			manifest:            manifest,
+			secondVersionStatus: models.EndpointRunning,
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{
+					User:                    "user@example.com",
+					Prune:                   true,
+					Commit:                  true,
+					RawManifest:             []byte("model: my-model\n"),
+					ValidateVersionEndpoint: m.validateManifestVersionEndpoint,
+				}
+			},
+			getFileErr: notFoundErr,
+			wantChanges: []change{
+				{kind: models.ManifestSchema, action: models.ManifestActionCreate, versionID: 2, applied: true},
+				{kind: models.ManifestModelEndpoint, action: models.ManifestActionUpdate, applied: true},
+				{kind: models.ManifestModelEndpointAlert, action: models.ManifestActionCreate, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionUpdate, versionID: 1, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionNone, versionID: 2},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionDelete, versionID: 3, applied: true},
+			},
+			wantCalls: manifestCalls{
+				"dry-run version endpoint 1",
+				"validate version endpoint 1 to serving",
+				"dry-run version endpoint 2",
+				"validate version endpoint 3 to terminated",
+				"save schema 0",
+				"save version 2 with schema 5",
+				"update model endpoint in production with weights [80 20]",
+				"create alert of model endpoint 7 for my-team",
+				"deploy version endpoint 1 with max replica 4",
+				"validate version endpoint 3 to terminated",
+				"undeploy version endpoint 3",
+			},
+			wantCommitted: true,
+		},
+		{
+			name:                "model endpoint waits for its version endpoints to be running",
+			manifest:            manifest,
+			secondVersionStatus: models.EndpointPending,
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{}
+			},
+			wantChanges: []change{
+				{kind: models.ManifestSchema, action: models.ManifestActionCreate, versionID: 2, applied: true},
+				{kind: models.ManifestModelEndpoint, action: models.ManifestActionUpdate},
+				{kind: models.ManifestModelEndpointAlert, action: models.ManifestActionCreate, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionUpdate, versionID: 1, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionNone, versionID: 2},
+			},
+			wantCalls: manifestCalls{
+				"dry-run version endpoint 1",
+				"dry-run version endpoint 2",
+				"save schema 0",
+				"save version 2 with schema 5",
+				"create alert of model endpoint 7 for my-team",
+				"deploy version endpoint 1 with max replica 4",
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:                "failed change returns the partially applied plan",
+			manifest:            manifest,
+			secondVersionStatus: models.EndpointRunning,
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{Commit: true}
+			},
+			deployErr: errors.New("connection refused"),
+			wantChanges: []change{
+				{kind: models.ManifestSchema, action: models.ManifestActionCreate, versionID: 2, applied: true},
+				{kind: models.ManifestModelEndpoint, action: models.ManifestActionUpdate, applied: true},
+				{kind: models.ManifestModelEndpointAlert, action: models.ManifestActionCreate, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionUpdate, versionID: 1},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionNone, versionID: 2},
+			},
+			wantCalls: manifestCalls{
+				"dry-run version endpoint 1",
+				"dry-run version endpoint 2",
+				"save schema 0",
+				"save version 2 with schema 5",
+				"update model endpoint in production with weights [80 20]",
+				"create alert of model endpoint 7 for my-team",
+				"deploy version endpoint 1 with max replica 4",
+			},
+			wantPlanError: true,
+		},
+		{
+			name:                "failed commit is a warning",
+			manifest:            manifest,
+			secondVersionStatus: models.EndpointRunning,
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{User: "user@example.com", Commit: true, RawManifest: []byte("model: my-model\n")}
+			},
+			getFileErr:    notFoundErr,
+			createFileErr: errors.New("403 Forbidden"),
+			wantChanges: []change{
+				{kind: models.ManifestSchema, action: models.ManifestActionCreate, versionID: 2, applied: true},
+				{kind: models.ManifestModelEndpoint, action: models.ManifestActionUpdate, applied: true},
+				{kind: models.ManifestModelEndpointAlert, action: models.ManifestActionCreate, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionUpdate, versionID: 1, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionNone, versionID: 2},
+			},
+			wantCalls: manifestCalls{
+				"dry-run version endpoint 1",
+				"dry-run version endpoint 2",
+				"save schema 0",
+				"save version 2 with schema 5",
+				"update model endpoint in production with weights [80 20]",
+				"create alert of model endpoint 7 for my-team",
+				"deploy version endpoint 1 with max replica 4",
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:                "manifest repository unavailable is a warning",
+			manifest:            manifest,
+			secondVersionStatus: models.EndpointRunning,
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{User: "user@example.com", Commit: true, RawManifest: []byte("model: my-model\n")}
+			},
+			getFileErr: errors.New("502 Bad Gateway"),
+			wantChanges: []change{
+				{kind: models.ManifestSchema, action: models.ManifestActionCreate, versionID: 2, applied: true},
+				{kind: models.ManifestModelEndpoint, action: models.ManifestActionUpdate, applied: true},
+				{kind: models.ManifestModelEndpointAlert, action: models.ManifestActionCreate, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionUpdate, versionID: 1, applied: true},
+				{kind: models.ManifestVersionEndpoint, action: models.ManifestActionNone, versionID: 2},
+			},
+			wantCalls: manifestCalls{
+				"dry-run version endpoint 1",
+				"dry-run version endpoint 2",
+				"save schema 0",
+				"save version 2 with schema 5",
+				"update model endpoint in production with weights [80 20]",
+				"create alert of model endpoint 7 for my-team",
+				"deploy version endpoint 1 with max replica 4",
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:                "invalid version endpoint",
+			manifest:            manifest,
+			secondVersionStatus: models.EndpointRunning,
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{
+					ValidateVersionEndpoint: func(ctx context.Context, model *models.Model, version *models.Version, env *models.Environment, prev *models.VersionEndpoint, new *models.VersionEndpoint) error {
+						return errors.New("max replica must be lower than 3")
+					},
+				}
+			},
+			wantErr: merror.ErrInvalidInput,
+		},
+		{
+			name:     "manifest of another model",
+			manifest: &models.ModelManifest{Model: "other-model"},
+			options: func(m *manifestMocks) service.ApplyOptions {
+				return service.ApplyOptions{}
+			},
+			wantErr: merror.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := newVersions(tt.secondVersionStatus)
+			modelEndpoint := &models.ModelEndpoint{
+				ID:              7,
+				ModelID:         model.ID,
+				Status:          models.EndpointServing,
+				EnvironmentName: env.Name,
+				Protocol:        protocol.HttpJson,
+				Rule: &models.ModelEndpointRule{
+					Destination: []*models.ModelEndpointRuleDestination{
+						{VersionEndpointID: versions[0].Endpoints[0].ID, VersionEndpoint: versions[0].Endpoints[0], Weight: 100},
+					},
+				},
+			}
+
+			m := newManifestMocks(t, env, versions, modelEndpoint, tt.deployErr)
+			options := tt.options(m)
+			if options.Prune {
+				// the versions deployed in the environments of the manifest are listed page by page
+				query := service.VersionQuery{
+					PaginationQuery: service.PaginationQuery{Limit: 50},
+					Search:          "environment_name:production",
+				}
+				m.versionsService.On("ListVersions", mock.Anything, model.ID, mock.Anything, query).Return(versions[:2], "next", nil).Once()
+				query.Cursor = "next"
+				m.versionsService.On("ListVersions", mock.Anything, model.ID, mock.Anything, query).Return(versions[2:], "", nil).Once()
+			}
+
+			gitlabClient := &gitlabmocks.Client{}
+			if options.Commit && tt.deployErr == nil {
+				gitlabClient.On("GetFileContent", gitlab.GetFileContentOptions{
+					Repository: "merlin/manifests",
+					Branch:     "master",
+					FileName:   "my-project/my-model.yaml",
+				}).Return("", tt.getFileErr)
+				if errors.Is(tt.getFileErr, gitlab.ErrFileNotFound) {
+					gitlabClient.On("CreateFile", mock.MatchedBy(func(opt gitlab.CreateFileOptions) bool {
+						return opt.FileName == "my-project/my-model.yaml" && opt.Content == "model: my-model\n" && opt.AuthorEmail == "user@example.com"
+					})).Return(tt.createFileErr)
+				}
+			}
+
+			svc := service.NewModelManifestService(m.params(gitlabClient))
+
+			plan, err := svc.Apply(context.Background(), model, tt.manifest, options)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, plan)
+				return
+			}
+			if tt.wantPlanError {
+				require.Error(t, err)
+				require.NotNil(t, plan)
+				assert.Equal(t, err.Error(), plan.Error)
+			} else {
+				require.NoError(t, err)
+				assert.Empty(t, plan.Error)
+			}
+
+			changes := []change{}
+			for _, c := range plan.Changes {
+				changes = append(changes, change{kind: c.Kind, action: c.Action, versionID: c.VersionID, applied: c.Applied})
+			}
+			assert.Equal(t, tt.wantChanges, changes)
+			assert.Equal(t, tt.wantCalls, *m.calls)
+			assert.Len(t, plan.Warnings, tt.wantWarnings)
+			assert.Equal(t, tt.wantCommitted, plan.Committed)
+
+			gitlabClient.AssertExpectations(t)
+
+			// the equivalent cpu request is not a change
+			assert.Len(t, plan.Changes[3].Diff, 1)
+			assert.Equal(t, "resource_request.max_replica", plan.Changes[3].Diff[0].Field)
+		})
+	}
+}
+
+func TestModelManifestService_Apply_Twice(t *testing.T) {
+	model := &models.Model{ID: 1, Name: "my-model", Project: mlp.Project{Name: "my-project"}}
+	env := &models.Environment{Name: "production"}
+
+	versionEndpoint := &models.VersionEndpoint{
+		ID:              uuid.New(),
+		VersionID:       1,
+		VersionModelID:  model.ID,
+		Status:          models.EndpointServing,
+		EnvironmentName: env.Name,
+		Protocol:        protocol.HttpJson,
+		ResourceRequest: &models.ResourceRequest{
+			MinReplica:    1,
+			MaxReplica:    2,
+			CPURequest:    resource.MustParse("500m"),
+			MemoryRequest: resource.MustParse("1Gi"),
+		},
+	}
+	versions := []*models.Version{{ID: 1, ModelID: model.ID, Endpoints: []*models.VersionEndpoint{versionEndpoint}}}
+	modelEndpoint := &models.ModelEndpoint{
+		ID:              7,
+		ModelID:         model.ID,
+		Status:          models.EndpointServing,
+		EnvironmentName: env.Name,
+		Protocol:        protocol.HttpJson,
+		Rule: &models.ModelEndpointRule{
+			Destination: []*models.ModelEndpointRuleDestination{
+				{VersionEndpointID: versionEndpoint.ID, VersionEndpoint: versionEndpoint, Weight: 100},
+			},
+		},
+	}
+
+	manifest := &models.ModelManifest{
+		Model:   "my-model",
+		Schemas: []*models.ModelSchemaManifest{{VersionID: 1, Spec: &models.SchemaSpec{SessionIDColumn: "session_id"}}},
+		VersionEndpoints: []*models.VersionEndpointManifest{
+			{
+				VersionID:       1,
+				EnvironmentName: "production",
+				Spec: &models.DeploymentSpec{
+					ResourceRequest: &models.ResourceRequest{
+						MinReplica:    1,
+						MaxReplica:    4,
+						CPURequest:    resource.MustParse("500m"),
+						MemoryRequest: resource.MustParse("1Gi"),
+					},
+					Transformer: &models.Transformer{
+						Enabled:         true,
+						TransformerType: models.StandardTransformerType,
+						EnvVars:         models.EnvVars{{Name: transformer.StandardTransformerConfigEnvName, Value: "{}"}},
+					},
+				},
+			},
+		},
+		ModelEndpoints: []*models.ModelEndpointManifest{
+			{
+				EnvironmentName: "production",
+				Destinations:    []*models.ModelEndpointDestinationManifest{{VersionID: 1, Weight: 100}},
+				Alert:           &models.ModelEndpointAlertManifest{TeamName: "my-team"},
+			},
+		},
+	}
+
+	m := newManifestMocks(t, env, versions, modelEndpoint, nil)
+	svc := service.NewModelManifestService(m.params(&gitlabmocks.Client{}))
+
+	plan, err := svc.Apply(context.Background(), model, manifest, service.ApplyOptions{})
+	require.NoError(t, err)
+	for _, change := range plan.Changes {
+		if change.Kind == models.ManifestModelEndpoint {
+			assert.Equal(t, models.ManifestActionNone, change.Action)
+			continue
+		}
+		assert.NotEqual(t, models.ManifestActionNone, change.Action, "%s of version %d", change.Kind, change.VersionID)
+		assert.True(t, change.Applied, "%s of version %d", change.Kind, change.VersionID)
+	}
+
+	// the transformer deployed by the first apply has the env vars added by the deployment
+	plan, err = svc.Apply(context.Background(), model, manifest, service.ApplyOptions{})
+	require.NoError(t, err)
+	for _, change := range plan.Changes {
+		assert.Equal(t, models.ManifestActionNone, change.Action, "%s of version %d: %v", change.Kind, change.VersionID, change.Diff)
+		assert.False(t, change.Applied)
+	}
+	assert.Empty(t, plan.Warnings)
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"text/template"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prommodel "github.com/prometheus/common/model"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/log"
+	"github.com/caraml-dev/merlin/models"
+)
+
+const (
+	// DefaultRolloutErrorRateQuery is the ratio of 5xx responses of the canary reported by Istio
+	DefaultRolloutErrorRateQuery = `sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="{{.Namespace}}",destination_workload=~"{{.InferenceServiceName}}-predictor.*",response_code=~"5.."}[{{.Window}}]))` +
+		` / sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="{{.Namespace}}",destination_workload=~"{{.InferenceServiceName}}-predictor.*"}[{{.Window}}]))`
+	// DefaultRolloutLatencyQuery is the latency percentile of the canary in milliseconds reported by Istio
+	DefaultRolloutLatencyQuery = `histogram_quantile({{.Percentile}}, sum(rate(istio_request_duration_milliseconds_bucket{reporter="destination",destination_workload_namespace="{{.Namespace}}",destination_workload=~"{{.InferenceServiceName}}-predictor.*"}[{{.Window}}])) by (le))`
+
+	minRolloutAnalysisWindow = time.Minute
+)
+
+// RolloutAnalyzer measures the metrics of the canary compared against the rollout's success criteria
+type RolloutAnalyzer interface {
+	// Analyze returns the canary metrics over the window, only the metrics having a threshold in the criteria are measured
+	Analyze(ctx context.Context, canary *models.VersionEndpoint, criteria *models.RolloutSuccessCriteria, window time.Duration) (*models.RolloutAnalysis, error)
+}
+
+// rolloutQueryData is the data the PromQL templates are executed with
+type rolloutQueryData struct {
+	Namespace            string
+	InferenceServiceName string
+	// Window is the range of the rate, e.g. 5m
+	Window     string
+	Percentile float64
+}
+
+type prometheusRolloutAnalyzer struct {
+	api            promv1.API
+	errorRateQuery *template.Template
+	latencyQuery   *template.Template
+}
+
+// NewPrometheusRolloutAnalyzer returns a RolloutAnalyzer querying the canary metrics from Prometheus
+func NewPrometheusRolloutAnalyzer(cfg config.CanaryRolloutConfig) (RolloutAnalyzer, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: cfg.PrometheusURL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client: %w", err)
+	}
+
+	errorRateQuery := cfg.ErrorRateQuery
+	if errorRateQuery == "" {
+		errorRateQuery = DefaultRolloutErrorRateQuery
+	}
+	errorRateTemplate, err := template.New("error_rate").Parse(errorRateQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid error rate query: %w", err)
+	}
+
+	latencyQuery := cfg.LatencyQuery
+	if latencyQuery == "" {
+		latencyQuery = DefaultRolloutLatencyQuery
+	}
+	latencyTemplate, err := template.New("latency").Parse(latencyQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latency query: %w", err)
+	}
+
+	return &prometheusRolloutAnalyzer{
+		api:            promv1.NewAPI(client),
+		errorRateQuery: errorRateTemplate,
+		latencyQuery:   latencyTemplate,
+	}, nil
+}
+
+func (a *prometheusRolloutAnalyzer) Analyze(ctx context.Context, canary *models.VersionEndpoint, criteria *models.RolloutSuccessCriteria, window time.Duration) (*models.RolloutAnalysis, error) {
+	if window < minRolloutAnalysisWindow {
+		window = minRolloutAnalysisWindow
+	}
+	data := rolloutQueryData{
+		Namespace:            canary.Namespace,
+		InferenceServiceName: canary.InferenceServiceName,
+		Window:               prommodel.Duration(window.Truncate(time.Second)).String(),
+		Percentile:           criteria.Percentile(),
+	}
+
+	analysis := &models.RolloutAnalysis{}
+	var err error
+	if criteria.MaxErrorRate != nil {
+		analysis.ErrorRate, err = a.query(ctx, a.errorRateQuery, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query error rate: %w", err)
+		}
+	}
+	if criteria.MaxLatencyMs != nil {
+		analysis.LatencyMs, err = a.query(ctx, a.latencyQuery, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query latency: %w", err)
+		}
+	}
+	return analysis, nil
+}
+
+// query returns the value of the first sample, nil if the query returns no sample or NaN (e.g. no traffic)
+func (a *prometheusRolloutAnalyzer) query(ctx context.Context, queryTemplate *template.Template, data rolloutQueryData) (*float64, error) {
+	var buf bytes.Buffer
+	if err := queryTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	result, warnings, err := a.api.Query(ctx, buf.String(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		log.Warnf("prometheus query %s returned warnings: %v", buf.String(), warnings)
+	}
+
+	vector, ok := result.(prommodel.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s", result.Type())
+	}
+	if len(vector) == 0 {
+		return nil, nil
+	}
+
+	value := float64(vector[0].Value)
+	if math.IsNaN(value) {
+		return nil, nil
+	}
+	return &value, nil
+}
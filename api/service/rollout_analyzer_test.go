@@ -0,0 +1,123 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/merlin/config"
+	"github.com/caraml-dev/merlin/models"
+)
+
+func TestPrometheusRolloutAnalyzer_Analyze(t *testing.T) {
+	maxErrorRate := 0.05
+	maxLatencyMs := 100.0
+
+	tests := []struct {
+		name          string
+		criteria      *models.RolloutSuccessCriteria
+		results       map[string]string
+		wantQueries   []string
+		wantErrorRate *float64
+		wantLatencyMs *float64
+		wantErr       bool
+	}{
+		{
+			name:     "error rate and latency",
+			criteria: &models.RolloutSuccessCriteria{MaxErrorRate: &maxErrorRate, MaxLatencyMs: &maxLatencyMs, LatencyPercentile: 0.95},
+			results: map[string]string{
+				"error_rate project-1 version-2 10m": `[{"metric":{},"value":[1700000000,"0.02"]}]`,
+				"latency 0.95 version-2 10m":         `[{"metric":{},"value":[1700000000,"87.5"]}]`,
+			},
+			wantQueries:   []string{"error_rate project-1 version-2 10m", "latency 0.95 version-2 10m"},
+			wantErrorRate: floatPtr(0.02),
+			wantLatencyMs: floatPtr(87.5),
+		},
+		{
+			name:     "only metrics with threshold are queried",
+			criteria: &models.RolloutSuccessCriteria{MaxLatencyMs: &maxLatencyMs},
+			results: map[string]string{
+				"latency 0.99 version-2 10m": `[{"metric":{},"value":[1700000000,"87.5"]}]`,
+			},
+			wantQueries:   []string{"latency 0.99 version-2 10m"},
+			wantLatencyMs: floatPtr(87.5),
+		},
+		{
+			name:     "no traffic",
+			criteria: &models.RolloutSuccessCriteria{MaxErrorRate: &maxErrorRate, MaxLatencyMs: &maxLatencyMs},
+			results: map[string]string{
+				"error_rate project-1 version-2 10m": `[{"metric":{},"value":[1700000000,"NaN"]}]`,
+				"latency 0.99 version-2 10m":         `[]`,
+			},
+			wantQueries: []string{"error_rate project-1 version-2 10m", "latency 0.99 version-2 10m"},
+		},
+		{
+			name:        "query failed",
+			criteria:    &models.RolloutSuccessCriteria{MaxErrorRate: &maxErrorRate},
+			wantQueries: []string{"error_rate project-1 version-2 10m"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/query", r.URL.Path)
+				query := r.FormValue("query")
+				queries = append(queries, query)
+
+				result, ok := tt.results[query]
+				if !ok {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"unknown query"}`)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+			}))
+			defer server.Close()
+
+			analyzer, err := NewPrometheusRolloutAnalyzer(config.CanaryRolloutConfig{
+				PrometheusURL:  server.URL,
+				ErrorRateQuery: "error_rate {{.Namespace}} {{.InferenceServiceName}} {{.Window}}",
+				LatencyQuery:   "latency {{.Percentile}} {{.InferenceServiceName}} {{.Window}}",
+			})
+			require.NoError(t, err)
+
+			canary := &models.VersionEndpoint{Namespace: "project-1", InferenceServiceName: "version-2"}
+			got, err := analyzer.Analyze(context.Background(), canary, tt.criteria, 10*time.Minute+300*time.Millisecond)
+			assert.Equal(t, tt.wantQueries, queries)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErrorRate, got.ErrorRate)
+			assert.Equal(t, tt.wantLatencyMs, got.LatencyMs)
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
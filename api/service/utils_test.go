@@ -47,6 +47,17 @@ var (
 		Protocol:             protocol.HttpJson,
 	}
 
+	versionEndpoint2ID, _ = uuid.NewUUID()
+	versionEndpoint2      = &models.VersionEndpoint{
+		ID:                   versionEndpoint2ID,
+		Status:               models.EndpointRunning,
+		URL:                  "http://version-2.project-1.mlp.io/v1/models/version-2:predict",
+		ServiceName:          "version-2-abcde",
+		InferenceServiceName: "version-2",
+		Namespace:            "project-1",
+		Protocol:             protocol.HttpJson,
+	}
+
 	modelEndpointRequest1 = &models.ModelEndpoint{
 		ModelID: 1,
 		Rule: &models.ModelEndpointRule{
@@ -0,0 +1,140 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/pkg/errors"
+
+	"github.com/caraml-dev/merlin/cluster"
+	"github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/log"
+	"github.com/caraml-dev/merlin/models"
+	"github.com/caraml-dev/merlin/storage"
+)
+
+// VersionEndpointScalingController applies the scaling schedules of version endpoints.
+//
+// On every sync, the effective scaling of each running or serving version endpoint having scaling schedules is
+// computed and the replicas of its inference service are updated. The replicas applied to an inference service are
+// remembered so that it is only updated when a scaling window starts or ends, or when the endpoint is redeployed.
+type VersionEndpointScalingController struct {
+	clusterControllers     map[string]cluster.Controller
+	versionEndpointStorage storage.VersionEndpointStorage
+
+	mu sync.Mutex
+	// applied is the replicas last applied to each inference service, by inference service name
+	applied map[string]map[kservev1beta1.ComponentType]resource.ComponentReplicas
+	now     func() time.Time
+}
+
+// NewVersionEndpointScalingController returns an initialized VersionEndpointScalingController.
+func NewVersionEndpointScalingController(
+	clusterControllers map[string]cluster.Controller,
+	versionEndpointStorage storage.VersionEndpointStorage,
+) *VersionEndpointScalingController {
+	return &VersionEndpointScalingController{
+		clusterControllers:     clusterControllers,
+		versionEndpointStorage: versionEndpointStorage,
+		applied:                map[string]map[kservev1beta1.ComponentType]resource.ComponentReplicas{},
+		now:                    time.Now,
+	}
+}
+
+// Sync applies the effective scaling of all scheduled version endpoints
+func (c *VersionEndpointScalingController) Sync() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx := context.Background()
+
+	endpoints, err := c.versionEndpointStorage.ListScheduledEndpoints()
+	if err != nil {
+		log.Errorf("failed to list scheduled version endpoints: %v", err)
+		return
+	}
+
+	now := c.now()
+	for _, endpoint := range endpoints {
+		if err := c.syncEndpoint(ctx, endpoint, now); err != nil {
+			log.Errorf("failed to sync scaling of version endpoint %s: %v", endpoint.ID, err)
+		}
+	}
+}
+
+func (c *VersionEndpointScalingController) syncEndpoint(ctx context.Context, endpoint *models.VersionEndpoint, now time.Time) error {
+	if endpoint.InferenceServiceName == "" {
+		return nil
+	}
+
+	scaling := models.NewEffectiveScaling(endpoint, now)
+	if scaling.MaxReplica == 0 {
+		return fmt.Errorf("version endpoint has no max replica")
+	}
+
+	replicas := scheduledReplicas(endpoint, scaling)
+	if reflect.DeepEqual(c.applied[endpoint.InferenceServiceName], replicas) {
+		return nil
+	}
+
+	ctl, ok := c.clusterControllers[endpoint.EnvironmentName]
+	if !ok {
+		return fmt.Errorf("unable to find cluster controller for environment %s", endpoint.EnvironmentName)
+	}
+
+	if err := ctl.UpdateInferenceServiceReplicas(ctx, endpoint.Namespace, endpoint.InferenceServiceName, replicas); err != nil {
+		return errors.Wrapf(err, "failed to update replicas of inference service %s", endpoint.InferenceServiceName)
+	}
+	c.applied[endpoint.InferenceServiceName] = replicas
+
+	if scaling.Schedule != "" {
+		log.Infof("applied scaling schedule %s to version endpoint %s: min replica %d, max replica %d",
+			scaling.Schedule, endpoint.ID, scaling.MinReplica, scaling.MaxReplica)
+	} else {
+		log.Infof("restored scaling of version endpoint %s: min replica %d, max replica %d",
+			endpoint.ID, scaling.MinReplica, scaling.MaxReplica)
+	}
+	return nil
+}
+
+// scheduledReplicas returns the replicas of the inference service components for the effective scaling,
+// the transformer keeps its replicas unless the endpoint is allowed to scale to zero
+func scheduledReplicas(endpoint *models.VersionEndpoint, scaling *models.EffectiveScaling) map[kservev1beta1.ComponentType]resource.ComponentReplicas {
+	replicas := map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+		kservev1beta1.PredictorComponent: {
+			MinReplica: scaling.MinReplica,
+			MaxReplica: scaling.MaxReplica,
+		},
+	}
+
+	transformer := endpoint.Transformer
+	if transformer != nil && transformer.Enabled && transformer.ResourceRequest != nil {
+		transformerReplicas := resource.ComponentReplicas{
+			MinReplica: transformer.ResourceRequest.MinReplica,
+			MaxReplica: transformer.ResourceRequest.MaxReplica,
+		}
+		if scaling.ScaleToZero {
+			transformerReplicas.MinReplica = 0
+		}
+		replicas[kservev1beta1.TransformerComponent] = transformerReplicas
+	}
+	return replicas
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/caraml-dev/merlin/cluster"
+	clusterMock "github.com/caraml-dev/merlin/cluster/mocks"
+	"github.com/caraml-dev/merlin/cluster/resource"
+	"github.com/caraml-dev/merlin/models"
+	storageMock "github.com/caraml-dev/merlin/storage/mocks"
+)
+
+func TestVersionEndpointScalingController_Sync(t *testing.T) {
+	env := "env1"
+	minReplica := 5
+	maxReplica := 10
+
+	newEndpoint := func(transformer *models.Transformer, scaleToZero bool) *models.VersionEndpoint {
+		schedule := &models.ScalingSchedule{
+			Name:     "business-hours",
+			Schedule: "0 8 * * *",
+			Duration: "10h",
+		}
+		if scaleToZero {
+			schedule.ScaleToZero = true
+		} else {
+			schedule.MinReplica = &minReplica
+			schedule.MaxReplica = &maxReplica
+		}
+
+		return &models.VersionEndpoint{
+			ID:                   uuid.New(),
+			InferenceServiceName: "my-model-1-r1",
+			Namespace:            "project-1",
+			EnvironmentName:      env,
+			Status:               models.EndpointRunning,
+			ResourceRequest:      &models.ResourceRequest{MinReplica: 1, MaxReplica: 2},
+			Transformer:          transformer,
+			ScalingSchedules:     models.ScalingSchedules{schedule},
+		}
+	}
+	transformer := &models.Transformer{
+		Enabled:         true,
+		ResourceRequest: &models.ResourceRequest{MinReplica: 1, MaxReplica: 3},
+	}
+
+	inWindow := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	outOfWindow := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		endpoint     *models.VersionEndpoint
+		environment  string
+		now          time.Time
+		applied      map[kservev1beta1.ComponentType]resource.ComponentReplicas
+		updateErr    error
+		wantReplicas map[kservev1beta1.ComponentType]resource.ComponentReplicas
+		wantApplied  map[kservev1beta1.ComponentType]resource.ComponentReplicas
+	}{
+		{
+			name:        "schedule window is active",
+			endpoint:    newEndpoint(nil, false),
+			environment: env,
+			now:         inWindow,
+			wantReplicas: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 5, MaxReplica: 10},
+			},
+			wantApplied: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 5, MaxReplica: 10},
+			},
+		},
+		{
+			name:        "schedule window has ended",
+			endpoint:    newEndpoint(nil, false),
+			environment: env,
+			now:         outOfWindow,
+			applied: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 5, MaxReplica: 10},
+			},
+			wantReplicas: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 1, MaxReplica: 2},
+			},
+			wantApplied: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 1, MaxReplica: 2},
+			},
+		},
+		{
+			name:        "replicas are already applied",
+			endpoint:    newEndpoint(nil, false),
+			environment: env,
+			now:         inWindow,
+			applied: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 5, MaxReplica: 10},
+			},
+			wantApplied: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 5, MaxReplica: 10},
+			},
+		},
+		{
+			name:        "scale to zero with transformer",
+			endpoint:    newEndpoint(transformer, true),
+			environment: env,
+			now:         inWindow,
+			wantReplicas: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent:   {MinReplica: 0, MaxReplica: 2},
+				kservev1beta1.TransformerComponent: {MinReplica: 0, MaxReplica: 3},
+			},
+			wantApplied: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent:   {MinReplica: 0, MaxReplica: 2},
+				kservev1beta1.TransformerComponent: {MinReplica: 0, MaxReplica: 3},
+			},
+		},
+		{
+			name:        "failed to update inference service",
+			endpoint:    newEndpoint(nil, false),
+			environment: env,
+			now:         inWindow,
+			updateErr:   errors.New("update error"),
+			wantReplicas: map[kservev1beta1.ComponentType]resource.ComponentReplicas{
+				kservev1beta1.PredictorComponent: {MinReplica: 5, MaxReplica: 10},
+			},
+		},
+		{
+			name:        "cluster controller not found",
+			endpoint:    newEndpoint(nil, false),
+			environment: "env2",
+			now:         inWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &storageMock.VersionEndpointStorage{}
+			storage.On("ListScheduledEndpoints").Return([]*models.VersionEndpoint{tt.endpoint}, nil)
+
+			controller := &clusterMock.Controller{}
+			if tt.wantReplicas != nil {
+				controller.On("UpdateInferenceServiceReplicas", mock.Anything, tt.endpoint.Namespace, tt.endpoint.InferenceServiceName, tt.wantReplicas).
+					Return(tt.updateErr)
+			}
+
+			c := NewVersionEndpointScalingController(map[string]cluster.Controller{tt.environment: controller}, storage)
+			c.now = func() time.Time { return tt.now }
+			if tt.applied != nil {
+				c.applied[tt.endpoint.InferenceServiceName] = tt.applied
+			}
+
+			c.Sync()
+
+			controller.AssertExpectations(t)
+			if tt.wantReplicas == nil {
+				controller.AssertNotCalled(t, "UpdateInferenceServiceReplicas", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			assert.Equal(t, tt.wantApplied, c.applied[tt.endpoint.InferenceServiceName])
+		})
+	}
+}
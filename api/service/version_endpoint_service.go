@@ -25,6 +25,7 @@ import (
 	"github.com/caraml-dev/merlin/models"
 	"github.com/caraml-dev/merlin/pkg/autoscaling"
 	"github.com/caraml-dev/merlin/pkg/deployment"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
 	"github.com/caraml-dev/merlin/pkg/imagebuilder"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	"github.com/caraml-dev/merlin/pkg/transformer"
@@ -46,6 +47,10 @@ type EndpointsService interface {
 	FindByID(ctx context.Context, endpointUuid uuid.UUID) (*models.VersionEndpoint, error)
 	// DeployEndpoint update or create an endpoint given a model version in the specified deployment environment
 	DeployEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.VersionEndpoint, error)
+	// DryRunEndpoint validates the deployment of an endpoint and renders its resources without persisting anything
+	DryRunEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.DeploymentPlan, error)
+	// RollbackEndpoint redeploys the configuration snapshotted by a previous deployment of the endpoint
+	RollbackEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint, deployment *models.Deployment) (*models.VersionEndpoint, error)
 	// UndeployEndpoint delete an endpoint given a model version in the specified deployment environment
 	UndeployEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint) (*models.VersionEndpoint, error)
 	// CountEndpoints count number of endpoint created from a model in an environment
@@ -163,6 +168,73 @@ func (k *endpointService) DeployEndpoint(ctx context.Context, environment *model
 	return endpoint, nil
 }
 
+// DryRunEndpoint applies the requested configuration on a copy of the endpoint and renders the resources of its next
+// revision with the cluster controller. Unlike DeployEndpoint, nothing is saved, no webhook is triggered and no image is built.
+func (k *endpointService) DryRunEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, newEndpoint *models.VersionEndpoint) (*models.DeploymentPlan, error) {
+	var endpoint *models.VersionEndpoint
+	if currentEndpoint, ok := version.GetEndpointByEnvironmentName(environment.Name); ok {
+		// copy to keep the endpoint of the version untouched
+		endpointCopy := *currentEndpoint
+		endpoint = &endpointCopy
+	} else {
+		endpoint = models.NewVersionEndpoint(environment, model.Project, model, version, k.monitoringConfig, newEndpoint.DeploymentMode)
+	}
+
+	if err := k.override(endpoint, newEndpoint, environment, model); err != nil {
+		return nil, err
+	}
+	if endpoint.Logger != nil && endpoint.ModelObservability.IsEnabled() {
+		endpoint.Logger.DestinationURL = k.mlObsLoggerDestinationURL
+	}
+	endpoint.RevisionID++
+
+	ctl, ok := k.clusterControllers[environment.Name]
+	if !ok {
+		return nil, fmt.Errorf("unable to find cluster controller for environment %s", environment.Name)
+	}
+
+	modelOpt := &models.ModelOption{}
+	var imageWarning string
+	switch model.Type {
+	case models.ModelTypePyFunc:
+		versionImage := k.imageBuilder.GetVersionImage(ctx, model.Project, model, version)
+		modelOpt.PyFuncImageName = versionImage.ImageRef
+		if !versionImage.Exists {
+			imageWarning = fmt.Sprintf("image %s doesn't exist yet and will be built on deployment", versionImage.ImageRef)
+		}
+	case models.ModelTypeCustom:
+		modelOpt = models.NewCustomModelOption(version)
+	}
+
+	plan, err := ctl.DryRun(ctx, models.NewService(model, version, modelOpt, endpoint), int(model.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Endpoint = endpoint
+	if imageWarning != "" {
+		plan.Warnings = append([]string{imageWarning}, plan.Warnings...)
+	}
+	return plan, nil
+}
+
+func (k *endpointService) RollbackEndpoint(ctx context.Context, environment *models.Environment, model *models.Model, version *models.Version, endpoint *models.VersionEndpoint, deployment *models.Deployment) (*models.VersionEndpoint, error) {
+	if deployment.Spec == nil {
+		return nil, merror.NewInvalidInputErrorf("deployment %d has no recorded configuration to roll back to", deployment.ID)
+	}
+	if endpoint.IsPending() {
+		return nil, merror.NewInvalidInputError("rolling back is not allowed when the endpoint is currently in the pending state")
+	}
+
+	newEndpoint := deployment.Spec.RollbackVersionEndpoint(endpoint)
+	if (endpoint.IsRunning() || endpoint.IsServing()) && newEndpoint.DeploymentMode != endpoint.DeploymentMode {
+		return nil, merror.NewInvalidInputErrorf("rolling back to deployment mode %s is not allowed while the endpoint is %s, please terminate it first", newEndpoint.DeploymentMode, endpoint.Status)
+	}
+
+	log.Infof("rolling back endpoint %s of model %s version %s to deployment %d", endpoint.ID, model.Name, version.ID, deployment.ID)
+	return k.DeployEndpoint(ctx, environment, model, version, newEndpoint)
+}
+
 // override left version endpoint with values on the right version endpoint
 func (k *endpointService) override(left *models.VersionEndpoint, right *models.VersionEndpoint, environment *models.Environment, model *models.Model) error {
 	// override deployment mode
@@ -170,6 +242,18 @@ func (k *endpointService) override(left *models.VersionEndpoint, right *models.V
 		left.DeploymentMode = right.DeploymentMode
 	}
 
+	// override deployment strategy, the default strategy is stored as empty
+	switch right.DeploymentStrategy {
+	case deployment.EmptyDeploymentStrategy:
+	case deployment.DefaultDeploymentStrategy:
+		left.DeploymentStrategy = deployment.EmptyDeploymentStrategy
+	default:
+		left.DeploymentStrategy = right.DeploymentStrategy
+	}
+	if err := deployment.ValidateStrategy(left.DeploymentStrategy, left.DeploymentMode); err != nil {
+		return err
+	}
+
 	// override autoscaling policy
 	if right.AutoscalingPolicy != nil {
 		err := autoscaling.ValidateAutoscalingPolicy(right.AutoscalingPolicy, left.DeploymentMode)
@@ -179,6 +263,14 @@ func (k *endpointService) override(left *models.VersionEndpoint, right *models.V
 		left.AutoscalingPolicy = right.AutoscalingPolicy
 	}
 
+	// override scaling schedules
+	if right.ScalingSchedules != nil {
+		if err := right.ScalingSchedules.Validate(left.DeploymentMode); err != nil {
+			return err
+		}
+		left.ScalingSchedules = right.ScalingSchedules
+	}
+
 	// override resource request
 	if right.ResourceRequest != nil {
 		left.ResourceRequest = right.ResourceRequest
@@ -42,6 +42,7 @@ import (
 	"github.com/caraml-dev/merlin/models"
 	"github.com/caraml-dev/merlin/pkg/autoscaling"
 	"github.com/caraml-dev/merlin/pkg/deployment"
+	merror "github.com/caraml-dev/merlin/pkg/errors"
 	imageBuilderMock "github.com/caraml-dev/merlin/pkg/imagebuilder/mocks"
 	"github.com/caraml-dev/merlin/pkg/protocol"
 	"github.com/caraml-dev/merlin/pkg/transformer"
@@ -82,6 +83,23 @@ func TestDeployEndpoint(t *testing.T) {
 	project := mlp.Project{Name: "project"}
 	model := &models.Model{Name: "model", Project: project}
 	version := &models.Version{ID: 1}
+	blueGreenVersion := func() *models.Version {
+		return &models.Version{
+			ID: 2,
+			Endpoints: []*models.VersionEndpoint{
+				{
+					EnvironmentName:    env.Name,
+					Namespace:          project.Name,
+					Status:             models.EndpointRunning,
+					ResourceRequest:    env.DefaultResourceRequest,
+					DeploymentMode:     deployment.RawDeploymentMode,
+					DeploymentStrategy: deployment.BlueGreenDeploymentStrategy,
+					AutoscalingPolicy:  autoscaling.DefaultRawDeploymentAutoscalingPolicy,
+					Protocol:           protocol.HttpJson,
+				},
+			},
+		}
+	}
 
 	tests := []struct {
 		name             string
@@ -548,6 +566,71 @@ func TestDeployEndpoint(t *testing.T) {
 			},
 			wantDeployError: false,
 		},
+		{
+			name: "success: raw deployment with blue green strategy",
+			args: args{
+				env,
+				model,
+				version,
+				&models.VersionEndpoint{
+					DeploymentMode:     deployment.RawDeploymentMode,
+					DeploymentStrategy: deployment.BlueGreenDeploymentStrategy,
+				},
+				false,
+			},
+			expectedEndpoint: &models.VersionEndpoint{
+				Namespace:          project.Name,
+				URL:                "",
+				Status:             models.EndpointPending,
+				DeploymentMode:     deployment.RawDeploymentMode,
+				DeploymentStrategy: deployment.BlueGreenDeploymentStrategy,
+				AutoscalingPolicy:  autoscaling.DefaultRawDeploymentAutoscalingPolicy,
+				Protocol:           protocol.HttpJson,
+			},
+			wantDeployError: false,
+		},
+		{
+			name: "success: existing blue green strategy is kept when not set",
+			args: args{
+				env,
+				model,
+				blueGreenVersion(),
+				&models.VersionEndpoint{},
+				false,
+			},
+			expectedEndpoint: &models.VersionEndpoint{
+				Namespace:          project.Name,
+				URL:                "",
+				Status:             models.EndpointRunning,
+				DeploymentMode:     deployment.RawDeploymentMode,
+				DeploymentStrategy: deployment.BlueGreenDeploymentStrategy,
+				AutoscalingPolicy:  autoscaling.DefaultRawDeploymentAutoscalingPolicy,
+				Protocol:           protocol.HttpJson,
+			},
+			wantDeployError: false,
+		},
+		{
+			name: "success: existing blue green strategy is reset to the default strategy",
+			args: args{
+				env,
+				model,
+				blueGreenVersion(),
+				&models.VersionEndpoint{
+					DeploymentStrategy: deployment.DefaultDeploymentStrategy,
+				},
+				false,
+			},
+			expectedEndpoint: &models.VersionEndpoint{
+				Namespace:          project.Name,
+				URL:                "",
+				Status:             models.EndpointRunning,
+				DeploymentMode:     deployment.RawDeploymentMode,
+				DeploymentStrategy: deployment.EmptyDeploymentStrategy,
+				AutoscalingPolicy:  autoscaling.DefaultRawDeploymentAutoscalingPolicy,
+				Protocol:           protocol.HttpJson,
+			},
+			wantDeployError: false,
+		},
 		{
 			name: "success: serverless deployment with autoscaling policy",
 			args: args{
@@ -879,6 +962,7 @@ func TestDeployEndpoint(t *testing.T) {
 			assert.Equal(t, tt.expectedEndpoint.Namespace, actualEndpoint.Namespace)
 			assert.Equal(t, tt.expectedEndpoint.InferenceServiceName, actualEndpoint.InferenceServiceName)
 			assert.Equal(t, tt.expectedEndpoint.DeploymentMode, actualEndpoint.DeploymentMode)
+			assert.Equal(t, tt.expectedEndpoint.DeploymentStrategy, actualEndpoint.DeploymentStrategy)
 			assert.Equal(t, tt.expectedEndpoint.AutoscalingPolicy, actualEndpoint.AutoscalingPolicy)
 			assert.Equal(t, tt.expectedEndpoint.Protocol, actualEndpoint.Protocol)
 
@@ -2221,6 +2305,265 @@ func TestDeployEndpoint_StandardTransformer(t *testing.T) {
 	}
 }
 
+func TestRollbackEndpoint(t *testing.T) {
+	env := &models.Environment{
+		Name:       "env1",
+		Cluster:    "cluster1",
+		IsDefault:  &isDefaultTrue,
+		Region:     "id",
+		GcpProject: "project",
+		DefaultResourceRequest: &models.ResourceRequest{
+			MinReplica:    0,
+			MaxReplica:    1,
+			CPURequest:    resource.MustParse("1"),
+			MemoryRequest: resource.MustParse("1Gi"),
+		},
+	}
+	project := mlp.Project{Name: "project"}
+	model := &models.Model{Name: "model", Project: project}
+
+	newEndpoint := func(status models.EndpointStatus) *models.VersionEndpoint {
+		return &models.VersionEndpoint{
+			ID:              uuid.New(),
+			EnvironmentName: env.Name,
+			Namespace:       project.Name,
+			Status:          status,
+			DeploymentMode:  deployment.ServerlessDeploymentMode,
+			ResourceRequest: env.DefaultResourceRequest,
+			EnvVars:         models.EnvVars{{Name: "WORKERS", Value: "4"}},
+			Transformer: &models.Transformer{
+				ID:              "1",
+				Enabled:         true,
+				TransformerType: models.CustomTransformerType,
+				Image:           "ghcr.io/caraml-dev/transformer:2",
+			},
+		}
+	}
+	previousResourceRequest := &models.ResourceRequest{
+		MinReplica:    1,
+		MaxReplica:    2,
+		CPURequest:    resource.MustParse("500m"),
+		MemoryRequest: resource.MustParse("512Mi"),
+	}
+
+	tests := []struct {
+		name            string
+		endpoint        *models.VersionEndpoint
+		spec            *models.DeploymentSpec
+		wantErr         bool
+		checkRolledBack func(t *testing.T, endpoint *models.VersionEndpoint)
+	}{
+		{
+			name:     "success: previous configuration is redeployed",
+			endpoint: newEndpoint(models.EndpointServing),
+			spec: &models.DeploymentSpec{
+				DeploymentMode:  deployment.ServerlessDeploymentMode,
+				Protocol:        protocol.HttpJson,
+				ResourceRequest: previousResourceRequest,
+				EnvVars:         models.EnvVars{{Name: "WORKERS", Value: "2"}},
+				Transformer: &models.Transformer{
+					Enabled:         true,
+					TransformerType: models.CustomTransformerType,
+					Image:           "ghcr.io/caraml-dev/transformer:1",
+				},
+			},
+			checkRolledBack: func(t *testing.T, endpoint *models.VersionEndpoint) {
+				assert.Equal(t, previousResourceRequest, endpoint.ResourceRequest)
+				assert.Equal(t, models.EnvVars{{Name: "WORKERS", Value: "2"}}, endpoint.EnvVars)
+				assert.Equal(t, "1", endpoint.Transformer.ID)
+				assert.Equal(t, endpoint.ID, endpoint.Transformer.VersionEndpointID)
+				assert.Equal(t, "ghcr.io/caraml-dev/transformer:1", endpoint.Transformer.Image)
+			},
+		},
+		{
+			name:     "success: configuration without transformer and env vars removes them",
+			endpoint: newEndpoint(models.EndpointRunning),
+			spec: &models.DeploymentSpec{
+				DeploymentMode:  deployment.ServerlessDeploymentMode,
+				ResourceRequest: previousResourceRequest,
+			},
+			checkRolledBack: func(t *testing.T, endpoint *models.VersionEndpoint) {
+				assert.Empty(t, endpoint.EnvVars)
+				assert.False(t, endpoint.Transformer.Enabled)
+				assert.Equal(t, "1", endpoint.Transformer.ID)
+				assert.Equal(t, protocol.HttpJson, endpoint.Protocol)
+			},
+		},
+		{
+			name:     "fail: deployment without recorded configuration",
+			endpoint: newEndpoint(models.EndpointRunning),
+			wantErr:  true,
+		},
+		{
+			name:     "fail: endpoint is pending",
+			endpoint: newEndpoint(models.EndpointPending),
+			spec:     &models.DeploymentSpec{DeploymentMode: deployment.ServerlessDeploymentMode},
+			wantErr:  true,
+		},
+		{
+			name:     "fail: deployment mode of running endpoint changes",
+			endpoint: newEndpoint(models.EndpointRunning),
+			spec:     &models.DeploymentSpec{DeploymentMode: deployment.RawDeploymentMode},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := &models.Version{ID: 1, Endpoints: []*models.VersionEndpoint{tt.endpoint}}
+			deploymentRecord := &models.Deployment{ID: 3, VersionEndpointID: tt.endpoint.ID, Spec: tt.spec}
+
+			mockQueueProducer := &queueMock.Producer{}
+			mockQueueProducer.On("EnqueueJob", mock.Anything).Return(nil)
+			mockStorage := &mocks.VersionEndpointStorage{}
+			mockStorage.On("Save", mock.Anything).Return(nil)
+			mockWebhook := &webhookMock.Client{}
+			mockWebhook.On("TriggerWebhooks", mock.Anything, webhooks.OnVersionEndpointPredeployment, mock.Anything).Return(nil)
+
+			endpointSvc := NewEndpointService(EndpointServiceParams{
+				ClusterControllers:   map[string]cluster.Controller{env.Name: &clusterMock.Controller{}},
+				Storage:              mockStorage,
+				DeploymentStorage:    &mocks.DeploymentStorage{},
+				Environment:          "dev",
+				LoggerDestinationURL: loggerDestinationURL,
+				JobProducer:          mockQueueProducer,
+				Webhook:              mockWebhook,
+			})
+			endpoint, err := endpointSvc.RollbackEndpoint(context.Background(), env, model, version, tt.endpoint, deploymentRecord)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, merror.ErrInvalidInput)
+				mockQueueProducer.AssertNotCalled(t, "EnqueueJob", mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			mockQueueProducer.AssertNumberOfCalls(t, "EnqueueJob", 1)
+			tt.checkRolledBack(t, endpoint)
+		})
+	}
+}
+
+func TestDryRunEndpoint(t *testing.T) {
+	env := &models.Environment{
+		Name:    "env1",
+		Cluster: "cluster1",
+		DefaultResourceRequest: &models.ResourceRequest{
+			MinReplica:    0,
+			MaxReplica:    1,
+			CPURequest:    resource.MustParse("1"),
+			MemoryRequest: resource.MustParse("1Gi"),
+		},
+	}
+	project := mlp.Project{ID: 1, Name: "project"}
+	model := &models.Model{Name: "model", Type: models.ModelTypePyFunc, Project: project, ProjectID: 1}
+	newResourceRequest := &models.ResourceRequest{
+		MinReplica:    2,
+		MaxReplica:    4,
+		CPURequest:    resource.MustParse("2"),
+		MemoryRequest: resource.MustParse("2Gi"),
+	}
+
+	tests := []struct {
+		name         string
+		newEndpoint  *models.VersionEndpoint
+		imageExists  bool
+		dryRunErr    error
+		wantWarnings []string
+		wantErr      error
+	}{
+		{
+			name:         "success",
+			newEndpoint:  &models.VersionEndpoint{ResourceRequest: newResourceRequest},
+			imageExists:  true,
+			wantWarnings: []string{"namespace warning"},
+		},
+		{
+			name:         "success: image is not built yet",
+			newEndpoint:  &models.VersionEndpoint{ResourceRequest: newResourceRequest},
+			wantWarnings: []string{"image gcr.io/project/model:1 doesn't exist yet and will be built on deployment", "namespace warning"},
+		},
+		{
+			name: "fail: invalid autoscaling policy",
+			newEndpoint: &models.VersionEndpoint{
+				ResourceRequest:   newResourceRequest,
+				AutoscalingPolicy: &autoscaling.AutoscalingPolicy{MetricsType: autoscaling.CPUUtilization, TargetValue: 0},
+			},
+			wantErr: merror.ErrInvalidInput,
+		},
+		{
+			name:        "fail: rejected by the cluster",
+			newEndpoint: &models.VersionEndpoint{ResourceRequest: newResourceRequest},
+			imageExists: true,
+			dryRunErr:   merror.NewInvalidInputError("inference service is rejected by the cluster"),
+			wantErr:     merror.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentEndpoint := &models.VersionEndpoint{
+				ID:              uuid.New(),
+				EnvironmentName: env.Name,
+				Namespace:       project.Name,
+				RevisionID:      models.ID(1),
+				Status:          models.EndpointRunning,
+				DeploymentMode:  deployment.ServerlessDeploymentMode,
+				ResourceRequest: env.DefaultResourceRequest,
+			}
+			version := &models.Version{ID: 1, Endpoints: []*models.VersionEndpoint{currentEndpoint}}
+
+			imageBuilder := &imageBuilderMock.ImageBuilder{}
+			imageBuilder.On("GetVersionImage", mock.Anything, project, model, version).
+				Return(models.VersionImage{ImageRef: "gcr.io/project/model:1", Exists: tt.imageExists})
+
+			controller := &clusterMock.Controller{}
+			controller.On("DryRun", mock.Anything, mock.MatchedBy(func(modelService *models.Service) bool {
+				return modelService.Name == "model-1-r2" &&
+					modelService.Options.PyFuncImageName == "gcr.io/project/model:1" &&
+					modelService.ResourceRequest == newResourceRequest
+			}), 1).Return(func(_ context.Context, _ *models.Service, _ int) (*models.DeploymentPlan, error) {
+				if tt.dryRunErr != nil {
+					return nil, tt.dryRunErr
+				}
+				plan := models.NewDeploymentPlan()
+				plan.AddWarning("namespace warning")
+				return plan, nil
+			})
+
+			mockStorage := &mocks.VersionEndpointStorage{}
+			mockQueueProducer := &queueMock.Producer{}
+			mockWebhook := &webhookMock.Client{}
+
+			endpointSvc := NewEndpointService(EndpointServiceParams{
+				ClusterControllers:   map[string]cluster.Controller{env.Name: controller},
+				ImageBuilder:         imageBuilder,
+				Storage:              mockStorage,
+				DeploymentStorage:    &mocks.DeploymentStorage{},
+				Environment:          "dev",
+				LoggerDestinationURL: loggerDestinationURL,
+				JobProducer:          mockQueueProducer,
+				Webhook:              mockWebhook,
+			})
+			plan, err := endpointSvc.DryRunEndpoint(context.Background(), env, model, version, tt.newEndpoint)
+
+			mockStorage.AssertNotCalled(t, "Save", mock.Anything)
+			mockQueueProducer.AssertNotCalled(t, "EnqueueJob", mock.Anything)
+			mockWebhook.AssertNotCalled(t, "TriggerWebhooks", mock.Anything, mock.Anything, mock.Anything)
+			assert.Equal(t, env.DefaultResourceRequest, currentEndpoint.ResourceRequest)
+			assert.Equal(t, models.ID(1), currentEndpoint.RevisionID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, newResourceRequest, plan.Endpoint.ResourceRequest)
+			assert.Equal(t, models.ID(2), plan.Endpoint.RevisionID)
+			assert.Equal(t, tt.wantWarnings, plan.Warnings)
+		})
+	}
+}
+
 func TestListContainers(t *testing.T) {
 	id := uuid.New()
 
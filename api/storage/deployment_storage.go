@@ -29,6 +29,8 @@ type DeploymentStorage interface {
 	ListInModelVersion(modelID, versionID, endpointUUID string) ([]*models.Deployment, error)
 	// Save saves the deployment to underlying storage
 	Save(deployment *models.Deployment) (*models.Deployment, error)
+	// Get gets the deployment with the given id
+	Get(deploymentID models.ID) (*models.Deployment, error)
 	// GetLatestDeployment gets the latest deployment record
 	GetLatestDeployment(modelID models.ID, versionID models.ID) (*models.Deployment, error)
 	// OnDeploymentSuccess updates the new deployment status to successful on DB and update all previous deployment status for that version endpoint to terminated.
@@ -65,6 +67,14 @@ func (d *deploymentStorage) Save(deployment *models.Deployment) (*models.Deploym
 	return deployment, err
 }
 
+func (d *deploymentStorage) Get(deploymentID models.ID) (*models.Deployment, error) {
+	deployment := &models.Deployment{}
+	if err := d.db.Where("id = ?", deploymentID).First(deployment).Error; err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
 func (d *deploymentStorage) GetLatestDeployment(modelID models.ID, versionID models.ID) (*models.Deployment, error) {
 	deployment := &models.Deployment{}
 	if err := d.db.Where("version_id = ? AND version_model_id = ? ORDER BY updated_at DESC LIMIT 1;", versionID,
@@ -186,6 +186,74 @@ func TestGetLatestDeployment(t *testing.T) {
 	})
 }
 
+func TestDeploymentStorage_Get(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		deploymentStorage := NewDeploymentStorage(db)
+		isDefaultTrue := true
+
+		p := mlp.Project{
+			Name:              "project",
+			MLFlowTrackingURL: "http://mlflow:5000",
+		}
+
+		m := models.Model{
+			ID:           1,
+			ProjectID:    models.ID(p.ID),
+			ExperimentID: 1,
+			Name:         "model",
+			Type:         models.ModelTypeSkLearn,
+		}
+		db.Create(&m)
+
+		v := models.Version{
+			ModelID:       m.ID,
+			RunID:         "1",
+			ArtifactURI:   "gcs:/mlp/1/1",
+			PythonVersion: "3.7.*",
+		}
+		db.Create(&v)
+
+		env1 := models.Environment{
+			Name:      "env1",
+			Cluster:   "k8s",
+			IsDefault: &isDefaultTrue,
+		}
+		db.Create(&env1)
+
+		endpoint := models.VersionEndpoint{
+			ID:              uuid.New(),
+			VersionID:       v.ID,
+			VersionModelID:  m.ID,
+			Status:          "pending",
+			EnvironmentName: env1.Name,
+			DeploymentMode:  deployment.ServerlessDeploymentMode,
+		}
+		db.Create(&endpoint)
+
+		deploy := &models.Deployment{
+			ProjectID:         models.ID(p.ID),
+			VersionID:         v.ID,
+			VersionModelID:    m.ID,
+			VersionEndpointID: endpoint.ID,
+			Status:            models.EndpointRunning,
+			Spec: &models.DeploymentSpec{
+				DeploymentMode: deployment.ServerlessDeploymentMode,
+				EnvVars:        models.EnvVars{{Name: "WORKERS", Value: "2"}},
+			},
+		}
+		_, err := deploymentStorage.Save(deploy)
+		assert.NoError(t, err)
+
+		dep, err := deploymentStorage.Get(deploy.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, endpoint.ID, dep.VersionEndpointID)
+		assert.Equal(t, deploy.Spec, dep.Spec)
+
+		_, err = deploymentStorage.Get(deploy.ID + 1)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
 func TestDeploymentStorage_GetFirstSuccessModelVersionPerModel(t *testing.T) {
 	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
 		deploymentStorage := NewDeploymentStorage(db)
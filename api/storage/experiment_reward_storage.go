@@ -0,0 +1,64 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/caraml-dev/merlin/models"
+)
+
+// ExperimentRewardStorage stores the rewards of the variants of the model endpoints' experiments
+type ExperimentRewardStorage interface {
+	// Add adds the successes and failures to the stored rewards of the variants
+	Add(ctx context.Context, rewards []*models.ExperimentReward) error
+	// List lists the rewards of the variants of the model endpoint's experiment
+	List(ctx context.Context, modelEndpointID models.ID, experimentName string) ([]*models.ExperimentReward, error)
+}
+
+type experimentRewardStorage struct {
+	db *gorm.DB
+}
+
+// NewExperimentRewardStorage creates new instance of ExperimentRewardStorage
+func NewExperimentRewardStorage(db *gorm.DB) ExperimentRewardStorage {
+	return &experimentRewardStorage{db: db}
+}
+
+func (s *experimentRewardStorage) Add(ctx context.Context, rewards []*models.ExperimentReward) error {
+	if len(rewards) == 0 {
+		return nil
+	}
+	return s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "model_endpoint_id"}, {Name: "experiment_name"}, {Name: "variant"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"successes":  gorm.Expr("model_endpoint_experiment_rewards.successes + excluded.successes"),
+			"failures":   gorm.Expr("model_endpoint_experiment_rewards.failures + excluded.failures"),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(&rewards).Error
+}
+
+func (s *experimentRewardStorage) List(ctx context.Context, modelEndpointID models.ID, experimentName string) ([]*models.ExperimentReward, error) {
+	var rewards []*models.ExperimentReward
+	err := s.db.
+		Where("model_endpoint_id = ? AND experiment_name = ?", modelEndpointID, experimentName).
+		Order("variant").
+		Find(&rewards).Error
+	return rewards, err
+}
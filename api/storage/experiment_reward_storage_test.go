@@ -0,0 +1,57 @@
+// Copyright 2020 The Merlin Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration || integration_local
+// +build integration integration_local
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/merlin/database"
+	"github.com/caraml-dev/merlin/models"
+)
+
+func TestExperimentRewardStorage(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		endpoints := populateModelEndpointTable(db)
+		storage := NewExperimentRewardStorage(db)
+		ctx := context.Background()
+
+		require.NoError(t, storage.Add(ctx, []*models.ExperimentReward{
+			{ModelEndpointID: endpoints[0].ID, ExperimentName: "exp", Variant: "control", Successes: 1, Failures: 2},
+			{ModelEndpointID: endpoints[0].ID, ExperimentName: "exp", Variant: "treatment", Successes: 3},
+			{ModelEndpointID: endpoints[0].ID, ExperimentName: "other", Variant: "control", Successes: 5},
+		}))
+		// rewards are accumulated
+		require.NoError(t, storage.Add(ctx, []*models.ExperimentReward{
+			{ModelEndpointID: endpoints[0].ID, ExperimentName: "exp", Variant: "control", Successes: 10, Failures: 20},
+		}))
+
+		rewards, err := storage.List(ctx, endpoints[0].ID, "exp")
+		require.NoError(t, err)
+		require.Len(t, rewards, 2)
+		assert.Equal(t, "control", rewards[0].Variant)
+		assert.Equal(t, int64(11), rewards[0].Successes)
+		assert.Equal(t, int64(22), rewards[0].Failures)
+		assert.Equal(t, "treatment", rewards[1].Variant)
+		assert.Equal(t, int64(3), rewards[1].Successes)
+	})
+}
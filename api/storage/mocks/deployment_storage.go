@@ -26,6 +26,32 @@ func (_m *DeploymentStorage) Delete(modelID models.ID, versionID models.ID) erro
 	return r0
 }
 
+// Get provides a mock function with given fields: deploymentID
+func (_m *DeploymentStorage) Get(deploymentID models.ID) (*models.Deployment, error) {
+	ret := _m.Called(deploymentID)
+
+	var r0 *models.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.ID) (*models.Deployment, error)); ok {
+		return rf(deploymentID)
+	}
+	if rf, ok := ret.Get(0).(func(models.ID) *models.Deployment); ok {
+		r0 = rf(deploymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.ID) error); ok {
+		r1 = rf(deploymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFirstSuccessModelVersionPerModel provides a mock function with given fields:
 func (_m *DeploymentStorage) GetFirstSuccessModelVersionPerModel() (map[models.ID]models.ID, error) {
 	ret := _m.Called()
@@ -106,6 +106,106 @@ func (_m *ModelEndpointStorage) ListModelEndpointsInProject(ctx context.Context,
 	return r0, r1
 }
 
+// ListBanditExperiments provides a mock function with given fields: ctx
+func (_m *ModelEndpointStorage) ListBanditExperiments(ctx context.Context) ([]*models.ModelEndpoint, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.ModelEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.ModelEndpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.ModelEndpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ModelEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRolloutsInProgress provides a mock function with given fields: ctx
+func (_m *ModelEndpointStorage) ListRolloutsInProgress(ctx context.Context) ([]*models.ModelEndpoint, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.ModelEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.ModelEndpoint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.ModelEndpoint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ModelEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateExperimentBandit provides a mock function with given fields: ctx, id, prevBandit, newBandit
+func (_m *ModelEndpointStorage) UpdateExperimentBandit(ctx context.Context, id models.ID, prevBandit *models.ExperimentBandit, newBandit *models.ExperimentBandit) (bool, error) {
+	ret := _m.Called(ctx, id, prevBandit, newBandit)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, *models.ExperimentBandit, *models.ExperimentBandit) (bool, error)); ok {
+		return rf(ctx, id, prevBandit, newBandit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, *models.ExperimentBandit, *models.ExperimentBandit) bool); ok {
+		r0 = rf(ctx, id, prevBandit, newBandit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, *models.ExperimentBandit, *models.ExperimentBandit) error); ok {
+		r1 = rf(ctx, id, prevBandit, newBandit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRolloutStatus provides a mock function with given fields: ctx, id, prevStatus, newStatus
+func (_m *ModelEndpointStorage) UpdateRolloutStatus(ctx context.Context, id models.ID, prevStatus *models.RolloutStatus, newStatus *models.RolloutStatus) (bool, error) {
+	ret := _m.Called(ctx, id, prevStatus, newStatus)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, *models.RolloutStatus, *models.RolloutStatus) (bool, error)); ok {
+		return rf(ctx, id, prevStatus, newStatus)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, *models.RolloutStatus, *models.RolloutStatus) bool); ok {
+		r0 = rf(ctx, id, prevStatus, newStatus)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, *models.RolloutStatus, *models.RolloutStatus) error); ok {
+		r1 = rf(ctx, id, prevStatus, newStatus)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, prevModelEndpoint, newModelEndpoint
 func (_m *ModelEndpointStorage) Save(ctx context.Context, prevModelEndpoint *models.ModelEndpoint, newModelEndpoint *models.ModelEndpoint) error {
 	ret := _m.Called(ctx, prevModelEndpoint, newModelEndpoint)
@@ -104,6 +104,32 @@ func (_m *VersionEndpointStorage) ListEndpoints(model *models.Model, version *mo
 	return r0, r1
 }
 
+// ListScheduledEndpoints provides a mock function with given fields:
+func (_m *VersionEndpointStorage) ListScheduledEndpoints() ([]*models.VersionEndpoint, error) {
+	ret := _m.Called()
+
+	var r0 []*models.VersionEndpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*models.VersionEndpoint, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*models.VersionEndpoint); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.VersionEndpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: endpoint
 func (_m *VersionEndpointStorage) Save(endpoint *models.VersionEndpoint) error {
 	ret := _m.Called(endpoint)
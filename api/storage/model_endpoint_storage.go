@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/caraml-dev/merlin/log"
 	"github.com/caraml-dev/merlin/models"
@@ -16,6 +17,16 @@ type ModelEndpointStorage interface {
 	ListModelEndpoints(ctx context.Context, modelID models.ID) ([]*models.ModelEndpoint, error)
 	// ListModelEndpointsInProject list all model endpoints within a project given the project ID
 	ListModelEndpointsInProject(ctx context.Context, projectID models.ID, region string) ([]*models.ModelEndpoint, error)
+	// ListRolloutsInProgress list all serving model endpoints with a canary rollout in progress
+	ListRolloutsInProgress(ctx context.Context) ([]*models.ModelEndpoint, error)
+	// UpdateRolloutStatus set the rollout status of a model endpoint to newStatus only if it is still prevStatus, it
+	// returns false if the rollout status has been changed in the meantime
+	UpdateRolloutStatus(ctx context.Context, id models.ID, prevStatus, newStatus *models.RolloutStatus) (bool, error)
+	// ListBanditExperiments list all serving model endpoints running an experiment with a bandit
+	ListBanditExperiments(ctx context.Context) ([]*models.ModelEndpoint, error)
+	// UpdateExperimentBandit set the experiment bandit of a model endpoint to newBandit only if it is still prevBandit,
+	// it returns false if the experiment bandit has been changed in the meantime
+	UpdateExperimentBandit(ctx context.Context, id models.ID, prevBandit, newBandit *models.ExperimentBandit) (bool, error)
 	// Save save newModelEndpoint and its nested version endpoint objects
 	Save(ctx context.Context, prevModelEndpoint, newModelEndpoint *models.ModelEndpoint) error
 	// Delete delete a model endpoint from the database
@@ -75,6 +86,84 @@ func (m *modelEndpointStorage) ListModelEndpointsInProject(ctx context.Context,
 	return endpoints, nil
 }
 
+// ListRolloutsInProgress list all serving model endpoints with a canary rollout in progress
+func (m *modelEndpointStorage) ListRolloutsInProgress(ctx context.Context) ([]*models.ModelEndpoint, error) {
+	endpoints := []*models.ModelEndpoint{}
+
+	err := m.query().
+		Where("model_endpoints.status = ?", models.EndpointServing).
+		Where("model_endpoints.rollout->'status'->>'phase' = ?", models.RolloutPhaseProgressing).
+		Find(&endpoints).Error
+	if err != nil {
+		log.Errorf("failed to list model endpoints with rollout in progress, %v", err)
+		return nil, errors.Wrap(err, "failed to list model endpoints with rollout in progress")
+	}
+
+	return endpoints, nil
+}
+
+// UpdateRolloutStatus set the rollout status of a model endpoint to newStatus only if it is still prevStatus, it
+// returns false if the rollout status has been changed in the meantime, e.g. by another API server replica
+func (m *modelEndpointStorage) UpdateRolloutStatus(ctx context.Context, id models.ID, prevStatus, newStatus *models.RolloutStatus) (bool, error) {
+	prev, err := json.Marshal(prevStatus)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal previous rollout status")
+	}
+	next, err := json.Marshal(newStatus)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal new rollout status")
+	}
+
+	result := m.db.WithContext(ctx).Model(&models.ModelEndpoint{}).
+		Where("id = ? AND rollout->'status' = ?::jsonb", id, string(prev)).
+		Update("rollout", gorm.Expr("jsonb_set(rollout, '{status}', ?::jsonb)", string(next)))
+	if result.Error != nil {
+		log.Errorf("failed to update rollout status of model endpoint %d, %v", id, result.Error)
+		return false, errors.Wrap(result.Error, "failed to update rollout status")
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ListBanditExperiments list all serving model endpoints running an experiment with a bandit
+func (m *modelEndpointStorage) ListBanditExperiments(ctx context.Context) ([]*models.ModelEndpoint, error) {
+	endpoints := []*models.ModelEndpoint{}
+
+	err := m.query().
+		Where("model_endpoints.status = ?", models.EndpointServing).
+		Where("model_endpoints.experiment->'bandit' IS NOT NULL").
+		Find(&endpoints).Error
+	if err != nil {
+		log.Errorf("failed to list model endpoints with bandit experiment, %v", err)
+		return nil, errors.Wrap(err, "failed to list model endpoints with bandit experiment")
+	}
+
+	return endpoints, nil
+}
+
+// UpdateExperimentBandit set the experiment bandit of a model endpoint to newBandit only if it is still prevBandit, it
+// returns false if the experiment bandit has been changed in the meantime, e.g. by another API server replica
+func (m *modelEndpointStorage) UpdateExperimentBandit(ctx context.Context, id models.ID, prevBandit, newBandit *models.ExperimentBandit) (bool, error) {
+	prev, err := json.Marshal(prevBandit)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal previous experiment bandit")
+	}
+	next, err := json.Marshal(newBandit)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal new experiment bandit")
+	}
+
+	result := m.db.WithContext(ctx).Model(&models.ModelEndpoint{}).
+		Where("id = ? AND experiment->'bandit' = ?::jsonb", id, string(prev)).
+		Update("experiment", gorm.Expr("jsonb_set(experiment, '{bandit}', ?::jsonb)", string(next)))
+	if result.Error != nil {
+		log.Errorf("failed to update experiment bandit of model endpoint %d, %v", id, result.Error)
+		return false, errors.Wrap(result.Error, "failed to update experiment bandit")
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 // Save save newModelEndpoint and its nested version endpoint objects
 func (m *modelEndpointStorage) Save(ctx context.Context, prevModelEndpoint, newModelEndpoint *models.ModelEndpoint) error {
 	tx := m.db.WithContext(ctx).Begin()
@@ -95,8 +184,8 @@ func (m *modelEndpointStorage) Save(ctx context.Context, prevModelEndpoint, newM
 
 	// Update version and version endpoints from previous model endpoint
 	if prevModelEndpoint != nil {
-		for _, ruleDestination := range prevModelEndpoint.Rule.Destination {
-			versionEndpoint, err := m.versionEndpointStorage.Get(ruleDestination.VersionEndpointID)
+		for _, versionEndpointID := range prevModelEndpoint.Rule.VersionEndpointIDs() {
+			versionEndpoint, err := m.versionEndpointStorage.Get(versionEndpointID)
 			if err != nil {
 				return err
 			}
@@ -109,10 +198,10 @@ func (m *modelEndpointStorage) Save(ctx context.Context, prevModelEndpoint, newM
 		}
 	}
 
-	// Update version and version endpoints from new model endpoint
-	for _, ruleDestination := range newModelEndpoint.Rule.Destination {
+	// Update version and version endpoints from new model endpoint, including the ones pinned by routes
+	for _, versionEndpointID := range newModelEndpoint.Rule.VersionEndpointIDs() {
 		var versionEndpoint *models.VersionEndpoint
-		versionEndpoint, err = m.versionEndpointStorage.Get(ruleDestination.VersionEndpointID)
+		versionEndpoint, err = m.versionEndpointStorage.Get(versionEndpointID)
 		if err != nil {
 			return err
 		}
@@ -20,6 +20,7 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/caraml-dev/merlin/pkg/deployment"
 	"github.com/google/uuid"
@@ -81,6 +82,136 @@ func TestModelEndpointsStorage_ListModelEndpointsInProject(t *testing.T) {
 	})
 }
 
+func TestModelEndpointsStorage_ListRolloutsInProgress(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		endpoints := populateModelEndpointTable(db)
+		storage := NewModelEndpointStorage(db)
+
+		endpoints[0].Rollout = &models.ModelEndpointRollout{
+			CanaryVersionEndpointID: uuid.New(),
+			Steps:                   []*models.RolloutStep{{Weight: 10, Duration: "10m"}},
+			Status:                  &models.RolloutStatus{Phase: models.RolloutPhaseProgressing},
+		}
+		assert.NoError(t, db.Save(endpoints[0]).Error)
+		endpoints[1].Rollout = &models.ModelEndpointRollout{
+			CanaryVersionEndpointID: uuid.New(),
+			Steps:                   []*models.RolloutStep{{Weight: 10, Duration: "10m"}},
+			Status:                  &models.RolloutStatus{Phase: models.RolloutPhasePromoted},
+		}
+		assert.NoError(t, db.Save(endpoints[1]).Error)
+
+		actualEndpoints, err := storage.ListRolloutsInProgress(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, actualEndpoints, 1)
+		assert.Equal(t, endpoints[0].ID, actualEndpoints[0].ID)
+		assert.Equal(t, int32(10), actualEndpoints[0].Rollout.Steps[0].Weight)
+	})
+}
+
+func TestModelEndpointsStorage_ListBanditExperiments(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		endpoints := populateModelEndpointTable(db)
+		storage := NewModelEndpointStorage(db)
+
+		variants := []*models.ExperimentVariant{
+			{Name: "control", VersionEndpointID: uuid.New(), Weight: 50},
+			{Name: "treatment", VersionEndpointID: uuid.New(), Weight: 50},
+		}
+		endpoints[0].Experiment = &models.ModelEndpointExperiment{
+			Name:         "bandit",
+			UnitIDHeader: "X-User-Id",
+			Variants:     variants,
+			Bandit:       &models.ExperimentBandit{MinWeight: 5},
+		}
+		assert.NoError(t, db.Save(endpoints[0]).Error)
+		endpoints[1].Experiment = &models.ModelEndpointExperiment{
+			Name:         "static",
+			UnitIDHeader: "X-User-Id",
+			Variants:     variants,
+		}
+		assert.NoError(t, db.Save(endpoints[1]).Error)
+
+		actualEndpoints, err := storage.ListBanditExperiments(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, actualEndpoints, 1)
+		assert.Equal(t, endpoints[0].ID, actualEndpoints[0].ID)
+		assert.Equal(t, int32(5), actualEndpoints[0].Experiment.Bandit.MinWeight)
+	})
+}
+
+func TestModelEndpointsStorage_UpdateRolloutStatus(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		endpoints := populateModelEndpointTable(db)
+		storage := NewModelEndpointStorage(db)
+
+		prevStatus := &models.RolloutStatus{
+			Phase:         models.RolloutPhaseProgressing,
+			StepStartedAt: time.Now().Add(-10 * time.Minute),
+		}
+		endpoints[0].Rollout = &models.ModelEndpointRollout{
+			CanaryVersionEndpointID: uuid.New(),
+			Steps:                   []*models.RolloutStep{{Weight: 10, Duration: "10m"}, {Weight: 50, Duration: "10m"}},
+			Status:                  prevStatus,
+		}
+		assert.NoError(t, db.Save(endpoints[0]).Error)
+
+		newStatus := &models.RolloutStatus{
+			Phase:         models.RolloutPhaseProgressing,
+			CurrentStep:   1,
+			StepStartedAt: time.Now(),
+		}
+		updated, err := storage.UpdateRolloutStatus(context.Background(), endpoints[0].ID, prevStatus, newStatus)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		// the rollout status has already been changed
+		updated, err = storage.UpdateRolloutStatus(context.Background(), endpoints[0].ID, prevStatus, newStatus)
+		assert.NoError(t, err)
+		assert.False(t, updated)
+
+		actualEndpoint, err := storage.FindByID(context.Background(), endpoints[0].ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, actualEndpoint.Rollout.Status.CurrentStep)
+		assert.Len(t, actualEndpoint.Rollout.Steps, 2)
+	})
+}
+
+func TestModelEndpointsStorage_UpdateExperimentBandit(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		endpoints := populateModelEndpointTable(db)
+		storage := NewModelEndpointStorage(db)
+
+		updatedAt := time.Now().Add(-10 * time.Minute)
+		prevBandit := &models.ExperimentBandit{MinWeight: 5, UpdatedAt: &updatedAt}
+		endpoints[0].Experiment = &models.ModelEndpointExperiment{
+			Name:         "exp",
+			UnitIDHeader: "X-User-Id",
+			Variants: []*models.ExperimentVariant{
+				{Name: "control", VersionEndpointID: uuid.New(), Weight: 50},
+				{Name: "treatment", VersionEndpointID: uuid.New(), Weight: 50},
+			},
+			Bandit: prevBandit,
+		}
+		assert.NoError(t, db.Save(endpoints[0]).Error)
+
+		now := time.Now()
+		newBandit := &models.ExperimentBandit{MinWeight: 5, UpdatedAt: &now}
+		updated, err := storage.UpdateExperimentBandit(context.Background(), endpoints[0].ID, prevBandit, newBandit)
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		// the experiment bandit has already been changed
+		updated, err = storage.UpdateExperimentBandit(context.Background(), endpoints[0].ID, prevBandit, newBandit)
+		assert.NoError(t, err)
+		assert.False(t, updated)
+
+		actualEndpoint, err := storage.FindByID(context.Background(), endpoints[0].ID)
+		assert.NoError(t, err)
+		assert.True(t, now.Equal(*actualEndpoint.Experiment.Bandit.UpdatedAt))
+		assert.Len(t, actualEndpoint.Experiment.Variants, 2)
+	})
+}
+
 func TestModelEndpointStorage_Save(t *testing.T) {
 	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
 		storage := NewModelEndpointStorage(db)
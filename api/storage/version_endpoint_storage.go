@@ -25,6 +25,8 @@ const maxMessageChar = 2048
 
 type VersionEndpointStorage interface {
 	ListEndpoints(model *models.Model, version *models.Version) (endpoints []*models.VersionEndpoint, err error)
+	// ListScheduledEndpoints lists the running and serving endpoints having scaling schedules
+	ListScheduledEndpoints() (endpoints []*models.VersionEndpoint, err error)
 	Get(uuid.UUID) (*models.VersionEndpoint, error)
 	Save(endpoint *models.VersionEndpoint) error
 	CountEndpoints(environment *models.Environment, model *models.Model) (int, error)
@@ -44,6 +46,14 @@ func (v *versionEndpointStorage) ListEndpoints(model *models.Model, version *mod
 	return
 }
 
+func (v *versionEndpointStorage) ListScheduledEndpoints() (endpoints []*models.VersionEndpoint, err error) {
+	err = v.query().
+		Where("version_endpoints.status IN ('running', 'serving')").
+		Where("version_endpoints.scaling_schedules IS NOT NULL AND jsonb_typeof(version_endpoints.scaling_schedules) = 'array' AND jsonb_array_length(version_endpoints.scaling_schedules) > 0").
+		Find(&endpoints).Error
+	return
+}
+
 func (v *versionEndpointStorage) Get(uuid uuid.UUID) (*models.VersionEndpoint, error) {
 	ve := &models.VersionEndpoint{}
 	if err := v.query().Where("version_endpoints.id = ?", uuid.String()).Find(&ve).Error; err != nil {
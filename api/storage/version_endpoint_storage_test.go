@@ -61,6 +61,39 @@ func TestVersionEndpointsStorage_ListEndpoints(t *testing.T) {
 	})
 }
 
+func TestVersionEndpointsStorage_ListScheduledEndpoints(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		endpoints := populateVersionEndpointTable(db)
+
+		minReplica := 5
+		scheduled := *endpoints[1]
+		scheduled.ID = uuid.New()
+		scheduled.Status = models.EndpointRunning
+		scheduled.ScalingSchedules = models.ScalingSchedules{
+			{Name: "peak", Schedule: "0 8 * * *", Duration: "10h", MinReplica: &minReplica},
+		}
+		db.Create(&scheduled)
+
+		terminated := scheduled
+		terminated.ID = uuid.New()
+		terminated.Status = models.EndpointTerminated
+		db.Create(&terminated)
+
+		unscheduled := scheduled
+		unscheduled.ID = uuid.New()
+		unscheduled.ScalingSchedules = models.ScalingSchedules{}
+		db.Create(&unscheduled)
+
+		endpointSvc := NewVersionEndpointStorage(db)
+
+		actualEndpoints, err := endpointSvc.ListScheduledEndpoints()
+		assert.NoError(t, err)
+		assert.Len(t, actualEndpoints, 1)
+		assert.Equal(t, scheduled.ID, actualEndpoints[0].ID)
+		assert.Equal(t, scheduled.ScalingSchedules, actualEndpoints[0].ScalingSchedules)
+	})
+}
+
 func TestVersionsService_CountEndpoints(t *testing.T) {
 	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
 		populateVersionEndpointTable(db)
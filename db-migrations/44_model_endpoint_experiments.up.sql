@@ -0,0 +1,27 @@
+-- Copyright 2020 The Merlin Authors
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--      http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+ALTER TABLE model_endpoints ADD COLUMN experiment jsonb;
+
+CREATE TABLE IF NOT EXISTS model_endpoint_experiment_rewards
+(
+    model_endpoint_id integer     NOT NULL REFERENCES model_endpoints (id) ON DELETE CASCADE,
+    experiment_name   varchar(64) NOT NULL,
+    variant           varchar(64) NOT NULL,
+    successes         bigint      NOT NULL DEFAULT 0,
+    failures          bigint      NOT NULL DEFAULT 0,
+    created_at        timestamp   NOT NULL DEFAULT current_timestamp,
+    updated_at        timestamp   NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (model_endpoint_id, experiment_name, variant)
+);